@@ -0,0 +1,61 @@
+package platform
+
+import (
+	"context"
+	"time"
+)
+
+// Meta is the audit metadata of a resource, set by the service storing it.
+type Meta struct {
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// CreatedBy is the ID of the user of the authorization that created the resource.
+	CreatedBy ID `json:"createdBy,omitempty"`
+}
+
+// Audit actions are the changes recorded by audit events.
+const (
+	CreateAuditAction = "create"
+	UpdateAuditAction = "update"
+	DeleteAuditAction = "delete"
+)
+
+// Audit resource types are the types of resources changed by audit events.
+const (
+	BucketAuditResource        = "bucket"
+	OrganizationAuditResource  = "organization"
+	UserAuditResource          = "user"
+	AuthorizationAuditResource = "authorization"
+	DashboardAuditResource     = "dashboard"
+	TaskAuditResource          = "task"
+	RunAuditResource           = "run"
+)
+
+// AuditEvent records a change to a resource.
+type AuditEvent struct {
+	ID           ID        `json:"id"`
+	Time         time.Time `json:"time"`
+	Action       string    `json:"action"`
+	ResourceType string    `json:"resourceType"`
+	ResourceID   ID        `json:"resourceID"`
+	// UserID and AuthorizationID are empty for changes not made with an authorization.
+	UserID          ID `json:"userID,omitempty"`
+	AuthorizationID ID `json:"authorizationID,omitempty"`
+}
+
+// AuditService represents a service for reading the audit log.
+// Events are appended to the log by the services changing resources and are never removed.
+type AuditService interface {
+	// FindAuditEvents returns the events that match filter, in the order they were appended,
+	// and the total count of matching events.
+	FindAuditEvents(ctx context.Context, filter AuditFilter, opt ...FindOptions) ([]*AuditEvent, int, error)
+}
+
+// AuditFilter represents a set of filters that restrict the returned audit events.
+type AuditFilter struct {
+	ResourceType *string
+	ResourceID   *ID
+	UserID       *ID
+	// Since restricts the results to events at or after the time.
+	Since *time.Time
+}
@@ -12,6 +12,7 @@ type Authorization struct {
 	User        string       `json:"user,omitempty"`
 	UserID      ID           `json:"userID,omitempty"`
 	Permissions []Permission `json:"permissions"`
+	Meta
 }
 
 // AuthorizationService represents a service for managing authorization data.
@@ -62,6 +63,8 @@ const (
 	UserResource = resource("user")
 	// OrganizationResource represents the org resource actions can apply to.
 	OrganizationResource = resource("org")
+	// BackupResource represents the backup of the platform's metadata.
+	BackupResource = resource("backup")
 )
 
 // BucketResource constructs a bucket resource.
@@ -90,6 +93,12 @@ var (
 		Action:   DeleteAction,
 		Resource: UserResource,
 	}
+	// ReadBackupPermission is a permission for backing up the platform's metadata.
+	// It grants access to all resources, so only administrators should hold it.
+	ReadBackupPermission = Permission{
+		Action:   ReadAction,
+		Resource: BackupResource,
+	}
 )
 
 // ReadBucket constructs a permission for reading a bucket.
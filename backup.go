@@ -0,0 +1,12 @@
+package platform
+
+import (
+	"context"
+	"io"
+)
+
+// BackupService represents a service for backing up the platform's metadata.
+type BackupService interface {
+	// Backup writes a consistent copy of the metadata store to w.
+	Backup(ctx context.Context, w io.Writer) error
+}
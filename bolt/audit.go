@@ -0,0 +1,181 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	idpctx "github.com/influxdata/platform/context"
+)
+
+var auditBucket = []byte("auditv1")
+
+func (c *Client) initializeAudit(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(auditBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// auditAuthorization returns the authorization on context of the change being made, if any.
+func auditAuthorization(ctx context.Context) *platform.Authorization {
+	a, err := idpctx.GetAuthorization(ctx)
+	if err != nil || a == nil {
+		return &platform.Authorization{}
+	}
+	return a
+}
+
+// createMeta sets the metadata of a resource being created.
+func createMeta(ctx context.Context, m *platform.Meta) {
+	now := time.Now().UTC()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+	m.CreatedBy = auditAuthorization(ctx).UserID
+}
+
+// updateMeta sets the metadata of a resource being updated.
+func updateMeta(ctx context.Context, m *platform.Meta) {
+	m.UpdatedAt = time.Now().UTC()
+}
+
+// appendAuditEvent appends an event of a change to a resource to the audit log,
+// within the transaction making the change.
+func (c *Client) appendAuditEvent(ctx context.Context, tx *bolt.Tx, action, resourceType string, resourceID platform.ID) error {
+	b := tx.Bucket(auditBucket)
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+
+	a := auditAuthorization(ctx)
+	e := &platform.AuditEvent{
+		ID:              platform.ID(k),
+		Time:            time.Now().UTC(),
+		Action:          action,
+		ResourceType:    resourceType,
+		ResourceID:      resourceID,
+		UserID:          a.UserID,
+		AuthorizationID: a.ID,
+	}
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return b.Put(k, v)
+}
+
+// FindAuditEvents returns the audit events that match filter.
+// Filters using Since start from the first event at that time; other filters scan the whole log.
+// Without a filter, and when sorted by ID, only the events of the requested page are decoded.
+func (c *Client) FindAuditEvents(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+	if filter == (platform.AuditFilter{}) && sortedByID(opt) {
+		return c.findAuditEventsPage(ctx, findOptions(opt))
+	}
+
+	es := []*platform.AuditEvent{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		es, err = c.findAuditEvents(ctx, tx, filter)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start, end, err := paginate(es, len(es), func(i int) platform.ID { return es[i].ID }, sortKeys{
+		"action":       func(i int) string { return es[i].Action },
+		"resourceType": func(i int) string { return es[i].ResourceType },
+	}, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return es[start:end], len(es), nil
+}
+
+// findAuditEventsPage finds the page of events in ID order described by opt,
+// decoding only the events of the page.
+func (c *Client) findAuditEventsPage(ctx context.Context, opt platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+	es := []*platform.AuditEvent{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		r := bucketRange(tx, auditBucket)
+		var ids []platform.ID
+		ids, n = pageIDs(r, opt)
+		for _, id := range ids {
+			e := &platform.AuditEvent{}
+			if err := json.Unmarshal(r.bucket.Get(id), e); err != nil {
+				return err
+			}
+			es = append(es, e)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return es, n, nil
+}
+
+func (c *Client) findAuditEvents(ctx context.Context, tx *bolt.Tx, filter platform.AuditFilter) ([]*platform.AuditEvent, error) {
+	es := []*platform.AuditEvent{}
+	cur := tx.Bucket(auditBucket).Cursor()
+	k, v := cur.First()
+	if filter.Since != nil {
+		// Events are appended in time order, so the first event since the time is found by a binary search.
+		k, v = searchAuditEvents(cur, *filter.Since)
+	}
+	for ; k != nil; k, v = cur.Next() {
+		e := &platform.AuditEvent{}
+		if err := json.Unmarshal(v, e); err != nil {
+			return nil, err
+		}
+		if filter.Since != nil && e.Time.Before(*filter.Since) {
+			continue
+		}
+		if filter.ResourceType != nil && e.ResourceType != *filter.ResourceType {
+			continue
+		}
+		if filter.ResourceID != nil && !bytes.Equal(e.ResourceID, *filter.ResourceID) {
+			continue
+		}
+		if filter.UserID != nil && !bytes.Equal(e.UserID, *filter.UserID) {
+			continue
+		}
+		es = append(es, e)
+	}
+	return es, nil
+}
+
+// searchAuditEvents positions the cursor on the first event at or after t.
+func searchAuditEvents(cur *bolt.Cursor, t time.Time) ([]byte, []byte) {
+	first, _ := cur.First()
+	last, _ := cur.Last()
+	if first == nil {
+		return nil, nil
+	}
+	lo, hi := binary.BigEndian.Uint64(first), binary.BigEndian.Uint64(last)+1
+	k := make([]byte, 8)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		binary.BigEndian.PutUint64(k, mid)
+		_, v := cur.Seek(k)
+		var e platform.AuditEvent
+		if v == nil || json.Unmarshal(v, &e) != nil || !e.Time.Before(t) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	binary.BigEndian.PutUint64(k, lo)
+	return cur.Seek(k)
+}
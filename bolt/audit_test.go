@@ -0,0 +1,198 @@
+package bolt_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	idpctx "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+)
+
+func TestClient_Meta(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+	c.IDGenerator = mock.NewIDGenerator("org1")
+
+	auth := &platform.Authorization{ID: platform.ID("auth1"), UserID: platform.ID("user1")}
+	ctx := idpctx.SetAuthorization(context.Background(), auth)
+
+	before := time.Now().UTC()
+	o := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	if o.CreatedAt.Before(before) || !o.UpdatedAt.Equal(o.CreatedAt) {
+		t.Errorf("expected creation time to be set, got %+v", o.Meta)
+	}
+	if !bytes.Equal(o.CreatedBy, auth.UserID) {
+		t.Errorf("expected organization created by %s, got %s", auth.UserID, o.CreatedBy)
+	}
+
+	name := "renamed"
+	upd, err := c.UpdateOrganization(context.Background(), o.ID, platform.OrganizationUpdate{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !upd.CreatedAt.Equal(o.CreatedAt) || !upd.UpdatedAt.After(o.CreatedAt) || !bytes.Equal(upd.CreatedBy, auth.UserID) {
+		t.Errorf("expected update time to be set and creation to be kept, got %+v", upd.Meta)
+	}
+
+	found, err := c.FindOrganizationByID(ctx, o.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.UpdatedAt.Equal(upd.UpdatedAt) {
+		t.Errorf("expected metadata to be stored, got %+v", found.Meta)
+	}
+}
+
+func TestClient_FindAuditEvents(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	auth := &platform.Authorization{ID: platform.ID("auth1"), UserID: platform.ID("user1")}
+	authCtx := idpctx.SetAuthorization(ctx, auth)
+
+	c.IDGenerator = mock.NewIDGenerator("org1")
+	o := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(authCtx, o); err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Now().UTC()
+	c.IDGenerator = mock.NewIDGenerator("bucket1")
+	b := &platform.Bucket{OrganizationID: o.ID, Name: "bucket"}
+	if err := c.CreateBucket(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteOrganization(authCtx, o.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	es, n, err := c.FindAuditEvents(ctx, platform.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		action, resourceType string
+		resourceID, userID   platform.ID
+	}{
+		{platform.CreateAuditAction, platform.OrganizationAuditResource, o.ID, auth.UserID},
+		{platform.CreateAuditAction, platform.BucketAuditResource, b.ID, nil},
+		{platform.DeleteAuditAction, platform.BucketAuditResource, b.ID, auth.UserID},
+		{platform.DeleteAuditAction, platform.OrganizationAuditResource, o.ID, auth.UserID},
+	}
+	if n != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), n)
+	}
+	for i, w := range want {
+		e := es[i]
+		if e.Action != w.action || e.ResourceType != w.resourceType || !bytes.Equal(e.ResourceID, w.resourceID) || !bytes.Equal(e.UserID, w.userID) {
+			t.Errorf("event %d: expected %s %s %s by %s, got %+v", i, w.action, w.resourceType, w.resourceID, w.userID, e)
+		}
+	}
+
+	filters := []struct {
+		name   string
+		filter platform.AuditFilter
+		want   int
+	}{
+		{name: "resource", filter: platform.AuditFilter{ResourceID: &b.ID}, want: 2},
+		{name: "resource type", filter: platform.AuditFilter{ResourceType: strPtr(platform.OrganizationAuditResource)}, want: 2},
+		{name: "user", filter: platform.AuditFilter{UserID: &auth.UserID}, want: 3},
+		{name: "since", filter: platform.AuditFilter{Since: &since}, want: 3},
+		{name: "since now", filter: platform.AuditFilter{Since: timePtr(time.Now().UTC())}, want: 0},
+	}
+	for _, tt := range filters {
+		t.Run(tt.name, func(t *testing.T) {
+			_, n, err := c.FindAuditEvents(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != tt.want {
+				t.Errorf("expected %d events, got %d", tt.want, n)
+			}
+		})
+	}
+
+	page, _, err := c.FindAuditEvents(ctx, platform.AuditFilter{}, platform.FindOptions{Limit: 2, After: es[1].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 2 || !bytes.Equal(page[0].ID, es[2].ID) {
+		t.Errorf("expected page of the events after the second, got %+v", page)
+	}
+}
+
+func TestClient_RunAuditEvents(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	auth := &platform.Authorization{ID: platform.ID("auth1"), UserID: platform.ID("user1")}
+	ctx := idpctx.SetAuthorization(context.Background(), auth)
+
+	task := &platform.Task{ID: platform.ID("task1"), Name: "task"}
+	if err := c.PutTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	c.IDGenerator = mock.NewIDGenerator("run1")
+	run := &platform.Run{TaskID: task.ID}
+	if err := c.CreateRun(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+	status := platform.RunFailed
+	if _, err := c.UpdateRun(ctx, task.ID, run.ID, platform.RunUpdate{Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+	c.IDGenerator = mock.NewIDGenerator("run2")
+	retry, err := c.RetryRun(ctx, task.ID, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Updating the task cancels the queued retry.
+	name := "renamed"
+	if _, err := c.UpdateTask(ctx, task.ID, platform.TaskUpdate{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+
+	es, _, err := c.FindAuditEvents(ctx, platform.AuditFilter{ResourceType: strPtr(platform.RunAuditResource)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		action     string
+		resourceID platform.ID
+	}{
+		{platform.CreateAuditAction, run.ID},
+		{platform.UpdateAuditAction, run.ID},
+		{platform.CreateAuditAction, retry.ID},
+		{platform.UpdateAuditAction, retry.ID},
+	}
+	if len(es) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(es))
+	}
+	for i, w := range want {
+		e := es[i]
+		if e.Action != w.action || !bytes.Equal(e.ResourceID, w.resourceID) || !bytes.Equal(e.UserID, auth.UserID) {
+			t.Errorf("event %d: expected %s %s by %s, got %+v", i, w.action, w.resourceID, auth.UserID, e)
+		}
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func timePtr(t time.Time) *time.Time { return &t }
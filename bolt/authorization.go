@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 )
 
 var (
@@ -62,8 +62,7 @@ func (c *Client) findAuthorizationByID(ctx context.Context, tx *bolt.Tx, id plat
 	v := tx.Bucket(authorizationBucket).Get(id)
 
 	if len(v) == 0 {
-		// TODO: Make standard error
-		return nil, fmt.Errorf("authorization not found")
+		return nil, kerrors.NotFoundf("authorization not found")
 	}
 
 	if err := json.Unmarshal(v, &a); err != nil {
@@ -122,6 +121,8 @@ func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platfor
 
 // FindAuthorizations retrives all authorizations that match an arbitrary authorization filter.
 // Filters using ID, or Token should be efficient.
+// Filters using UserID or User use the user index,
+// and when sorted by ID only the authorizations of the requested page are decoded.
 // Other filters will do a linear scan across all authorizations searching for a match.
 func (c *Client) FindAuthorizations(ctx context.Context, filter platform.AuthorizationFilter, opt ...platform.FindOptions) ([]*platform.Authorization, int, error) {
 	if filter.ID != nil {
@@ -142,6 +143,10 @@ func (c *Client) FindAuthorizations(ctx context.Context, filter platform.Authori
 		return []*platform.Authorization{a}, 1, nil
 	}
 
+	if sortedByID(opt) {
+		return c.findAuthorizationsPage(ctx, filter, findOptions(opt))
+	}
+
 	as := []*platform.Authorization{}
 	err := c.db.View(func(tx *bolt.Tx) error {
 		auths, err := c.findAuthorizations(ctx, tx, filter)
@@ -156,7 +161,52 @@ func (c *Client) FindAuthorizations(ctx context.Context, filter platform.Authori
 		return nil, 0, err
 	}
 
-	return as, len(as), nil
+	start, end, err := paginate(as, len(as), func(i int) platform.ID { return as[i].ID }, sortKeys{
+		"user": func(i int) string { return as[i].User },
+	}, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return as[start:end], len(as), nil
+}
+
+// findAuthorizationsPage finds the page of authorizations in ID order described by opt,
+// decoding only the authorizations of the page.
+func (c *Client) findAuthorizationsPage(ctx context.Context, f platform.AuthorizationFilter, opt platform.FindOptions) ([]*platform.Authorization, int, error) {
+	as := []*platform.Authorization{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		if f.User != nil {
+			u, err := c.findUserByName(ctx, tx, *f.User)
+			if err != nil {
+				return err
+			}
+			f.UserID = &u.ID
+		}
+
+		r := bucketRange(tx, authorizationBucket)
+		if f.UserID != nil {
+			r = indexRange(tx, authorizationUserIndex, *f.UserID)
+		}
+
+		var ids []platform.ID
+		ids, n = pageIDs(r, opt)
+		for _, id := range ids {
+			a, err := c.findAuthorizationByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			as = append(as, a)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return as, n, nil
 }
 
 func (c *Client) findAuthorizations(ctx context.Context, tx *bolt.Tx, f platform.AuthorizationFilter) ([]*platform.Authorization, error) {
@@ -171,12 +221,19 @@ func (c *Client) findAuthorizations(ctx context.Context, tx *bolt.Tx, f platform
 
 	as := []*platform.Authorization{}
 	filterFn := filterAuthorizationsFn(f)
-	err := c.forEachAuthorization(ctx, tx, func(a *platform.Authorization) bool {
+	fn := func(a *platform.Authorization) bool {
 		if filterFn(a) {
 			as = append(as, a)
 		}
 		return true
-	})
+	}
+
+	var err error
+	if f.UserID != nil {
+		err = c.forEachUserAuthorization(ctx, tx, *f.UserID, fn)
+	} else {
+		err = c.forEachAuthorization(ctx, tx, fn)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -198,8 +255,7 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 		unique := c.uniqueAuthorizationToken(ctx, tx, a)
 
 		if !unique {
-			// TODO: make standard error
-			return fmt.Errorf("token already exists")
+			return kerrors.Conflictf("token already exists")
 		}
 
 		token, err := c.TokenGenerator.Token()
@@ -209,8 +265,12 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 		a.Token = token
 
 		a.ID = c.IDGenerator.ID()
+		createMeta(ctx, &a.Meta)
 
-		return c.putAuthorization(ctx, tx, a)
+		if err := c.putAuthorization(ctx, tx, a); err != nil {
+			return err
+		}
+		return c.appendAuditEvent(ctx, tx, platform.CreateAuditAction, platform.AuthorizationAuditResource, a.ID)
 	})
 }
 
@@ -231,7 +291,11 @@ func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.
 	if err := tx.Bucket(authorizationIndex).Put(authorizationIndexKey(a.Token), a.ID); err != nil {
 		return err
 	}
+	if err := putSecondaryIndex(tx, authorizationUserIndex, a.UserID, a.ID); err != nil {
+		return err
+	}
 	if err := tx.Bucket(authorizationBucket).Put(a.ID, v); err != nil {
+		return err
 	}
 	return c.setUserOnAuthorization(ctx, tx, a)
 }
@@ -259,6 +323,17 @@ func (c *Client) forEachAuthorization(ctx context.Context, tx *bolt.Tx, fn func(
 	return nil
 }
 
+// forEachUserAuthorization will iterate through the authorizations of a user while fn returns true.
+func (c *Client) forEachUserAuthorization(ctx context.Context, tx *bolt.Tx, userID platform.ID, fn func(*platform.Authorization) bool) error {
+	return forEachSecondaryIndex(tx, authorizationUserIndex, userID, func(id platform.ID) (bool, error) {
+		a, err := c.findAuthorizationByID(ctx, tx, id)
+		if err != nil {
+			return false, err
+		}
+		return fn(a), nil
+	})
+}
+
 func (c *Client) uniqueAuthorizationToken(ctx context.Context, tx *bolt.Tx, a *platform.Authorization) bool {
 	v := tx.Bucket(authorizationIndex).Get(authorizationIndexKey(a.Token))
 	return len(v) == 0
@@ -279,5 +354,11 @@ func (c *Client) deleteAuthorization(ctx context.Context, tx *bolt.Tx, id platfo
 	if err := tx.Bucket(authorizationIndex).Delete(authorizationIndexKey(a.Token)); err != nil {
 		return err
 	}
-	return tx.Bucket(authorizationBucket).Delete(id)
+	if err := deleteSecondaryIndex(tx, authorizationUserIndex, a.UserID, id); err != nil {
+		return err
+	}
+	if err := tx.Bucket(authorizationBucket).Delete(id); err != nil {
+		return err
+	}
+	return c.appendAuditEvent(ctx, tx, platform.DeleteAuditAction, platform.AuthorizationAuditResource, id)
 }
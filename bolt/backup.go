@@ -0,0 +1,73 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// Backup writes a consistent copy of the database to w while it remains open for reads and writes.
+func (c *Client) Backup(ctx context.Context, w io.Writer) error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
+
+// Restore replaces the database at path with the backup read from r.
+// The database must not be open, and the backup is verified to be a bolt database
+// before the database is replaced.
+func Restore(ctx context.Context, path string, r io.Reader) error {
+	if _, err := os.Stat(path); err == nil {
+		// Make sure the database is not in use.
+		db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+		if err != nil {
+			return fmt.Errorf(ErrUnableToOpen, err)
+		}
+		db.Close()
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".restore-")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	db, err := bolt.Open(tmp, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("invalid backup: %v", err)
+	}
+	err = db.View(func(tx *bolt.Tx) error {
+		// Drain all errors so the checking goroutine exits.
+		var first error
+		for err := range tx.Check() {
+			if first == nil {
+				first = err
+			}
+		}
+		return first
+	})
+	db.Close()
+	if err != nil {
+		return fmt.Errorf("invalid backup: %v", err)
+	}
+
+	return os.Rename(tmp, path)
+}
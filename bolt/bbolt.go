@@ -23,6 +23,9 @@ const (
 	ErrUnableToMigrate = "Unable to migrate boltdb:  %v"
 )
 
+// DefaultMaxRunsPerTask is the number of most recent runs kept for each task by default.
+const DefaultMaxRunsPerTask = 1000
+
 // Client is a client for the boltDB data store.
 type Client struct {
 	Path string
@@ -30,6 +33,14 @@ type Client struct {
 
 	IDGenerator    platform.IDGenerator
 	TokenGenerator platform.TokenGenerator
+
+	// Migrations are applied to the database when it is opened.
+	Migrations []Migration
+
+	// MaxRunsPerTask is the number of most recent runs kept for each task.
+	// Older runs are deleted with their logs when a new run is created, once they have finished.
+	// Zero keeps every run.
+	MaxRunsPerTask int
 }
 
 // NewClient returns an instance of a Client.
@@ -37,13 +48,18 @@ func NewClient() *Client {
 	return &Client{
 		IDGenerator:    snowflake.NewIDGenerator(),
 		TokenGenerator: rand.NewTokenGenerator(64),
+		Migrations:     Migrations,
+		MaxRunsPerTask: DefaultMaxRunsPerTask,
 	}
 }
 
-// Open / create boltDB file.
+// Open / create boltDB file and migrate it to the latest schema version.
 func (c *Client) Open(ctx context.Context) error {
-	if _, err := os.Stat(c.Path); err != nil && !os.IsNotExist(err) {
+	created := false
+	if fi, err := os.Stat(c.Path); err != nil && !os.IsNotExist(err) {
 		return err
+	} else if err != nil || fi.Size() == 0 {
+		created = true
 	}
 
 	// Open database file.
@@ -53,7 +69,17 @@ func (c *Client) Open(ctx context.Context) error {
 	}
 	c.db = db
 
-	return c.initialize(context.TODO())
+	if err := c.initialize(ctx); err != nil {
+		c.Close()
+		return err
+	}
+
+	if err := c.migrate(ctx, created); err != nil {
+		c.Close()
+		return err
+	}
+
+	return nil
 }
 
 // initialize creates Buckets that are missing
@@ -83,6 +109,26 @@ func (c *Client) initialize(ctx context.Context) error {
 		if err := c.initializeAuthorizations(ctx, tx); err != nil {
 			return err
 		}
+
+		// Always create Task buckets.
+		if err := c.initializeTasks(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create secondary index buckets.
+		if err := c.initializeIndexes(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Audit bucket.
+		if err := c.initializeAudit(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Migrations bucket.
+		if err := c.initializeMigrations(ctx, tx); err != nil {
+			return err
+		}
 		return nil
 	}); err != nil {
 		return err
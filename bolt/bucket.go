@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 )
 
 var (
@@ -60,8 +60,7 @@ func (c *Client) findBucketByID(ctx context.Context, tx *bolt.Tx, id platform.ID
 	v := tx.Bucket(bucketBucket).Get(id)
 
 	if len(v) == 0 {
-		// TODO: Make standard error
-		return nil, fmt.Errorf("bucket not found")
+		return nil, kerrors.NotFoundf("bucket not found")
 	}
 
 	if err := json.Unmarshal(v, &b); err != nil {
@@ -138,7 +137,7 @@ func (c *Client) FindBucket(ctx context.Context, filter platform.BucketFilter) (
 	}
 
 	if b == nil {
-		return nil, fmt.Errorf("bucket not found")
+		return nil, kerrors.NotFoundf("bucket not found")
 	}
 
 	return b, nil
@@ -174,6 +173,8 @@ func filterBucketsFn(filter platform.BucketFilter) func(b *platform.Bucket) bool
 
 // FindBuckets retrives all buckets that match an arbitrary bucket filter.
 // Filters using ID, or OrganizationID and bucket Name should be efficient.
+// Filters using OrganizationID or Organization use the organization index,
+// and when sorted by ID only the buckets of the requested page are decoded.
 // Other filters will do a linear scan across all buckets searching for a match.
 func (c *Client) FindBuckets(ctx context.Context, filter platform.BucketFilter, opt ...platform.FindOptions) ([]*platform.Bucket, int, error) {
 	if filter.ID != nil {
@@ -194,6 +195,10 @@ func (c *Client) FindBuckets(ctx context.Context, filter platform.BucketFilter,
 		return []*platform.Bucket{b}, 1, nil
 	}
 
+	if filter.Name == nil && sortedByID(opt) {
+		return c.findBucketsPage(ctx, filter, findOptions(opt))
+	}
+
 	bs := []*platform.Bucket{}
 	err := c.db.View(func(tx *bolt.Tx) error {
 		bkts, err := c.findBuckets(ctx, tx, filter)
@@ -208,7 +213,53 @@ func (c *Client) FindBuckets(ctx context.Context, filter platform.BucketFilter,
 		return nil, 0, err
 	}
 
-	return bs, len(bs), nil
+	start, end, err := paginate(bs, len(bs), func(i int) platform.ID { return bs[i].ID }, sortKeys{
+		"name":         func(i int) string { return bs[i].Name },
+		"organization": func(i int) string { return bs[i].Organization },
+	}, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bs[start:end], len(bs), nil
+}
+
+// findBucketsPage finds the page of buckets in ID order described by opt,
+// decoding only the buckets of the page.
+func (c *Client) findBucketsPage(ctx context.Context, filter platform.BucketFilter, opt platform.FindOptions) ([]*platform.Bucket, int, error) {
+	bs := []*platform.Bucket{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		if filter.Organization != nil {
+			o, err := c.findOrganizationByName(ctx, tx, *filter.Organization)
+			if err != nil {
+				return err
+			}
+			filter.OrganizationID = &o.ID
+		}
+
+		r := bucketRange(tx, bucketBucket)
+		if filter.OrganizationID != nil {
+			r = indexRange(tx, bucketOrganizationIndex, *filter.OrganizationID)
+		}
+
+		var ids []platform.ID
+		ids, n = pageIDs(r, opt)
+		for _, id := range ids {
+			b, err := c.findBucketByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			bs = append(bs, b)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bs, n, nil
 }
 
 func (c *Client) findBuckets(ctx context.Context, tx *bolt.Tx, filter platform.BucketFilter) ([]*platform.Bucket, error) {
@@ -222,12 +273,19 @@ func (c *Client) findBuckets(ctx context.Context, tx *bolt.Tx, filter platform.B
 	}
 
 	filterFn := filterBucketsFn(filter)
-	err := c.forEachBucket(ctx, tx, func(b *platform.Bucket) bool {
+	fn := func(b *platform.Bucket) bool {
 		if filterFn(b) {
 			bs = append(bs, b)
 		}
 		return true
-	})
+	}
+
+	var err error
+	if filter.OrganizationID != nil {
+		err = c.forEachOrganizationBucket(ctx, tx, *filter.OrganizationID, fn)
+	} else {
+		err = c.forEachBucket(ctx, tx, fn)
+	}
 
 	if err != nil {
 		return nil, err
@@ -250,13 +308,16 @@ func (c *Client) CreateBucket(ctx context.Context, b *platform.Bucket) error {
 		unique := c.uniqueBucketName(ctx, tx, b)
 
 		if !unique {
-			// TODO: make standard error
-			return fmt.Errorf("bucket with name %s already exists", b.Name)
+			return kerrors.Conflictf("bucket with name %s already exists", b.Name)
 		}
 
 		b.ID = c.IDGenerator.ID()
+		createMeta(ctx, &b.Meta)
 
-		return c.putBucket(ctx, tx, b)
+		if err := c.putBucket(ctx, tx, b); err != nil {
+			return err
+		}
+		return c.appendAuditEvent(ctx, tx, platform.CreateAuditAction, platform.BucketAuditResource, b.ID)
 	})
 }
 
@@ -277,6 +338,9 @@ func (c *Client) putBucket(ctx context.Context, tx *bolt.Tx, b *platform.Bucket)
 	if err := tx.Bucket(bucketIndex).Put(bucketIndexKey(b), b.ID); err != nil {
 		return err
 	}
+	if err := putSecondaryIndex(tx, bucketOrganizationIndex, b.OrganizationID, b.ID); err != nil {
+		return err
+	}
 	if err := tx.Bucket(bucketBucket).Put(b.ID, v); err != nil {
 		return err
 	}
@@ -309,6 +373,17 @@ func (c *Client) forEachBucket(ctx context.Context, tx *bolt.Tx, fn func(*platfo
 	return nil
 }
 
+// forEachOrganizationBucket will iterate through the buckets of an organization while fn returns true.
+func (c *Client) forEachOrganizationBucket(ctx context.Context, tx *bolt.Tx, orgID platform.ID, fn func(*platform.Bucket) bool) error {
+	return forEachSecondaryIndex(tx, bucketOrganizationIndex, orgID, func(id platform.ID) (bool, error) {
+		b, err := c.findBucketByID(ctx, tx, id)
+		if err != nil {
+			return false, err
+		}
+		return fn(b), nil
+	})
+}
+
 func (c *Client) uniqueBucketName(ctx context.Context, tx *bolt.Tx, b *platform.Bucket) bool {
 	v := tx.Bucket(bucketIndex).Get(bucketIndexKey(b))
 	return len(v) == 0
@@ -348,10 +423,15 @@ func (c *Client) updateBucket(ctx context.Context, tx *bolt.Tx, id platform.ID,
 		b.Name = *upd.Name
 	}
 
+	updateMeta(ctx, &b.Meta)
 	if err := c.putBucket(ctx, tx, b); err != nil {
 		return nil, err
 	}
 
+	if err := c.appendAuditEvent(ctx, tx, platform.UpdateAuditAction, platform.BucketAuditResource, b.ID); err != nil {
+		return nil, err
+	}
+
 	if err := c.setOrganizationOnBucket(ctx, tx, b); err != nil {
 		return nil, err
 	}
@@ -375,5 +455,11 @@ func (c *Client) deleteBucket(ctx context.Context, tx *bolt.Tx, id platform.ID)
 	if err := tx.Bucket(bucketIndex).Delete(bucketIndexKey(b)); err != nil {
 		return err
 	}
-	return tx.Bucket(bucketBucket).Delete(id)
+	if err := deleteSecondaryIndex(tx, bucketOrganizationIndex, b.OrganizationID, b.ID); err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketBucket).Delete(id); err != nil {
+		return err
+	}
+	return c.appendAuditEvent(ctx, tx, platform.DeleteAuditAction, platform.BucketAuditResource, id)
 }
@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 )
 
 var (
@@ -56,8 +56,7 @@ func (c *Client) findDashboardByID(ctx context.Context, tx *bolt.Tx, id platform
 	v := tx.Bucket(dashboardBucket).Get(id)
 
 	if len(v) == 0 {
-		// TODO: Make standard error
-		return nil, fmt.Errorf("dashboard not found")
+		return nil, kerrors.NotFoundf("dashboard not found")
 	}
 
 	if err := json.Unmarshal(v, &d); err != nil {
@@ -104,7 +103,7 @@ func (c *Client) FindDashboard(ctx context.Context, filter platform.DashboardFil
 	}
 
 	if d == nil {
-		return nil, fmt.Errorf("dashboard not found")
+		return nil, kerrors.NotFoundf("dashboard not found")
 	}
 
 	return d, nil
@@ -137,9 +136,11 @@ func (c *Client) FindDashboardsByOrganizationName(ctx context.Context, org strin
 }
 
 // FindDashboards retrives all dashboards that match an arbitrary dashboard filter.
-// Filters using ID, or OrganizationID and dashboard Name should be efficient.
+// Filters using ID should be efficient.
+// Filters using OrganizationID or Organization use the organization index,
+// and when sorted by ID only the dashboards of the requested page are decoded.
 // Other filters will do a linear scan across all dashboards searching for a match.
-func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFilter) ([]*platform.Dashboard, int, error) {
+func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFilter, opt ...platform.FindOptions) ([]*platform.Dashboard, int, error) {
 	if filter.ID != nil {
 		d, err := c.FindDashboardByID(ctx, *filter.ID)
 		if err != nil {
@@ -149,6 +150,10 @@ func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFi
 		return []*platform.Dashboard{d}, 1, nil
 	}
 
+	if sortedByID(opt) {
+		return c.findDashboardsPage(ctx, filter, findOptions(opt))
+	}
+
 	ds := []*platform.Dashboard{}
 	err := c.db.View(func(tx *bolt.Tx) error {
 		dashs, err := c.findDashboards(ctx, tx, filter)
@@ -163,7 +168,53 @@ func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFi
 		return nil, 0, err
 	}
 
-	return ds, len(ds), nil
+	start, end, err := paginate(ds, len(ds), func(i int) platform.ID { return ds[i].ID }, sortKeys{
+		"name":         func(i int) string { return ds[i].Name },
+		"organization": func(i int) string { return ds[i].Organization },
+	}, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ds[start:end], len(ds), nil
+}
+
+// findDashboardsPage finds the page of dashboards in ID order described by opt,
+// decoding only the dashboards of the page.
+func (c *Client) findDashboardsPage(ctx context.Context, filter platform.DashboardFilter, opt platform.FindOptions) ([]*platform.Dashboard, int, error) {
+	ds := []*platform.Dashboard{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		if filter.Organization != nil {
+			o, err := c.findOrganizationByName(ctx, tx, *filter.Organization)
+			if err != nil {
+				return err
+			}
+			filter.OrganizationID = &o.ID
+		}
+
+		r := bucketRange(tx, dashboardBucket)
+		if filter.OrganizationID != nil {
+			r = indexRange(tx, dashboardOrganizationIndex, *filter.OrganizationID)
+		}
+
+		var ids []platform.ID
+		ids, n = pageIDs(r, opt)
+		for _, id := range ids {
+			d, err := c.findDashboardByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			ds = append(ds, d)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ds, n, nil
 }
 
 func (c *Client) findDashboards(ctx context.Context, tx *bolt.Tx, filter platform.DashboardFilter) ([]*platform.Dashboard, error) {
@@ -177,12 +228,19 @@ func (c *Client) findDashboards(ctx context.Context, tx *bolt.Tx, filter platfor
 	}
 
 	filterFn := filterDashboardsFn(filter)
-	err := c.forEachDashboard(ctx, tx, func(d *platform.Dashboard) bool {
+	fn := func(d *platform.Dashboard) bool {
 		if filterFn(d) {
 			ds = append(ds, d)
 		}
 		return true
-	})
+	}
+
+	var err error
+	if filter.OrganizationID != nil {
+		err = c.forEachOrganizationDashboard(ctx, tx, *filter.OrganizationID, fn)
+	} else {
+		err = c.forEachDashboard(ctx, tx, fn)
+	}
 
 	if err != nil {
 		return nil, err
@@ -202,14 +260,22 @@ func (c *Client) CreateDashboard(ctx context.Context, d *platform.Dashboard) err
 			d.OrganizationID = o.ID
 		}
 
+		if err := d.Validate(); err != nil {
+			return kerrors.InvalidDataf("invalid dashboard: %v", err)
+		}
+
 		d.ID = c.IDGenerator.ID()
 
 		for i, cell := range d.Cells {
 			cell.ID = c.IDGenerator.ID()
 			d.Cells[i] = cell
 		}
+		createMeta(ctx, &d.Meta)
 
-		return c.putDashboard(ctx, tx, d)
+		if err := c.putDashboard(ctx, tx, d); err != nil {
+			return err
+		}
+		return c.appendAuditEvent(ctx, tx, platform.CreateAuditAction, platform.DashboardAuditResource, d.ID)
 	})
 }
 
@@ -226,12 +292,24 @@ func (c *Client) putDashboard(ctx context.Context, tx *bolt.Tx, d *platform.Dash
 	if err != nil {
 		return err
 	}
+	if err := putSecondaryIndex(tx, dashboardOrganizationIndex, d.OrganizationID, d.ID); err != nil {
+		return err
+	}
 	if err := tx.Bucket(dashboardBucket).Put(d.ID, v); err != nil {
 		return err
 	}
 	return c.setOrganizationOnDashboard(ctx, tx, d)
 }
 
+// putUpdatedDashboard puts a changed dashboard, recording the update in its metadata and the audit log.
+func (c *Client) putUpdatedDashboard(ctx context.Context, tx *bolt.Tx, d *platform.Dashboard) error {
+	updateMeta(ctx, &d.Meta)
+	if err := c.putDashboard(ctx, tx, d); err != nil {
+		return err
+	}
+	return c.appendAuditEvent(ctx, tx, platform.UpdateAuditAction, platform.DashboardAuditResource, d.ID)
+}
+
 // forEachDashboard will iterate through all dashboards while fn returns true.
 func (c *Client) forEachDashboard(ctx context.Context, tx *bolt.Tx, fn func(*platform.Dashboard) bool) error {
 	cur := tx.Bucket(dashboardBucket).Cursor()
@@ -251,6 +329,17 @@ func (c *Client) forEachDashboard(ctx context.Context, tx *bolt.Tx, fn func(*pla
 	return nil
 }
 
+// forEachOrganizationDashboard will iterate through the dashboards of an organization while fn returns true.
+func (c *Client) forEachOrganizationDashboard(ctx context.Context, tx *bolt.Tx, orgID platform.ID, fn func(*platform.Dashboard) bool) error {
+	return forEachSecondaryIndex(tx, dashboardOrganizationIndex, orgID, func(id platform.ID) (bool, error) {
+		d, err := c.findDashboardByID(ctx, tx, id)
+		if err != nil {
+			return false, err
+		}
+		return fn(d), nil
+	})
+}
+
 // UpdateDashboard updates a dashboard according the parameters set on upd.
 func (c *Client) UpdateDashboard(ctx context.Context, id platform.ID, upd platform.DashboardUpdate) (*platform.Dashboard, error) {
 	var d *platform.Dashboard
@@ -276,7 +365,14 @@ func (c *Client) updateDashboard(ctx context.Context, tx *bolt.Tx, id platform.I
 		d.Name = *upd.Name
 	}
 
-	if err := c.putDashboard(ctx, tx, d); err != nil {
+	if upd.Variables != nil {
+		if err := platform.ValidateDashboardVariables(*upd.Variables); err != nil {
+			return nil, kerrors.InvalidDataf("invalid variables: %v", err)
+		}
+		d.Variables = *upd.Variables
+	}
+
+	if err := c.putUpdatedDashboard(ctx, tx, d); err != nil {
 		return nil, err
 	}
 
@@ -295,15 +391,25 @@ func (c *Client) DeleteDashboard(ctx context.Context, id platform.ID) error {
 }
 
 func (c *Client) deleteDashboard(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
-	_, err := c.findDashboardByID(ctx, tx, id)
+	d, err := c.findDashboardByID(ctx, tx, id)
 	if err != nil {
 		return err
 	}
-	return tx.Bucket(dashboardBucket).Delete(id)
+	if err := deleteSecondaryIndex(tx, dashboardOrganizationIndex, d.OrganizationID, id); err != nil {
+		return err
+	}
+	if err := tx.Bucket(dashboardBucket).Delete(id); err != nil {
+		return err
+	}
+	return c.appendAuditEvent(ctx, tx, platform.DeleteAuditAction, platform.DashboardAuditResource, id)
 }
 
 // AddDashboardCell adds a cell to a dashboard.
+// The cell is validated before it is added.
 func (c *Client) AddDashboardCell(ctx context.Context, dashboardID platform.ID, cell *platform.DashboardCell) error {
+	if err := cell.Validate(); err != nil {
+		return kerrors.InvalidDataf("invalid cell: %v", err)
+	}
 	return c.db.Update(func(tx *bolt.Tx) error {
 		d, err := c.findDashboardByID(ctx, tx, dashboardID)
 		if err != nil {
@@ -311,12 +417,16 @@ func (c *Client) AddDashboardCell(ctx context.Context, dashboardID platform.ID,
 		}
 		cell.ID = c.IDGenerator.ID()
 		d.Cells = append(d.Cells, *cell)
-		return c.putDashboard(ctx, tx, d)
+		return c.putUpdatedDashboard(ctx, tx, d)
 	})
 }
 
 // ReplaceDashboardCell updates a cell in a dashboard.
+// The cell is validated before it replaces the existing cell.
 func (c *Client) ReplaceDashboardCell(ctx context.Context, dashboardID platform.ID, dc *platform.DashboardCell) error {
+	if err := dc.Validate(); err != nil {
+		return kerrors.InvalidDataf("invalid cell: %v", err)
+	}
 	return c.db.Update(func(tx *bolt.Tx) error {
 		d, err := c.findDashboardByID(ctx, tx, dashboardID)
 		if err != nil {
@@ -331,12 +441,12 @@ func (c *Client) ReplaceDashboardCell(ctx context.Context, dashboardID platform.
 		}
 
 		if idx == -1 {
-			return fmt.Errorf("cell not found")
+			return kerrors.NotFoundf("cell not found")
 		}
 
 		d.Cells[idx] = *dc
 
-		return c.putDashboard(ctx, tx, d)
+		return c.putUpdatedDashboard(ctx, tx, d)
 	})
 }
 
@@ -356,12 +466,12 @@ func (c *Client) RemoveDashboardCell(ctx context.Context, dashboardID platform.I
 		}
 
 		if idx == -1 {
-			return fmt.Errorf("cell not found")
+			return kerrors.NotFoundf("cell not found")
 		}
 
 		// Remove cell
 		d.Cells = append(d.Cells[:idx], d.Cells[idx+1:]...)
 
-		return c.putDashboard(ctx, tx, d)
+		return c.putUpdatedDashboard(ctx, tx, d)
 	})
 }
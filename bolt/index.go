@@ -0,0 +1,161 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+// Secondary indexes map the ID of a parent, such as an organization, to the IDs of its
+// children, such as its buckets. They are kept up to date in the transaction that
+// puts or deletes a child, and can be rebuilt from the children with rebuildIndexes.
+var (
+	bucketOrganizationIndex    = []byte("bucketorganizationindexv1")
+	dashboardOrganizationIndex = []byte("dashboardorganizationindexv1")
+	authorizationUserIndex     = []byte("authorizationuserindexv1")
+)
+
+// secondaryIndexes are the secondary indexes of each bucket of children,
+// with a function returning the parent ID of an encoded child.
+var secondaryIndexes = []struct {
+	index    []byte
+	children []byte
+	parent   func(v []byte) (platform.ID, error)
+}{
+	{
+		index:    bucketOrganizationIndex,
+		children: bucketBucket,
+		parent: func(v []byte) (platform.ID, error) {
+			var b platform.Bucket
+			err := json.Unmarshal(v, &b)
+			return b.OrganizationID, err
+		},
+	},
+	{
+		index:    dashboardOrganizationIndex,
+		children: dashboardBucket,
+		parent: func(v []byte) (platform.ID, error) {
+			var d platform.Dashboard
+			err := json.Unmarshal(v, &d)
+			return d.OrganizationID, err
+		},
+	},
+	{
+		index:    authorizationUserIndex,
+		children: authorizationBucket,
+		parent: func(v []byte) (platform.ID, error) {
+			var a platform.Authorization
+			err := json.Unmarshal(v, &a)
+			return a.UserID, err
+		},
+	},
+}
+
+func (c *Client) initializeIndexes(ctx context.Context, tx *bolt.Tx) error {
+	for _, idx := range secondaryIndexes {
+		if _, err := tx.CreateBucketIfNotExists(idx.index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secondaryIndexPrefix returns the prefix of the keys of the children of parent.
+// The parent is prefixed by its length so that IDs of any length can be indexed.
+func secondaryIndexPrefix(parent platform.ID) []byte {
+	k := make([]byte, 1+len(parent))
+	k[0] = byte(len(parent))
+	copy(k[1:], parent)
+	return k
+}
+
+func secondaryIndexKey(parent, child platform.ID) []byte {
+	return append(secondaryIndexPrefix(parent), child...)
+}
+
+// putSecondaryIndex indexes child under parent, removing it from its previous parent if it moved.
+// It must be called before the child is stored.
+func putSecondaryIndex(tx *bolt.Tx, index []byte, parent, child platform.ID) error {
+	previous, err := storedParent(tx, index, child)
+	if err != nil {
+		return err
+	}
+	if previous != nil && !bytes.Equal(previous, parent) {
+		if err := deleteSecondaryIndex(tx, index, previous, child); err != nil {
+			return err
+		}
+	}
+	return tx.Bucket(index).Put(secondaryIndexKey(parent, child), child)
+}
+
+// storedParent returns the parent of the stored child in the index, or nil if the child is not stored.
+func storedParent(tx *bolt.Tx, index []byte, child platform.ID) (platform.ID, error) {
+	for _, idx := range secondaryIndexes {
+		if !bytes.Equal(idx.index, index) {
+			continue
+		}
+		v := tx.Bucket(idx.children).Get(child)
+		if v == nil {
+			return nil, nil
+		}
+		return idx.parent(v)
+	}
+	return nil, nil
+}
+
+func deleteSecondaryIndex(tx *bolt.Tx, index []byte, parent, child platform.ID) error {
+	return tx.Bucket(index).Delete(secondaryIndexKey(parent, child))
+}
+
+// forEachSecondaryIndex calls fn with the IDs of the children of parent in ID order while fn returns true.
+func forEachSecondaryIndex(tx *bolt.Tx, index []byte, parent platform.ID, fn func(child platform.ID) (bool, error)) error {
+	prefix := secondaryIndexPrefix(parent)
+	cur := tx.Bucket(index).Cursor()
+	for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+		ok, err := fn(platform.ID(v))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+	}
+	return nil
+}
+
+// RebuildIndexes rebuilds the secondary indexes from the records they index.
+func (c *Client) RebuildIndexes(ctx context.Context) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return rebuildIndexes(ctx, tx)
+	})
+}
+
+func rebuildIndexes(ctx context.Context, tx *bolt.Tx) error {
+	for _, idx := range secondaryIndexes {
+		if tx.Bucket(idx.index) != nil {
+			if err := tx.DeleteBucket(idx.index); err != nil {
+				return err
+			}
+		}
+		b, err := tx.CreateBucket(idx.index)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Bucket(idx.children).ForEach(func(k, v []byte) error {
+			parent, err := idx.parent(v)
+			if err != nil {
+				return err
+			}
+			child := append(platform.ID(nil), k...)
+			return b.Put(secondaryIndexKey(parent, child), child)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
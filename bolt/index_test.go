@@ -0,0 +1,209 @@
+package bolt_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+)
+
+func TestClient_SecondaryIndexes(t *testing.T) {
+	ctx := context.Background()
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFn()
+
+	org1 := &platform.Organization{ID: platform.ID("org1"), Name: "org1"}
+	org2 := &platform.Organization{ID: platform.ID("org2"), Name: "org2"}
+	for _, o := range []*platform.Organization{org1, org2} {
+		if err := c.PutOrganization(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bucketsOf := func(orgID platform.ID) []string {
+		t.Helper()
+		bs, _, err := c.FindBuckets(ctx, platform.BucketFilter{OrganizationID: &orgID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, b := range bs {
+			names = append(names, b.Name)
+		}
+		return names
+	}
+
+	b1 := &platform.Bucket{ID: platform.ID("b1"), OrganizationID: org1.ID, Name: "b1"}
+	b2 := &platform.Bucket{ID: platform.ID("b2"), OrganizationID: org1.ID, Name: "b2"}
+	for _, b := range []*platform.Bucket{b1, b2} {
+		if err := c.PutBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := bucketsOf(org1.ID); fmt.Sprint(got) != "[b1 b2]" {
+		t.Errorf("unexpected buckets of org1: %v", got)
+	}
+
+	// Moving a bucket to another organization updates both organizations.
+	b1.OrganizationID = org2.ID
+	if err := c.PutBucket(ctx, b1); err != nil {
+		t.Fatal(err)
+	}
+	if got := bucketsOf(org1.ID); fmt.Sprint(got) != "[b2]" {
+		t.Errorf("unexpected buckets of org1: %v", got)
+	}
+	if got := bucketsOf(org2.ID); fmt.Sprint(got) != "[b1]" {
+		t.Errorf("unexpected buckets of org2: %v", got)
+	}
+
+	if err := c.DeleteBucket(ctx, b2.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := bucketsOf(org1.ID); len(got) != 0 {
+		t.Errorf("expected no buckets of org1, got %v", got)
+	}
+
+	// Rebuilding the indexes gives the same results.
+	if err := c.RebuildIndexes(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := bucketsOf(org2.ID); fmt.Sprint(got) != "[b1]" {
+		t.Errorf("unexpected buckets of org2 after rebuild: %v", got)
+	}
+
+	// Authorizations are indexed by user.
+	u := &platform.User{ID: platform.ID("user1"), Name: "user1"}
+	if err := c.PutUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	a := &platform.Authorization{UserID: u.ID}
+	if err := c.CreateAuthorization(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	as, _, err := c.FindAuthorizations(ctx, platform.AuthorizationFilter{User: &u.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(as) != 1 || !bytes.Equal(as[0].ID, a.ID) {
+		t.Errorf("expected the authorization of user1, got %v", as)
+	}
+	if err := c.DeleteAuthorization(ctx, a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if as, _, err := c.FindAuthorizations(ctx, platform.AuthorizationFilter{UserID: &u.ID}); err != nil || len(as) != 0 {
+		t.Errorf("expected no authorizations of user1, got %v %v", as, err)
+	}
+}
+
+// newBenchmarkClient returns a client with orgs organizations of perOrg buckets each.
+func newBenchmarkClient(b *testing.B, orgs, perOrg int) (*bolt.Client, []platform.ID, func()) {
+	ctx := context.Background()
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var orgIDs []platform.ID
+	for i := 0; i < orgs; i++ {
+		o := &platform.Organization{Name: fmt.Sprintf("org%d", i)}
+		if err := c.CreateOrganization(ctx, o); err != nil {
+			b.Fatal(err)
+		}
+		orgIDs = append(orgIDs, o.ID)
+		for j := 0; j < perOrg; j++ {
+			if err := c.CreateBucket(ctx, &platform.Bucket{OrganizationID: o.ID, Name: fmt.Sprintf("bucket%d", j)}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	return c, orgIDs, closeFn
+}
+
+// BenchmarkFindBuckets_Organization compares finding the buckets of an organization
+// with the organization index to the linear scan across all buckets it replaced.
+func BenchmarkFindBuckets_Organization(b *testing.B) {
+	ctx := context.Background()
+	c, orgIDs, closeFn := newBenchmarkClient(b, 50, 10)
+	defer closeFn()
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			orgID := orgIDs[i%len(orgIDs)]
+			if _, _, err := c.FindBuckets(ctx, platform.BucketFilter{OrganizationID: &orgID}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			orgID := orgIDs[i%len(orgIDs)]
+			bs, _, err := c.FindBuckets(ctx, platform.BucketFilter{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			var found []*platform.Bucket
+			for _, bkt := range bs {
+				if bytes.Equal(bkt.OrganizationID, orgID) {
+					found = append(found, bkt)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkFindAuthorizations_User compares finding the authorizations of a user
+// with the user index to the linear scan across all authorizations it replaced.
+func BenchmarkFindAuthorizations_User(b *testing.B) {
+	ctx := context.Background()
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer closeFn()
+
+	var userIDs []platform.ID
+	for i := 0; i < 50; i++ {
+		u := &platform.User{Name: fmt.Sprintf("user%d", i)}
+		if err := c.CreateUser(ctx, u); err != nil {
+			b.Fatal(err)
+		}
+		userIDs = append(userIDs, u.ID)
+		for j := 0; j < 10; j++ {
+			if err := c.CreateAuthorization(ctx, &platform.Authorization{UserID: u.ID}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			userID := userIDs[i%len(userIDs)]
+			if _, _, err := c.FindAuthorizations(ctx, platform.AuthorizationFilter{UserID: &userID}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			userID := userIDs[i%len(userIDs)]
+			as, _, err := c.FindAuthorizations(ctx, platform.AuthorizationFilter{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			var found []*platform.Authorization
+			for _, a := range as {
+				if bytes.Equal(a.UserID, userID) {
+					found = append(found, a)
+				}
+			}
+		}
+	})
+}
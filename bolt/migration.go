@@ -0,0 +1,132 @@
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/bbolt"
+)
+
+var (
+	migrationBucket = []byte("migrationsv1")
+	versionKey      = []byte("version")
+)
+
+// Migration is a change to the schema of the bolt database.
+type Migration struct {
+	// Version is the schema version of the database after the migration.
+	Version int
+	// Description describes the change made by the migration.
+	Description string
+	// Up migrates the database from the previous version.
+	Up func(ctx context.Context, tx *bolt.Tx) error
+}
+
+// Migrations are the migrations of the bolt database schema in version order.
+// The schema created by initialize, without any migrations applied, is version 0.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "index buckets and dashboards by organization and authorizations by user",
+		Up:          rebuildIndexes,
+	},
+	{
+		Version:     2,
+		Description: "index queued runs by task",
+		Up:          indexQueuedRuns,
+	},
+}
+
+func (c *Client) initializeMigrations(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(migrationBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Version returns the schema version of the database.
+func (c *Client) Version(ctx context.Context) (int, error) {
+	var v int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		v = schemaVersion(tx)
+		return nil
+	})
+	return v, err
+}
+
+func schemaVersion(tx *bolt.Tx) int {
+	v := tx.Bucket(migrationBucket).Get(versionKey)
+	if v == nil {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(v))
+}
+
+func setSchemaVersion(tx *bolt.Tx, version int) error {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(version))
+	return tx.Bucket(migrationBucket).Put(versionKey, v)
+}
+
+// migrate applies the pending migrations, each in its own transaction.
+// Unless the database was just created, it is backed up before any migration is applied.
+func (c *Client) migrate(ctx context.Context, created bool) error {
+	version, err := c.Version(ctx)
+	if err != nil {
+		return fmt.Errorf(ErrUnableToMigrate, err)
+	}
+
+	var pending []Migration
+	for _, m := range c.Migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if !created {
+		if err := c.backupFile(ctx, c.backupPath(version)); err != nil {
+			return fmt.Errorf(ErrUnableToBackup, err)
+		}
+	}
+
+	for _, m := range pending {
+		err := c.db.Update(func(tx *bolt.Tx) error {
+			if err := m.Up(ctx, tx); err != nil {
+				return fmt.Errorf("migration %d (%s): %v", m.Version, m.Description, err)
+			}
+			return setSchemaVersion(tx, m.Version)
+		})
+		if err != nil {
+			return fmt.Errorf(ErrUnableToMigrate, err)
+		}
+	}
+
+	return nil
+}
+
+// backupPath returns the path the database is backed up to before migrating it from version.
+func (c *Client) backupPath(version int) string {
+	return filepath.Join(filepath.Dir(c.Path), "backup", fmt.Sprintf("%s.v%d", filepath.Base(c.Path), version))
+}
+
+func (c *Client) backupFile(ctx context.Context, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err := c.Backup(ctx, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
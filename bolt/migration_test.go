@@ -0,0 +1,141 @@
+package bolt_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bbolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+)
+
+func TestClient_Migrate(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "influxdata-platform-bolt-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "idpd.bolt")
+
+	var applied []int
+	migration := func(version int, err error) bolt.Migration {
+		return bolt.Migration{
+			Version:     version,
+			Description: "test",
+			Up: func(ctx context.Context, tx *bbolt.Tx) error {
+				applied = append(applied, version)
+				return err
+			},
+		}
+	}
+
+	open := func(ms ...bolt.Migration) (*bolt.Client, error) {
+		c := bolt.NewClient()
+		c.Path = path
+		c.Migrations = ms
+		return c, c.Open(ctx)
+	}
+
+	// A new database is migrated without a backup.
+	c, err := open(migration(1, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CreateUser(ctx, &platform.User{Name: "user1"}); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	if _, err := os.Stat(filepath.Join(dir, "backup")); !os.IsNotExist(err) {
+		t.Errorf("expected no backup of a new database, got %v", err)
+	}
+
+	// Only pending migrations are applied, after backing up the database.
+	c, err = open(migration(1, nil), migration(2, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Version(ctx); err != nil || v != 2 {
+		t.Errorf("expected version 2, got %d %v", v, err)
+	}
+	c.Close()
+	if want := []int{1, 2}; !equalInts(applied, want) {
+		t.Errorf("unexpected migrations applied: got %v want %v", applied, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "backup", "idpd.bolt.v1")); err != nil {
+		t.Errorf("expected backup of version 1: %v", err)
+	}
+
+	// A failed migration fails to open the database and leaves its version unchanged.
+	if _, err := open(migration(1, nil), migration(2, nil), migration(3, errors.New("oops"))); err == nil {
+		t.Fatal("expected failed migration to fail opening the database")
+	}
+	c, err = open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if v, err := c.Version(ctx); err != nil || v != 2 {
+		t.Errorf("expected version 2, got %d %v", v, err)
+	}
+}
+
+func TestClient_BackupRestore(t *testing.T) {
+	ctx := context.Background()
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFn()
+
+	if err := c.CreateUser(ctx, &platform.User{Name: "user1"}); err != nil {
+		t.Fatal(err)
+	}
+	var backup bytes.Buffer
+	if err := c.Backup(ctx, &backup); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CreateUser(ctx, &platform.User{Name: "user2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bolt.Restore(ctx, c.Path, bytes.NewReader(backup.Bytes())); err == nil {
+		t.Fatal("expected restoring an open database to fail")
+	}
+	c.Close()
+
+	if err := bolt.Restore(ctx, c.Path, bytes.NewReader([]byte("not a database"))); err == nil {
+		t.Fatal("expected restoring an invalid backup to fail")
+	}
+	if err := bolt.Restore(ctx, c.Path, bytes.NewReader(backup.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	users, _, err := c.FindUsers(ctx, platform.UserFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].Name != "user1" {
+		t.Errorf("expected only user1 to be restored, got %v", users)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 )
 
 var (
@@ -53,8 +53,7 @@ func (c *Client) findOrganizationByID(ctx context.Context, tx *bolt.Tx, id platf
 	v := tx.Bucket(organizationBucket).Get(id)
 
 	if len(v) == 0 {
-		// TODO: Make standard error
-		return nil, fmt.Errorf("organization not found")
+		return nil, kerrors.NotFoundf("organization not found")
 	}
 
 	if err := json.Unmarshal(v, &o); err != nil {
@@ -115,7 +114,7 @@ func (c *Client) FindOrganization(ctx context.Context, filter platform.Organizat
 	}
 
 	if o == nil {
-		return nil, fmt.Errorf("organization not found")
+		return nil, kerrors.NotFoundf("organization not found")
 	}
 
 	return o, nil
@@ -139,6 +138,7 @@ func filterOrganizationsFn(filter platform.OrganizationFilter) func(o *platform.
 
 // FindOrganizations retrives all organizations that match an arbitrary organization filter.
 // Filters using ID, or Name should be efficient.
+// When sorted by ID only the organizations of the requested page are decoded.
 // Other filters will do a linear scan across all organizations searching for a match.
 func (c *Client) FindOrganizations(ctx context.Context, filter platform.OrganizationFilter, opt ...platform.FindOptions) ([]*platform.Organization, int, error) {
 	if filter.ID != nil {
@@ -159,6 +159,10 @@ func (c *Client) FindOrganizations(ctx context.Context, filter platform.Organiza
 		return []*platform.Organization{o}, 1, nil
 	}
 
+	if sortedByID(opt) {
+		return c.findOrganizationsPage(ctx, findOptions(opt))
+	}
+
 	os := []*platform.Organization{}
 	filterFn := filterOrganizationsFn(filter)
 	err := c.db.View(func(tx *bolt.Tx) error {
@@ -174,7 +178,39 @@ func (c *Client) FindOrganizations(ctx context.Context, filter platform.Organiza
 		return nil, 0, err
 	}
 
-	return os, len(os), nil
+	start, end, err := paginate(os, len(os), func(i int) platform.ID { return os[i].ID }, sortKeys{
+		"name": func(i int) string { return os[i].Name },
+	}, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return os[start:end], len(os), nil
+}
+
+// findOrganizationsPage finds the page of organizations in ID order described by opt,
+// decoding only the organizations of the page.
+func (c *Client) findOrganizationsPage(ctx context.Context, opt platform.FindOptions) ([]*platform.Organization, int, error) {
+	os := []*platform.Organization{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var ids []platform.ID
+		ids, n = pageIDs(bucketRange(tx, organizationBucket), opt)
+		for _, id := range ids {
+			o, err := c.findOrganizationByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			os = append(os, o)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return os, n, nil
 }
 
 // CreateOrganization creates a platform organization and sets b.ID.
@@ -183,13 +219,16 @@ func (c *Client) CreateOrganization(ctx context.Context, o *platform.Organizatio
 		unique := c.uniqueOrganizationName(ctx, tx, o)
 
 		if !unique {
-			// TODO: make standard error
-			return fmt.Errorf("organization with name %s already exists", o.Name)
+			return kerrors.Conflictf("organization with name %s already exists", o.Name)
 		}
 
 		o.ID = c.IDGenerator.ID()
+		createMeta(ctx, &o.Meta)
 
-		return c.putOrganization(ctx, tx, o)
+		if err := c.putOrganization(ctx, tx, o); err != nil {
+			return err
+		}
+		return c.appendAuditEvent(ctx, tx, platform.CreateAuditAction, platform.OrganizationAuditResource, o.ID)
 	})
 }
 
@@ -267,10 +306,15 @@ func (c *Client) updateOrganization(ctx context.Context, tx *bolt.Tx, id platfor
 		o.Name = *upd.Name
 	}
 
+	updateMeta(ctx, &o.Meta)
 	if err := c.putOrganization(ctx, tx, o); err != nil {
 		return nil, err
 	}
 
+	if err := c.appendAuditEvent(ctx, tx, platform.UpdateAuditAction, platform.OrganizationAuditResource, o.ID); err != nil {
+		return nil, err
+	}
+
 	return o, nil
 }
 
@@ -292,7 +336,10 @@ func (c *Client) deleteOrganization(ctx context.Context, tx *bolt.Tx, id platfor
 	if err := tx.Bucket(organizationIndex).Delete(organizationIndexKey(o.Name)); err != nil {
 		return err
 	}
-	return tx.Bucket(organizationBucket).Delete(id)
+	if err := tx.Bucket(organizationBucket).Delete(id); err != nil {
+		return err
+	}
+	return c.appendAuditEvent(ctx, tx, platform.DeleteAuditAction, platform.OrganizationAuditResource, id)
 }
 
 func (c *Client) deleteOrganizationsBuckets(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
@@ -0,0 +1,190 @@
+package bolt
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+// keyRange is the range of keys of a bolt bucket sharing a prefix, which end in the IDs
+// of the results they refer to, such as all keys of a bucket keyed by ID or the children
+// of a parent in a secondary index.
+type keyRange struct {
+	bucket *bolt.Bucket
+	prefix []byte
+}
+
+// bucketRange returns the range of all keys of the bucket with the given name.
+func bucketRange(tx *bolt.Tx, name []byte) keyRange {
+	return keyRange{bucket: tx.Bucket(name)}
+}
+
+// indexRange returns the range of the children of parent in a secondary index.
+func indexRange(tx *bolt.Tx, index []byte, parent platform.ID) keyRange {
+	return keyRange{bucket: tx.Bucket(index), prefix: secondaryIndexPrefix(parent)}
+}
+
+// count returns the number of keys in the range without reading their values.
+func (r keyRange) count() int {
+	if len(r.prefix) == 0 {
+		return r.bucket.Stats().KeyN
+	}
+	n := 0
+	cur := r.bucket.Cursor()
+	for k, _ := cur.Seek(r.prefix); k != nil && bytes.HasPrefix(k, r.prefix); k, _ = cur.Next() {
+		n++
+	}
+	return n
+}
+
+// last positions the cursor on the last key of the range.
+func (r keyRange) last(cur *bolt.Cursor) []byte {
+	// The keys of the range are followed by the first key greater than every key with the prefix.
+	end := append([]byte(nil), r.prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i]++; end[i] != 0 {
+			k, _ := cur.Seek(end[:i+1])
+			if k == nil {
+				k, _ = cur.Last()
+				return k
+			}
+			k, _ = cur.Prev()
+			return k
+		}
+	}
+	k, _ := cur.Last()
+	return k
+}
+
+// findOptions returns the find options in opt, or the default options if there are none.
+func findOptions(opt []platform.FindOptions) platform.FindOptions {
+	if len(opt) == 0 {
+		return platform.FindOptions{}
+	}
+	return opt[0]
+}
+
+// sortedByID reports whether the find options sort results by ID,
+// in which case a page is found by pageIDs without decoding results outside of it.
+func sortedByID(opt []platform.FindOptions) bool {
+	o := findOptions(opt)
+	return o.SortBy == "" || o.SortBy == "id"
+}
+
+// pageIDs returns the IDs of the page within r described by the find options, which sort by ID,
+// and the total count of IDs in r, or -1 if o.SkipCount is set.
+// The cursor seeks to o.After, skips o.Offset keys and stops after o.Limit keys,
+// so only the keys of the page are read unless the total count is needed.
+func pageIDs(r keyRange, o platform.FindOptions) ([]platform.ID, int) {
+	cur := r.bucket.Cursor()
+	next := cur.Next
+	if o.Descending {
+		next = cur.Prev
+	}
+
+	var k []byte
+	switch {
+	case len(o.After) > 0 && !o.Descending:
+		after := append(append([]byte(nil), r.prefix...), o.After...)
+		if k, _ = cur.Seek(after); bytes.Equal(k, after) {
+			k, _ = cur.Next()
+		}
+	case len(o.After) > 0:
+		after := append(append([]byte(nil), r.prefix...), o.After...)
+		if k, _ = cur.Seek(after); k == nil {
+			k, _ = cur.Last()
+		} else {
+			k, _ = cur.Prev()
+		}
+	case !o.Descending:
+		k, _ = cur.Seek(r.prefix)
+	default:
+		k = r.last(cur)
+	}
+
+	var ids []platform.ID
+	for skip := o.Offset; k != nil && bytes.HasPrefix(k, r.prefix); k, _ = next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if o.Limit > 0 && len(ids) == o.Limit {
+			break
+		}
+		ids = append(ids, append(platform.ID(nil), k[len(r.prefix):]...))
+	}
+	if o.SkipCount {
+		return ids, -1
+	}
+	return ids, r.count()
+}
+
+// sortKeys maps the fields results may be sorted by, other than "id",
+// to a function returning the sort key of the i-th result.
+type sortKeys map[string]func(i int) string
+
+// paginate sorts results, a slice of n results, according to the find options
+// and returns the bounds of the requested page within it.
+// It requires every matching result to be decoded, a full scan, so it is only used when
+// results are sorted by a field other than ID or filtered by fields without an index.
+// Results are expected in ID order, as they are stored in bolt, so the default
+// sort by ascending ID requires no sorting and its cursor is found by a binary search.
+func paginate(results interface{}, n int, id func(i int) platform.ID, keys sortKeys, opt []platform.FindOptions) (int, int, error) {
+	if len(opt) == 0 {
+		return 0, n, nil
+	}
+	o := opt[0]
+
+	byID := o.SortBy == "" || o.SortBy == "id"
+	if !byID {
+		key, ok := keys[o.SortBy]
+		if !ok {
+			return 0, 0, kerrors.InvalidDataf("cannot sort by %q", o.SortBy)
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			if ki, kj := key(i), key(j); ki != kj {
+				return (ki < kj) != o.Descending
+			}
+			return (bytes.Compare(id(i), id(j)) < 0) != o.Descending
+		})
+	} else if o.Descending {
+		sort.SliceStable(results, func(i, j int) bool {
+			return bytes.Compare(id(i), id(j)) > 0
+		})
+	}
+
+	start := 0
+	if len(o.After) > 0 {
+		switch {
+		case byID && !o.Descending:
+			start = sort.Search(n, func(i int) bool { return bytes.Compare(id(i), o.After) > 0 })
+		case byID:
+			start = sort.Search(n, func(i int) bool { return bytes.Compare(id(i), o.After) < 0 })
+		default:
+			start = -1
+			for i := 0; i < n; i++ {
+				if bytes.Equal(id(i), o.After) {
+					start = i + 1
+					break
+				}
+			}
+			if start < 0 {
+				return 0, 0, kerrors.InvalidDataf("cursor %s not found", o.After)
+			}
+		}
+	}
+
+	start += o.Offset
+	if start > n {
+		start = n
+	}
+	end := n
+	if o.Limit > 0 && start+o.Limit < n {
+		end = start + o.Limit
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,133 @@
+package bolt_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestClient_FindBucketsPage(t *testing.T) {
+	ctx := context.Background()
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFn()
+
+	org1 := &platform.Organization{ID: platform.ID("org1"), Name: "org1"}
+	org2 := &platform.Organization{ID: platform.ID("org2"), Name: "org2"}
+	for _, o := range []*platform.Organization{org1, org2} {
+		if err := c.PutOrganization(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, b := range []*platform.Bucket{
+		{ID: platform.ID("b1"), OrganizationID: org1.ID, Name: "b1"},
+		{ID: platform.ID("b2"), OrganizationID: org2.ID, Name: "b2"},
+		{ID: platform.ID("b3"), OrganizationID: org1.ID, Name: "b3"},
+		{ID: platform.ID("b4"), OrganizationID: org1.ID, Name: "b4"},
+		{ID: platform.ID("b5"), OrganizationID: org2.ID, Name: "b5"},
+	} {
+		if err := c.PutBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter platform.BucketFilter
+		opt    platform.FindOptions
+		want   []string
+		count  int
+	}{
+		{
+			name:  "all",
+			want:  []string{"b1", "b2", "b3", "b4", "b5"},
+			count: 5,
+		},
+		{
+			name:  "limit and offset",
+			opt:   platform.FindOptions{Limit: 2, Offset: 1},
+			want:  []string{"b2", "b3"},
+			count: 5,
+		},
+		{
+			name:  "after cursor",
+			opt:   platform.FindOptions{Limit: 2, After: platform.ID("b2")},
+			want:  []string{"b3", "b4"},
+			count: 5,
+		},
+		{
+			name:  "descending",
+			opt:   platform.FindOptions{Limit: 2, Descending: true},
+			want:  []string{"b5", "b4"},
+			count: 5,
+		},
+		{
+			name:  "descending after cursor",
+			opt:   platform.FindOptions{Descending: true, After: platform.ID("b3")},
+			want:  []string{"b2", "b1"},
+			count: 5,
+		},
+		{
+			name:   "organization",
+			filter: platform.BucketFilter{OrganizationID: &org1.ID},
+			opt:    platform.FindOptions{Limit: 2, After: platform.ID("b1")},
+			want:   []string{"b3", "b4"},
+			count:  3,
+		},
+		{
+			name:   "organization descending",
+			filter: platform.BucketFilter{Organization: &org2.Name},
+			opt:    platform.FindOptions{Descending: true},
+			want:   []string{"b5", "b2"},
+			count:  2,
+		},
+		{
+			name:   "organization descending after cursor",
+			filter: platform.BucketFilter{OrganizationID: &org1.ID},
+			opt:    platform.FindOptions{Descending: true, After: platform.ID("b5")},
+			want:   []string{"b4", "b3", "b1"},
+			count:  3,
+		},
+		{
+			name:  "skip count",
+			opt:   platform.FindOptions{Limit: 2, SkipCount: true},
+			want:  []string{"b1", "b2"},
+			count: -1,
+		},
+		{
+			name:   "organization skip count",
+			filter: platform.BucketFilter{OrganizationID: &org1.ID},
+			opt:    platform.FindOptions{Limit: 1, SkipCount: true},
+			want:   []string{"b1"},
+			count:  -1,
+		},
+		{
+			name:   "offset past the end",
+			filter: platform.BucketFilter{OrganizationID: &org2.ID},
+			opt:    platform.FindOptions{Offset: 3},
+			count:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs, n, err := c.FindBuckets(ctx, tt.filter, tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, b := range bs {
+				names = append(names, b.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("unexpected buckets: got %v want %v", names, tt.want)
+			}
+			if n != tt.count {
+				t.Errorf("unexpected count: got %d want %d", n, tt.count)
+			}
+		})
+	}
+}
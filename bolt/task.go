@@ -0,0 +1,843 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/task"
+)
+
+var (
+	taskBucket = []byte("tasksv1")
+	// taskRunBucket holds a nested bucket of runs for every task, keyed by task ID.
+	taskRunBucket = []byte("taskrunsv1")
+	// taskLogBucket holds a nested bucket for every task with a nested bucket of logs for every run.
+	taskLogBucket = []byte("tasklogsv1")
+	// taskQueuedRunIndex indexes the queued runs of every task by task ID, so that the
+	// scheduler finds them without reading the run history of the task.
+	taskQueuedRunIndex = []byte("taskqueuedrunindexv1")
+)
+
+func (c *Client) initializeTasks(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(taskBucket); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucketIfNotExists(taskRunBucket); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucketIfNotExists(taskLogBucket); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucketIfNotExists(taskQueuedRunIndex); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) setOwnerOnTask(ctx context.Context, tx *bolt.Tx, t *platform.Task) error {
+	if len(t.Owner.ID) == 0 {
+		return nil
+	}
+	u, err := c.findUserByID(ctx, tx, t.Owner.ID)
+	if err != nil {
+		return err
+	}
+	t.Owner.Name = u.Name
+	return nil
+}
+
+func (c *Client) setLastRunOnTask(ctx context.Context, tx *bolt.Tx, t *platform.Task) error {
+	b := tx.Bucket(taskRunBucket).Bucket(t.ID)
+	if b == nil {
+		return nil
+	}
+	_, v := b.Cursor().Last()
+	if v == nil {
+		return nil
+	}
+	var r platform.Run
+	if err := json.Unmarshal(v, &r); err != nil {
+		return err
+	}
+	t.Last = &r
+	return nil
+}
+
+// setTaskOptions sets the schedule of the task from the task option of its Flux script.
+func setTaskOptions(ctx context.Context, t *platform.Task) error {
+	opts, err := task.Options(ctx, t.Flux)
+	if err != nil {
+		return kerrors.InvalidDataf("invalid task flux: %v", err)
+	}
+	if t.Name == "" {
+		t.Name = opts.Name
+	}
+	t.Every = ""
+	if opts.Every != 0 {
+		t.Every = time.Duration(opts.Every).String()
+	}
+	t.Cron = opts.Cron
+	return nil
+}
+
+// FindTaskByID retrieves a task by id.
+func (c *Client) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
+	var t *platform.Task
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		tsk, err := c.findTaskByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		t = tsk
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (c *Client) findTaskByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.Task, error) {
+	var t platform.Task
+
+	v := tx.Bucket(taskBucket).Get(id)
+
+	if len(v) == 0 {
+		return nil, kerrors.NotFoundf("task not found")
+	}
+
+	if err := json.Unmarshal(v, &t); err != nil {
+		return nil, err
+	}
+
+	if err := c.setOwnerOnTask(ctx, tx, &t); err != nil {
+		return nil, err
+	}
+
+	if err := c.setLastRunOnTask(ctx, tx, &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func filterTasksFn(filter platform.TaskFilter) func(t *platform.Task) bool {
+	return func(t *platform.Task) bool {
+		if filter.User != nil && !bytes.Equal(t.Owner.ID, *filter.User) {
+			return false
+		}
+		if filter.OrganizationID != nil && !bytes.Equal(t.OrganizationID, *filter.OrganizationID) {
+			return false
+		}
+		return true
+	}
+}
+
+// FindTasks retrives all tasks that match an arbitrary task filter.
+// Filters using ID should be efficient.
+// Without a filter, and when sorted by ID, only the tasks of the requested page are decoded.
+// Other filters will do a linear scan across all tasks searching for a match.
+func (c *Client) FindTasks(ctx context.Context, filter platform.TaskFilter, opt ...platform.FindOptions) ([]*platform.Task, int, error) {
+	if filter.ID != nil {
+		t, err := c.FindTaskByID(ctx, *filter.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return []*platform.Task{t}, 1, nil
+	}
+
+	if filter.User == nil && filter.OrganizationID == nil && sortedByID(opt) {
+		return c.findTasksPage(ctx, findOptions(opt))
+	}
+
+	ts := []*platform.Task{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		tasks, err := c.findTasks(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		ts = tasks
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start, end, err := paginate(ts, len(ts), func(i int) platform.ID { return ts[i].ID }, sortKeys{
+		"name":   func(i int) string { return ts[i].Name },
+		"status": func(i int) string { return ts[i].Status },
+	}, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ts[start:end], len(ts), nil
+}
+
+// findTasksPage finds the page of tasks in ID order described by opt,
+// decoding only the tasks of the page.
+func (c *Client) findTasksPage(ctx context.Context, opt platform.FindOptions) ([]*platform.Task, int, error) {
+	ts := []*platform.Task{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var ids []platform.ID
+		ids, n = pageIDs(bucketRange(tx, taskBucket), opt)
+		for _, id := range ids {
+			t, err := c.findTaskByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			ts = append(ts, t)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ts, n, nil
+}
+
+func (c *Client) findTasks(ctx context.Context, tx *bolt.Tx, filter platform.TaskFilter) ([]*platform.Task, error) {
+	ts := []*platform.Task{}
+	filterFn := filterTasksFn(filter)
+	err := c.forEachTask(ctx, tx, func(t *platform.Task) bool {
+		if filterFn(t) {
+			ts = append(ts, t)
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+// forEachTask will iterate through all tasks while fn returns true.
+func (c *Client) forEachTask(ctx context.Context, tx *bolt.Tx, fn func(*platform.Task) bool) error {
+	cur := tx.Bucket(taskBucket).Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		t := &platform.Task{}
+		if err := json.Unmarshal(v, t); err != nil {
+			return err
+		}
+		if err := c.setOwnerOnTask(ctx, tx, t); err != nil {
+			return err
+		}
+		if err := c.setLastRunOnTask(ctx, tx, t); err != nil {
+			return err
+		}
+		if !fn(t) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// CreateTask creates a platform task and sets t.ID.
+// The name, if unset, and the schedule of the task are taken from the task option of its Flux script.
+func (c *Client) CreateTask(ctx context.Context, t *platform.Task) error {
+	if err := setTaskOptions(ctx, t); err != nil {
+		return err
+	}
+	switch t.Status {
+	case "":
+		t.Status = platform.TaskEnabled
+	case platform.TaskEnabled, platform.TaskDisabled:
+	default:
+		return kerrors.InvalidDataf("invalid task status %q", t.Status)
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if len(t.OrganizationID) != 0 {
+			if _, err := c.findOrganizationByID(ctx, tx, t.OrganizationID); err != nil {
+				return err
+			}
+		}
+
+		t.ID = c.IDGenerator.ID()
+		t.Last = nil
+
+		if err := c.putTask(ctx, tx, t); err != nil {
+			return err
+		}
+		return c.appendAuditEvent(ctx, tx, platform.CreateAuditAction, platform.TaskAuditResource, t.ID)
+	})
+}
+
+// PutTask will put a task without setting an ID.
+func (c *Client) PutTask(ctx context.Context, t *platform.Task) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.putTask(ctx, tx, t)
+	})
+}
+
+func (c *Client) putTask(ctx context.Context, tx *bolt.Tx, t *platform.Task) error {
+	// The owner name and the last run are derived when the task is read.
+	owner, last := t.Owner.Name, t.Last
+	t.Owner.Name, t.Last = "", nil
+	v, err := json.Marshal(t)
+	t.Owner.Name, t.Last = owner, last
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(taskBucket).Put(t.ID, v); err != nil {
+		return err
+	}
+	return c.setOwnerOnTask(ctx, tx, t)
+}
+
+// UpdateTask updates a task according the parameters set on upd and cancels its queued runs.
+func (c *Client) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+	var t *platform.Task
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		tsk, err := c.updateTask(ctx, tx, id, upd)
+		if err != nil {
+			return err
+		}
+		t = tsk
+		return nil
+	})
+
+	return t, err
+}
+
+func (c *Client) updateTask(ctx context.Context, tx *bolt.Tx, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+	t, err := c.findTaskByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.Name != nil {
+		t.Name = *upd.Name
+	}
+
+	if upd.Status != nil {
+		switch *upd.Status {
+		case platform.TaskEnabled, platform.TaskDisabled:
+			t.Status = *upd.Status
+		default:
+			return nil, kerrors.InvalidDataf("invalid task status %q", *upd.Status)
+		}
+	}
+
+	if upd.Flux != nil {
+		t.Flux = *upd.Flux
+		if err := setTaskOptions(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.cancelQueuedRuns(ctx, tx, t.ID); err != nil {
+		return nil, err
+	}
+
+	if err := c.putTask(ctx, tx, t); err != nil {
+		return nil, err
+	}
+
+	if err := c.appendAuditEvent(ctx, tx, platform.UpdateAuditAction, platform.TaskAuditResource, t.ID); err != nil {
+		return nil, err
+	}
+
+	if err := c.setLastRunOnTask(ctx, tx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// cancelQueuedRuns marks all queued runs of a task as failed.
+func (c *Client) cancelQueuedRuns(ctx context.Context, tx *bolt.Tx, taskID platform.ID) error {
+	queued := platform.RunQueued
+	runs, err := c.findRuns(ctx, tx, platform.RunFilter{Task: taskID, Status: &queued})
+	if err != nil {
+		return err
+	}
+
+	failed := platform.RunFailed
+	now := time.Now().UTC()
+	upd := platform.RunUpdate{
+		Status:  &failed,
+		EndTime: &now,
+		Error: &platform.RunError{
+			Code:    platform.RunErrorCanceled,
+			Message: "run canceled by task update",
+		},
+	}
+	for _, r := range runs {
+		if _, err := c.updateRun(ctx, tx, taskID, r.ID, upd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTask deletes a task together with its runs and logs.
+func (c *Client) DeleteTask(ctx context.Context, id platform.ID) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.deleteTask(ctx, tx, id)
+	})
+}
+
+func (c *Client) deleteTask(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
+	_, err := c.findTaskByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := deleteNestedBucket(tx.Bucket(taskRunBucket), id); err != nil {
+		return err
+	}
+	if err := deleteNestedBucket(tx.Bucket(taskLogBucket), id); err != nil {
+		return err
+	}
+	if err := deleteQueuedRunIndex(tx, id); err != nil {
+		return err
+	}
+	if err := tx.Bucket(taskBucket).Delete(id); err != nil {
+		return err
+	}
+	return c.appendAuditEvent(ctx, tx, platform.DeleteAuditAction, platform.TaskAuditResource, id)
+}
+
+func deleteNestedBucket(b *bolt.Bucket, key []byte) error {
+	if b.Bucket(key) == nil {
+		return nil
+	}
+	return b.DeleteBucket(key)
+}
+
+// FindRunByID retrieves a run of a task by id.
+func (c *Client) FindRunByID(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	var r *platform.Run
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		run, err := c.findRunByID(ctx, tx, taskID, runID)
+		if err != nil {
+			return err
+		}
+		r = run
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *Client) findRunByID(ctx context.Context, tx *bolt.Tx, taskID, runID platform.ID) (*platform.Run, error) {
+	var r platform.Run
+
+	var v []byte
+	if b := tx.Bucket(taskRunBucket).Bucket(taskID); b != nil {
+		v = b.Get(runID)
+	}
+
+	if len(v) == 0 {
+		return nil, kerrors.NotFoundf("run not found")
+	}
+
+	if err := json.Unmarshal(v, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func filterRunsFn(filter platform.RunFilter) func(r *platform.Run) bool {
+	return func(r *platform.Run) bool {
+		if filter.Status != nil && r.Status != *filter.Status {
+			return false
+		}
+		if filter.AfterTime != nil && !r.QueuedAt.After(*filter.AfterTime) {
+			return false
+		}
+		if filter.BeforeTime != nil && !r.QueuedAt.Before(*filter.BeforeTime) {
+			return false
+		}
+		return true
+	}
+}
+
+// FindRuns retrieves the runs of a task that match the filter in the order they were queued.
+func (c *Client) FindRuns(ctx context.Context, filter platform.RunFilter) ([]*platform.Run, int, error) {
+	rs := []*platform.Run{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		if _, err := c.findTaskByID(ctx, tx, filter.Task); err != nil {
+			return err
+		}
+		runs, err := c.findRuns(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		rs = runs
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rs, len(rs), nil
+}
+
+func (c *Client) findRuns(ctx context.Context, tx *bolt.Tx, filter platform.RunFilter) ([]*platform.Run, error) {
+	if filter.Status != nil && *filter.Status == platform.RunQueued {
+		return c.findQueuedRuns(ctx, tx, filter)
+	}
+
+	rs := []*platform.Run{}
+	b := tx.Bucket(taskRunBucket).Bucket(filter.Task)
+	if b == nil {
+		return rs, nil
+	}
+
+	filterFn := filterRunsFn(filter)
+	cur := b.Cursor()
+	k, v := cur.First()
+	if filter.After != nil {
+		k, v = cur.Seek(*filter.After)
+		if k != nil && bytes.Equal(k, *filter.After) {
+			k, v = cur.Next()
+		}
+	}
+	for ; k != nil; k, v = cur.Next() {
+		r := &platform.Run{}
+		if err := json.Unmarshal(v, r); err != nil {
+			return nil, err
+		}
+		if !filterFn(r) {
+			continue
+		}
+		rs = append(rs, r)
+		if filter.Limit > 0 && len(rs) >= filter.Limit {
+			break
+		}
+	}
+
+	return rs, nil
+}
+
+// findQueuedRuns finds the queued runs of a task from the queued run index.
+func (c *Client) findQueuedRuns(ctx context.Context, tx *bolt.Tx, filter platform.RunFilter) ([]*platform.Run, error) {
+	rs := []*platform.Run{}
+	filterFn := filterRunsFn(filter)
+	err := forEachSecondaryIndex(tx, taskQueuedRunIndex, filter.Task, func(id platform.ID) (bool, error) {
+		if filter.After != nil && bytes.Compare(id, *filter.After) <= 0 {
+			return true, nil
+		}
+		r, err := c.findRunByID(ctx, tx, filter.Task, id)
+		if err != nil {
+			return false, err
+		}
+		if filterFn(r) {
+			rs = append(rs, r)
+		}
+		return filter.Limit <= 0 || len(rs) < filter.Limit, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// RetryRun queues a new run of a task for a run that has finished.
+func (c *Client) RetryRun(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	var r *platform.Run
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		run, err := c.findRunByID(ctx, tx, taskID, runID)
+		if err != nil {
+			return err
+		}
+		if run.Status == platform.RunQueued || run.Status == platform.RunExecuting {
+			return kerrors.Conflictf("run is %s and cannot be retried", run.Status)
+		}
+
+		r = &platform.Run{
+			TaskID:   taskID,
+			Status:   platform.RunQueued,
+			QueuedAt: time.Now().UTC(),
+		}
+		return c.createRun(ctx, tx, r)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CreateRun queues a run of a task and sets r.ID.
+func (c *Client) CreateRun(ctx context.Context, r *platform.Run) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.createRun(ctx, tx, r)
+	})
+}
+
+func (c *Client) createRun(ctx context.Context, tx *bolt.Tx, r *platform.Run) error {
+	if _, err := c.findTaskByID(ctx, tx, r.TaskID); err != nil {
+		return err
+	}
+	if r.Status == "" {
+		r.Status = platform.RunQueued
+	}
+	r.ID = c.IDGenerator.ID()
+	if err := c.putRun(ctx, tx, r); err != nil {
+		return err
+	}
+	if err := c.appendAuditEvent(ctx, tx, platform.CreateAuditAction, platform.RunAuditResource, r.ID); err != nil {
+		return err
+	}
+	return c.pruneRuns(ctx, tx, r.TaskID)
+}
+
+// pruneRuns deletes the finished runs of a task, and their logs, that are older than
+// its c.MaxRunsPerTask most recent runs. Older runs that are still queued or executing are kept.
+func (c *Client) pruneRuns(ctx context.Context, tx *bolt.Tx, taskID platform.ID) error {
+	b := tx.Bucket(taskRunBucket).Bucket(taskID)
+	if c.MaxRunsPerTask <= 0 || b == nil {
+		return nil
+	}
+
+	// Only the keys of the most recent runs are read; older runs are decoded to check their status.
+	var ids []platform.ID
+	cur := b.Cursor()
+	n := 0
+	for k, v := cur.Last(); k != nil; k, v = cur.Prev() {
+		if n++; n <= c.MaxRunsPerTask {
+			continue
+		}
+		var r platform.Run
+		if err := json.Unmarshal(v, &r); err != nil {
+			return err
+		}
+		if r.Status == platform.RunQueued || r.Status == platform.RunExecuting {
+			continue
+		}
+		ids = append(ids, append(platform.ID(nil), k...))
+	}
+
+	lb := tx.Bucket(taskLogBucket).Bucket(taskID)
+	for _, id := range ids {
+		if err := b.Delete(id); err != nil {
+			return err
+		}
+		if lb != nil {
+			if err := deleteNestedBucket(lb, id); err != nil {
+				return err
+			}
+		}
+		if err := c.appendAuditEvent(ctx, tx, platform.DeleteAuditAction, platform.RunAuditResource, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutRun will put a run without setting an ID.
+func (c *Client) PutRun(ctx context.Context, r *platform.Run) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.putRun(ctx, tx, r)
+	})
+}
+
+func (c *Client) putRun(ctx context.Context, tx *bolt.Tx, r *platform.Run) error {
+	v, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	b, err := tx.Bucket(taskRunBucket).CreateBucketIfNotExists(r.TaskID)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(r.ID, v); err != nil {
+		return err
+	}
+
+	idx := tx.Bucket(taskQueuedRunIndex)
+	if r.Status == platform.RunQueued {
+		return idx.Put(secondaryIndexKey(r.TaskID, r.ID), r.ID)
+	}
+	return idx.Delete(secondaryIndexKey(r.TaskID, r.ID))
+}
+
+// deleteQueuedRunIndex removes the queued runs of a task from the queued run index.
+func deleteQueuedRunIndex(tx *bolt.Tx, taskID platform.ID) error {
+	var ids []platform.ID
+	err := forEachSecondaryIndex(tx, taskQueuedRunIndex, taskID, func(id platform.ID) (bool, error) {
+		ids = append(ids, id)
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := deleteSecondaryIndex(tx, taskQueuedRunIndex, taskID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexQueuedRuns rebuilds the queued run index from the runs of every task.
+func indexQueuedRuns(ctx context.Context, tx *bolt.Tx) error {
+	if err := tx.DeleteBucket(taskQueuedRunIndex); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	idx, err := tx.CreateBucket(taskQueuedRunIndex)
+	if err != nil {
+		return err
+	}
+
+	runs := tx.Bucket(taskRunBucket)
+	return runs.ForEach(func(taskID, _ []byte) error {
+		b := runs.Bucket(taskID)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var r platform.Run
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			if r.Status != platform.RunQueued {
+				return nil
+			}
+			return idx.Put(secondaryIndexKey(r.TaskID, r.ID), r.ID)
+		})
+	})
+}
+
+// UpdateRun updates a run according the parameters set on upd.
+func (c *Client) UpdateRun(ctx context.Context, taskID, runID platform.ID, upd platform.RunUpdate) (*platform.Run, error) {
+	var r *platform.Run
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		run, err := c.updateRun(ctx, tx, taskID, runID, upd)
+		if err != nil {
+			return err
+		}
+		r = run
+		return nil
+	})
+
+	return r, err
+}
+
+func (c *Client) updateRun(ctx context.Context, tx *bolt.Tx, taskID, runID platform.ID, upd platform.RunUpdate) (*platform.Run, error) {
+	r, err := c.findRunByID(ctx, tx, taskID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.Status != nil {
+		r.Status = *upd.Status
+	}
+
+	if upd.StartTime != nil {
+		r.StartTime = upd.StartTime
+	}
+
+	if upd.EndTime != nil {
+		r.EndTime = upd.EndTime
+	}
+
+	if upd.Error != nil {
+		r.Error = upd.Error
+	}
+
+	if err := c.putRun(ctx, tx, r); err != nil {
+		return nil, err
+	}
+	if err := c.appendAuditEvent(ctx, tx, platform.UpdateAuditAction, platform.RunAuditResource, r.ID); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// AddRunLog appends a log to the logs of a run.
+func (c *Client) AddRunLog(ctx context.Context, taskID platform.ID, l *platform.Log) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if _, err := c.findRunByID(ctx, tx, taskID, l.RunID); err != nil {
+			return err
+		}
+
+		tb, err := tx.Bucket(taskLogBucket).CreateBucketIfNotExists(taskID)
+		if err != nil {
+			return err
+		}
+		rb, err := tb.CreateBucketIfNotExists(l.RunID)
+		if err != nil {
+			return err
+		}
+
+		v, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		seq, err := rb.NextSequence()
+		if err != nil {
+			return err
+		}
+		k := make([]byte, 8)
+		binary.BigEndian.PutUint64(k, seq)
+		return rb.Put(k, v)
+	})
+}
+
+// FindLogs retrieves the logs of a task, or of a single run, ordered by run and then by time.
+func (c *Client) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*platform.Log, int, error) {
+	ls := []*platform.Log{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		if _, err := c.findTaskByID(ctx, tx, filter.Task); err != nil {
+			return err
+		}
+		if filter.Run != nil {
+			if _, err := c.findRunByID(ctx, tx, filter.Task, *filter.Run); err != nil {
+				return err
+			}
+		}
+
+		tb := tx.Bucket(taskLogBucket).Bucket(filter.Task)
+		if tb == nil {
+			return nil
+		}
+		return tb.ForEach(func(runID, _ []byte) error {
+			if filter.Run != nil && !bytes.Equal(runID, *filter.Run) {
+				return nil
+			}
+			return tb.Bucket(runID).ForEach(func(_, v []byte) error {
+				l := &platform.Log{}
+				if err := json.Unmarshal(v, l); err != nil {
+					return err
+				}
+				ls = append(ls, l)
+				return nil
+			})
+		})
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ls, len(ls), nil
+}
@@ -0,0 +1,192 @@
+package bolt_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	_ "github.com/influxdata/platform/query/builtin"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initTaskService(f platformtesting.TaskFields, t *testing.T) (platform.TaskService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	c.IDGenerator = f.IDGenerator
+	ctx := context.TODO()
+	for _, u := range f.Users {
+		if err := c.PutUser(ctx, u); err != nil {
+			t.Fatalf("failed to populate users")
+		}
+	}
+	for _, o := range f.Organizations {
+		if err := c.PutOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to populate organizations")
+		}
+	}
+	for _, task := range f.Tasks {
+		if err := c.PutTask(ctx, task); err != nil {
+			t.Fatalf("failed to populate tasks")
+		}
+	}
+	runTasks := make(map[string]platform.ID)
+	for _, r := range f.Runs {
+		if err := c.PutRun(ctx, r); err != nil {
+			t.Fatalf("failed to populate runs")
+		}
+		runTasks[r.ID.String()] = r.TaskID
+	}
+	for _, l := range f.Logs {
+		if err := c.AddRunLog(ctx, runTasks[l.RunID.String()], l); err != nil {
+			t.Fatalf("failed to populate logs: %v", err)
+		}
+	}
+	return c, func() {
+		defer closeFn()
+		for _, task := range f.Tasks {
+			if err := c.DeleteTask(ctx, task.ID); err != nil {
+				t.Logf("failed to remove task: %v", err)
+			}
+		}
+	}
+}
+
+func TestTaskService_CreateTask(t *testing.T) {
+	platformtesting.CreateTask(initTaskService, t)
+}
+
+func TestTaskService_FindTaskByID(t *testing.T) {
+	platformtesting.FindTaskByID(initTaskService, t)
+}
+
+func TestTaskService_FindTasks(t *testing.T) {
+	platformtesting.FindTasks(initTaskService, t)
+}
+
+func TestTaskService_UpdateTask(t *testing.T) {
+	platformtesting.UpdateTask(initTaskService, t)
+}
+
+func TestTaskService_DeleteTask(t *testing.T) {
+	platformtesting.DeleteTask(initTaskService, t)
+}
+
+func TestTaskService_FindRuns(t *testing.T) {
+	platformtesting.FindRuns(initTaskService, t)
+}
+
+func TestTaskService_RetryRun(t *testing.T) {
+	platformtesting.RetryRun(initTaskService, t)
+}
+
+func TestTaskService_FindLogs(t *testing.T) {
+	platformtesting.FindLogs(initTaskService, t)
+}
+
+func TestClient_FindQueuedRuns(t *testing.T) {
+	ctx := context.Background()
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFn()
+
+	task := &platform.Task{ID: platform.ID("task1"), Name: "task1", Status: platform.TaskEnabled}
+	if err := c.PutTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	var runs []*platform.Run
+	for i := 0; i < 3; i++ {
+		r := &platform.Run{TaskID: task.ID, QueuedAt: time.Now().UTC()}
+		if err := c.CreateRun(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+		runs = append(runs, r)
+	}
+	executing := platform.RunExecuting
+	if _, err := c.UpdateRun(ctx, task.ID, runs[1].ID, platform.RunUpdate{Status: &executing}); err != nil {
+		t.Fatal(err)
+	}
+
+	queued := platform.RunQueued
+	rs, _, err := c.FindRuns(ctx, platform.RunFilter{Task: task.ID, Status: &queued})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 2 || !bytes.Equal(rs[0].ID, runs[0].ID) || !bytes.Equal(rs[1].ID, runs[2].ID) {
+		t.Fatalf("expected the first and last runs to be queued, got %+v", rs)
+	}
+
+	rs, _, err = c.FindRuns(ctx, platform.RunFilter{Task: task.ID, Status: &queued, After: &runs[0].ID, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 1 || !bytes.Equal(rs[0].ID, runs[2].ID) {
+		t.Fatalf("expected the last run after the first one, got %+v", rs)
+	}
+
+	// Updating the task cancels its queued runs.
+	name := "renamed"
+	if _, err := c.UpdateTask(ctx, task.ID, platform.TaskUpdate{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if rs, _, err := c.FindRuns(ctx, platform.RunFilter{Task: task.ID, Status: &queued}); err != nil {
+		t.Fatal(err)
+	} else if len(rs) != 0 {
+		t.Fatalf("expected no queued runs after the update, got %+v", rs)
+	}
+}
+
+func TestClient_MaxRunsPerTask(t *testing.T) {
+	ctx := context.Background()
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFn()
+	c.MaxRunsPerTask = 2
+
+	task := &platform.Task{ID: platform.ID("task1"), Name: "task1", Status: platform.TaskEnabled}
+	if err := c.PutTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	// The oldest run is still executing, so it is kept.
+	var runs []*platform.Run
+	for _, status := range []string{platform.RunExecuting, platform.RunSuccess, platform.RunFailed, platform.RunSuccess, platform.RunQueued} {
+		r := &platform.Run{TaskID: task.ID, Status: status, QueuedAt: time.Now().UTC()}
+		if err := c.CreateRun(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.AddRunLog(ctx, task.ID, &platform.Log{RunID: r.ID, Time: time.Now().UTC(), Message: "log"}); err != nil {
+			t.Fatal(err)
+		}
+		runs = append(runs, r)
+	}
+
+	rs, _, err := c.FindRuns(ctx, platform.RunFilter{Task: task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []platform.ID
+	for _, r := range rs {
+		got = append(got, r.ID)
+	}
+	want := []platform.ID{runs[0].ID, runs[3].ID, runs[4].ID}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected runs: got %v want %v", got, want)
+	}
+
+	ls, _, err := c.FindLogs(ctx, platform.LogFilter{Task: task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != len(want) {
+		t.Fatalf("expected the logs of pruned runs to be deleted, got %d logs", len(ls))
+	}
+}
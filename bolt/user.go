@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 )
 
 var (
@@ -53,8 +53,7 @@ func (c *Client) findUserByID(ctx context.Context, tx *bolt.Tx, id platform.ID)
 	v := tx.Bucket(userBucket).Get(id)
 
 	if len(v) == 0 {
-		// TODO: Make standard error
-		return nil, fmt.Errorf("user not found")
+		return nil, kerrors.NotFoundf("user not found")
 	}
 
 	if err := json.Unmarshal(v, &u); err != nil {
@@ -115,7 +114,7 @@ func (c *Client) FindUser(ctx context.Context, filter platform.UserFilter) (*pla
 	}
 
 	if u == nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, kerrors.NotFoundf("user not found")
 	}
 
 	return u, nil
@@ -139,6 +138,7 @@ func filterUsersFn(filter platform.UserFilter) func(u *platform.User) bool {
 
 // FindUsers retrives all users that match an arbitrary user filter.
 // Filters using ID, or Name should be efficient.
+// When sorted by ID only the users of the requested page are decoded.
 // Other filters will do a linear scan across all users searching for a match.
 func (c *Client) FindUsers(ctx context.Context, filter platform.UserFilter, opt ...platform.FindOptions) ([]*platform.User, int, error) {
 	if filter.ID != nil {
@@ -159,6 +159,10 @@ func (c *Client) FindUsers(ctx context.Context, filter platform.UserFilter, opt
 		return []*platform.User{u}, 1, nil
 	}
 
+	if sortedByID(opt) {
+		return c.findUsersPage(ctx, findOptions(opt))
+	}
+
 	us := []*platform.User{}
 	filterFn := filterUsersFn(filter)
 	err := c.db.View(func(tx *bolt.Tx) error {
@@ -174,7 +178,39 @@ func (c *Client) FindUsers(ctx context.Context, filter platform.UserFilter, opt
 		return nil, 0, err
 	}
 
-	return us, len(us), nil
+	start, end, err := paginate(us, len(us), func(i int) platform.ID { return us[i].ID }, sortKeys{
+		"name": func(i int) string { return us[i].Name },
+	}, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return us[start:end], len(us), nil
+}
+
+// findUsersPage finds the page of users in ID order described by opt,
+// decoding only the users of the page.
+func (c *Client) findUsersPage(ctx context.Context, opt platform.FindOptions) ([]*platform.User, int, error) {
+	us := []*platform.User{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var ids []platform.ID
+		ids, n = pageIDs(bucketRange(tx, userBucket), opt)
+		for _, id := range ids {
+			u, err := c.findUserByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			us = append(us, u)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return us, n, nil
 }
 
 // CreateUser creates a platform user and sets b.ID.
@@ -183,13 +219,16 @@ func (c *Client) CreateUser(ctx context.Context, u *platform.User) error {
 		unique := c.uniqueUserName(ctx, tx, u)
 
 		if !unique {
-			// TODO: make standard error
-			return fmt.Errorf("user with name %s already exists", u.Name)
+			return kerrors.Conflictf("user with name %s already exists", u.Name)
 		}
 
 		u.ID = c.IDGenerator.ID()
+		createMeta(ctx, &u.Meta)
 
-		return c.putUser(ctx, tx, u)
+		if err := c.putUser(ctx, tx, u); err != nil {
+			return err
+		}
+		return c.appendAuditEvent(ctx, tx, platform.CreateAuditAction, platform.UserAuditResource, u.ID)
 	})
 }
 
@@ -267,10 +306,15 @@ func (c *Client) updateUser(ctx context.Context, tx *bolt.Tx, id platform.ID, up
 		u.Name = *upd.Name
 	}
 
+	updateMeta(ctx, &u.Meta)
 	if err := c.putUser(ctx, tx, u); err != nil {
 		return nil, err
 	}
 
+	if err := c.appendAuditEvent(ctx, tx, platform.UpdateAuditAction, platform.UserAuditResource, u.ID); err != nil {
+		return nil, err
+	}
+
 	return u, nil
 }
 
@@ -293,7 +337,10 @@ func (c *Client) deleteUser(ctx context.Context, tx *bolt.Tx, id platform.ID) er
 	if err := tx.Bucket(userIndex).Delete(userIndexKey(u.Name)); err != nil {
 		return err
 	}
-	return tx.Bucket(userBucket).Delete(id)
+	if err := tx.Bucket(userBucket).Delete(id); err != nil {
+		return err
+	}
+	return c.appendAuditEvent(ctx, tx, platform.DeleteAuditAction, platform.UserAuditResource, id)
 }
 
 func (c *Client) deleteUsersAuthorizations(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
@@ -12,6 +12,7 @@ type Bucket struct {
 	Organization    string        `json:"organization,omitempty"`
 	Name            string        `json:"name"`
 	RetentionPeriod time.Duration `json:"retentionPeriod"`
+	Meta
 }
 
 // BucketService represents a service for managing bucket data.
@@ -54,8 +55,17 @@ type BucketFilter struct {
 
 // FindOptions represents options passed to all find methods with multiple results.
 type FindOptions struct {
-	Limit      int
-	Offset     int
-	SortBy     string
+	// Limit is the maximum number of results returned, zero means no limit.
+	Limit int
+	// Offset is the number of results skipped after the After cursor.
+	Offset int
+	// SortBy is the field to sort on, the default is "id".
+	SortBy string
+	// Descending reverses the sort order.
 	Descending bool
+	// After is a cursor, only results sorted after the result with this ID are returned.
+	After ID
+	// SkipCount is set by callers that do not use the total count of results.
+	// Services that would have to read results outside of the page to count them return -1 instead.
+	SkipCount bool
 }
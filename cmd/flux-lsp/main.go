@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/influxdata/platform/query/lsp"
+	"github.com/spf13/cobra"
+
+	_ "github.com/influxdata/platform/query/builtin"
+)
+
+func main() {
+	Execute()
+}
+
+var lspCmd = &cobra.Command{
+	Use:   "flux-lsp",
+	Short: "Flux Language Server",
+	Long: `Flux Language Server communicating with the editor over stdin and stdout
+		using the Language Server Protocol.`,
+	Args: cobra.NoArgs,
+	Run:  lspF,
+}
+
+func lspF(cmd *cobra.Command, args []string) {
+	if err := lsp.NewServer(os.Stdin, os.Stdout).Serve(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Execute executes the flux-lsp command
+func Execute() {
+	if err := lspCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -14,12 +14,14 @@ import (
 	"github.com/influxdata/platform/http"
 	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/cache"
 	"github.com/influxdata/platform/query/control"
 	"github.com/influxdata/platform/query/execute"
 	"github.com/influxdata/platform/query/functions"
 	"github.com/influxdata/platform/query/functions/storage"
 	"github.com/influxdata/platform/query/functions/storage/pb"
 	"github.com/influxdata/platform/query/id"
+	"github.com/influxdata/platform/query/plan"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -43,6 +45,7 @@ var (
 	bindAddr         string
 	concurrencyQuota int
 	memoryBytesQuota int
+	cacheTTL         time.Duration
 )
 
 func init() {
@@ -64,6 +67,10 @@ func init() {
 	viper.BindEnv("MEM_BYTES")
 	viper.BindPFlag("mem_bytes", fluxdCmd.PersistentFlags().Lookup("mem-bytes"))
 
+	fluxdCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "How long query results are cached, 0 disables the result cache.")
+	viper.BindEnv("CACHE_TTL")
+	viper.BindPFlag("cache_ttl", fluxdCmd.PersistentFlags().Lookup("cache-ttl"))
+
 	fluxdCmd.PersistentFlags().String("storage-hosts", "localhost:8082", "host:port address of the storage server.")
 	viper.BindEnv("STORAGE_HOSTS")
 	viper.BindPFlag("STORAGE_HOSTS", fluxdCmd.PersistentFlags().Lookup("storage-hosts"))
@@ -90,10 +97,12 @@ func fluxF(cmd *cobra.Command, args []string) {
 		MemoryBytesQuota:     int64(memoryBytesQuota),
 		Verbose:              viper.GetBool("verbose"),
 	}
-	if err := injectDeps(config.ExecutorDependencies); err != nil {
+	s, err := injectDeps(config.ExecutorDependencies)
+	if err != nil {
 		logger.Error("error injecting dependencies", zap.Error(err))
 		os.Exit(1)
 	}
+	config.Storage = s
 	c := control.New(config)
 
 	orgName, err := getStrList("ORGANIZATION_NAME")
@@ -102,9 +111,14 @@ func fluxF(cmd *cobra.Command, args []string) {
 	}
 	orgSvc := StaticOrganizationService{Name: orgName[0]}
 
+	var asyncQueryService query.AsyncQueryService = wrapController{Controller: c}
+	if cacheTTL > 0 {
+		asyncQueryService = cache.NewAsyncQueryService(cache.New(cache.Config{TTL: cacheTTL}), asyncQueryService)
+	}
+
 	queryHandler := http.NewQueryHandler()
 	queryHandler.QueryService = query.QueryServiceBridge{
-		AsyncQueryService: wrapController{Controller: c},
+		AsyncQueryService: asyncQueryService,
 	}
 	queryHandler.OrganizationService = &orgSvc
 
@@ -128,26 +142,31 @@ func getStrList(key string) ([]string, error) {
 	return strings.Split(valStr, ","), nil
 }
 
-func injectDeps(deps execute.Dependencies) error {
+// injectDeps adds the storage dependencies to deps and returns the storage metadata used for planning.
+func injectDeps(deps execute.Dependencies) (plan.Storage, error) {
 	storageHosts, err := getStrList("STORAGE_HOSTS")
 	if err != nil {
-		return errors.Wrap(err, "failed to get storage hosts")
+		return nil, errors.Wrap(err, "failed to get storage hosts")
 	}
-	sr, err := pb.NewReader(storage.NewStaticLookup(storageHosts))
+	hl := storage.NewStaticLookup(storageHosts)
+	sr, err := pb.NewReader(hl)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	bucketName, err := getStrList("BUCKET_NAME")
 	if err != nil {
-		return errors.Wrap(err, "failed to get bucket name")
+		return nil, errors.Wrap(err, "failed to get bucket name")
 	}
 	bucketSvc := StaticBucketService{Name: bucketName[0]}
 
-	return functions.InjectFromDependencies(deps, storage.Dependencies{
+	if err := functions.InjectFromDependencies(deps, storage.Dependencies{
 		Reader:       sr,
 		BucketLookup: query.FromBucketService(&bucketSvc),
-	})
+	}); err != nil {
+		return nil, err
+	}
+	return storage.NewShardStorage(sr, hl, storage.DefaultShardRefreshInterval), nil
 }
 
 func main() {
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/spf13/cobra"
+)
+
+var backupTokenCmd = &cobra.Command{
+	Use:   "backup-token <user>",
+	Short: "Create a token of the user with the backup permission; idpd must not be running",
+	Args:  cobra.ExactArgs(1),
+	Run:   backupTokenF,
+}
+
+func init() {
+	platformCmd.AddCommand(backupTokenCmd)
+}
+
+func backupTokenF(cmd *cobra.Command, args []string) {
+	c := bolt.NewClient()
+	c.Path = boltPath
+
+	ctx := context.Background()
+	if err := c.Open(ctx); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	a := &platform.Authorization{
+		User:        args[0],
+		Permissions: []platform.Permission{platform.ReadBackupPermission},
+	}
+	if err := c.CreateAuthorization(ctx, a); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(a.Token)
+}
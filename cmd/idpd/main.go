@@ -7,6 +7,8 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,6 +18,15 @@ import (
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
 	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/control"
+	"github.com/influxdata/platform/query/execute"
+	"github.com/influxdata/platform/query/functions"
+	"github.com/influxdata/platform/query/functions/storage"
+	"github.com/influxdata/platform/query/functions/storage/pb"
+	"github.com/influxdata/platform/query/id"
+	"github.com/influxdata/platform/task"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -28,6 +39,9 @@ var (
 	httpBindAddress   string
 	authorizationPath string
 	boltPath          string
+	storageHosts      string
+	validateRequests  bool
+	maxRunsPerTask    int
 )
 
 func init() {
@@ -45,11 +59,29 @@ func init() {
 		authorizationPath = h
 	}
 
-	platformCmd.Flags().StringVar(&boltPath, "bolt-path", "idpdb.bolt", "path to boltdb database")
+	platformCmd.PersistentFlags().StringVar(&boltPath, "bolt-path", "idpdb.bolt", "path to boltdb database")
 	viper.BindEnv("BOLT_PATH")
 	if h := viper.GetString("BOLT_PATH"); h != "" {
 		boltPath = h
 	}
+
+	platformCmd.Flags().StringVar(&storageHosts, "storage-hosts", "", "comma separated host:port addresses of the storage servers, tasks are only run when set")
+	viper.BindEnv("STORAGE_HOSTS")
+	if h := viper.GetString("STORAGE_HOSTS"); h != "" {
+		storageHosts = h
+	}
+
+	platformCmd.Flags().BoolVar(&validateRequests, "validate-requests", false, "reject requests that do not conform to the API spec")
+	viper.BindEnv("VALIDATE_REQUESTS")
+	if viper.IsSet("VALIDATE_REQUESTS") {
+		validateRequests = viper.GetBool("VALIDATE_REQUESTS")
+	}
+
+	platformCmd.Flags().IntVar(&maxRunsPerTask, "max-runs-per-task", bolt.DefaultMaxRunsPerTask, "number of most recent runs kept for each task, 0 keeps every run")
+	viper.BindEnv("MAX_RUNS_PER_TASK")
+	if viper.IsSet("MAX_RUNS_PER_TASK") {
+		maxRunsPerTask = viper.GetInt("MAX_RUNS_PER_TASK")
+	}
 }
 
 var platformCmd = &cobra.Command{
@@ -64,6 +96,7 @@ func platformF(cmd *cobra.Command, args []string) {
 
 	c := bolt.NewClient()
 	c.Path = boltPath
+	c.MaxRunsPerTask = maxRunsPerTask
 
 	if err := c.Open(context.TODO()); err != nil {
 		logger.Error("failed opening bolt", zap.Error(err))
@@ -96,6 +129,38 @@ func platformF(cmd *cobra.Command, args []string) {
 		dashboardSvc = c
 	}
 
+	var taskSvc platform.TaskService
+	{
+		taskSvc = c
+	}
+
+	var auditSvc platform.AuditService
+	{
+		auditSvc = c
+	}
+
+	var queryService query.QueryService
+	if storageHosts != "" {
+		qs, err := newQueryService(bucketSvc)
+		if err != nil {
+			logger.Error("failed creating query service", zap.Error(err))
+			os.Exit(1)
+		}
+
+		scheduler := task.NewScheduler()
+		scheduler.TaskService = taskSvc
+		scheduler.RunService = c
+		scheduler.QueryService = qs
+		scheduler.Logger = logger.With(zap.String("service", "task-scheduler"))
+		if err := scheduler.Open(); err != nil {
+			logger.Error("failed starting task scheduler", zap.Error(err))
+			os.Exit(1)
+		}
+		defer scheduler.Close()
+
+		queryService = qs
+	}
+
 	errc := make(chan error)
 
 	sigs := make(chan os.Signal, 1)
@@ -118,20 +183,40 @@ func platformF(cmd *cobra.Command, args []string) {
 
 		dashboardHandler := http.NewDashboardHandler()
 		dashboardHandler.DashboardService = dashboardSvc
+		dashboardHandler.QueryService = queryService
+		dashboardHandler.BucketService = bucketSvc
+
+		taskHandler := http.NewTaskHandler()
+		taskHandler.TaskService = taskSvc
 
 		authHandler := http.NewAuthorizationHandler()
 		authHandler.AuthorizationService = authSvc
 		authHandler.Logger = logger.With(zap.String("handler", "auth"))
 
+		backupHandler := http.NewBackupHandler()
+		backupHandler.BackupService = c
+		backupHandler.AuthorizationService = authSvc
+		backupHandler.Logger = logger.With(zap.String("handler", "backup"))
+
+		auditHandler := http.NewAuditHandler()
+		auditHandler.AuditService = auditSvc
+
 		platformHandler := &http.PlatformHandler{
 			BucketHandler:        bucketHandler,
 			OrgHandler:           orgHandler,
 			UserHandler:          userHandler,
 			AuthorizationHandler: authHandler,
 			DashboardHandler:     dashboardHandler,
+			TaskHandler:          taskHandler,
+			BackupHandler:        backupHandler,
+			AuditHandler:         auditHandler,
+			AuthorizationService: authSvc,
 		}
 		h := http.NewHandler("platform")
 		h.Handler = platformHandler
+		if validateRequests {
+			h.ValidationSpec = http.PlatformSpec()
+		}
 
 		httpServer.Handler = h
 		logger.Info("listening", zap.String("transport", "http"), zap.String("addr", httpBindAddress))
@@ -149,6 +234,44 @@ func platformF(cmd *cobra.Command, args []string) {
 	httpServer.Shutdown(ctx)
 }
 
+// newQueryService creates a query controller that reads from the storage hosts.
+func newQueryService(bucketSvc platform.BucketService) (query.QueryService, error) {
+	hl := storage.NewStaticLookup(strings.Split(storageHosts, ","))
+	sr, err := pb.NewReader(hl)
+	if err != nil {
+		return nil, err
+	}
+
+	config := control.Config{
+		ExecutorDependencies: make(execute.Dependencies),
+		ConcurrencyQuota:     runtime.NumCPU() * 2,
+	}
+	if err := functions.InjectFromDependencies(config.ExecutorDependencies, storage.Dependencies{
+		Reader:       sr,
+		BucketLookup: query.FromBucketService(bucketSvc),
+	}); err != nil {
+		return nil, err
+	}
+	config.Storage = storage.NewShardStorage(sr, hl, storage.DefaultShardRefreshInterval)
+
+	return query.QueryServiceBridge{
+		AsyncQueryService: wrapController{Controller: control.New(config)},
+	}, nil
+}
+
+// wrapController makes a *control.Controller implement query.AsyncQueryService.
+type wrapController struct {
+	*control.Controller
+}
+
+func (c wrapController) Query(ctx context.Context, orgID platform.ID, q *query.Spec) (query.Query, error) {
+	return c.Controller.Query(ctx, id.ID(orgID), q)
+}
+
+func (c wrapController) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.Query, error) {
+	return c.Controller.QueryWithCompile(ctx, id.ID(orgID), q)
+}
+
 // Execute executes the idped command
 func Execute() {
 	if err := platformCmd.Execute(); err != nil {
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/influxdata/platform/bolt"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <backup file>",
+	Short: "Restore the boltdb database from a backup; idpd must not be running",
+	Args:  cobra.ExactArgs(1),
+	Run:   restoreF,
+}
+
+func init() {
+	platformCmd.AddCommand(restoreCmd)
+}
+
+func restoreF(cmd *cobra.Command, args []string) {
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	if err := bolt.Restore(context.Background(), boltPath, f); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("restored %s from %s\n", boltPath, args[0])
+}
@@ -26,6 +26,7 @@ type AuthorizationCreateFlags struct {
 
 	createUserPermission bool
 	deleteUserPermission bool
+	backupPermission     bool
 
 	readBucketPermissions  []string
 	writeBucketPermissions []string
@@ -45,6 +46,7 @@ func init() {
 
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.createUserPermission, "create-user", "", false, "grants the permission to create users")
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.deleteUserPermission, "delete-user", "", false, "grants the permission to delete users")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.backupPermission, "backup", "", false, "grants the permission to back up all metadata, the token used must hold it")
 
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.readBucketPermissions, "read-bucket", "", []string{}, "bucket id")
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.writeBucketPermissions, "write-bucket", "", []string{}, "bucket id")
@@ -60,6 +62,9 @@ func authorizationCreateF(cmd *cobra.Command, args []string) {
 	if authorizationCreateFlags.deleteUserPermission {
 		permissions = append(permissions, platform.DeleteUserPermission)
 	}
+	if authorizationCreateFlags.backupPermission {
+		permissions = append(permissions, platform.ReadBackupPermission)
+	}
 
 	for _, p := range authorizationCreateFlags.writeBucketPermissions {
 		var id platform.ID
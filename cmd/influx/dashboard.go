@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/cmd/influx/internal"
+	"github.com/influxdata/platform/http"
+	"github.com/spf13/cobra"
+)
+
+// Dashboard Command
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "dashboard related commands",
+	Run:   dashboardF,
+}
+
+func dashboardF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+// Export Command
+type DashboardExportFlags struct {
+	id   string
+	file string
+}
+
+var dashboardExportFlags DashboardExportFlags
+
+func init() {
+	dashboardExportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export dashboard",
+		Run:   dashboardExportF,
+	}
+
+	dashboardExportCmd.Flags().StringVarP(&dashboardExportFlags.id, "id", "i", "", "id of the dashboard to export")
+	dashboardExportCmd.Flags().StringVarP(&dashboardExportFlags.file, "file", "f", "", "file to write the export to, defaults to stdout")
+	dashboardExportCmd.MarkFlagRequired("id")
+
+	dashboardCmd.AddCommand(dashboardExportCmd)
+}
+
+func dashboardExportF(cmd *cobra.Command, args []string) {
+	s := &http.DashboardService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(dashboardExportFlags.id); err != nil {
+		fmt.Printf("error parsing dashboard id: %v\n", err)
+		os.Exit(1)
+	}
+
+	e, err := s.ExportDashboard(context.Background(), id)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	octets, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	octets = append(octets, '\n')
+
+	if dashboardExportFlags.file == "" {
+		os.Stdout.Write(octets)
+		return
+	}
+	if err := ioutil.WriteFile(dashboardExportFlags.file, octets, 0644); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// Import Command
+type DashboardImportFlags struct {
+	file    string
+	name    string
+	org     string
+	orgID   string
+	buckets []string
+}
+
+var dashboardImportFlags DashboardImportFlags
+
+func init() {
+	dashboardImportCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import dashboard",
+		Run:   dashboardImportF,
+	}
+
+	dashboardImportCmd.Flags().StringVarP(&dashboardImportFlags.file, "file", "f", "", "file of the dashboard export, - for stdin")
+	dashboardImportCmd.Flags().StringVarP(&dashboardImportFlags.name, "name", "n", "", "name of the new dashboard, defaults to the exported name")
+	dashboardImportCmd.Flags().StringVarP(&dashboardImportFlags.org, "org", "o", "", "name of the organization that owns the dashboard")
+	dashboardImportCmd.Flags().StringVarP(&dashboardImportFlags.orgID, "org-id", "", "", "id of the organization that owns the dashboard")
+	dashboardImportCmd.Flags().StringSliceVarP(&dashboardImportFlags.buckets, "bucket", "b", nil, "rename a bucket read by the dashboard queries, as old=new")
+	dashboardImportCmd.MarkFlagRequired("file")
+
+	dashboardCmd.AddCommand(dashboardImportCmd)
+}
+
+func dashboardImportF(cmd *cobra.Command, args []string) {
+	if (dashboardImportFlags.org == "") == (dashboardImportFlags.orgID == "") {
+		fmt.Println("must specify exactly one of org or org-id")
+		cmd.Usage()
+		os.Exit(1)
+	}
+
+	s := &http.DashboardService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var octets []byte
+	var err error
+	if dashboardImportFlags.file == "-" {
+		octets, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		octets, err = ioutil.ReadFile(dashboardImportFlags.file)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var e platform.DashboardExport
+	if err := json.Unmarshal(octets, &e); err != nil {
+		fmt.Printf("error parsing dashboard export: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := platform.DashboardImportOptions{
+		Name:         dashboardImportFlags.name,
+		Organization: dashboardImportFlags.org,
+	}
+
+	if dashboardImportFlags.orgID != "" {
+		var id platform.ID
+		if err := id.DecodeFromString(dashboardImportFlags.orgID); err != nil {
+			fmt.Printf("error parsing organization id: %v\n", err)
+			os.Exit(1)
+		}
+		opts.OrganizationID = id
+	}
+
+	if len(dashboardImportFlags.buckets) > 0 {
+		opts.Buckets = make(map[string]string, len(dashboardImportFlags.buckets))
+		for _, b := range dashboardImportFlags.buckets {
+			parts := strings.SplitN(b, "=", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				fmt.Printf("invalid bucket %q, must be old=new\n", b)
+				os.Exit(1)
+			}
+			opts.Buckets[parts[0]] = parts[1]
+		}
+	}
+
+	d, err := s.ImportDashboard(context.Background(), &e, opts)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Name",
+		"OrganizationID",
+		"Cells",
+	)
+	w.Write(map[string]interface{}{
+		"ID":             d.ID.String(),
+		"Name":           d.Name,
+		"OrganizationID": d.OrganizationID.String(),
+		"Cells":          len(d.Cells),
+	})
+	w.Flush()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/influxdata/platform/query/ast"
+	"github.com/influxdata/platform/query/parser"
+	"github.com/spf13/cobra"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [flags] file.flux...",
+	Short: "Format Flux files",
+	Long: `Format Flux files in the canonical format and print the result,
+		or rewrite the files in place with the -w flag.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  fluxFmtF,
+}
+
+var fmtFlags struct {
+	Write bool
+}
+
+func init() {
+	fmtCmd.Flags().BoolVarP(&fmtFlags.Write, "write", "w", false, "Write the result to the file instead of printing it")
+}
+
+func fluxFmtF(cmd *cobra.Command, args []string) {
+	for _, path := range args {
+		if err := formatFile(path, fmtFlags.Write); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// formatFile formats the Flux file, writing the result to the file or to stdout.
+func formatFile(path string, write bool) error {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	program, err := parser.NewAST(string(src))
+	if err != nil {
+		return err
+	}
+	formatted := ast.Format(program)
+	if !write {
+		_, err := fmt.Fprint(os.Stdout, formatted)
+		return err
+	}
+	if formatted == string(src) {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(formatted), info.Mode())
+}
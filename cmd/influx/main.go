@@ -21,6 +21,8 @@ var influxCmd = &cobra.Command{
 func init() {
 	influxCmd.AddCommand(authorizationCmd)
 	influxCmd.AddCommand(bucketCmd)
+	influxCmd.AddCommand(dashboardCmd)
+	influxCmd.AddCommand(fmtCmd)
 	influxCmd.AddCommand(replCmd)
 	influxCmd.AddCommand(queryCmd)
 	influxCmd.AddCommand(organizationCmd)
@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"os"
+	"runtime"
 	"strings"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/http"
 	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/control"
 	"github.com/influxdata/platform/query/execute"
 	"github.com/influxdata/platform/query/functions"
 	"github.com/influxdata/platform/query/functions/storage"
@@ -29,12 +33,19 @@ var queryCmd = &cobra.Command{
 }
 
 var queryFlags struct {
+	QueryHost    string
 	StorageHosts string
 	OrgID        string
 	Verbose      bool
 }
 
 func init() {
+	queryCmd.PersistentFlags().StringVar(&queryFlags.QueryHost, "query-host", "", "HTTP address of the server executing the query, when empty the query is executed locally against the storage hosts")
+	viper.BindEnv("QUERY_HOST")
+	if h := viper.GetString("QUERY_HOST"); h != "" {
+		queryFlags.QueryHost = h
+	}
+
 	queryCmd.PersistentFlags().StringVar(&queryFlags.StorageHosts, "storage-hosts", "localhost:8082", "Comma-separated list of storage hosts")
 	viper.BindEnv("STORAGE_HOSTS")
 	if h := viper.GetString("STORAGE_HOSTS"); h != "" {
@@ -61,30 +72,19 @@ func fluxQueryF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	hosts, err := storageHostReader(strings.Split(queryFlags.StorageHosts, ","))
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
 	org, err := orgID(queryFlags.OrgID)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	buckets, err := bucketService(flags.host, flags.token)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
-	r, err := getFluxREPL(hosts, buckets, org, queryFlags.Verbose)
+	qs, err := queryService(queryFlags.QueryHost, queryFlags.StorageHosts, queryFlags.Verbose)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
+	r := repl.New(qs, platform.ID(org))
 	if err := r.Input(q); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -98,6 +98,58 @@ func injectDeps(deps execute.Dependencies, hosts storage.Reader, buckets platfor
 	})
 }
 
+// queryService returns a query service that executes queries on the server at queryHost using the API token.
+// When queryHost is empty, queries are executed by a local controller reading from the storage hosts.
+func queryService(queryHost, storageHosts string, verbose bool) (query.QueryService, error) {
+	if queryHost != "" {
+		return &http.QueryService{
+			Addr:  queryHost,
+			Token: flags.token,
+		}, nil
+	}
+
+	buckets, err := bucketService(flags.host, flags.token)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := storageHostReader(strings.Split(storageHosts, ","))
+	if err != nil {
+		return nil, err
+	}
+
+	conf := control.Config{
+		ExecutorDependencies: make(execute.Dependencies),
+		ConcurrencyQuota:     runtime.NumCPU() * 2,
+		MemoryBytesQuota:     math.MaxInt64,
+		Verbose:              verbose,
+	}
+
+	if err := injectDeps(conf.ExecutorDependencies, hosts, buckets); err != nil {
+		return nil, err
+	}
+	if mc, ok := hosts.(storage.MetaClient); ok {
+		conf.Storage = storage.NewShardStorage(mc, nil, storage.DefaultShardRefreshInterval)
+	}
+
+	return query.QueryServiceBridge{
+		AsyncQueryService: wrapController{Controller: control.New(conf)},
+	}, nil
+}
+
+// wrapController is needed to make *control.Controller implement query.AsyncQueryService.
+type wrapController struct {
+	*control.Controller
+}
+
+func (c wrapController) Query(ctx context.Context, orgID platform.ID, query *query.Spec) (query.Query, error) {
+	return c.Controller.Query(ctx, qid.ID(orgID), query)
+}
+
+func (c wrapController) QueryWithCompile(ctx context.Context, orgID platform.ID, query string) (query.Query, error) {
+	return c.Controller.QueryWithCompile(ctx, qid.ID(orgID), query)
+}
+
 func storageHostReader(hosts []string) (storage.Reader, error) {
 	return pb.NewReader(storage.NewStaticLookup(hosts))
 }
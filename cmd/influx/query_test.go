@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform/http"
+)
+
+func TestQueryService(t *testing.T) {
+	defer func(f Flags) { flags = f }(flags)
+	flags.token = "Token secret"
+
+	// A query host executes queries remotely with the API token.
+	qs, err := queryService("http://localhost:9999", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote, ok := qs.(*http.QueryService)
+	if !ok {
+		t.Fatalf("expected a remote query service, got %T", qs)
+	}
+	if remote.Addr != "http://localhost:9999" || remote.Token != flags.token {
+		t.Errorf("unexpected remote query service: %+v", remote)
+	}
+
+	// Without a query host, queries are executed locally and buckets are looked up on the host.
+	flags.host = ""
+	if _, err := queryService("", "localhost:8082", false); err == nil || err.Error() != "bucket host address required" {
+		t.Errorf("expected an error executing queries locally without a host to look up buckets, got %v", err)
+	}
+}
@@ -2,17 +2,10 @@ package main
 
 import (
 	"fmt"
-	"math"
 	"os"
-	"runtime"
-	"strings"
 
 	"github.com/influxdata/platform"
 	_ "github.com/influxdata/platform/query/builtin"
-	"github.com/influxdata/platform/query/control"
-	"github.com/influxdata/platform/query/execute"
-	"github.com/influxdata/platform/query/functions/storage"
-	qid "github.com/influxdata/platform/query/id"
 	"github.com/influxdata/platform/query/repl"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -26,12 +19,19 @@ var replCmd = &cobra.Command{
 }
 
 var replFlags struct {
+	QueryHost    string
 	StorageHosts string
 	OrgID        string
 	Verbose      bool
 }
 
 func init() {
+	replCmd.PersistentFlags().StringVar(&replFlags.QueryHost, "query-host", "", "HTTP address of the server executing queries, when empty queries are executed locally against the storage hosts")
+	viper.BindEnv("QUERY_HOST")
+	if h := viper.GetString("QUERY_HOST"); h != "" {
+		replFlags.QueryHost = h
+	}
+
 	replCmd.PersistentFlags().StringVar(&replFlags.StorageHosts, "storage-hosts", "localhost:8082", "Comma-separated list of storage hosts")
 	viper.BindEnv("STORAGE_HOSTS")
 	if h := viper.GetString("STORAGE_HOSTS"); h != "" {
@@ -52,45 +52,17 @@ func init() {
 }
 
 func replF(cmd *cobra.Command, args []string) {
-	hosts, err := storageHostReader(strings.Split(replFlags.StorageHosts, ","))
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
 	org, err := orgID(replFlags.OrgID)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	buckets, err := bucketService(flags.host, flags.token)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
-	r, err := getFluxREPL(hosts, buckets, org, replFlags.Verbose)
+	qs, err := queryService(replFlags.QueryHost, replFlags.StorageHosts, replFlags.Verbose)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	r.Run()
-}
-
-func getFluxREPL(storageHosts storage.Reader, buckets platform.BucketService, org qid.ID, verbose bool) (*repl.REPL, error) {
-	conf := control.Config{
-		ExecutorDependencies: make(execute.Dependencies),
-		ConcurrencyQuota:     runtime.NumCPU() * 2,
-		MemoryBytesQuota:     math.MaxInt64,
-		Verbose:              verbose,
-	}
-
-	if err := injectDeps(conf.ExecutorDependencies, storageHosts, buckets); err != nil {
-		return nil, err
-	}
-
-	c := control.New(conf)
-	return repl.New(c, org), nil
+	repl.New(qs, platform.ID(org)).Run()
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 )
 
 // DashboardService represents a service for managing dashboard data.
@@ -19,7 +20,7 @@ type DashboardService interface {
 
 	// FindDashboards returns a list of dashboards that match filter and the total count of matching dashboards.
 	// Additional options provide pagination & sorting.
-	FindDashboards(ctx context.Context, filter DashboardFilter) ([]*Dashboard, int, error)
+	FindDashboards(ctx context.Context, filter DashboardFilter, opt ...FindOptions) ([]*Dashboard, int, error)
 
 	// CreateDashboard creates a new dashboard and sets b.ID with the new identifier.
 	CreateDashboard(ctx context.Context, b *Dashboard) error
@@ -51,17 +52,124 @@ type DashboardFilter struct {
 // DashboardUpdate represents updates to a dashboard.
 // Only fields which are set are updated.
 type DashboardUpdate struct {
-	Name *string `json:"name,omitempty"`
+	Name      *string              `json:"name,omitempty"`
+	Variables *[]DashboardVariable `json:"variables,omitempty"`
 }
 
 // Dashboard represents all visual and query data for a dashboard
 type Dashboard struct {
-	// TODO: add meta information fields like created_at, updated_at, created_by, etc
 	ID             ID              `json:"id"`
 	OrganizationID ID              `json:"organizationID"`
 	Organization   string          `json:"organization"`
 	Name           string          `json:"name"`
 	Cells          []DashboardCell `json:"cells"`
+	// Variables are the template variables referenced by the queries of the cells.
+	Variables []DashboardVariable `json:"variables,omitempty"`
+	Meta
+}
+
+// Validate returns an error if a variable or cell of the dashboard is invalid.
+func (d *Dashboard) Validate() error {
+	if err := ValidateDashboardVariables(d.Variables); err != nil {
+		return err
+	}
+	for _, c := range d.Cells {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("cell %q: %v", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Types of dashboard variables.
+const (
+	// ConstantVariableType is the type of variables with a constant list of values.
+	ConstantVariableType = "constant"
+	// QueryVariableType is the type of variables whose values are the results of a Flux query.
+	QueryVariableType = "query"
+	// TimeRangeVariableType is the type of variables holding the time range of queries.
+	TimeRangeVariableType = "timeRange"
+)
+
+// variableNamePattern matches the identifiers variables may be referenced by in Flux.
+var variableNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// DashboardVariable is a template variable of a dashboard.
+// The queries of the cells reference variables by name, their selected values
+// are declared in the scope of the queries when they are run.
+type DashboardVariable struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Values are the values of a constant variable.
+	Values []string `json:"values,omitempty"`
+	// Query is the Flux query of a query variable.
+	// Its values are the values of the _value column of the results, such as the tag values returned by keys().
+	Query string `json:"query,omitempty"`
+	// TimeRange is the default time range of a time range variable.
+	// Time range variables are objects with start and stop times.
+	TimeRange *TimeRange `json:"timeRange,omitempty"`
+}
+
+// Validate returns an error if the variable is not a valid Flux identifier or
+// does not have exactly the fields of its type.
+func (v DashboardVariable) Validate() error {
+	if !variableNamePattern.MatchString(v.Name) {
+		return fmt.Errorf("invalid variable name %q", v.Name)
+	}
+
+	var constant, query, timeRange bool
+	switch v.Type {
+	case ConstantVariableType:
+		constant = true
+		if len(v.Values) == 0 {
+			return fmt.Errorf("constant variable %q has no values", v.Name)
+		}
+	case QueryVariableType:
+		query = true
+		if v.Query == "" {
+			return fmt.Errorf("query variable %q has no query", v.Name)
+		}
+	case TimeRangeVariableType:
+		timeRange = true
+		if v.TimeRange == nil {
+			return fmt.Errorf("time range variable %q has no time range", v.Name)
+		}
+		if err := v.TimeRange.Validate(); err != nil {
+			return fmt.Errorf("variable %q: %v", v.Name, err)
+		}
+	default:
+		return fmt.Errorf("unknown type %q of variable %q", v.Type, v.Name)
+	}
+
+	if (len(v.Values) > 0 && !constant) || (v.Query != "" && !query) || (v.TimeRange != nil && !timeRange) {
+		return fmt.Errorf("variable %q has fields of another type than %s", v.Name, v.Type)
+	}
+	return nil
+}
+
+// ValidateDashboardVariables returns an error if a variable is invalid or variables have the same name.
+func ValidateDashboardVariables(vs []DashboardVariable) error {
+	names := make(map[string]bool, len(vs))
+	for _, v := range vs {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+		if names[v.Name] {
+			return fmt.Errorf("duplicate variable %q", v.Name)
+		}
+		names[v.Name] = true
+	}
+	return nil
+}
+
+// Variable returns the variable of the dashboard with the given name.
+func (d *Dashboard) Variable(name string) (DashboardVariable, bool) {
+	for _, v := range d.Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return DashboardVariable{}, false
 }
 
 // DashboardCell holds positional and visual information for a cell.
@@ -71,7 +179,7 @@ type DashboardCell struct {
 }
 
 type DashboardCellContents struct {
-	ID   ID     `json:"id"`
+	ID   ID     `json:"id,omitempty"`
 	Name string `json:"name"`
 
 	X int32 `json:"x"`
@@ -80,10 +188,6 @@ type DashboardCellContents struct {
 	H int32 `json:"h"`
 }
 
-type Visualization interface {
-	Visualization()
-}
-
 func (c DashboardCell) MarshalJSON() ([]byte, error) {
 	vis, err := MarshalVisualizationJSON(c.Visualization)
 	if err != nil {
@@ -112,58 +216,31 @@ func (c *DashboardCell) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// TODO: fill in with real visualization requirements
-type CommonVisualization struct {
-	Query string `json:"query"`
-}
-
-func (CommonVisualization) Visualization() {}
-
-func UnmarshalVisualizationJSON(b []byte) (Visualization, error) {
-	var v struct {
-		B json.RawMessage `json:"visualization"`
-	}
-
-	if err := json.Unmarshal(b, &v); err != nil {
-		return nil, err
-	}
-
-	var t struct {
-		Type string `json:"type"`
-	}
-
-	if err := json.Unmarshal(v.B, &t); err != nil {
-		return nil, err
-	}
-
-	var vis Visualization
-	switch t.Type {
-	case "common":
-		var qv CommonVisualization
-		if err := json.Unmarshal(v.B, &qv); err != nil {
-			return nil, err
-		}
-		vis = qv
+// Queries returns the queries of the visualization of the cell.
+func (c DashboardCell) Queries() []DashboardQuery {
+	switch v := c.Visualization.(type) {
+	case CommonVisualization:
+		return []DashboardQuery{{Query: v.Query}}
+	case LineGraphVisualization:
+		return v.Queries
+	case SingleStatVisualization:
+		return v.Queries
+	case GaugeVisualization:
+		return v.Queries
+	case TableVisualization:
+		return v.Queries
 	default:
-		return nil, fmt.Errorf("unknown type %v", t.Type)
+		return nil
 	}
-
-	return vis, nil
 }
 
-func MarshalVisualizationJSON(v Visualization) ([]byte, error) {
-	var s interface{}
-	switch vis := v.(type) {
-	case CommonVisualization:
-		s = struct {
-			Type string `json:"type"`
-			CommonVisualization
-		}{
-			Type:                "common",
-			CommonVisualization: vis,
-		}
-	default:
-		return nil, fmt.Errorf("unsupported type")
+// Validate returns an error if the cell is out of bounds or its visualization is invalid.
+func (c DashboardCell) Validate() error {
+	if c.X < 0 || c.Y < 0 || c.W < 0 || c.H < 0 {
+		return fmt.Errorf("cell position and size must not be negative")
+	}
+	if c.Visualization == nil {
+		return fmt.Errorf("cell has no visualization")
 	}
-	return json.Marshal(s)
+	return c.Visualization.Validate()
 }
@@ -0,0 +1,95 @@
+package platform
+
+import "fmt"
+
+// DashboardExportVersion is the version of the format of exported dashboards.
+const DashboardExportVersion = 1
+
+// DashboardExport is a dashboard in a portable format that can be imported into any organization.
+// It has none of the IDs assigned to the dashboard and its cells by the server.
+type DashboardExport struct {
+	Version   int                 `json:"version"`
+	Name      string              `json:"name"`
+	Cells     []DashboardCell     `json:"cells"`
+	Variables []DashboardVariable `json:"variables,omitempty"`
+	// Buckets are the names of the buckets read by the queries of the dashboard.
+	Buckets []string `json:"buckets,omitempty"`
+}
+
+// NewDashboardExport returns the export of d, referencing the buckets read by its queries.
+func NewDashboardExport(d *Dashboard, buckets []string) *DashboardExport {
+	e := &DashboardExport{
+		Version:   DashboardExportVersion,
+		Name:      d.Name,
+		Cells:     make([]DashboardCell, len(d.Cells)),
+		Variables: append([]DashboardVariable(nil), d.Variables...),
+		Buckets:   buckets,
+	}
+	for i, c := range d.Cells {
+		c.ID = nil
+		e.Cells[i] = c
+	}
+	return e
+}
+
+// Dashboard returns a new dashboard, without IDs, from the export.
+func (e *DashboardExport) Dashboard() (*Dashboard, error) {
+	if e.Version != DashboardExportVersion {
+		return nil, fmt.Errorf("unsupported dashboard export version %d", e.Version)
+	}
+	d := &Dashboard{
+		Name:      e.Name,
+		Cells:     make([]DashboardCell, len(e.Cells)),
+		Variables: append([]DashboardVariable(nil), e.Variables...),
+	}
+	copy(d.Cells, e.Cells)
+	return d, nil
+}
+
+// DashboardImportOptions are the options of importing or cloning a dashboard.
+type DashboardImportOptions struct {
+	// OrganizationID or Organization is the organization of the new dashboard.
+	OrganizationID ID     `json:"organizationID,omitempty"`
+	Organization   string `json:"organization,omitempty"`
+	// Name overrides the name of the dashboard.
+	Name string `json:"name,omitempty"`
+	// Buckets renames the buckets read by the queries of the dashboard.
+	// The buckets must exist in the organization of the new dashboard.
+	Buckets map[string]string `json:"buckets,omitempty"`
+}
+
+// RewriteQueries replaces each query of the visualization of the cell with the result of fn.
+func (c *DashboardCell) RewriteQueries(fn func(q string) (string, error)) error {
+	rewrite := func(qs []DashboardQuery) ([]DashboardQuery, error) {
+		out := make([]DashboardQuery, len(qs))
+		for i, q := range qs {
+			s, err := fn(q.Query)
+			if err != nil {
+				return nil, err
+			}
+			q.Query = s
+			out[i] = q
+		}
+		return out, nil
+	}
+
+	var err error
+	switch v := c.Visualization.(type) {
+	case CommonVisualization:
+		v.Query, err = fn(v.Query)
+		c.Visualization = v
+	case LineGraphVisualization:
+		v.Queries, err = rewrite(v.Queries)
+		c.Visualization = v
+	case SingleStatVisualization:
+		v.Queries, err = rewrite(v.Queries)
+		c.Visualization = v
+	case GaugeVisualization:
+		v.Queries, err = rewrite(v.Queries)
+		c.Visualization = v
+	case TableVisualization:
+		v.Queries, err = rewrite(v.Queries)
+		c.Visualization = v
+	}
+	return err
+}
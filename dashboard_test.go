@@ -3,6 +3,7 @@ package platform_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
@@ -85,3 +86,181 @@ func jsonEqual(s1, s2 string) (eq bool, err error) {
 
 	return cmp.Equal(o1, o2), nil
 }
+
+func TestDashboardCell_JSONRoundTrip(t *testing.T) {
+	start := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	min, max, places := 0.0, 100.0, 2
+
+	tests := []struct {
+		name string
+		vis  platform.Visualization
+	}{
+		{
+			name: "line graph",
+			vis: platform.LineGraphVisualization{
+				Queries: []platform.DashboardQuery{
+					{Query: "from(db:\"telegraf\") |> range(start:-1h)"},
+					{Query: "from(db:\"telegraf\") |> range(start:-1d)", TimeRange: &platform.TimeRange{Start: &start}},
+				},
+				XAxis:  platform.Axis{Label: "time"},
+				YAxis:  platform.Axis{Label: "usage", Min: &min, Max: &max, Scale: "log", Suffix: "%"},
+				Legend: platform.Legend{Orientation: "right"},
+				Colors: []string{"#7A65F2", "#00C9FF"},
+			},
+		},
+		{
+			name: "single stat",
+			vis: platform.SingleStatVisualization{
+				Queries:       []platform.DashboardQuery{{Query: "SELECT last(usage) FROM cpu", TimeRange: &platform.TimeRange{Duration: "5m"}}},
+				Suffix:        "%",
+				DecimalPlaces: &places,
+				Thresholds:    []platform.Threshold{{Value: 0, Color: "#00C9FF"}, {Value: 80, Color: "#DC4E58"}},
+			},
+		},
+		{
+			name: "gauge",
+			vis: platform.GaugeVisualization{
+				Queries:    []platform.DashboardQuery{{Query: "SELECT last(usage) FROM cpu"}},
+				Min:        0,
+				Max:        100,
+				Thresholds: []platform.Threshold{{Value: 80, Color: "#DC4E58"}},
+			},
+		},
+		{
+			name: "table",
+			vis: platform.TableVisualization{
+				Queries:        []platform.DashboardQuery{{Query: "SELECT * FROM cpu"}},
+				Columns:        []platform.TableColumn{{Name: "_time", DisplayName: "Time"}, {Name: "usage", DecimalPlaces: &places}, {Name: "host", Hidden: true}},
+				SortBy:         "_time",
+				TimeFormat:     time.RFC3339,
+				FixFirstColumn: true,
+			},
+		},
+		{
+			name: "markdown",
+			vis:  platform.MarkdownVisualization{Note: "# CPU\nUsage of all hosts."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cell := platform.DashboardCell{
+				DashboardCellContents: platform.DashboardCellContents{ID: platform.ID("0"), Name: tt.name, W: 4, H: 4},
+				Visualization:         tt.vis,
+			}
+			if err := cell.Validate(); err != nil {
+				t.Fatalf("unexpected invalid cell: %v", err)
+			}
+
+			b, err := json.Marshal(cell)
+			if err != nil {
+				t.Fatalf("error marshalling json: %v", err)
+			}
+			var got platform.DashboardCell
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("error unmarshalling json: %v", err)
+			}
+			if diff := cmp.Diff(got, cell); diff != "" {
+				t.Errorf("cell is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalVisualizationJSON_Strict(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{name: "unknown type", json: `{"visualization": {"type": "pie"}}`},
+		{name: "unknown field", json: `{"visualization": {"type": "markdown", "note": "hi", "color": "#00C9FF"}}`},
+		{name: "field of another type", json: `{"visualization": {"type": "gauge", "queries": [{"query": "q"}], "max": 1, "legend": {}}}`},
+		{name: "wrong field type", json: `{"visualization": {"type": "line", "queries": "q"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := platform.UnmarshalVisualizationJSON([]byte(tt.json)); err == nil {
+				t.Errorf("expected error unmarshalling %s", tt.json)
+			}
+		})
+	}
+}
+
+func TestDashboardCell_Validate(t *testing.T) {
+	start := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	stop := start.Add(-time.Hour)
+	min, max, places := 10.0, 1.0, 11
+	query := []platform.DashboardQuery{{Query: "SELECT * FROM cpu"}}
+
+	tests := []struct {
+		name string
+		vis  platform.Visualization
+	}{
+		{name: "no visualization"},
+		{name: "no queries", vis: platform.LineGraphVisualization{}},
+		{name: "empty query", vis: platform.TableVisualization{Queries: []platform.DashboardQuery{{}}}},
+		{name: "empty time range", vis: platform.LineGraphVisualization{Queries: []platform.DashboardQuery{{Query: "q", TimeRange: &platform.TimeRange{}}}}},
+		{name: "invalid duration", vis: platform.LineGraphVisualization{Queries: []platform.DashboardQuery{{Query: "q", TimeRange: &platform.TimeRange{Duration: "-1h"}}}}},
+		{name: "stop before start", vis: platform.LineGraphVisualization{Queries: []platform.DashboardQuery{{Query: "q", TimeRange: &platform.TimeRange{Start: &start, Stop: &stop}}}}},
+		{name: "inverted axis", vis: platform.LineGraphVisualization{Queries: query, YAxis: platform.Axis{Min: &min, Max: &max}}},
+		{name: "unknown scale", vis: platform.LineGraphVisualization{Queries: query, YAxis: platform.Axis{Scale: "exp"}}},
+		{name: "unknown legend orientation", vis: platform.LineGraphVisualization{Queries: query, Legend: platform.Legend{Orientation: "center"}}},
+		{name: "invalid color", vis: platform.LineGraphVisualization{Queries: query, Colors: []string{"red"}}},
+		{name: "too many decimal places", vis: platform.SingleStatVisualization{Queries: query, DecimalPlaces: &places}},
+		{name: "decreasing thresholds", vis: platform.SingleStatVisualization{Queries: query, Thresholds: []platform.Threshold{{Value: 2, Color: "#00C9FF"}, {Value: 1, Color: "#DC4E58"}}}},
+		{name: "empty gauge", vis: platform.GaugeVisualization{Queries: query}},
+		{name: "threshold outside gauge", vis: platform.GaugeVisualization{Queries: query, Max: 10, Thresholds: []platform.Threshold{{Value: 11, Color: "#DC4E58"}}}},
+		{name: "duplicate columns", vis: platform.TableVisualization{Queries: query, Columns: []platform.TableColumn{{Name: "host"}, {Name: "host"}}}},
+		{name: "sort by unknown column", vis: platform.TableVisualization{Queries: query, Columns: []platform.TableColumn{{Name: "host"}}, SortBy: "region"}},
+		{name: "empty note", vis: platform.MarkdownVisualization{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cell := platform.DashboardCell{Visualization: tt.vis}
+			if err := cell.Validate(); err == nil {
+				t.Errorf("expected cell to be invalid")
+			}
+		})
+	}
+}
+
+func TestValidateDashboardVariables(t *testing.T) {
+	tests := []struct {
+		name string
+		vars []platform.DashboardVariable
+		ok   bool
+	}{
+		{
+			name: "valid",
+			vars: []platform.DashboardVariable{
+				{Name: "bucket", Type: platform.ConstantVariableType, Values: []string{"telegraf"}},
+				{Name: "host", Type: platform.QueryVariableType, Query: `from(db:"telegraf") |> range(start:-1h) |> keys()`},
+				{Name: "time_range", Type: platform.TimeRangeVariableType, TimeRange: &platform.TimeRange{Duration: "1h"}},
+			},
+			ok: true,
+		},
+		{name: "invalid name", vars: []platform.DashboardVariable{{Name: "1host", Type: platform.ConstantVariableType, Values: []string{"a"}}}},
+		{name: "unknown type", vars: []platform.DashboardVariable{{Name: "host", Type: "list", Values: []string{"a"}}}},
+		{name: "constant without values", vars: []platform.DashboardVariable{{Name: "host", Type: platform.ConstantVariableType}}},
+		{name: "query without query", vars: []platform.DashboardVariable{{Name: "host", Type: platform.QueryVariableType}}},
+		{name: "time range without time range", vars: []platform.DashboardVariable{{Name: "tr", Type: platform.TimeRangeVariableType}}},
+		{name: "fields of another type", vars: []platform.DashboardVariable{{Name: "host", Type: platform.QueryVariableType, Query: "q", Values: []string{"a"}}}},
+		{
+			name: "duplicate names",
+			vars: []platform.DashboardVariable{
+				{Name: "host", Type: platform.ConstantVariableType, Values: []string{"a"}},
+				{Name: "host", Type: platform.ConstantVariableType, Values: []string{"b"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := platform.ValidateDashboardVariables(tt.vars); (err == nil) != tt.ok {
+				t.Errorf("expected valid %v, got %v", tt.ok, err)
+			}
+		})
+	}
+}
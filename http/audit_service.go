@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+)
+
+// AuditHandler represents an HTTP API handler for the audit log.
+type AuditHandler struct {
+	*httprouter.Router
+
+	AuditService platform.AuditService
+}
+
+// NewAuditHandler returns a new instance of AuditHandler.
+func NewAuditHandler() *AuditHandler {
+	h := &AuditHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", auditPath, h.handleGetAuditEvents)
+	return h
+}
+
+// handleGetAuditEvents is the HTTP handler for the GET /v1/audit route.
+func (h *AuditHandler) handleGetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetAuditEventsRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	es, _, err := h.AuditService.FindAuditEvents(ctx, req.filter, pageOptions(req.opts))
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	n, links := newPage(r, req.opts, len(es), func(i int) platform.ID { return es[i].ID })
+	if err := encodeResponse(ctx, w, http.StatusOK, auditEventsResponse{Events: es[:n], Links: links}); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type getAuditEventsRequest struct {
+	filter platform.AuditFilter
+	opts   platform.FindOptions
+}
+
+type auditEventsResponse struct {
+	Events []*platform.AuditEvent `json:"events"`
+	Links  Links                  `json:"links"`
+}
+
+func decodeGetAuditEventsRequest(ctx context.Context, r *http.Request) (*getAuditEventsRequest, error) {
+	qp := r.URL.Query()
+	req := &getAuditEventsRequest{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
+	if id := qp.Get("resource"); id != "" {
+		req.filter.ResourceID = &platform.ID{}
+		if err := req.filter.ResourceID.DecodeFromString(id); err != nil {
+			return nil, kerrors.InvalidDataf("invalid resource: %v", err)
+		}
+	}
+
+	if typ := qp.Get("resourceType"); typ != "" {
+		req.filter.ResourceType = &typ
+	}
+
+	if id := qp.Get("user"); id != "" {
+		req.filter.UserID = &platform.ID{}
+		if err := req.filter.UserID.DecodeFromString(id); err != nil {
+			return nil, kerrors.InvalidDataf("invalid user: %v", err)
+		}
+	}
+
+	if since := qp.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, kerrors.InvalidDataf("since must be an RFC3339 time: %v", err)
+		}
+		req.filter.Since = &t
+	}
+
+	return req, nil
+}
+
+const (
+	auditPath = "/v1/audit"
+)
+
+// AuditService connects to Influx via HTTP using tokens to read the audit log.
+type AuditService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// FindAuditEvents returns the audit events that match filter.
+func (s *AuditService) FindAuditEvents(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+	query := url.Values{}
+	if filter.ResourceID != nil {
+		query.Add("resource", filter.ResourceID.String())
+	}
+	if filter.ResourceType != nil {
+		query.Add("resourceType", *filter.ResourceType)
+	}
+	if filter.UserID != nil {
+		query.Add("user", filter.UserID.String())
+	}
+	if filter.Since != nil {
+		query.Add("since", filter.Since.Format(time.RFC3339Nano))
+	}
+
+	var es []*platform.AuditEvent
+	err := getPages(ctx, s.Addr, s.Token, s.InsecureSkipVerify, auditPath, query, opt, func(dec *json.Decoder) (*Links, error) {
+		var resp auditEventsResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		es = append(es, resp.Events...)
+		return &resp.Links, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return es, len(es), nil
+}
@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 	"path"
 
 	"go.uber.org/zap"
 
 	"github.com/influxdata/platform"
+	idpctx "github.com/influxdata/platform/context"
 	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/julienschmidt/httprouter"
 )
@@ -47,7 +49,11 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 		return
 	}
 
-	// TODO: Need to do some validation of req.Authorization.Permissions
+	// TODO: Need to do some validation of the other permissions of req.Authorization.
+	if err := validateBackupPermission(ctx, req.Authorization.Permissions); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
 
 	if err := h.AuthorizationService.CreateAuthorization(ctx, req.Authorization); err != nil {
 		// Don't log here, it should already be handled by the service
@@ -62,6 +68,20 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 	}
 }
 
+// validateBackupPermission rejects granting the backup permission unless the authorization
+// of the request already holds it. A backup contains every token, so the permission must
+// not be self-granted; the first one is created with idpd backup-token.
+func validateBackupPermission(ctx context.Context, ps []platform.Permission) error {
+	if !platform.Allowed(platform.ReadBackupPermission, ps) {
+		return nil
+	}
+	a, err := idpctx.GetAuthorization(ctx)
+	if err != nil || !platform.Allowed(platform.ReadBackupPermission, a.Permissions) {
+		return kerrors.Forbiddenf("granting the %s permission requires it", platform.ReadBackupPermission)
+	}
+	return nil
+}
+
 type postAuthorizationRequest struct {
 	Authorization *platform.Authorization
 }
@@ -88,14 +108,15 @@ func (h *AuthorizationHandler) handleGetAuthorizations(w http.ResponseWriter, r
 		return
 	}
 
-	as, _, err := h.AuthorizationService.FindAuthorizations(ctx, req.filter)
+	as, _, err := h.AuthorizationService.FindAuthorizations(ctx, req.filter, pageOptions(req.opts))
 	if err != nil {
 		// Don't log here, it should already be handled by the service
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, as); err != nil {
+	n, links := newPage(r, req.opts, len(as), func(i int) platform.ID { return as[i].ID })
+	if err := encodeResponse(ctx, w, http.StatusOK, authsResponse{Authorizations: as[:n], Links: links}); err != nil {
 		h.Logger.Info("failed to encode response", zap.String("handler", "getAuthorizations"), zap.Error(err))
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
@@ -104,6 +125,12 @@ func (h *AuthorizationHandler) handleGetAuthorizations(w http.ResponseWriter, r
 
 type getAuthorizationsRequest struct {
 	filter platform.AuthorizationFilter
+	opts   platform.FindOptions
+}
+
+type authsResponse struct {
+	Authorizations []*platform.Authorization `json:"authorizations"`
+	Links          Links                     `json:"links"`
 }
 
 func decodeGetAuthorizationsRequest(ctx context.Context, r *http.Request) (*getAuthorizationsRequest, error) {
@@ -111,6 +138,12 @@ func decodeGetAuthorizationsRequest(ctx context.Context, r *http.Request) (*getA
 
 	req := &getAuthorizationsRequest{}
 
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
 	userID := qp.Get("userID")
 	if userID != "" {
 		req.filter.UserID = &platform.ID{}
@@ -268,52 +301,33 @@ func (s *AuthorizationService) FindAuthorizationByToken(ctx context.Context, tok
 }
 
 // FindAuthorizations returns a list of authorizations that match filter and the total count of matching authorizations.
-// Additional options provide pagination & sorting.
+// Additional options request a single page of authorizations, otherwise all pages are requested.
 func (s *AuthorizationService) FindAuthorizations(ctx context.Context, filter platform.AuthorizationFilter, opt ...platform.FindOptions) ([]*platform.Authorization, int, error) {
-	u, err := newURL(s.Addr, authorizationPath)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	query := u.Query()
-
-	req, err := http.NewRequest("GET", u.String(), nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
+	query := url.Values{}
 	if filter.ID != nil {
 		query.Add("id", filter.ID.String())
 	}
-
 	if filter.UserID != nil {
 		query.Add("userID", filter.UserID.String())
 	}
-
 	if filter.User != nil {
 		query.Add("user", *filter.User)
 	}
 
-	req.URL.RawQuery = query.Encode()
-	req.Header.Set("Authorization", s.Token)
-
-	hc := newClient(u.Scheme, s.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	var as []*platform.Authorization
+	err := getPages(ctx, s.Addr, s.Token, s.InsecureSkipVerify, authorizationPath, query, opt, func(dec *json.Decoder) (*Links, error) {
+		var resp authsResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		as = append(as, resp.Authorizations...)
+		return &resp.Links, nil
+	})
 	if err != nil {
 		return nil, 0, err
 	}
 
-	if err := CheckError(resp); err != nil {
-		return nil, 0, err
-	}
-
-	var bs []*platform.Authorization
-	if err := json.NewDecoder(resp.Body).Decode(&bs); err != nil {
-		return nil, 0, err
-	}
-	defer resp.Body.Close()
-
-	return bs, len(bs), nil
+	return as, len(as), nil
 }
 
 const (
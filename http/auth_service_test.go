@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+func TestAuthorizationService_CreateBackupAuthorization(t *testing.T) {
+	ctx := context.Background()
+	f, err := ioutil.TempFile("", "influxdata-platform-http-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(c.Path)
+	defer c.Close()
+
+	authHandler := NewAuthorizationHandler()
+	authHandler.AuthorizationService = c
+	server := httptest.NewServer(&PlatformHandler{
+		AuthorizationHandler: authHandler,
+		AuthorizationService: c,
+	})
+	defer server.Close()
+
+	user := &platform.User{Name: "user"}
+	if err := c.CreateUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	plain := &platform.Authorization{UserID: user.ID, Permissions: []platform.Permission{platform.CreateUserPermission}}
+	backup := &platform.Authorization{UserID: user.ID, Permissions: []platform.Permission{platform.ReadBackupPermission}}
+	for _, a := range []*platform.Authorization{plain, backup} {
+		if err := c.CreateAuthorization(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		permissions []platform.Permission
+		code        int
+	}{
+		{
+			name:        "without the backup permission",
+			token:       plain.Token,
+			permissions: []platform.Permission{platform.CreateUserPermission},
+		},
+		{
+			name:        "granting the backup permission",
+			token:       plain.Token,
+			permissions: []platform.Permission{platform.ReadBackupPermission},
+			code:        kerrors.Forbidden,
+		},
+		{
+			name:        "granting the backup permission with an unknown token",
+			token:       "nope",
+			permissions: []platform.Permission{platform.ReadBackupPermission},
+			code:        kerrors.Forbidden,
+		},
+		{
+			name:        "granting the backup permission when holding it",
+			token:       backup.Token,
+			permissions: []platform.Permission{platform.ReadBackupPermission},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AuthorizationService{Addr: server.URL, Token: "Token " + tt.token}
+			err := s.CreateAuthorization(ctx, &platform.Authorization{User: user.Name, Permissions: tt.permissions})
+			if code := kerrors.Reference(err); code != tt.code {
+				t.Fatalf("unexpected error code: got %d want %d: %v", code, tt.code, err)
+			}
+		})
+	}
+}
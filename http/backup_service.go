@@ -0,0 +1,118 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	idpctx "github.com/influxdata/platform/context"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// BackupHandler represents an HTTP API handler for backups.
+type BackupHandler struct {
+	*httprouter.Router
+	Logger *zap.Logger
+
+	BackupService        platform.BackupService
+	AuthorizationService platform.AuthorizationService
+}
+
+// NewBackupHandler returns a new instance of BackupHandler.
+func NewBackupHandler() *BackupHandler {
+	h := &BackupHandler{
+		Router: httprouter.New(),
+		Logger: zap.NewNop(),
+	}
+
+	h.HandlerFunc("GET", backupPath, h.handleGetBackup)
+	return h
+}
+
+// handleGetBackup is the HTTP handler for the GET /v1/backup route.
+// Only authorizations with the backup permission may back up the metadata store.
+func (h *BackupHandler) handleGetBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetBackupRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	a, err := h.AuthorizationService.FindAuthorizationByToken(ctx, req.Token)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, kerrors.Unauthorizedf("invalid token: %v", err), w)
+		return
+	}
+	if !platform.Allowed(platform.ReadBackupPermission, a.Permissions) {
+		kerrors.EncodeHTTP(ctx, kerrors.Forbiddenf("backups require the %s permission", platform.ReadBackupPermission), w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="idpd.bolt"`)
+	w.WriteHeader(http.StatusOK)
+
+	// The status has been written, so errors can only be logged.
+	if err := h.BackupService.Backup(ctx, w); err != nil {
+		h.Logger.Info("failed to write backup", zap.String("handler", "getBackup"), zap.Error(err))
+	}
+}
+
+type getBackupRequest struct {
+	Token string
+}
+
+func decodeGetBackupRequest(ctx context.Context, r *http.Request) (*getBackupRequest, error) {
+	t, err := idpctx.GetToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &getBackupRequest{
+		Token: t,
+	}, nil
+}
+
+const (
+	backupPath = "/v1/backup"
+)
+
+// BackupService connects to Influx via HTTP using tokens to back up the metadata store.
+type BackupService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// Backup writes a consistent copy of the metadata store to w.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer) error {
+	u, err := newURL(s.Addr, backupPath)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", s.Token)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
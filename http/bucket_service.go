@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
+	"net/url"
 	"path"
 
 	"github.com/influxdata/platform"
@@ -164,13 +164,14 @@ func (h *BucketHandler) handleGetBuckets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	bs, _, err := h.BucketService.FindBuckets(ctx, req.filter)
+	bs, _, err := h.BucketService.FindBuckets(ctx, req.filter, pageOptions(req.opts))
 	if err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, bs); err != nil {
+	n, links := newPage(r, req.opts, len(bs), func(i int) platform.ID { return bs[i].ID })
+	if err := encodeResponse(ctx, w, http.StatusOK, bucketsResponse{Buckets: bs[:n], Links: links}); err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
@@ -178,12 +179,24 @@ func (h *BucketHandler) handleGetBuckets(w http.ResponseWriter, r *http.Request)
 
 type getBucketsRequest struct {
 	filter platform.BucketFilter
+	opts   platform.FindOptions
+}
+
+type bucketsResponse struct {
+	Buckets []*platform.Bucket `json:"buckets"`
+	Links   Links              `json:"links"`
 }
 
 func decodeGetBucketsRequest(ctx context.Context, r *http.Request) (*getBucketsRequest, error) {
 	qp := r.URL.Query()
 	req := &getBucketsRequest{}
 
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
 	if id := qp.Get("orgID"); id != "" {
 		req.filter.OrganizationID = &platform.ID{}
 		if err := req.filter.OrganizationID.DecodeFromString(id); err != nil {
@@ -310,21 +323,16 @@ func (s *BucketService) FindBucket(ctx context.Context, filter platform.BucketFi
 	}
 
 	if n == 0 {
-		return nil, errors.New("found no matching buckets")
+		return nil, kerrors.NotFoundf("found no matching buckets")
 	}
 
 	return bs[0], nil
 }
 
 // FindBuckets returns a list of buckets that match filter and the total count of matching buckets.
-// Additional options provide pagination & sorting.
+// Additional options request a single page of buckets, otherwise all pages are requested.
 func (s *BucketService) FindBuckets(ctx context.Context, filter platform.BucketFilter, opt ...platform.FindOptions) ([]*platform.Bucket, int, error) {
-	u, err := newURL(s.Addr, bucketPath)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	query := u.Query()
+	query := url.Values{}
 	if filter.OrganizationID != nil {
 		query.Add("orgID", filter.OrganizationID.String())
 	}
@@ -338,29 +346,18 @@ func (s *BucketService) FindBuckets(ctx context.Context, filter platform.BucketF
 		query.Add("name", *filter.Name)
 	}
 
-	req, err := http.NewRequest("GET", u.String(), nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	req.URL.RawQuery = query.Encode()
-	req.Header.Set("Authorization", s.Token)
-
-	hc := newClient(u.Scheme, s.InsecureSkipVerify)
-	resp, err := hc.Do(req)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	if err := CheckError(resp); err != nil {
-		return nil, 0, err
-	}
-
 	var bs []*platform.Bucket
-	if err := json.NewDecoder(resp.Body).Decode(&bs); err != nil {
+	err := getPages(ctx, s.Addr, s.Token, s.InsecureSkipVerify, bucketPath, query, opt, func(dec *json.Decoder) (*Links, error) {
+		var resp bucketsResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		bs = append(bs, resp.Buckets...)
+		return &resp.Links, nil
+	})
+	if err != nil {
 		return nil, 0, err
 	}
-	defer resp.Body.Close()
 
 	return bs, len(bs), nil
 }
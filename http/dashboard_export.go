@@ -0,0 +1,319 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/query"
+	"github.com/julienschmidt/httprouter"
+)
+
+const dashboardImportPath = "/v1/dashboards/import"
+
+// ServeHTTP serves the import route before delegating to the router,
+// which cannot route a static path alongside the paths with a dashboard ID.
+func (h *DashboardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" && r.URL.Path == dashboardImportPath {
+		h.handlePostDashboardImport(w, r)
+		return
+	}
+	h.Router.ServeHTTP(w, r)
+}
+
+// handleGetDashboardExport is the HTTP handler for the GET /v1/dashboards/:id/export route.
+func (h *DashboardHandler) handleGetDashboardExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetDashboardRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	d, err := h.DashboardService.FindDashboardByID(ctx, req.DashboardID)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, exportDashboard(d)); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+// handlePostDashboardImport is the HTTP handler for the POST /v1/dashboards/import route.
+func (h *DashboardHandler) handlePostDashboardImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostDashboardImportRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	d, err := h.importDashboard(ctx, req.Dashboard, req.DashboardImportOptions)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, d); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type postDashboardImportRequest struct {
+	platform.DashboardImportOptions
+	Dashboard *platform.DashboardExport `json:"dashboard"`
+}
+
+func decodePostDashboardImportRequest(ctx context.Context, r *http.Request) (*postDashboardImportRequest, error) {
+	req := &postDashboardImportRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, kerrors.MalformedDataf("invalid import request: %v", err)
+	}
+	if req.Dashboard == nil {
+		return nil, kerrors.InvalidDataf("import request missing dashboard")
+	}
+	return req, nil
+}
+
+// handlePostDashboardClone is the HTTP handler for the POST /v1/dashboards/:id/clone route.
+// The dashboard is cloned into its own organization unless the options set another one.
+func (h *DashboardHandler) handlePostDashboardClone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostDashboardCloneRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	src, err := h.DashboardService.FindDashboardByID(ctx, req.DashboardID)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	opts := req.Options
+	if len(opts.OrganizationID) == 0 && opts.Organization == "" {
+		opts.OrganizationID = src.OrganizationID
+	}
+
+	d, err := h.importDashboard(ctx, exportDashboard(src), opts)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, d); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type postDashboardCloneRequest struct {
+	DashboardID platform.ID
+	Options     platform.DashboardImportOptions
+}
+
+func decodePostDashboardCloneRequest(ctx context.Context, r *http.Request) (*postDashboardCloneRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("url missing id")
+	}
+
+	req := &postDashboardCloneRequest{}
+	if err := req.DashboardID.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.Options); err != nil && err != io.EOF {
+		return nil, kerrors.MalformedDataf("invalid clone request: %v", err)
+	}
+	return req, nil
+}
+
+// exportDashboard returns the export of d referencing the buckets read by its Flux queries.
+// Queries that are not Flux, such as InfluxQL, reference no buckets.
+func exportDashboard(d *platform.Dashboard) *platform.DashboardExport {
+	var buckets []string
+	seen := make(map[string]bool)
+	add := func(q string) {
+		names, err := query.QueryBuckets(q)
+		if err != nil {
+			return
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				buckets = append(buckets, name)
+			}
+		}
+	}
+
+	for _, c := range d.Cells {
+		for _, q := range c.Queries() {
+			add(q.Query)
+		}
+	}
+	for _, v := range d.Variables {
+		if v.Type == platform.QueryVariableType {
+			add(v.Query)
+		}
+	}
+
+	return platform.NewDashboardExport(d, buckets)
+}
+
+// importDashboard creates a dashboard from the export in the organization of the options,
+// renaming the buckets read by its queries. The buckets must exist in the organization.
+func (h *DashboardHandler) importDashboard(ctx context.Context, e *platform.DashboardExport, opts platform.DashboardImportOptions) (*platform.Dashboard, error) {
+	if len(opts.OrganizationID) == 0 && opts.Organization == "" {
+		return nil, kerrors.InvalidDataf("must specify an organization to import the dashboard into")
+	}
+
+	d, err := e.Dashboard()
+	if err != nil {
+		return nil, kerrors.InvalidDataf("%v", err)
+	}
+	d.OrganizationID = opts.OrganizationID
+	d.Organization = opts.Organization
+	if opts.Name != "" {
+		d.Name = opts.Name
+	}
+
+	rename := func(q string) (string, error) {
+		if len(opts.Buckets) == 0 {
+			return q, nil
+		}
+		renamed, err := query.RenameQueryBuckets(q, opts.Buckets)
+		if err != nil {
+			// Queries that are not Flux are imported as is.
+			return q, nil
+		}
+		return renamed, nil
+	}
+	for i := range d.Cells {
+		if err := d.Cells[i].RewriteQueries(rename); err != nil {
+			return nil, err
+		}
+	}
+	for i, v := range d.Variables {
+		if v.Type == platform.QueryVariableType {
+			d.Variables[i].Query, _ = rename(v.Query)
+		}
+	}
+
+	for _, name := range e.Buckets {
+		if to, ok := opts.Buckets[name]; ok {
+			name = to
+		}
+		if err := h.findImportBucket(ctx, opts, name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.DashboardService.CreateDashboard(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// findImportBucket returns an error if the bucket does not exist in the organization of the options.
+func (h *DashboardHandler) findImportBucket(ctx context.Context, opts platform.DashboardImportOptions, name string) error {
+	if h.BucketService == nil {
+		return kerrors.Unavailablef("buckets are not enabled")
+	}
+
+	filter := platform.BucketFilter{Name: &name}
+	if len(opts.OrganizationID) > 0 {
+		filter.OrganizationID = &opts.OrganizationID
+	} else {
+		filter.Organization = &opts.Organization
+	}
+
+	_, n, err := h.BucketService.FindBuckets(ctx, filter)
+	if err != nil && kerrors.Reference(err) != kerrors.NotFound {
+		return err
+	}
+	if err != nil || n == 0 {
+		return kerrors.InvalidDataf("bucket %q not found in the organization, rename it with the buckets option", name)
+	}
+	return nil
+}
+
+// ExportDashboard returns the export of the dashboard.
+func (s *DashboardService) ExportDashboard(ctx context.Context, id platform.ID) (*platform.DashboardExport, error) {
+	var e platform.DashboardExport
+	if err := s.do(ctx, "GET", path.Join(dashboardIDPath(id), "export"), nil, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ImportDashboard creates a dashboard from the export with the options.
+func (s *DashboardService) ImportDashboard(ctx context.Context, e *platform.DashboardExport, opts platform.DashboardImportOptions) (*platform.Dashboard, error) {
+	req := postDashboardImportRequest{
+		DashboardImportOptions: opts,
+		Dashboard:              e,
+	}
+	var d platform.Dashboard
+	if err := s.do(ctx, "POST", dashboardImportPath, req, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// CloneDashboard creates a copy of the dashboard with the options.
+func (s *DashboardService) CloneDashboard(ctx context.Context, id platform.ID, opts platform.DashboardImportOptions) (*platform.Dashboard, error) {
+	var d platform.Dashboard
+	if err := s.do(ctx, "POST", path.Join(dashboardIDPath(id), "clone"), opts, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *DashboardService) do(ctx context.Context, method, p string, body, v interface{}) error {
+	u, err := newURL(s.Addr, p)
+	if err != nil {
+		return err
+	}
+
+	var octets []byte
+	if body != nil {
+		if octets, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", s.Token)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
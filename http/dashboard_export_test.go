@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+func newTestDashboardHandler(t *testing.T) (*bolt.Client, *httptest.Server, func()) {
+	t.Helper()
+	f, err := ioutil.TempFile("", "influxdata-platform-http-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewDashboardHandler()
+	h.DashboardService = c
+	h.BucketService = c
+	server := httptest.NewServer(h)
+
+	return c, server, func() {
+		server.Close()
+		c.Close()
+		os.Remove(c.Path)
+	}
+}
+
+func TestDashboardService_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	c, server, done := newTestDashboardHandler(t)
+	defer done()
+
+	org1 := &platform.Organization{Name: "org1"}
+	org2 := &platform.Organization{Name: "org2"}
+	for _, o := range []*platform.Organization{org1, org2} {
+		if err := c.CreateOrganization(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, b := range []*platform.Bucket{
+		{OrganizationID: org1.ID, Name: "telegraf"},
+		{OrganizationID: org2.ID, Name: "metrics"},
+	} {
+		if err := c.CreateBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src := &platform.Dashboard{
+		OrganizationID: org1.ID,
+		Name:           "hosts",
+		Cells: []platform.DashboardCell{
+			{
+				DashboardCellContents: platform.DashboardCellContents{Name: "cpu", W: 4, H: 4},
+				Visualization: platform.LineGraphVisualization{
+					Queries: []platform.DashboardQuery{{Query: `from(bucket:"telegraf") |> range(start:-1h)`}},
+				},
+			},
+			{
+				DashboardCellContents: platform.DashboardCellContents{Name: "legacy", W: 4, H: 4},
+				Visualization:         platform.CommonVisualization{Query: "SELECT * FROM cpu"},
+			},
+		},
+	}
+	if err := c.CreateDashboard(ctx, src); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &DashboardService{Addr: server.URL}
+
+	e, err := s.ExportDashboard(ctx, src.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Version != platform.DashboardExportVersion || e.Name != "hosts" || len(e.Cells) != 2 {
+		t.Fatalf("unexpected export %+v", e)
+	}
+	for _, cell := range e.Cells {
+		if len(cell.ID) != 0 {
+			t.Errorf("expected exported cell without ID, got %s", cell.ID)
+		}
+	}
+	if len(e.Buckets) != 1 || e.Buckets[0] != "telegraf" {
+		t.Errorf("expected export to reference bucket telegraf, got %v", e.Buckets)
+	}
+
+	// The bucket does not exist in the other organization unless it is renamed.
+	_, err = s.ImportDashboard(ctx, e, platform.DashboardImportOptions{OrganizationID: org2.ID})
+	if code := kerrors.Reference(err); code != kerrors.InvalidData {
+		t.Fatalf("expected error code %d got %d: %v", kerrors.InvalidData, code, err)
+	}
+
+	d, err := s.ImportDashboard(ctx, e, platform.DashboardImportOptions{
+		Organization: "org2",
+		Buckets:      map[string]string{"telegraf": "metrics"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(d.ID) == string(src.ID) || string(d.OrganizationID) != string(org2.ID) {
+		t.Errorf("expected a new dashboard in org2, got %+v", d)
+	}
+	if e2 := exportDashboard(d); len(e2.Buckets) != 1 || e2.Buckets[0] != "metrics" {
+		t.Errorf("expected imported dashboard to read bucket metrics, got %v", e2.Buckets)
+	}
+	if q := d.Cells[1].Queries()[0].Query; q != "SELECT * FROM cpu" {
+		t.Errorf("expected InfluxQL query to be imported as is, got %q", q)
+	}
+
+	clone, err := s.CloneDashboard(ctx, src.ID, platform.DashboardImportOptions{Name: "hosts copy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.Name != "hosts copy" || string(clone.OrganizationID) != string(org1.ID) || len(clone.Cells) != 2 {
+		t.Errorf("expected a copy of the dashboard in org1, got %+v", clone)
+	}
+	if string(clone.Cells[0].ID) == string(src.Cells[0].ID) {
+		t.Errorf("expected cloned cells to have new IDs")
+	}
+
+	e.Version = platform.DashboardExportVersion + 1
+	_, err = s.ImportDashboard(ctx, e, platform.DashboardImportOptions{OrganizationID: org1.ID})
+	if code := kerrors.Reference(err); code != kerrors.InvalidData {
+		t.Fatalf("expected error code %d got %d: %v", kerrors.InvalidData, code, err)
+	}
+}
@@ -4,12 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 
 	"github.com/influxdata/platform"
 	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/query"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -18,6 +19,10 @@ type DashboardHandler struct {
 	*httprouter.Router
 
 	DashboardService platform.DashboardService
+	// QueryService runs the queries of cells and query variables.
+	QueryService query.QueryService
+	// BucketService finds the buckets read by imported dashboards.
+	BucketService platform.BucketService
 }
 
 // NewDashboardHandler returns a new instance of DashboardHandler.
@@ -31,10 +36,15 @@ func NewDashboardHandler() *DashboardHandler {
 	h.HandlerFunc("GET", "/v1/dashboards/:id", h.handleGetDashboard)
 	h.HandlerFunc("PATCH", "/v1/dashboards/:id", h.handlePatchDashboard)
 	h.HandlerFunc("DELETE", "/v1/dashboards/:id", h.handleDeleteDashboard)
+	h.HandlerFunc("GET", "/v1/dashboards/:id/export", h.handleGetDashboardExport)
+	h.HandlerFunc("POST", "/v1/dashboards/:id/clone", h.handlePostDashboardClone)
 
 	h.HandlerFunc("POST", "/v1/dashboards/:id/cells", h.handlePostDashboardCell)
 	h.HandlerFunc("PUT", "/v1/dashboards/:id/cells/:cell_id", h.handlePutDashboardCell)
 	h.HandlerFunc("DELETE", "/v1/dashboards/:id/cells/:cell_id", h.handleDeleteDashboardCell)
+	h.HandlerFunc("POST", "/v1/dashboards/:id/cells/:cell_id/query", h.handlePostDashboardCellQuery)
+
+	h.HandlerFunc("GET", "/v1/dashboards/:id/variables/:name/values", h.handleGetDashboardVariableValues)
 	return h
 }
 
@@ -168,13 +178,14 @@ func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	bs, _, err := h.DashboardService.FindDashboards(ctx, req.filter)
+	ds, _, err := h.DashboardService.FindDashboards(ctx, req.filter, pageOptions(req.opts))
 	if err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, bs); err != nil {
+	n, links := newPage(r, req.opts, len(ds), func(i int) platform.ID { return ds[i].ID })
+	if err := encodeResponse(ctx, w, http.StatusOK, dashboardsResponse{Dashboards: ds[:n], Links: links}); err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
@@ -182,12 +193,24 @@ func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Re
 
 type getDashboardsRequest struct {
 	filter platform.DashboardFilter
+	opts   platform.FindOptions
+}
+
+type dashboardsResponse struct {
+	Dashboards []*platform.Dashboard `json:"dashboards"`
+	Links      Links                 `json:"links"`
 }
 
 func decodeGetDashboardsRequest(ctx context.Context, r *http.Request) (*getDashboardsRequest, error) {
 	qp := r.URL.Query()
 	req := &getDashboardsRequest{}
 
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
 	if id := qp.Get("orgID"); id != "" {
 		req.filter.OrganizationID = &platform.ID{}
 		if err := req.filter.OrganizationID.DecodeFromString(id); err != nil {
@@ -298,7 +321,7 @@ func decodePostDashboardCellRequest(ctx context.Context, r *http.Request) (*post
 
 	c := &platform.DashboardCell{}
 	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
-		return nil, err
+		return nil, kerrors.MalformedDataf("invalid cell: %v", err)
 	}
 
 	return &postDashboardCellRequest{
@@ -356,11 +379,11 @@ func decodePutDashboardCellRequest(ctx context.Context, r *http.Request) (*putDa
 
 	req.Cell = &platform.DashboardCell{}
 	if err := json.NewDecoder(r.Body).Decode(req.Cell); err != nil {
-		return nil, err
+		return nil, kerrors.MalformedDataf("invalid cell: %v", err)
 	}
 
 	if !bytes.Equal(req.Cell.ID, cid) {
-		return nil, fmt.Errorf("url cell_id does not match id on provided cell")
+		return nil, kerrors.InvalidDataf("url cell_id does not match id on provided cell")
 	}
 
 	return req, nil
@@ -466,13 +489,9 @@ func (s *DashboardService) FindDashboardsByOrganizationName(ctx context.Context,
 }
 
 // FindDashboards returns a list of dashboards that match filter and the total count of matching dashboards.
-func (s *DashboardService) FindDashboards(ctx context.Context, filter platform.DashboardFilter) ([]*platform.Dashboard, int, error) {
-	u, err := newURL(s.Addr, dashboardPath)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	query := u.Query()
+// Additional options request a single page of dashboards, otherwise all pages are requested.
+func (s *DashboardService) FindDashboards(ctx context.Context, filter platform.DashboardFilter, opt ...platform.FindOptions) ([]*platform.Dashboard, int, error) {
+	query := url.Values{}
 	if filter.OrganizationID != nil {
 		query.Add("orgID", filter.OrganizationID.String())
 	}
@@ -483,31 +502,20 @@ func (s *DashboardService) FindDashboards(ctx context.Context, filter platform.D
 		query.Add("id", filter.ID.String())
 	}
 
-	req, err := http.NewRequest("GET", u.String(), nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	req.URL.RawQuery = query.Encode()
-	req.Header.Set("Authorization", s.Token)
-
-	hc := newClient(u.Scheme, s.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	var ds []*platform.Dashboard
+	err := getPages(ctx, s.Addr, s.Token, s.InsecureSkipVerify, dashboardPath, query, opt, func(dec *json.Decoder) (*Links, error) {
+		var resp dashboardsResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		ds = append(ds, resp.Dashboards...)
+		return &resp.Links, nil
+	})
 	if err != nil {
 		return nil, 0, err
 	}
 
-	if err := CheckError(resp); err != nil {
-		return nil, 0, err
-	}
-
-	var bs []*platform.Dashboard
-	if err := json.NewDecoder(resp.Body).Decode(&bs); err != nil {
-		return nil, 0, err
-	}
-	defer resp.Body.Close()
-
-	return bs, len(bs), nil
+	return ds, len(ds), nil
 }
 
 // CreateDashboard creates a new dashboard and sets b.ID with the new identifier.
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/csv"
+	"github.com/julienschmidt/httprouter"
+)
+
+// handleGetDashboardVariableValues is the HTTP handler for the GET /v1/dashboards/:id/variables/:name/values route.
+// The values of query variables are evaluated by running their query.
+func (h *DashboardHandler) handleGetDashboardVariableValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetDashboardVariableValuesRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	d, err := h.DashboardService.FindDashboardByID(ctx, req.DashboardID)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	v, ok := d.Variable(req.Name)
+	if !ok {
+		kerrors.EncodeHTTP(ctx, kerrors.NotFoundf("variable %q not found", req.Name), w)
+		return
+	}
+	if v.Type == platform.QueryVariableType && h.QueryService == nil {
+		kerrors.EncodeHTTP(ctx, kerrors.Unavailablef("queries are not enabled"), w)
+		return
+	}
+	if v.Type == platform.TimeRangeVariableType {
+		kerrors.EncodeHTTP(ctx, kerrors.InvalidDataf("time range variable %q has no values", v.Name), w)
+		return
+	}
+
+	values, err := query.DashboardVariableValues(ctx, h.QueryService, d.OrganizationID, v)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, variableValuesResponse{Values: values}); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type variableValuesResponse struct {
+	Values []string `json:"values"`
+}
+
+type getDashboardVariableValuesRequest struct {
+	DashboardID platform.ID
+	Name        string
+}
+
+func decodeGetDashboardVariableValuesRequest(ctx context.Context, r *http.Request) (*getDashboardVariableValuesRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("url missing id")
+	}
+	var i platform.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	name := params.ByName("name")
+	if name == "" {
+		return nil, kerrors.InvalidDataf("url missing name")
+	}
+
+	return &getDashboardVariableValuesRequest{
+		DashboardID: i,
+		Name:        name,
+	}, nil
+}
+
+// handlePostDashboardCellQuery is the HTTP handler for the POST /v1/dashboards/:id/cells/:cell_id/query route.
+// It runs a query of the cell with the selected values of the dashboard variables declared in its scope,
+// and responds with the results as CSV.
+func (h *DashboardHandler) handlePostDashboardCellQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostDashboardCellQueryRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if h.QueryService == nil {
+		kerrors.EncodeHTTP(ctx, kerrors.Unavailablef("queries are not enabled"), w)
+		return
+	}
+
+	d, err := h.DashboardService.FindDashboardByID(ctx, req.DashboardID)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	var cell *platform.DashboardCell
+	for i := range d.Cells {
+		if bytes.Equal(d.Cells[i].ID, req.CellID) {
+			cell = &d.Cells[i]
+			break
+		}
+	}
+	if cell == nil {
+		kerrors.EncodeHTTP(ctx, kerrors.NotFoundf("cell not found"), w)
+		return
+	}
+
+	queries := cell.Queries()
+	if req.Index < 0 || req.Index >= len(queries) {
+		kerrors.EncodeHTTP(ctx, kerrors.NotFoundf("cell has no query %d", req.Index), w)
+		return
+	}
+	q := queries[req.Index]
+
+	vars, err := query.DashboardVariables(d.Variables, req.Variables, q.TimeRange, time.Now())
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, kerrors.InvalidDataf("%v", err), w)
+		return
+	}
+
+	spec, err := query.Compile(ctx, q.Query, query.Variables(vars))
+	if err != nil {
+		encodeQueryError(ctx, err, w)
+		return
+	}
+
+	results, err := h.QueryService.Query(ctx, d.OrganizationID, spec)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+	csv.NewMultiResultEncoder(csv.DefaultEncoderConfig()).Encode(w, results)
+}
+
+type postDashboardCellQueryRequest struct {
+	DashboardID platform.ID `json:"-"`
+	CellID      platform.ID `json:"-"`
+	// Index is the index of the query among the queries of the cell.
+	Index int `json:"index"`
+	// Variables are the selected values of the dashboard variables by name.
+	Variables map[string]string `json:"variables"`
+}
+
+func decodePostDashboardCellQueryRequest(ctx context.Context, r *http.Request) (*postDashboardCellQueryRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("url missing id")
+	}
+	cellID := params.ByName("cell_id")
+	if cellID == "" {
+		return nil, kerrors.InvalidDataf("url missing cell_id")
+	}
+
+	req := &postDashboardCellQueryRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		return nil, kerrors.MalformedDataf("invalid query request: %v", err)
+	}
+	if err := req.DashboardID.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	if err := req.CellID.DecodeFromString(cellID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
@@ -31,8 +31,8 @@ func CheckError(resp *http.Response) error {
 		// return the error message by itself.
 		ref, err := strconv.Atoi(resp.Header.Get("X-Influx-Reference"))
 		if err != nil {
-			// We cannot parse the reference number so just use internal.
-			ref = kerrors.InternalError
+			// We cannot parse the reference number so use the one of the status.
+			ref = kerrors.ReferenceFromStatus(resp.StatusCode)
 		}
 		return &kerrors.Error{
 			Reference: ref,
@@ -42,7 +42,7 @@ func CheckError(resp *http.Response) error {
 	}
 
 	// There is no influx error so we need to report that we have some kind
-	// of error from somewhere.
+	// of error from somewhere, with the reference code of its status.
 	// TODO(jsternberg): Try to make this more advance by reading the response
 	// and either decoding a possible json message or just reading the text itself.
 	// This might be good enough though.
@@ -50,5 +50,9 @@ func CheckError(resp *http.Response) error {
 	if resp.StatusCode/100 == 4 {
 		msg = "client error"
 	}
-	return errors.Wrap(errors.New(resp.Status), msg)
+	return &kerrors.Error{
+		Reference: kerrors.ReferenceFromStatus(resp.StatusCode),
+		Code:      resp.StatusCode,
+		Err:       errors.Wrap(errors.New(resp.Status), msg).Error(),
+	}
 }
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+func TestCheckError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		ref    int
+	}{
+		{name: "not found", err: kerrors.NotFoundf("bucket not found"), status: http.StatusNotFound, ref: kerrors.NotFound},
+		{name: "conflict", err: kerrors.Conflictf("bucket with name b1 already exists"), status: http.StatusConflict, ref: kerrors.Conflict},
+		{name: "invalid", err: kerrors.InvalidDataf("cannot sort by %q", "color"), status: http.StatusUnprocessableEntity, ref: kerrors.InvalidData},
+		{name: "unauthorized", err: kerrors.Unauthorizedf("invalid token"), status: http.StatusUnauthorized, ref: kerrors.Unauthorized},
+		{name: "unavailable", err: kerrors.Unavailablef("backups are not enabled"), status: http.StatusServiceUnavailable, ref: kerrors.Unavailable},
+		{name: "untyped", err: errors.New("oops"), status: http.StatusInternalServerError, ref: kerrors.InternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			kerrors.EncodeHTTP(context.Background(), tt.err, w)
+			resp := w.Result()
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d got %d", tt.status, resp.StatusCode)
+			}
+			if ref := kerrors.Reference(CheckError(resp)); ref != tt.ref {
+				t.Errorf("expected error code %d got %d", tt.ref, ref)
+			}
+		})
+	}
+}
+
+func TestCheckError_Status(t *testing.T) {
+	tests := []struct {
+		status int
+		ref    int
+	}{
+		{status: http.StatusNotFound, ref: kerrors.NotFound},
+		{status: http.StatusConflict, ref: kerrors.Conflict},
+		{status: http.StatusUnauthorized, ref: kerrors.Unauthorized},
+		{status: http.StatusServiceUnavailable, ref: kerrors.Unavailable},
+		{status: http.StatusBadGateway, ref: kerrors.InternalError},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status), Header: http.Header{}}
+		if ref := kerrors.Reference(CheckError(resp)); ref != tt.ref {
+			t.Errorf("status %d: expected error code %d got %d", tt.status, tt.ref, ref)
+		}
+	}
+}
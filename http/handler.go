@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -30,6 +31,9 @@ type Handler struct {
 	DebugHandler http.Handler
 	// Handler handles all other requests
 	Handler http.Handler
+	// ValidationSpec, when set, is the API spec that requests passed to Handler are validated against.
+	// Requests that do not conform to it are rejected.
+	ValidationSpec *Spec
 }
 
 // NewHandler creates a new handler with the given name.
@@ -74,6 +78,13 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case strings.HasPrefix(r.URL.Path, DebugPath):
 		h.DebugHandler.ServeHTTP(w, r)
 	default:
+		// Preflight requests of CORS are not operations of the API.
+		if h.ValidationSpec != nil && r.Method != "OPTIONS" {
+			if err := h.ValidationSpec.ValidateRequest(r); err != nil {
+				kerrors.EncodeHTTP(r.Context(), err, w)
+				return
+			}
+		}
 		h.Handler.ServeHTTP(w, r)
 	}
 }
@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
+	"net/url"
 	"path"
 
 	"github.com/influxdata/platform"
@@ -125,13 +125,14 @@ func (h *OrgHandler) handleGetOrgs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orgs, _, err := h.OrganizationService.FindOrganizations(ctx, req.filter)
+	orgs, _, err := h.OrganizationService.FindOrganizations(ctx, req.filter, pageOptions(req.opts))
 	if err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, orgs); err != nil {
+	n, links := newPage(r, req.opts, len(orgs), func(i int) platform.ID { return orgs[i].ID })
+	if err := encodeResponse(ctx, w, http.StatusOK, orgsResponse{Organizations: orgs[:n], Links: links}); err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
@@ -139,12 +140,24 @@ func (h *OrgHandler) handleGetOrgs(w http.ResponseWriter, r *http.Request) {
 
 type getOrgsRequest struct {
 	filter platform.OrganizationFilter
+	opts   platform.FindOptions
+}
+
+type orgsResponse struct {
+	Organizations []*platform.Organization `json:"orgs"`
+	Links         Links                    `json:"links"`
 }
 
 func decodeGetOrgsRequest(ctx context.Context, r *http.Request) (*getOrgsRequest, error) {
 	qp := r.URL.Query()
 	req := &getOrgsRequest{}
 
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
 	if id := qp.Get("id"); id != "" {
 		req.filter.ID = &platform.ID{}
 		if err := req.filter.ID.DecodeFromString(id); err != nil {
@@ -272,51 +285,37 @@ func (s *OrganizationService) FindOrganization(ctx context.Context, filter platf
 	}
 
 	if n < 1 {
-		return nil, errors.New("expected at least one organization")
+		return nil, kerrors.NotFoundf("found no matching organization")
 	}
 
 	return os[0], nil
 }
 
+// FindOrganizations returns a list of organizations that match filter and the total count of matching organizations.
+// Additional options request a single page of organizations, otherwise all pages are requested.
 func (s *OrganizationService) FindOrganizations(ctx context.Context, filter platform.OrganizationFilter, opt ...platform.FindOptions) ([]*platform.Organization, int, error) {
-	url, err := newURL(s.Addr, organizationPath)
-	if err != nil {
-		return nil, 0, err
-	}
-	qp := url.Query()
-
+	qp := url.Values{}
 	if filter.Name != nil {
 		qp.Add("name", *filter.Name)
 	}
 	if filter.ID != nil {
 		qp.Add("id", filter.ID.String())
 	}
-	url.RawQuery = qp.Encode()
-
-	req, err := http.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	req.Header.Set("Authorization", s.Token)
-	hc := newClient(url.Scheme, s.InsecureSkipVerify)
-
-	resp, err := hc.Do(req)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	if err := CheckError(resp); err != nil {
-		return nil, 0, err
-	}
 
 	var os []*platform.Organization
-	if err := json.NewDecoder(resp.Body).Decode(&os); err != nil {
+	err := getPages(ctx, s.Addr, s.Token, s.InsecureSkipVerify, organizationPath, qp, opt, func(dec *json.Decoder) (*Links, error) {
+		var resp orgsResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		os = append(os, resp.Organizations...)
+		return &resp.Links, nil
+	})
+	if err != nil {
 		return nil, 0, err
 	}
 
 	return os, len(os), nil
-
 }
 
 // CreateOrganization creates an organization.
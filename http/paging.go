@@ -0,0 +1,187 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+const (
+	// DefaultPageSize is the number of results returned by list endpoints when no limit is set.
+	DefaultPageSize = 20
+	// MaxPageSize is the maximum number of results returned by list endpoints.
+	MaxPageSize = 100
+)
+
+// Link is a link to a page of results.
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links are the links to the current page of results and to its neighbours.
+// Next and Prev are omitted on the last and first pages respectively.
+type Links struct {
+	Self Link  `json:"self"`
+	Next *Link `json:"next,omitempty"`
+	Prev *Link `json:"prev,omitempty"`
+}
+
+// decodeFindOptions decodes the limit, offset, sortBy, descending and after query parameters.
+func decodeFindOptions(ctx context.Context, r *http.Request) (platform.FindOptions, error) {
+	qp := r.URL.Query()
+	opts := platform.FindOptions{Limit: DefaultPageSize}
+
+	if limit := qp.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 || l > MaxPageSize {
+			return opts, kerrors.InvalidDataf("limit must be between 1 and %d", MaxPageSize)
+		}
+		opts.Limit = l
+	}
+
+	if offset := qp.Get("offset"); offset != "" {
+		o, err := strconv.Atoi(offset)
+		if err != nil || o < 0 {
+			return opts, kerrors.InvalidDataf("offset must be a non-negative integer")
+		}
+		opts.Offset = o
+	}
+
+	opts.SortBy = qp.Get("sortBy")
+
+	if desc := qp.Get("descending"); desc != "" {
+		d, err := strconv.ParseBool(desc)
+		if err != nil {
+			return opts, kerrors.InvalidDataf("descending must be a boolean")
+		}
+		opts.Descending = d
+	}
+
+	if after := qp.Get("after"); after != "" {
+		if err := opts.After.DecodeFromString(after); err != nil {
+			return opts, err
+		}
+	}
+
+	return opts, nil
+}
+
+// encodeFindOptions adds the find options to the query parameters of a request.
+func encodeFindOptions(qp url.Values, opts platform.FindOptions) {
+	if opts.Limit > 0 {
+		qp.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		qp.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	if opts.SortBy != "" {
+		qp.Set("sortBy", opts.SortBy)
+	}
+	if opts.Descending {
+		qp.Set("descending", "true")
+	}
+	if len(opts.After) > 0 {
+		qp.Set("after", opts.After.String())
+	}
+}
+
+// pageOptions returns the options used to find a page of results.
+// One more result than requested is found, to know whether there is a next page.
+// The total count of results is not part of the response, so it is not computed.
+func pageOptions(opts platform.FindOptions) platform.FindOptions {
+	opts.Limit++
+	opts.SkipCount = true
+	return opts
+}
+
+// newPage trims n results found with pageOptions(opts) to the requested page and returns
+// its length and links, where id returns the ID of the i-th result.
+// The next link continues after the last result of the page and the previous link is set
+// when the page was requested with an offset.
+func newPage(r *http.Request, opts platform.FindOptions, n int, id func(i int) platform.ID) (int, Links) {
+	u := url.URL{Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+	links := Links{
+		Self: Link{Href: u.String()},
+	}
+
+	if n > opts.Limit {
+		n = opts.Limit
+		qp := r.URL.Query()
+		qp.Del("offset")
+		qp.Set("after", id(n-1).String())
+		u.RawQuery = qp.Encode()
+		links.Next = &Link{Href: u.String()}
+	}
+
+	if opts.Offset > 0 {
+		qp := r.URL.Query()
+		if offset := opts.Offset - opts.Limit; offset > 0 {
+			qp.Set("offset", strconv.Itoa(offset))
+		} else {
+			qp.Del("offset")
+		}
+		u.RawQuery = qp.Encode()
+		links.Prev = &Link{Href: u.String()}
+	}
+
+	return n, links
+}
+
+// getPages requests the list endpoint at path and each page of results that follows it.
+// When opts is given only the requested page is returned, otherwise the next links are
+// followed until all results are found.
+// Each response is decoded by decode, which returns the links of the page.
+func getPages(ctx context.Context, addr, token string, insecure bool, path string, qp url.Values, opts []platform.FindOptions, decode func(*json.Decoder) (*Links, error)) error {
+	u, err := newURL(addr, path)
+	if err != nil {
+		return err
+	}
+	if len(opts) > 0 {
+		encodeFindOptions(qp, opts[0])
+	} else {
+		qp.Set("limit", strconv.Itoa(MaxPageSize))
+	}
+	u.RawQuery = qp.Encode()
+
+	hc := newClient(u.Scheme, insecure)
+	for {
+		req, err := http.NewRequest("GET", u.String(), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", token)
+
+		links, err := getPage(hc, req, decode)
+		if err != nil {
+			return err
+		}
+		if len(opts) > 0 || links == nil || links.Next == nil {
+			return nil
+		}
+
+		next, err := url.Parse(links.Next.Href)
+		if err != nil {
+			return err
+		}
+		u = u.ResolveReference(next)
+	}
+}
+
+func getPage(hc *http.Client, req *http.Request, decode func(*json.Decoder) (*Links, error)) (*Links, error) {
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	return decode(json.NewDecoder(resp.Body))
+}
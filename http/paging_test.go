@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+func TestNewPage(t *testing.T) {
+	ids := []platform.ID{{0x01}, {0x02}, {0x03}}
+	id := func(i int) platform.ID { return ids[i] }
+
+	type wants struct {
+		n     int
+		links Links
+	}
+
+	tests := []struct {
+		name  string
+		url   string
+		n     int
+		wants wants
+	}{
+		{
+			name: "last page",
+			url:  "/v1/buckets?limit=3",
+			n:    3,
+			wants: wants{
+				n: 3,
+				links: Links{
+					Self: Link{Href: "/v1/buckets?limit=3"},
+				},
+			},
+		},
+		{
+			name: "next page",
+			url:  "/v1/buckets?limit=2&sortBy=name",
+			n:    3,
+			wants: wants{
+				n: 2,
+				links: Links{
+					Self: Link{Href: "/v1/buckets?limit=2&sortBy=name"},
+					Next: &Link{Href: "/v1/buckets?after=02&limit=2&sortBy=name"},
+				},
+			},
+		},
+		{
+			name: "offset page",
+			url:  "/v1/buckets?limit=2&offset=3",
+			n:    3,
+			wants: wants{
+				n: 2,
+				links: Links{
+					Self: Link{Href: "/v1/buckets?limit=2&offset=3"},
+					Next: &Link{Href: "/v1/buckets?after=02&limit=2"},
+					Prev: &Link{Href: "/v1/buckets?limit=2&offset=1"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+			opts, err := decodeFindOptions(r.Context(), r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			n, links := newPage(r, opts, tt.n, id)
+			if n != tt.wants.n {
+				t.Errorf("unexpected page length: got %d want %d", n, tt.wants.n)
+			}
+			if diff := cmp.Diff(links, tt.wants.links); diff != "" {
+				t.Errorf("links are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeFindOptions_Invalid(t *testing.T) {
+	for _, u := range []string{
+		"/v1/buckets?limit=0",
+		"/v1/buckets?limit=101",
+		"/v1/buckets?offset=-1",
+		"/v1/buckets?descending=maybe",
+		"/v1/buckets?after=zz",
+	} {
+		r := httptest.NewRequest("GET", u, nil)
+		if _, err := decodeFindOptions(r.Context(), r); err == nil {
+			t.Errorf("expected error decoding %q", u)
+		}
+	}
+}
@@ -5,7 +5,9 @@ import (
 	nethttp "net/http"
 	"strings"
 
+	"github.com/influxdata/platform"
 	idpctx "github.com/influxdata/platform/context"
+	kerrors "github.com/influxdata/platform/kit/errors"
 )
 
 // PlatformHandler is a collection of all the service handlers.
@@ -15,6 +17,13 @@ type PlatformHandler struct {
 	OrgHandler           *OrgHandler
 	AuthorizationHandler *AuthorizationHandler
 	DashboardHandler     *DashboardHandler
+	TaskHandler          *TaskHandler
+	BackupHandler        *BackupHandler
+	AuditHandler         *AuditHandler
+
+	// AuthorizationService finds the authorization of the token of a request,
+	// which services record as the author of the changes made by the request.
+	AuthorizationService platform.AuthorizationService
 }
 
 func setCORSResponseHeaders(w nethttp.ResponseWriter, r *nethttp.Request) {
@@ -33,10 +42,16 @@ func (h *PlatformHandler) ServeHTTP(w nethttp.ResponseWriter, r *nethttp.Request
 		return
 	}
 
+	// The spec of the API is public.
+	if r.URL.Path == swaggerPath {
+		PlatformSpec().ServeHTTP(w, r)
+		return
+	}
+
 	ctx := r.Context()
 	var err error
-	if ctx, err = extractAuthorization(ctx, r); err != nil {
-		nethttp.Error(w, err.Error(), nethttp.StatusBadRequest)
+	if ctx, err = h.extractAuthorization(ctx, r); err != nil {
+		kerrors.EncodeHTTP(ctx, kerrors.Unauthorizedf("%v", err), w)
 		return
 	}
 	r = r.WithContext(ctx)
@@ -66,13 +81,50 @@ func (h *PlatformHandler) ServeHTTP(w nethttp.ResponseWriter, r *nethttp.Request
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/v1/tasks") {
+		h.TaskHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/v1/backup") {
+		if h.BackupHandler == nil {
+			kerrors.EncodeHTTP(ctx, kerrors.Unavailablef("backups are not enabled"), w)
+			return
+		}
+		h.BackupHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/v1/audit") {
+		if h.AuditHandler == nil {
+			kerrors.EncodeHTTP(ctx, kerrors.Unavailablef("audit log is not enabled"), w)
+			return
+		}
+		h.AuditHandler.ServeHTTP(w, r)
+		return
+	}
+
 	nethttp.NotFound(w, r)
 }
 
-func extractAuthorization(ctx context.Context, r *nethttp.Request) (context.Context, error) {
+// extractAuthorization sets the token of the request on context, and its authorization
+// when it is found by the authorization service.
+func (h *PlatformHandler) extractAuthorization(ctx context.Context, r *nethttp.Request) (context.Context, error) {
 	t, err := ParseAuthHeaderToken(r)
 	if err != nil {
 		return ctx, err
 	}
-	return idpctx.SetToken(ctx, t), nil
+	ctx = idpctx.SetToken(ctx, t)
+
+	if h.AuthorizationService == nil {
+		return ctx, nil
+	}
+	a, err := h.AuthorizationService.FindAuthorizationByToken(ctx, t)
+	if kerrors.Reference(err) == kerrors.NotFound {
+		return ctx, nil
+	}
+	if err != nil {
+		return ctx, err
+	}
+	return idpctx.SetAuthorization(ctx, a), nil
 }
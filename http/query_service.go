@@ -10,7 +10,9 @@ import (
 	"github.com/influxdata/platform"
 	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/cache"
 	"github.com/influxdata/platform/query/csv"
+	"github.com/influxdata/platform/query/semantic"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 )
@@ -42,12 +44,15 @@ func NewQueryHandler() *QueryHandler {
 // handlePostQuery is the HTTP handler for the POST /v1/query route.
 func (h *QueryHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	if r.Header.Get("Cache-Control") == "no-cache" {
+		ctx = cache.WithBypass(ctx)
+	}
 
 	var orgID platform.ID
 	if id := r.FormValue("orgID"); id != "" {
 		err := orgID.DecodeFromString(id)
 		if err != nil {
-			kerrors.EncodeHTTP(ctx, errors.Wrap(err, "failed to decode orgID"), w)
+			kerrors.EncodeHTTP(ctx, kerrors.MalformedDataf("failed to decode orgID: %v", err), w)
 			return
 		}
 	}
@@ -56,14 +61,15 @@ func (h *QueryHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 			Name: &name,
 		})
 		if err != nil {
-			kerrors.EncodeHTTP(ctx, errors.Wrap(err, "failed to load organization"), w)
+			// The error is not wrapped so that its reference code, such as NotFound, is kept.
+			kerrors.EncodeHTTP(ctx, err, w)
 			return
 		}
 		orgID = org.ID
 	}
 
 	if len(orgID) == 0 {
-		kerrors.EncodeHTTP(ctx, errors.New("must pass organization name or ID as string in orgName or orgID parameter"), w)
+		kerrors.EncodeHTTP(ctx, kerrors.InvalidDataf("must pass organization name or ID as string in orgName or orgID parameter"), w)
 		return
 	}
 
@@ -84,12 +90,12 @@ func (h *QueryHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	} else {
 		queryStr := r.FormValue("q")
 		if queryStr == "" {
-			kerrors.EncodeHTTP(ctx, errors.New("must pass query string in q parameter"), w)
+			kerrors.EncodeHTTP(ctx, kerrors.InvalidDataf("must pass query string in q parameter"), w)
 			return
 		}
 		rs, err := h.QueryService.QueryWithCompile(ctx, orgID, queryStr)
 		if err != nil {
-			kerrors.EncodeHTTP(ctx, err, w)
+			encodeQueryError(ctx, err, w)
 			return
 		}
 		results = rs
@@ -107,6 +113,41 @@ func (h *QueryHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// queryErrorsResponse is the body of the response to a query that failed to compile because of type errors.
+type queryErrorsResponse struct {
+	Errors []queryError `json:"errors"`
+}
+
+// queryError is an error at a position in the query, the line and column are omitted if the position is unknown.
+type queryError struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// encodeQueryError encodes the error of a query.
+// Type errors are returned as invalid data, with the position of each error listed in the body of the response.
+func encodeQueryError(ctx context.Context, err error, w http.ResponseWriter) {
+	typeErrs, ok := errors.Cause(err).(semantic.TypeErrors)
+	if !ok {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+	res := queryErrorsResponse{
+		Errors: make([]queryError, len(typeErrs)),
+	}
+	for i, e := range typeErrs {
+		res.Errors[i].Message = e.Msg
+		if e.Loc != nil {
+			res.Errors[i].Line = e.Loc.Start.Line
+			res.Errors[i].Column = e.Loc.Start.Column
+		}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	kerrors.EncodeHTTP(ctx, kerrors.InvalidDataf("%v", err), w)
+	json.NewEncoder(w).Encode(res)
+}
+
 type postQueryRequest struct {
 	Spec *query.Spec `json:"spec"`
 }
@@ -149,9 +190,12 @@ func (s *QueryService) Query(ctx context.Context, orgID platform.ID, query *quer
 	req.Header.Set("Authorization", s.Token)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "text/csv")
+	if cache.BypassFromContext(ctx) {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
 
 	hc := newClient(u.Scheme, s.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	resp, err := hc.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -174,9 +218,12 @@ func (s *QueryService) QueryWithCompile(ctx context.Context, orgID platform.ID,
 	}
 	req.Header.Set("Authorization", s.Token)
 	req.Header.Set("Accept", "text/csv")
+	if cache.BypassFromContext(ctx) {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
 
 	hc := newClient(u.Scheme, s.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	resp, err := hc.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
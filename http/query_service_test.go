@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+)
+
+func TestQueryHandler_Organization(t *testing.T) {
+	ctx := context.Background()
+	f, err := ioutil.TempFile("", "influxdata-platform-http-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(c.Path)
+	defer c.Close()
+
+	org := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewQueryHandler()
+	h.QueryService = testQueryService{}
+	h.OrganizationService = c
+
+	tests := []struct {
+		name   string
+		params url.Values
+		status int
+	}{
+		{
+			name:   "organization name",
+			params: url.Values{"orgName": {org.Name}},
+			status: http.StatusOK,
+		},
+		{
+			name:   "unknown organization name",
+			params: url.Values{"orgName": {"nope"}},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "malformed organization ID",
+			params: url.Values{"orgID": {"not hex"}},
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.Set("q", `from(bucket:"telegraf") |> range(start:-1h)`)
+			r := httptest.NewRequest("POST", queryPath+"?"+tt.params.Encode(), nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Errorf("unexpected status: got %d want %d: %s", w.Code, tt.status, w.Header().Get("X-Influx-Error"))
+			}
+		})
+	}
+}
@@ -0,0 +1,684 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+//go:generate go run swaggergen/main.go swagger.yml swagger_gen.go
+
+const swaggerPath = "/v1/swagger.json"
+
+var (
+	platformSpec     *Spec
+	platformSpecOnce sync.Once
+)
+
+// PlatformSpec returns the OpenAPI spec of the platform API, http/swagger.yml.
+func PlatformSpec() *Spec {
+	platformSpecOnce.Do(func() {
+		s, err := ParseSpec(swaggerJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid swagger.yml: %v", err))
+		}
+		platformSpec = s
+	})
+	return platformSpec
+}
+
+// Spec is an OpenAPI 3 spec of an HTTP API, which requests and responses can be validated against.
+// Only the parts of OpenAPI used by swagger.yml are supported.
+type Spec struct {
+	octets []byte
+	prefix string
+	routes []*specRoute
+	doc    specDocument
+}
+
+type specDocument struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Headers    map[string]*specParameter `json:"headers"`
+		Parameters map[string]*specParameter `json:"parameters"`
+		Responses  map[string]*specResponse  `json:"responses"`
+		Schemas    map[string]*specSchema    `json:"schemas"`
+	} `json:"components"`
+}
+
+type specOperation struct {
+	Parameters  []*specParameter         `json:"parameters"`
+	RequestBody *specRequestBody         `json:"requestBody"`
+	Responses   map[string]*specResponse `json:"responses"`
+}
+
+type specParameter struct {
+	Ref      string      `json:"$ref"`
+	In       string      `json:"in"`
+	Name     string      `json:"name"`
+	Required bool        `json:"required"`
+	Schema   *specSchema `json:"schema"`
+}
+
+type specRequestBody struct {
+	Required bool                      `json:"required"`
+	Content  map[string]*specMediaType `json:"content"`
+}
+
+type specResponse struct {
+	Ref     string                    `json:"$ref"`
+	Headers map[string]*specParameter `json:"headers"`
+	Content map[string]*specMediaType `json:"content"`
+}
+
+type specMediaType struct {
+	Schema *specSchema `json:"schema"`
+
+	// json is whether the media type is JSON.
+	json bool
+}
+
+type specSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Format               string                 `json:"format"`
+	Nullable             bool                   `json:"nullable"`
+	ReadOnly             bool                   `json:"readOnly"`
+	WriteOnly            bool                   `json:"writeOnly"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	Properties           map[string]*specSchema `json:"properties"`
+	AdditionalProperties *specSchema            `json:"additionalProperties"`
+	Required             []string               `json:"required"`
+	Items                *specSchema            `json:"items"`
+	AllOf                []*specSchema          `json:"allOf"`
+	OneOf                []*specSchema          `json:"oneOf"`
+	Discriminator        *struct {
+		PropertyName string            `json:"propertyName"`
+		Mapping      map[string]string `json:"mapping"`
+	} `json:"discriminator"`
+}
+
+// specRoute is an operation of the spec and the template of its path.
+type specRoute struct {
+	method   string
+	path     string
+	segments []string
+	op       *specOperation
+}
+
+// match returns the path parameters of p if it matches the path template of the route.
+func (r *specRoute) match(p string) (map[string]string, bool) {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, s := range r.segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[s[1:len(s)-1]] = segments[i]
+		} else if s != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+var specMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// ParseSpec parses an OpenAPI 3 spec in JSON.
+// All references of the spec must resolve to its components.
+func ParseSpec(octets []byte) (*Spec, error) {
+	s := &Spec{octets: octets}
+	if err := json.Unmarshal(octets, &s.doc); err != nil {
+		return nil, err
+	}
+	if len(s.doc.Servers) > 0 {
+		u, err := url.Parse(s.doc.Servers[0].URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server url: %v", err)
+		}
+		s.prefix = strings.TrimSuffix(u.Path, "/")
+	}
+
+	for p, item := range s.doc.Paths {
+		for _, method := range specMethods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			op := &specOperation{}
+			if err := json.Unmarshal(raw, op); err != nil {
+				return nil, fmt.Errorf("%s %s: %v", strings.ToUpper(method), p, err)
+			}
+			if err := s.resolveOperation(op); err != nil {
+				return nil, fmt.Errorf("%s %s: %v", strings.ToUpper(method), p, err)
+			}
+			s.routes = append(s.routes, &specRoute{
+				method:   strings.ToUpper(method),
+				path:     p,
+				segments: strings.Split(strings.Trim(p, "/"), "/"),
+				op:       op,
+			})
+		}
+	}
+	for name, schema := range s.doc.Components.Schemas {
+		if err := s.checkRefs(schema); err != nil {
+			return nil, fmt.Errorf("schema %s: %v", name, err)
+		}
+	}
+
+	// Routes with static segments are matched before routes with parameters in their place.
+	sort.Slice(s.routes, func(i, j int) bool {
+		return s.routes[i].path < s.routes[j].path
+	})
+	return s, nil
+}
+
+// resolveOperation replaces the references to parameters, responses and headers of the operation with their components.
+func (s *Spec) resolveOperation(op *specOperation) error {
+	for i, p := range op.Parameters {
+		if p.Ref == "" {
+			continue
+		}
+		c, ok := s.doc.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+		if !ok {
+			return fmt.Errorf("parameter %s not found", p.Ref)
+		}
+		op.Parameters[i] = c
+	}
+	for code, r := range op.Responses {
+		if r.Ref == "" {
+			continue
+		}
+		c, ok := s.doc.Components.Responses[strings.TrimPrefix(r.Ref, "#/components/responses/")]
+		if !ok {
+			return fmt.Errorf("response %s not found", r.Ref)
+		}
+		op.Responses[code] = c
+	}
+	for _, r := range op.Responses {
+		for name, h := range r.Headers {
+			if h.Ref == "" {
+				continue
+			}
+			c, ok := s.doc.Components.Headers[strings.TrimPrefix(h.Ref, "#/components/headers/")]
+			if !ok {
+				return fmt.Errorf("header %s not found", h.Ref)
+			}
+			r.Headers[name] = c
+		}
+	}
+	if op.RequestBody != nil {
+		for t, m := range op.RequestBody.Content {
+			m.json = t == "application/json"
+			if err := s.checkRefs(m.Schema); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range op.Responses {
+		for t, m := range r.Content {
+			m.json = t == "application/json"
+			if err := s.checkRefs(m.Schema); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRefs returns an error if a schema referenced by schema is not found.
+func (s *Spec) checkRefs(schema *specSchema) error {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		_, err := s.schema(schema)
+		return err
+	}
+	for _, p := range schema.Properties {
+		if err := s.checkRefs(p); err != nil {
+			return err
+		}
+	}
+	for _, x := range append(append([]*specSchema{schema.Items, schema.AdditionalProperties}, schema.AllOf...), schema.OneOf...) {
+		if err := s.checkRefs(x); err != nil {
+			return err
+		}
+	}
+	if schema.Discriminator != nil {
+		for _, ref := range schema.Discriminator.Mapping {
+			if err := s.checkRefs(&specSchema{Ref: ref}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schema resolves the reference of schema to a component, if it has one.
+func (s *Spec) schema(schema *specSchema) (*specSchema, error) {
+	if schema.Ref == "" {
+		return schema, nil
+	}
+	c, ok := s.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	if !ok {
+		return nil, fmt.Errorf("schema %s not found", schema.Ref)
+	}
+	return c, nil
+}
+
+// ServeHTTP serves the spec in JSON.
+func (s *Spec) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.octets)
+}
+
+// Operations returns the method and path template of each operation of the spec, in order.
+func (s *Spec) Operations() []string {
+	ops := make([]string, len(s.routes))
+	for i, r := range s.routes {
+		ops[i] = r.method + " " + s.prefix + r.path
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// route returns the route of the operation of a request and its path parameters.
+func (s *Spec) route(method, p string) (*specRoute, map[string]string, error) {
+	if !strings.HasPrefix(p, s.prefix+"/") {
+		return nil, nil, kerrors.NotFoundf("path %s is not in the API spec", p)
+	}
+	p = strings.TrimPrefix(p, s.prefix)
+
+	found := false
+	for _, r := range s.routes {
+		params, ok := r.match(p)
+		if !ok {
+			continue
+		}
+		found = true
+		if r.method == method {
+			return r, params, nil
+		}
+	}
+	if found {
+		return nil, nil, kerrors.NotFoundf("method %s of path %s is not in the API spec", method, p)
+	}
+	return nil, nil, kerrors.NotFoundf("path %s is not in the API spec", p)
+}
+
+// ValidateRequest returns an error if the request does not conform to the spec.
+// The body of the request is read and replaced, so that it can be read again by a handler.
+func (s *Spec) ValidateRequest(r *http.Request) error {
+	route, pathParams, err := s.route(r.Method, r.URL.Path)
+	if err != nil {
+		return err
+	}
+	op := route.op
+
+	qp := r.URL.Query()
+	for _, p := range op.Parameters {
+		var v string
+		var ok bool
+		switch p.In {
+		case "path":
+			v, ok = pathParams[p.Name]
+		case "query":
+			if vs, found := qp[p.Name]; found && len(vs) > 0 {
+				v, ok = vs[0], true
+			}
+		case "header":
+			v = r.Header.Get(p.Name)
+			ok = v != ""
+		default:
+			continue
+		}
+		if !ok {
+			if p.Required {
+				return kerrors.InvalidDataf("%s parameter %s is required", p.In, p.Name)
+			}
+			continue
+		}
+		if err := s.validateParameter(p, v); err != nil {
+			return err
+		}
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return err
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if op.RequestBody == nil {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return kerrors.InvalidDataf("request body is not accepted")
+		}
+		return nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return kerrors.InvalidDataf("request body is required")
+		}
+		return nil
+	}
+
+	mt, err := s.mediaType(op.RequestBody.Content, r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	if err := s.validateBody(mt, body, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateResponse returns an error if the response to the request does not conform to the spec.
+func (s *Spec) ValidateResponse(r *http.Request, code int, header http.Header, body []byte) error {
+	route, _, err := s.route(r.Method, r.URL.Path)
+	if err != nil {
+		return err
+	}
+
+	res, ok := route.op.Responses[strconv.Itoa(code)]
+	if !ok {
+		res, ok = route.op.Responses[fmt.Sprintf("%dXX", code/100)]
+	}
+	if !ok {
+		res, ok = route.op.Responses["default"]
+	}
+	if !ok {
+		return kerrors.InvalidDataf("response status %d is not in the API spec", code)
+	}
+
+	for name, h := range res.Headers {
+		v := header.Get(name)
+		if v == "" {
+			if h.Required {
+				return kerrors.InvalidDataf("response header %s is required", name)
+			}
+			continue
+		}
+		if err := s.validateParameter(&specParameter{In: "header", Name: name, Schema: h.Schema}, v); err != nil {
+			return err
+		}
+	}
+
+	// Errors are described by the headers of the response, their body is optional.
+	if code >= 400 && len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if len(res.Content) == 0 {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return kerrors.InvalidDataf("response status %d has no body in the API spec", code)
+		}
+		return nil
+	}
+	mt, err := s.mediaType(res.Content, header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	return s.validateBody(mt, body, false)
+}
+
+// mediaType returns the content of the content type, which must be in content.
+func (s *Spec) mediaType(content map[string]*specMediaType, contentType string) (*specMediaType, error) {
+	t, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		if len(content) == 1 && contentType == "" {
+			// A body without a content type is assumed to be of the only content of the spec.
+			for _, mt := range content {
+				return mt, nil
+			}
+		}
+		return nil, kerrors.InvalidDataf("invalid content type %q", contentType)
+	}
+	mt, ok := content[t]
+	if !ok {
+		return nil, kerrors.InvalidDataf("content type %s is not in the API spec", t)
+	}
+	return mt, nil
+}
+
+// validateBody validates a body against the schema of its media type.
+// Only JSON bodies, which have an object or array schema, are validated.
+func (s *Spec) validateBody(mt *specMediaType, body []byte, request bool) error {
+	if mt.Schema == nil || !mt.json {
+		return nil
+	}
+	schema, err := s.schema(mt.Schema)
+	if err != nil {
+		return err
+	}
+	if schema.Type == "string" {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return kerrors.MalformedDataf("invalid JSON body: %v", err)
+	}
+	if err := s.validateValue(mt.Schema, v, "body", request); err != nil {
+		return kerrors.InvalidDataf("%v", err)
+	}
+	return nil
+}
+
+// validateParameter validates the string value of a parameter against its schema.
+func (s *Spec) validateParameter(p *specParameter, v string) error {
+	if p.Schema == nil {
+		return nil
+	}
+	schema, err := s.schema(p.Schema)
+	if err != nil {
+		return err
+	}
+
+	var value interface{} = v
+	switch schema.Type {
+	case "integer", "number":
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return kerrors.InvalidDataf("%s parameter %s must be a %s", p.In, p.Name, schema.Type)
+		}
+		value = f
+	case "boolean":
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return kerrors.InvalidDataf("%s parameter %s must be a boolean", p.In, p.Name)
+		}
+		value = b
+	}
+	if err := s.validateValue(schema, value, p.In+" parameter "+p.Name, true); err != nil {
+		return kerrors.InvalidDataf("%v", err)
+	}
+	return nil
+}
+
+// validateValue validates a value decoded from JSON against schema.
+// Read only properties are not required in requests and write only properties are not required in responses.
+func (s *Spec) validateValue(schema *specSchema, v interface{}, name string, request bool) error {
+	schema, err := s.schema(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, x := range schema.AllOf {
+		if err := s.validateValue(x, v, name, request); err != nil {
+			return err
+		}
+	}
+
+	if v == nil {
+		if schema.Nullable || (schema.Type == "" && len(schema.AllOf) == 0 && len(schema.OneOf) == 0) {
+			return nil
+		}
+		return fmt.Errorf("%s must not be null", name)
+	}
+
+	if len(schema.OneOf) > 0 {
+		if err := s.validateOneOf(schema, v, name, request); err != nil {
+			return err
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, e := range schema.Enum {
+			if e == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s must be one of %v", name, schema.Enum)
+		}
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", name)
+		}
+		return s.validateObject(schema, obj, name, request)
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", name)
+		}
+		if schema.Items != nil {
+			for i, x := range arr {
+				if err := s.validateValue(schema.Items, x, fmt.Sprintf("%s[%d]", name, i), request); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", name)
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				return fmt.Errorf("%s must be an RFC3339 time", name)
+			}
+		}
+	case "integer", "number":
+		f, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("%s must be a %s", name, schema.Type)
+		}
+		if schema.Type == "integer" && f != float64(int64(f)) {
+			return fmt.Errorf("%s must be an integer", name)
+		}
+		if schema.Minimum != nil && f < *schema.Minimum {
+			return fmt.Errorf("%s must be at least %v", name, *schema.Minimum)
+		}
+		if schema.Maximum != nil && f > *schema.Maximum {
+			return fmt.Errorf("%s must be at most %v", name, *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", name)
+		}
+	case "":
+		// Objects may omit their type.
+		if obj, ok := v.(map[string]interface{}); ok && (len(schema.Properties) > 0 || schema.AdditionalProperties != nil) {
+			return s.validateObject(schema, obj, name, request)
+		}
+	}
+	return nil
+}
+
+func (s *Spec) validateObject(schema *specSchema, obj map[string]interface{}, name string, request bool) error {
+	for _, p := range schema.Required {
+		ps, ok := schema.Properties[p]
+		if ok {
+			var err error
+			if ps, err = s.schema(ps); err != nil {
+				return err
+			}
+			if (request && ps.ReadOnly) || (!request && ps.WriteOnly) {
+				continue
+			}
+		}
+		if _, ok := obj[p]; !ok {
+			return fmt.Errorf("%s.%s is required", name, p)
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ps, ok := schema.Properties[k]
+		if !ok {
+			ps = schema.AdditionalProperties
+		}
+		if ps == nil {
+			continue
+		}
+		if err := s.validateValue(ps, obj[k], name+"."+k, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOneOf validates v against the schema of its discriminator, or else against exactly one of the schemas.
+func (s *Spec) validateOneOf(schema *specSchema, v interface{}, name string, request bool) error {
+	if d := schema.Discriminator; d != nil {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", name)
+		}
+		t, ok := obj[d.PropertyName].(string)
+		if !ok {
+			return fmt.Errorf("%s.%s is required", name, d.PropertyName)
+		}
+		ref, ok := d.Mapping[t]
+		if !ok {
+			return fmt.Errorf("%s.%s %q is not one of the types in the API spec", name, d.PropertyName, t)
+		}
+		return s.validateValue(&specSchema{Ref: ref}, v, name, request)
+	}
+
+	matched := 0
+	for _, x := range schema.OneOf {
+		if s.validateValue(x, v, name, request) == nil {
+			matched++
+		}
+	}
+	if matched != 1 {
+		return fmt.Errorf("%s must match exactly one schema, matched %d", name, matched)
+	}
+	return nil
+}
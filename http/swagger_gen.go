@@ -0,0 +1,3275 @@
+package http
+
+// DO NOT EDIT: This file is autogenerated via the swaggergen command from swagger.yml.
+
+// swaggerJSON is the OpenAPI spec of the platform in JSON.
+var swaggerJSON = []byte(`{
+  "components": {
+    "headers": {
+      "X-Influx-Error": {
+        "description": "the message of the error",
+        "required": true,
+        "schema": {
+          "type": "string"
+        }
+      },
+      "X-Influx-Reference": {
+        "description": "reference code of the error: 1 internal error, 2 malformed data, 3 invalid data, 4 forbidden, 5 not found, 6 conflict, 7 unauthorized, 8 unavailable\n",
+        "required": true,
+        "schema": {
+          "maximum": 8,
+          "minimum": 1,
+          "type": "integer"
+        }
+      }
+    },
+    "parameters": {
+      "After": {
+        "description": "returns results sorted after the result with this ID",
+        "in": "query",
+        "name": "after",
+        "schema": {
+          "type": "string"
+        }
+      },
+      "Descending": {
+        "description": "sort results in descending order",
+        "in": "query",
+        "name": "descending",
+        "schema": {
+          "default": false,
+          "type": "boolean"
+        }
+      },
+      "Limit": {
+        "description": "the maximum number of results returned",
+        "in": "query",
+        "name": "limit",
+        "schema": {
+          "default": 20,
+          "maximum": 100,
+          "minimum": 1,
+          "type": "integer"
+        }
+      },
+      "Offset": {
+        "description": "the number of results skipped",
+        "in": "query",
+        "name": "offset",
+        "schema": {
+          "minimum": 0,
+          "type": "integer"
+        }
+      },
+      "SortBy": {
+        "description": "the field results are sorted by",
+        "in": "query",
+        "name": "sortBy",
+        "schema": {
+          "default": "id",
+          "type": "string"
+        }
+      }
+    },
+    "responses": {
+      "Error": {
+        "description": "unexpected error, described by the headers of the response. The status of the response is that of the reference code of the error.\n",
+        "headers": {
+          "X-Influx-Error": {
+            "$ref": "#/components/headers/X-Influx-Error"
+          },
+          "X-Influx-Reference": {
+            "$ref": "#/components/headers/X-Influx-Reference"
+          }
+        }
+      }
+    },
+    "schemas": {
+      "AuditEvent": {
+        "properties": {
+          "action": {
+            "enum": [
+              "create",
+              "update",
+              "delete"
+            ],
+            "type": "string"
+          },
+          "authorizationID": {
+            "type": "string"
+          },
+          "id": {
+            "type": "string"
+          },
+          "resourceID": {
+            "type": "string"
+          },
+          "resourceType": {
+            "enum": [
+              "bucket",
+              "organization",
+              "user",
+              "authorization",
+              "dashboard",
+              "task",
+              "run"
+            ],
+            "type": "string"
+          },
+          "time": {
+            "format": "date-time",
+            "type": "string"
+          },
+          "userID": {
+            "description": "ID of the user of the authorization that made the change, if any",
+            "type": "string"
+          }
+        },
+        "readOnly": true,
+        "required": [
+          "id",
+          "time",
+          "action",
+          "resourceType",
+          "resourceID"
+        ]
+      },
+      "AuditEvents": {
+        "properties": {
+          "events": {
+            "items": {
+              "$ref": "#/components/schemas/AuditEvent"
+            },
+            "nullable": true,
+            "type": "array"
+          },
+          "links": {
+            "$ref": "#/components/schemas/Links"
+          }
+        },
+        "type": "object"
+      },
+      "Authorization": {
+        "properties": {
+          "createdAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "createdBy": {
+            "description": "ID of the user that created the resource",
+            "readOnly": true,
+            "type": "string"
+          },
+          "id": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "permissions": {
+            "items": {
+              "$ref": "#/components/schemas/Permission"
+            },
+            "nullable": true,
+            "type": "array"
+          },
+          "token": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "updatedAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "user": {
+            "description": "name of the user of the authorization",
+            "type": "string"
+          },
+          "userID": {
+            "description": "ID of the user of the authorization",
+            "type": "string"
+          }
+        }
+      },
+      "Authorizations": {
+        "properties": {
+          "authorizations": {
+            "items": {
+              "$ref": "#/components/schemas/Authorization"
+            },
+            "nullable": true,
+            "type": "array"
+          },
+          "links": {
+            "$ref": "#/components/schemas/Links"
+          }
+        },
+        "type": "object"
+      },
+      "Axis": {
+        "properties": {
+          "label": {
+            "type": "string"
+          },
+          "max": {
+            "description": "upper bound of the axis, the data is fit when unset",
+            "type": "number"
+          },
+          "min": {
+            "description": "lower bound of the axis, the data is fit when unset",
+            "type": "number"
+          },
+          "prefix": {
+            "type": "string"
+          },
+          "scale": {
+            "enum": [
+              "linear",
+              "log"
+            ],
+            "type": "string"
+          },
+          "suffix": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "Bucket": {
+        "properties": {
+          "createdAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "createdBy": {
+            "description": "ID of the user that created the resource",
+            "readOnly": true,
+            "type": "string"
+          },
+          "id": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "organization": {
+            "description": "name of the organization of the bucket, which may be passed instead of its ID",
+            "type": "string"
+          },
+          "organizationID": {
+            "type": "string"
+          },
+          "retentionPeriod": {
+            "description": "the duration data is retained for, in nanoseconds",
+            "format": "int64",
+            "type": "integer"
+          },
+          "updatedAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          }
+        },
+        "required": [
+          "name",
+          "retentionPeriod"
+        ]
+      },
+      "BucketUpdate": {
+        "properties": {
+          "name": {
+            "type": "string"
+          },
+          "retentionPeriod": {
+            "format": "int64",
+            "type": "integer"
+          }
+        }
+      },
+      "Buckets": {
+        "properties": {
+          "buckets": {
+            "items": {
+              "$ref": "#/components/schemas/Bucket"
+            },
+            "nullable": true,
+            "type": "array"
+          },
+          "links": {
+            "$ref": "#/components/schemas/Links"
+          }
+        },
+        "type": "object"
+      },
+      "Cell": {
+        "properties": {
+          "h": {
+            "format": "int32",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "id": {
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "visualization": {
+            "$ref": "#/components/schemas/Visualization"
+          },
+          "w": {
+            "format": "int32",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "x": {
+            "format": "int32",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "y": {
+            "format": "int32",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "required": [
+          "visualization"
+        ]
+      },
+      "CellQuery": {
+        "properties": {
+          "index": {
+            "description": "the index of the query among the queries of the cell",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "variables": {
+            "additionalProperties": {
+              "type": "string"
+            },
+            "description": "the selected values of the dashboard variables by name",
+            "nullable": true,
+            "type": "object"
+          }
+        }
+      },
+      "CommonVisualization": {
+        "properties": {
+          "query": {
+            "type": "string"
+          },
+          "type": {
+            "enum": [
+              "common"
+            ],
+            "type": "string"
+          }
+        },
+        "required": [
+          "type",
+          "query"
+        ]
+      },
+      "Dashboard": {
+        "properties": {
+          "cells": {
+            "items": {
+              "$ref": "#/components/schemas/Cell"
+            },
+            "nullable": true,
+            "type": "array"
+          },
+          "createdAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "createdBy": {
+            "description": "ID of the user that created the resource",
+            "readOnly": true,
+            "type": "string"
+          },
+          "id": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "organization": {
+            "description": "name of the organization of the dashboard, which may be passed instead of its ID",
+            "type": "string"
+          },
+          "organizationID": {
+            "type": "string"
+          },
+          "updatedAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "variables": {
+            "items": {
+              "$ref": "#/components/schemas/DashboardVariable"
+            },
+            "type": "array"
+          }
+        },
+        "required": [
+          "name"
+        ]
+      },
+      "DashboardExport": {
+        "description": "a dashboard in a portable format, without any of the IDs assigned by the server",
+        "properties": {
+          "buckets": {
+            "description": "names of the buckets read by the queries of the dashboard",
+            "items": {
+              "type": "string"
+            },
+            "type": "array"
+          },
+          "cells": {
+            "items": {
+              "$ref": "#/components/schemas/Cell"
+            },
+            "nullable": true,
+            "type": "array"
+          },
+          "name": {
+            "type": "string"
+          },
+          "variables": {
+            "items": {
+              "$ref": "#/components/schemas/DashboardVariable"
+            },
+            "type": "array"
+          },
+          "version": {
+            "enum": [
+              1
+            ],
+            "type": "integer"
+          }
+        },
+        "required": [
+          "version",
+          "name",
+          "cells"
+        ]
+      },
+      "DashboardImport": {
+        "allOf": [
+          {
+            "$ref": "#/components/schemas/DashboardImportOptions"
+          },
+          {
+            "properties": {
+              "dashboard": {
+                "$ref": "#/components/schemas/DashboardExport"
+              }
+            },
+            "required": [
+              "dashboard"
+            ]
+          }
+        ]
+      },
+      "DashboardImportOptions": {
+        "properties": {
+          "buckets": {
+            "additionalProperties": {
+              "type": "string"
+            },
+            "description": "new names of the buckets read by the queries of the dashboard, by their names in the export",
+            "type": "object"
+          },
+          "name": {
+            "description": "overrides the name of the dashboard",
+            "type": "string"
+          },
+          "organization": {
+            "description": "name of the organization of the new dashboard",
+            "type": "string"
+          },
+          "organizationID": {
+            "description": "ID of the organization of the new dashboard",
+            "type": "string"
+          }
+        }
+      },
+      "DashboardQuery": {
+        "properties": {
+          "query": {
+            "type": "string"
+          },
+          "timeRange": {
+            "$ref": "#/components/schemas/TimeRange"
+          }
+        },
+        "required": [
+          "query"
+        ]
+      },
+      "DashboardUpdate": {
+        "properties": {
+          "name": {
+            "type": "string"
+          },
+          "variables": {
+            "items": {
+              "$ref": "#/components/schemas/DashboardVariable"
+            },
+            "type": "array"
+          }
+        }
+      },
+      "DashboardVariable": {
+        "description": "a template variable referenced by name in the queries of the cells",
+        "properties": {
+          "name": {
+            "type": "string"
+          },
+          "query": {
+            "description": "the Flux query of a query variable",
+            "type": "string"
+          },
+          "timeRange": {
+            "$ref": "#/components/schemas/TimeRange"
+          },
+          "type": {
+            "enum": [
+              "constant",
+              "query",
+              "timeRange"
+            ],
+            "type": "string"
+          },
+          "values": {
+            "description": "the values of a constant variable",
+            "items": {
+              "type": "string"
+            },
+            "type": "array"
+          }
+        },
+        "required": [
+          "name",
+          "type"
+        ]
+      },
+      "Dashboards": {
+        "properties": {
+          "dashboards": {
+            "items": {
+              "$ref": "#/components/schemas/Dashboard"
+            },
+            "nullable": true,
+            "type": "array"
+          },
+          "links": {
+            "$ref": "#/components/schemas/Links"
+          }
+        },
+        "type": "object"
+      },
+      "GaugeVisualization": {
+        "properties": {
+          "decimalPlaces": {
+            "maximum": 10,
+            "minimum": 0,
+            "type": "integer"
+          },
+          "max": {
+            "type": "number"
+          },
+          "min": {
+            "type": "number"
+          },
+          "prefix": {
+            "type": "string"
+          },
+          "queries": {
+            "items": {
+              "$ref": "#/components/schemas/DashboardQuery"
+            },
+            "type": "array"
+          },
+          "suffix": {
+            "type": "string"
+          },
+          "thresholds": {
+            "items": {
+              "$ref": "#/components/schemas/Threshold"
+            },
+            "type": "array"
+          },
+          "type": {
+            "enum": [
+              "gauge"
+            ],
+            "type": "string"
+          }
+        },
+        "required": [
+          "type",
+          "queries",
+          "min",
+          "max"
+        ]
+      },
+      "Legend": {
+        "properties": {
+          "hidden": {
+            "type": "boolean"
+          },
+          "orientation": {
+            "enum": [
+              "top",
+              "bottom",
+              "left",
+              "right"
+            ],
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "LineGraphVisualization": {
+        "properties": {
+          "colors": {
+            "items": {
+              "type": "string"
+            },
+            "type": "array"
+          },
+          "legend": {
+            "$ref": "#/components/schemas/Legend"
+          },
+          "queries": {
+            "items": {
+              "$ref": "#/components/schemas/DashboardQuery"
+            },
+            "type": "array"
+          },
+          "type": {
+            "enum": [
+              "line"
+            ],
+            "type": "string"
+          },
+          "xAxis": {
+            "$ref": "#/components/schemas/Axis"
+          },
+          "yAxis": {
+            "$ref": "#/components/schemas/Axis"
+          }
+        },
+        "required": [
+          "type",
+          "queries"
+        ]
+      },
+      "Link": {
+        "description": "URI of resource.",
+        "properties": {
+          "href": {
+            "format": "url",
+            "type": "string"
+          }
+        },
+        "readOnly": true,
+        "required": [
+          "href"
+        ],
+        "type": "object"
+      },
+      "Links": {
+        "properties": {
+          "next": {
+            "$ref": "#/components/schemas/Link"
+          },
+          "prev": {
+            "$ref": "#/components/schemas/Link"
+          },
+          "self": {
+            "$ref": "#/components/schemas/Link"
+          }
+        },
+        "required": [
+          "self"
+        ],
+        "type": "object"
+      },
+      "Log": {
+        "properties": {
+          "message": {
+            "type": "string"
+          },
+          "runID": {
+            "type": "string"
+          },
+          "time": {
+            "format": "date-time",
+            "type": "string"
+          }
+        },
+        "readOnly": true,
+        "required": [
+          "runID",
+          "time",
+          "message"
+        ]
+      },
+      "Logs": {
+        "items": {
+          "$ref": "#/components/schemas/Log"
+        },
+        "nullable": true,
+        "type": "array"
+      },
+      "MarkdownVisualization": {
+        "properties": {
+          "note": {
+            "type": "string"
+          },
+          "type": {
+            "enum": [
+              "markdown"
+            ],
+            "type": "string"
+          }
+        },
+        "required": [
+          "type",
+          "note"
+        ]
+      },
+      "Organization": {
+        "properties": {
+          "createdAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "createdBy": {
+            "description": "ID of the user that created the resource",
+            "readOnly": true,
+            "type": "string"
+          },
+          "id": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "updatedAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          }
+        },
+        "required": [
+          "name"
+        ]
+      },
+      "OrganizationUpdate": {
+        "properties": {
+          "name": {
+            "type": "string"
+          }
+        }
+      },
+      "Organizations": {
+        "properties": {
+          "links": {
+            "$ref": "#/components/schemas/Links"
+          },
+          "orgs": {
+            "items": {
+              "$ref": "#/components/schemas/Organization"
+            },
+            "nullable": true,
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "Permission": {
+        "properties": {
+          "action": {
+            "enum": [
+              "read",
+              "write",
+              "create",
+              "delete"
+            ],
+            "type": "string"
+          },
+          "resource": {
+            "description": "the resource, such as user, org, backup or bucket/\u003cbucket ID\u003e",
+            "type": "string"
+          }
+        },
+        "required": [
+          "action",
+          "resource"
+        ]
+      },
+      "QueryErrors": {
+        "description": "the errors of a query that failed to compile",
+        "properties": {
+          "errors": {
+            "items": {
+              "properties": {
+                "column": {
+                  "type": "integer"
+                },
+                "line": {
+                  "type": "integer"
+                },
+                "message": {
+                  "type": "string"
+                }
+              },
+              "required": [
+                "message"
+              ]
+            },
+            "type": "array"
+          }
+        },
+        "required": [
+          "errors"
+        ]
+      },
+      "Run": {
+        "properties": {
+          "endTime": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "error": {
+            "$ref": "#/components/schemas/RunError"
+          },
+          "id": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "log": {
+            "description": "A url to a relevant log.",
+            "readOnly": true,
+            "type": "string"
+          },
+          "queuedAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "startTime": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "status": {
+            "enum": [
+              "queued",
+              "executing",
+              "failed",
+              "success"
+            ],
+            "type": "string"
+          },
+          "taskID": {
+            "readOnly": true,
+            "type": "string"
+          }
+        },
+        "required": [
+          "queuedAt",
+          "status"
+        ]
+      },
+      "RunError": {
+        "properties": {
+          "code": {
+            "format": "int32",
+            "type": "integer"
+          },
+          "message": {
+            "type": "string"
+          }
+        },
+        "readOnly": true,
+        "required": [
+          "code",
+          "message"
+        ]
+      },
+      "SingleStatVisualization": {
+        "properties": {
+          "decimalPlaces": {
+            "maximum": 10,
+            "minimum": 0,
+            "type": "integer"
+          },
+          "prefix": {
+            "type": "string"
+          },
+          "queries": {
+            "items": {
+              "$ref": "#/components/schemas/DashboardQuery"
+            },
+            "type": "array"
+          },
+          "suffix": {
+            "type": "string"
+          },
+          "thresholds": {
+            "items": {
+              "$ref": "#/components/schemas/Threshold"
+            },
+            "type": "array"
+          },
+          "type": {
+            "enum": [
+              "single-stat"
+            ],
+            "type": "string"
+          }
+        },
+        "required": [
+          "type",
+          "queries"
+        ]
+      },
+      "TableColumn": {
+        "properties": {
+          "decimalPlaces": {
+            "maximum": 10,
+            "minimum": 0,
+            "type": "integer"
+          },
+          "displayName": {
+            "type": "string"
+          },
+          "hidden": {
+            "type": "boolean"
+          },
+          "name": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "name"
+        ]
+      },
+      "TableVisualization": {
+        "properties": {
+          "columns": {
+            "items": {
+              "$ref": "#/components/schemas/TableColumn"
+            },
+            "type": "array"
+          },
+          "fixFirstColumn": {
+            "type": "boolean"
+          },
+          "queries": {
+            "items": {
+              "$ref": "#/components/schemas/DashboardQuery"
+            },
+            "type": "array"
+          },
+          "sortBy": {
+            "type": "string"
+          },
+          "timeFormat": {
+            "type": "string"
+          },
+          "type": {
+            "enum": [
+              "table"
+            ],
+            "type": "string"
+          }
+        },
+        "required": [
+          "type",
+          "queries"
+        ]
+      },
+      "Task": {
+        "properties": {
+          "cron": {
+            "description": "A task repetition schedule in the form '* * * * * *'; parsed from Flux.",
+            "readOnly": true,
+            "type": "string"
+          },
+          "every": {
+            "description": "A simple task repetition schedule; parsed from Flux.",
+            "readOnly": true,
+            "type": "string"
+          },
+          "flux": {
+            "description": "The Flux script to run for this task.",
+            "type": "string"
+          },
+          "id": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "last": {
+            "$ref": "#/components/schemas/Run"
+          },
+          "name": {
+            "description": "A modifiable description of the task.",
+            "type": "string"
+          },
+          "organizationID": {
+            "description": "The organization the Flux script is run as.",
+            "type": "string"
+          },
+          "owner": {
+            "$ref": "#/components/schemas/User"
+          },
+          "status": {
+            "description": "The current status of the task. When updated to 'disabled', cancels all queued jobs of this task.",
+            "enum": [
+              "enabled",
+              "disabled"
+            ],
+            "type": "string"
+          }
+        }
+      },
+      "TaskUpdate": {
+        "properties": {
+          "flux": {
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "status": {
+            "enum": [
+              "enabled",
+              "disabled"
+            ],
+            "type": "string"
+          }
+        }
+      },
+      "Tasks": {
+        "items": {
+          "$ref": "#/components/schemas/Task"
+        },
+        "nullable": true,
+        "type": "array"
+      },
+      "Threshold": {
+        "properties": {
+          "color": {
+            "description": "a hexadecimal RGB color, such as \"#7A65F2\"",
+            "type": "string"
+          },
+          "value": {
+            "type": "number"
+          }
+        },
+        "required": [
+          "value",
+          "color"
+        ]
+      },
+      "TimeRange": {
+        "description": "a range of time relative to now, with a duration, or absolute, with a start and optional stop",
+        "properties": {
+          "duration": {
+            "description": "a range ending now, such as \"1h\"",
+            "type": "string"
+          },
+          "start": {
+            "format": "date-time",
+            "type": "string"
+          },
+          "stop": {
+            "format": "date-time",
+            "type": "string"
+          }
+        }
+      },
+      "Usage": {
+        "properties": {
+          "bucketID": {
+            "type": "string"
+          },
+          "organizationID": {
+            "type": "string"
+          },
+          "type": {
+            "enum": [
+              "usage_write_request_count",
+              "usage_write_request_bytes"
+            ],
+            "type": "string"
+          },
+          "value": {
+            "type": "number"
+          }
+        },
+        "readOnly": true,
+        "required": [
+          "type",
+          "value"
+        ]
+      },
+      "User": {
+        "properties": {
+          "createdAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          },
+          "createdBy": {
+            "description": "ID of the user that created the resource",
+            "readOnly": true,
+            "type": "string"
+          },
+          "id": {
+            "readOnly": true,
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "updatedAt": {
+            "format": "date-time",
+            "readOnly": true,
+            "type": "string"
+          }
+        },
+        "required": [
+          "name"
+        ]
+      },
+      "UserUpdate": {
+        "properties": {
+          "name": {
+            "type": "string"
+          }
+        }
+      },
+      "Users": {
+        "properties": {
+          "links": {
+            "$ref": "#/components/schemas/Links"
+          },
+          "users": {
+            "items": {
+              "$ref": "#/components/schemas/User"
+            },
+            "nullable": true,
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "VariableValues": {
+        "properties": {
+          "values": {
+            "items": {
+              "type": "string"
+            },
+            "nullable": true,
+            "type": "array"
+          }
+        },
+        "required": [
+          "values"
+        ]
+      },
+      "Visualization": {
+        "description": "the visual representation of the data queried by a cell, of one of the types",
+        "discriminator": {
+          "mapping": {
+            "common": "#/components/schemas/CommonVisualization",
+            "gauge": "#/components/schemas/GaugeVisualization",
+            "line": "#/components/schemas/LineGraphVisualization",
+            "markdown": "#/components/schemas/MarkdownVisualization",
+            "single-stat": "#/components/schemas/SingleStatVisualization",
+            "table": "#/components/schemas/TableVisualization"
+          },
+          "propertyName": "type"
+        },
+        "oneOf": [
+          {
+            "$ref": "#/components/schemas/CommonVisualization"
+          },
+          {
+            "$ref": "#/components/schemas/LineGraphVisualization"
+          },
+          {
+            "$ref": "#/components/schemas/SingleStatVisualization"
+          },
+          {
+            "$ref": "#/components/schemas/GaugeVisualization"
+          },
+          {
+            "$ref": "#/components/schemas/TableVisualization"
+          },
+          {
+            "$ref": "#/components/schemas/MarkdownVisualization"
+          }
+        ]
+      }
+    },
+    "securitySchemes": {
+      "TokenAuthentication": {
+        "description": "the token of an authorization, as \"Token \u003ctoken\u003e\"",
+        "in": "header",
+        "name": "Authorization",
+        "type": "apiKey"
+      }
+    }
+  },
+  "info": {
+    "title": "Gateway Service",
+    "version": "0.1.0"
+  },
+  "openapi": "3.0.0",
+  "paths": {
+    "/audit": {
+      "get": {
+        "parameters": [
+          {
+            "description": "only changes to the resource with this ID",
+            "in": "query",
+            "name": "resource",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only changes to resources of this type",
+            "in": "query",
+            "name": "resourceType",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only changes made by the user with this ID",
+            "in": "query",
+            "name": "user",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only changes made at or after this time",
+            "in": "query",
+            "name": "since",
+            "schema": {
+              "format": "date-time",
+              "type": "string"
+            }
+          },
+          {
+            "$ref": "#/components/parameters/Limit"
+          },
+          {
+            "$ref": "#/components/parameters/Offset"
+          },
+          {
+            "$ref": "#/components/parameters/SortBy"
+          },
+          {
+            "$ref": "#/components/parameters/Descending"
+          },
+          {
+            "$ref": "#/components/parameters/After"
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/AuditEvents"
+                }
+              }
+            },
+            "description": "a list of audit events"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List the changes made to resources, in the order they were made",
+        "tags": [
+          "Audit"
+        ]
+      }
+    },
+    "/authorizations": {
+      "get": {
+        "parameters": [
+          {
+            "description": "only authorizations of the user with this ID",
+            "in": "query",
+            "name": "userID",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only authorizations of the user with this name",
+            "in": "query",
+            "name": "user",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only the authorization with this ID",
+            "in": "query",
+            "name": "id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "$ref": "#/components/parameters/Limit"
+          },
+          {
+            "$ref": "#/components/parameters/Offset"
+          },
+          {
+            "$ref": "#/components/parameters/SortBy"
+          },
+          {
+            "$ref": "#/components/parameters/Descending"
+          },
+          {
+            "$ref": "#/components/parameters/After"
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Authorizations"
+                }
+              }
+            },
+            "description": "a list of authorizations"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List all authorizations",
+        "tags": [
+          "Authorizations"
+        ]
+      },
+      "post": {
+        "description": "Creates an authorization, the token of which is generated by the server. Granting the read:backup permission requires it.",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Authorization"
+              }
+            }
+          },
+          "description": "authorization to create",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Authorization"
+                }
+              }
+            },
+            "description": "Authorization created"
+          },
+          "403": {
+            "$ref": "#/components/responses/Error"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Create an authorization",
+        "tags": [
+          "Authorizations"
+        ]
+      }
+    },
+    "/authorizations/{authorizationId}": {
+      "delete": {
+        "parameters": [
+          {
+            "description": "ID of authorization to delete",
+            "in": "path",
+            "name": "authorizationId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "authorization deleted"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Delete an authorization",
+        "tags": [
+          "Authorizations"
+        ]
+      },
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of authorization to get",
+            "in": "path",
+            "name": "authorizationId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Authorization"
+                }
+              }
+            },
+            "description": "authorization details"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve an authorization",
+        "tags": [
+          "Authorizations"
+        ]
+      }
+    },
+    "/backup": {
+      "get": {
+        "description": "Returns a consistent copy of the bolt database. Requires the read:backup permission.",
+        "responses": {
+          "200": {
+            "content": {
+              "application/octet-stream": {
+                "schema": {
+                  "format": "binary",
+                  "type": "string"
+                }
+              }
+            },
+            "description": "a copy of the bolt database"
+          },
+          "403": {
+            "$ref": "#/components/responses/Error"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Back up the metadata store",
+        "tags": [
+          "Backup"
+        ]
+      }
+    },
+    "/buckets": {
+      "get": {
+        "parameters": [
+          {
+            "description": "only buckets of the organization with this ID",
+            "in": "query",
+            "name": "orgID",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only buckets of the organization with this name",
+            "in": "query",
+            "name": "org",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only the bucket with this ID",
+            "in": "query",
+            "name": "id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only buckets with this name",
+            "in": "query",
+            "name": "name",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "$ref": "#/components/parameters/Limit"
+          },
+          {
+            "$ref": "#/components/parameters/Offset"
+          },
+          {
+            "$ref": "#/components/parameters/SortBy"
+          },
+          {
+            "$ref": "#/components/parameters/Descending"
+          },
+          {
+            "$ref": "#/components/parameters/After"
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Buckets"
+                }
+              }
+            },
+            "description": "a list of buckets"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List all buckets",
+        "tags": [
+          "Buckets"
+        ]
+      },
+      "post": {
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Bucket"
+              }
+            }
+          },
+          "description": "bucket to create",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Bucket"
+                }
+              }
+            },
+            "description": "Bucket created"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Create a bucket",
+        "tags": [
+          "Buckets"
+        ]
+      }
+    },
+    "/buckets/{bucketId}": {
+      "delete": {
+        "parameters": [
+          {
+            "description": "ID of bucket to delete",
+            "in": "path",
+            "name": "bucketId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "bucket deleted"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Delete a bucket",
+        "tags": [
+          "Buckets"
+        ]
+      },
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of bucket to get",
+            "in": "path",
+            "name": "bucketId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Bucket"
+                }
+              }
+            },
+            "description": "bucket details"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve a bucket",
+        "tags": [
+          "Buckets"
+        ]
+      },
+      "patch": {
+        "parameters": [
+          {
+            "description": "ID of bucket to update",
+            "in": "path",
+            "name": "bucketId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/BucketUpdate"
+              }
+            }
+          },
+          "description": "bucket update to apply",
+          "required": true
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Bucket"
+                }
+              }
+            },
+            "description": "An updated bucket"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Update a bucket",
+        "tags": [
+          "Buckets"
+        ]
+      }
+    },
+    "/dashboards": {
+      "get": {
+        "parameters": [
+          {
+            "description": "only dashboards of the organization with this ID",
+            "in": "query",
+            "name": "orgID",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only dashboards of the organization with this name",
+            "in": "query",
+            "name": "org",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only the dashboard with this ID",
+            "in": "query",
+            "name": "id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "$ref": "#/components/parameters/Limit"
+          },
+          {
+            "$ref": "#/components/parameters/Offset"
+          },
+          {
+            "$ref": "#/components/parameters/SortBy"
+          },
+          {
+            "$ref": "#/components/parameters/Descending"
+          },
+          {
+            "$ref": "#/components/parameters/After"
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Dashboards"
+                }
+              }
+            },
+            "description": "a list of dashboards"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List all dashboards",
+        "tags": [
+          "Dashboards"
+        ]
+      },
+      "post": {
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Dashboard"
+              }
+            }
+          },
+          "description": "dashboard to create",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Dashboard"
+                }
+              }
+            },
+            "description": "Dashboard created"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Create a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/import": {
+      "post": {
+        "description": "Creates a dashboard from an export in the organization of the options. The buckets read by the queries of the dashboard must exist in the organization.\n",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/DashboardImport"
+              }
+            }
+          },
+          "description": "dashboard export and the options of importing it",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Dashboard"
+                }
+              }
+            },
+            "description": "Dashboard imported"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Import a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/{dashboardId}": {
+      "delete": {
+        "parameters": [
+          {
+            "description": "ID of dashboard to delete",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "dashboard deleted"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Delete a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      },
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of dashboard to get",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Dashboard"
+                }
+              }
+            },
+            "description": "dashboard details"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      },
+      "patch": {
+        "parameters": [
+          {
+            "description": "ID of dashboard to update",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/DashboardUpdate"
+              }
+            }
+          },
+          "description": "dashboard update to apply",
+          "required": true
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Dashboard"
+                }
+              }
+            },
+            "description": "An updated dashboard"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Update a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/{dashboardId}/cells": {
+      "post": {
+        "parameters": [
+          {
+            "description": "ID of dashboard to add the cell to",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Cell"
+              }
+            }
+          },
+          "description": "cell to add",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Cell"
+                }
+              }
+            },
+            "description": "Cell added"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Add a cell to a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/{dashboardId}/cells/{cellId}": {
+      "delete": {
+        "parameters": [
+          {
+            "description": "ID of dashboard of the cell",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "ID of cell to remove",
+            "in": "path",
+            "name": "cellId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "cell removed"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Remove a cell from a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      },
+      "put": {
+        "parameters": [
+          {
+            "description": "ID of dashboard of the cell",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "ID of cell to replace, which must be the ID of the cell in the body",
+            "in": "path",
+            "name": "cellId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Cell"
+              }
+            }
+          },
+          "description": "cell that replaces the cell",
+          "required": true
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Cell"
+                }
+              }
+            },
+            "description": "Cell replaced"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Replace a cell of a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/{dashboardId}/cells/{cellId}/query": {
+      "post": {
+        "description": "Runs a query of the cell with the selected values of the dashboard variables declared in its scope. Variables without a selected value have their default value.\n",
+        "parameters": [
+          {
+            "description": "ID of dashboard of the cell",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "ID of cell to query",
+            "in": "path",
+            "name": "cellId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/CellQuery"
+              }
+            }
+          },
+          "description": "the query to run and the selected values of the variables"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "text/csv": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            },
+            "description": "the results of the query"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/QueryErrors"
+                }
+              }
+            },
+            "description": "the query is invalid",
+            "headers": {
+              "X-Influx-Error": {
+                "$ref": "#/components/headers/X-Influx-Error"
+              },
+              "X-Influx-Reference": {
+                "$ref": "#/components/headers/X-Influx-Reference"
+              }
+            }
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Run a query of a cell",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/{dashboardId}/clone": {
+      "post": {
+        "description": "Creates a copy of the dashboard, in the organization of the dashboard unless the options name another.",
+        "parameters": [
+          {
+            "description": "ID of dashboard to clone",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/DashboardImportOptions"
+              }
+            }
+          },
+          "description": "the options of the copy"
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Dashboard"
+                }
+              }
+            },
+            "description": "Dashboard cloned"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Clone a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/{dashboardId}/export": {
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of dashboard to export",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/DashboardExport"
+                }
+              }
+            },
+            "description": "the dashboard in a portable format"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Export a dashboard",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/dashboards/{dashboardId}/variables/{variableName}/values": {
+      "get": {
+        "description": "Returns the values of a constant variable, or the values returned by the query of a query variable.",
+        "parameters": [
+          {
+            "description": "ID of dashboard of the variable",
+            "in": "path",
+            "name": "dashboardId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "name of the variable",
+            "in": "path",
+            "name": "variableName",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/VariableValues"
+                }
+              }
+            },
+            "description": "the values of the variable"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List the values of a dashboard variable",
+        "tags": [
+          "Dashboards"
+        ]
+      }
+    },
+    "/orgs": {
+      "get": {
+        "parameters": [
+          {
+            "description": "only the organization with this ID",
+            "in": "query",
+            "name": "id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only the organization with this name",
+            "in": "query",
+            "name": "name",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "$ref": "#/components/parameters/Limit"
+          },
+          {
+            "$ref": "#/components/parameters/Offset"
+          },
+          {
+            "$ref": "#/components/parameters/SortBy"
+          },
+          {
+            "$ref": "#/components/parameters/Descending"
+          },
+          {
+            "$ref": "#/components/parameters/After"
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Organizations"
+                }
+              }
+            },
+            "description": "a list of organizations"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List all organizations",
+        "tags": [
+          "Organizations"
+        ]
+      },
+      "post": {
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Organization"
+              }
+            }
+          },
+          "description": "organization to create",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Organization"
+                }
+              }
+            },
+            "description": "Organization created"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Create an organization",
+        "tags": [
+          "Organizations"
+        ]
+      }
+    },
+    "/orgs/{orgId}": {
+      "delete": {
+        "parameters": [
+          {
+            "description": "ID of organization to delete",
+            "in": "path",
+            "name": "orgId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "organization deleted"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Delete an organization",
+        "tags": [
+          "Organizations"
+        ]
+      },
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of organization to get",
+            "in": "path",
+            "name": "orgId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Organization"
+                }
+              }
+            },
+            "description": "organization details"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve an organization",
+        "tags": [
+          "Organizations"
+        ]
+      },
+      "patch": {
+        "parameters": [
+          {
+            "description": "ID of organization to update",
+            "in": "path",
+            "name": "orgId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationUpdate"
+              }
+            }
+          },
+          "description": "organization update to apply",
+          "required": true
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Organization"
+                }
+              }
+            },
+            "description": "organization updated"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Update an organization",
+        "tags": [
+          "Organizations"
+        ]
+      }
+    },
+    "/query": {
+      "post": {
+        "description": "Runs a Flux query, passed in the q parameter, or a query spec, passed as the JSON body of the request, as the organization of the orgID or orgName parameter. The parameters may be passed in the URL or in a form body.\n",
+        "parameters": [
+          {
+            "description": "ID of the organization the query is run as",
+            "in": "query",
+            "name": "orgID",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "name of the organization the query is run as",
+            "in": "query",
+            "name": "orgName",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "the Flux query to run",
+            "in": "query",
+            "name": "q",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "bypass the query cache",
+            "in": "header",
+            "name": "Cache-Control",
+            "schema": {
+              "enum": [
+                "no-cache"
+              ],
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "description": "a query spec",
+                "type": "object"
+              }
+            },
+            "application/x-www-form-urlencoded": {
+              "schema": {
+                "properties": {
+                  "orgID": {
+                    "type": "string"
+                  },
+                  "orgName": {
+                    "type": "string"
+                  },
+                  "q": {
+                    "type": "string"
+                  }
+                },
+                "type": "object"
+              }
+            }
+          },
+          "description": "the query spec to run, or the parameters as a form"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "text/csv": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            },
+            "description": "the results of the query"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/QueryErrors"
+                }
+              }
+            },
+            "description": "the query is invalid",
+            "headers": {
+              "X-Influx-Error": {
+                "$ref": "#/components/headers/X-Influx-Error"
+              },
+              "X-Influx-Reference": {
+                "$ref": "#/components/headers/X-Influx-Reference"
+              }
+            }
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Query data",
+        "tags": [
+          "Query"
+        ]
+      }
+    },
+    "/swagger.json": {
+      "get": {
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            },
+            "description": "the OpenAPI spec of the API in JSON"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "security": [],
+        "summary": "Retrieve this spec of the API",
+        "tags": [
+          "Spec"
+        ]
+      }
+    },
+    "/tasks": {
+      "get": {
+        "description": "Lists tasks, limit 100",
+        "parameters": [
+          {
+            "$ref": "#/components/parameters/Limit"
+          },
+          {
+            "$ref": "#/components/parameters/Offset"
+          },
+          {
+            "$ref": "#/components/parameters/SortBy"
+          },
+          {
+            "$ref": "#/components/parameters/Descending"
+          },
+          {
+            "$ref": "#/components/parameters/After"
+          },
+          {
+            "description": "filter tasks to a specific user id",
+            "in": "query",
+            "name": "user",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "filter tasks to a specific organization id",
+            "in": "query",
+            "name": "organization",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "properties": {
+                    "links": {
+                      "$ref": "#/components/schemas/Links"
+                    },
+                    "tasks": {
+                      "$ref": "#/components/schemas/Tasks"
+                    }
+                  },
+                  "type": "object"
+                }
+              }
+            },
+            "description": "A list of tasks"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List tasks.",
+        "tags": [
+          "Tasks"
+        ]
+      },
+      "post": {
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Task"
+              }
+            }
+          },
+          "description": "task to create",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Task"
+                }
+              }
+            },
+            "description": "Task created"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Create a new task",
+        "tags": [
+          "Tasks"
+        ]
+      }
+    },
+    "/tasks/{taskId}": {
+      "delete": {
+        "description": "Deletes a task and all associated records",
+        "parameters": [
+          {
+            "description": "ID of task to delete",
+            "in": "path",
+            "name": "taskId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "task deleted"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Delete a task",
+        "tags": [
+          "Tasks"
+        ]
+      },
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of task to get",
+            "in": "path",
+            "name": "taskId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Task"
+                }
+              }
+            },
+            "description": "task details"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve an task",
+        "tags": [
+          "Tasks"
+        ]
+      },
+      "patch": {
+        "description": "Update a task. This will cancel all queued runs.",
+        "parameters": [
+          {
+            "description": "ID of task to get",
+            "in": "path",
+            "name": "taskId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TaskUpdate"
+              }
+            }
+          },
+          "description": "task update to apply",
+          "required": true
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Task"
+                }
+              }
+            },
+            "description": "task updated"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Update a task",
+        "tags": [
+          "Tasks"
+        ]
+      }
+    },
+    "/tasks/{taskId}/logs": {
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of task to get logs for",
+            "in": "path",
+            "name": "taskId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Filters logs to a specific run.",
+            "in": "query",
+            "name": "run",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "properties": {
+                    "logs": {
+                      "$ref": "#/components/schemas/Logs"
+                    }
+                  },
+                  "type": "object"
+                }
+              }
+            },
+            "description": "all logs for a task"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve all logs for a task",
+        "tags": [
+          "Tasks"
+        ]
+      }
+    },
+    "/tasks/{taskId}/runs": {
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of task to get runs for",
+            "in": "path",
+            "name": "taskId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "returns runs after specified ID",
+            "in": "query",
+            "name": "after",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "the number of runs to return",
+            "in": "query",
+            "name": "limit",
+            "schema": {
+              "default": 20,
+              "maximum": 100,
+              "minimum": 1,
+              "type": "integer"
+            }
+          },
+          {
+            "description": "filter runs to those queued after this time",
+            "in": "query",
+            "name": "afterTime",
+            "schema": {
+              "format": "date-time",
+              "type": "string"
+            }
+          },
+          {
+            "description": "filter runs to those queued before this time",
+            "in": "query",
+            "name": "beforeTime",
+            "schema": {
+              "format": "date-time",
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "properties": {
+                    "links": {
+                      "$ref": "#/components/schemas/Links"
+                    },
+                    "runs": {
+                      "items": {
+                        "$ref": "#/components/schemas/Run"
+                      },
+                      "nullable": true,
+                      "type": "array"
+                    }
+                  },
+                  "type": "object"
+                }
+              }
+            },
+            "description": "a list of task runs"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve list of run records for a task",
+        "tags": [
+          "Tasks"
+        ]
+      }
+    },
+    "/tasks/{taskId}/runs/{runId}": {
+      "get": {
+        "parameters": [
+          {
+            "description": "task ID",
+            "in": "path",
+            "name": "taskId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "run ID",
+            "in": "path",
+            "name": "runId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Run"
+                }
+              }
+            },
+            "description": "The run record"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve a single run record for a task",
+        "tags": [
+          "Tasks"
+        ]
+      }
+    },
+    "/tasks/{taskId}/runs/{runId}/retry": {
+      "post": {
+        "parameters": [
+          {
+            "description": "task ID",
+            "in": "path",
+            "name": "taskId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "run ID",
+            "in": "path",
+            "name": "runId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Run"
+                }
+              }
+            },
+            "description": "The newly created retry run"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retry a task run",
+        "tags": [
+          "Tasks"
+        ]
+      }
+    },
+    "/usage": {
+      "get": {
+        "description": "Returns the usage of the current month, unless a start and stop time are both passed.",
+        "parameters": [
+          {
+            "description": "only the usage of the organization with this ID",
+            "in": "query",
+            "name": "orgID",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only the usage of the bucket with this ID",
+            "in": "query",
+            "name": "bucketID",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "start of the time range of the usage, required with stop",
+            "in": "query",
+            "name": "start",
+            "schema": {
+              "format": "date-time",
+              "type": "string"
+            }
+          },
+          {
+            "description": "stop of the time range of the usage, required with start",
+            "in": "query",
+            "name": "stop",
+            "schema": {
+              "format": "date-time",
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "additionalProperties": {
+                    "$ref": "#/components/schemas/Usage"
+                  },
+                  "type": "object"
+                }
+              }
+            },
+            "description": "the usage by metric"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve usage statistics",
+        "tags": [
+          "Usage"
+        ]
+      }
+    },
+    "/users": {
+      "get": {
+        "parameters": [
+          {
+            "description": "only the user with this ID",
+            "in": "query",
+            "name": "id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "only the user with this name",
+            "in": "query",
+            "name": "name",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "$ref": "#/components/parameters/Limit"
+          },
+          {
+            "$ref": "#/components/parameters/Offset"
+          },
+          {
+            "$ref": "#/components/parameters/SortBy"
+          },
+          {
+            "$ref": "#/components/parameters/Descending"
+          },
+          {
+            "$ref": "#/components/parameters/After"
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Users"
+                }
+              }
+            },
+            "description": "a list of users"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "List all users",
+        "tags": [
+          "Users"
+        ]
+      },
+      "post": {
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/User"
+              }
+            }
+          },
+          "description": "user to create",
+          "required": true
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/User"
+                }
+              }
+            },
+            "description": "User created"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Create a user",
+        "tags": [
+          "Users"
+        ]
+      }
+    },
+    "/users/{userId}": {
+      "delete": {
+        "parameters": [
+          {
+            "description": "ID of user to delete",
+            "in": "path",
+            "name": "userId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "user deleted"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Delete a user",
+        "tags": [
+          "Users"
+        ]
+      },
+      "get": {
+        "parameters": [
+          {
+            "description": "ID of user to get",
+            "in": "path",
+            "name": "userId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/User"
+                }
+              }
+            },
+            "description": "user details"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Retrieve a user",
+        "tags": [
+          "Users"
+        ]
+      },
+      "patch": {
+        "parameters": [
+          {
+            "description": "ID of user to update",
+            "in": "path",
+            "name": "userId",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UserUpdate"
+              }
+            }
+          },
+          "description": "user update to apply",
+          "required": true
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/User"
+                }
+              }
+            },
+            "description": "user updated"
+          },
+          "default": {
+            "$ref": "#/components/responses/Error"
+          }
+        },
+        "summary": "Update a user",
+        "tags": [
+          "Users"
+        ]
+      }
+    }
+  },
+  "security": [
+    {
+      "TokenAuthentication": []
+    }
+  ],
+  "servers": [
+    {
+      "url": "/v1"
+    }
+  ]
+}`)
@@ -0,0 +1,405 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const testTaskFlux = `option task = {name: "hourly", every: 1h}
+from(bucket: "telegraf") |> range(start: -1h)`
+
+type testQueryService struct{}
+
+func (testQueryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.ResultIterator, error) {
+	return query.NewSliceResultIterator(nil), nil
+}
+
+func (testQueryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.ResultIterator, error) {
+	return query.NewSliceResultIterator(nil), nil
+}
+
+type testUsageService struct{}
+
+func (testUsageService) GetUsage(ctx context.Context, filter platform.UsageFilter) (map[platform.UsageMetric]*platform.Usage, error) {
+	return map[platform.UsageMetric]*platform.Usage{
+		platform.UsageWriteRequestCount: {OrganizationID: filter.OrgID, Type: platform.UsageWriteRequestCount, Value: 10},
+	}, nil
+}
+
+func TestSwagger_Generated(t *testing.T) {
+	b, err := ioutil.ReadFile("swagger.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec interface{}
+	if err := yaml.Unmarshal(b, &spec); err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.Marshal(stringKeys(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got, exp interface{}
+	if err := json.Unmarshal(swaggerJSON, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(want, &exp); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Error("swagger_gen.go is out of date with swagger.yml, run make")
+	}
+}
+
+// stringKeys converts the maps decoded from YAML to maps with string keys.
+func stringKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			m[fmt.Sprint(k)] = stringKeys(x)
+		}
+		return m
+	case []interface{}:
+		for i, x := range v {
+			v[i] = stringKeys(x)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// TestPlatformHandler_Spec runs a request of every operation of the spec against the handlers,
+// and validates the requests and responses against the spec.
+func TestPlatformHandler_Spec(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := ioutil.TempFile("", "influxdata-platform-http-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(c.Path)
+	defer c.Close()
+
+	org := &platform.Organization{Name: "org"}
+	doomedOrg := &platform.Organization{Name: "doomed"}
+	for _, o := range []*platform.Organization{org, doomedOrg} {
+		if err := c.CreateOrganization(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	user := &platform.User{Name: "user"}
+	doomedUser := &platform.User{Name: "doomed"}
+	for _, u := range []*platform.User{user, doomedUser} {
+		if err := c.CreateUser(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	auth := &platform.Authorization{UserID: user.ID, Permissions: []platform.Permission{platform.ReadBackupPermission}}
+	doomedAuth := &platform.Authorization{UserID: user.ID}
+	for _, a := range []*platform.Authorization{auth, doomedAuth} {
+		if err := c.CreateAuthorization(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bucket := &platform.Bucket{OrganizationID: org.ID, Name: "telegraf"}
+	doomedBucket := &platform.Bucket{OrganizationID: org.ID, Name: "doomed"}
+	for _, b := range []*platform.Bucket{bucket, doomedBucket} {
+		if err := c.CreateBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dashboard := &platform.Dashboard{
+		OrganizationID: org.ID,
+		Name:           "hosts",
+		Variables: []platform.DashboardVariable{
+			{Name: "host", Type: platform.ConstantVariableType, Values: []string{"a", "b"}},
+		},
+		Cells: []platform.DashboardCell{
+			{
+				DashboardCellContents: platform.DashboardCellContents{Name: "cpu", W: 4, H: 4},
+				Visualization: platform.LineGraphVisualization{
+					Queries: []platform.DashboardQuery{{Query: `from(bucket:"telegraf") |> range(start:-1h) |> filter(fn: (r) => r.host == host)`}},
+				},
+			},
+			{
+				DashboardCellContents: platform.DashboardCellContents{Name: "note", W: 4, H: 2},
+				Visualization:         platform.MarkdownVisualization{Note: "hosts"},
+			},
+		},
+	}
+	doomedDashboard := &platform.Dashboard{OrganizationID: org.ID, Name: "doomed"}
+	for _, d := range []*platform.Dashboard{dashboard, doomedDashboard} {
+		if err := c.CreateDashboard(ctx, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	task := &platform.Task{OrganizationID: org.ID, Flux: testTaskFlux}
+	doomedTask := &platform.Task{OrganizationID: org.ID, Flux: testTaskFlux}
+	for _, task := range []*platform.Task{task, doomedTask} {
+		if err := c.CreateTask(ctx, task); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run := &platform.Run{TaskID: task.ID, Status: platform.RunSuccess, QueuedAt: time.Now().UTC()}
+	if err := c.CreateRun(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddRunLog(ctx, task.ID, &platform.Log{RunID: run.ID, Time: time.Now().UTC(), Message: "started"}); err != nil {
+		t.Fatal(err)
+	}
+
+	dashboardHandler := NewDashboardHandler()
+	dashboardHandler.DashboardService = c
+	dashboardHandler.BucketService = c
+	dashboardHandler.QueryService = testQueryService{}
+	backupHandler := NewBackupHandler()
+	backupHandler.BackupService = c
+	backupHandler.AuthorizationService = c
+	bucketHandler := NewBucketHandler()
+	bucketHandler.BucketService = c
+	orgHandler := NewOrgHandler()
+	orgHandler.OrganizationService = c
+	userHandler := NewUserHandler()
+	userHandler.UserService = c
+	authHandler := NewAuthorizationHandler()
+	authHandler.AuthorizationService = c
+	taskHandler := NewTaskHandler()
+	taskHandler.TaskService = c
+	auditHandler := NewAuditHandler()
+	auditHandler.AuditService = c
+	usageHandler := NewUsageHandler()
+	usageHandler.UsageService = testUsageService{}
+	queryHandler := NewQueryHandler()
+	queryHandler.QueryService = testQueryService{}
+	queryHandler.OrganizationService = c
+
+	mux := http.NewServeMux()
+	mux.Handle("/", &PlatformHandler{
+		BucketHandler:        bucketHandler,
+		UserHandler:          userHandler,
+		OrgHandler:           orgHandler,
+		AuthorizationHandler: authHandler,
+		DashboardHandler:     dashboardHandler,
+		TaskHandler:          taskHandler,
+		BackupHandler:        backupHandler,
+		AuditHandler:         auditHandler,
+		AuthorizationService: c,
+	})
+	mux.Handle("/v1/usage", usageHandler)
+	mux.Handle(queryPath, queryHandler)
+
+	export, err := json.Marshal(platform.NewDashboardExport(dashboard, []string{"telegraf"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cell := dashboard.Cells[0]
+	cell.Name = "load"
+	replacement, err := json.Marshal(cell)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flux, err := json.Marshal(testTaskFlux)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queryParams := url.Values{"orgID": {org.ID.String()}, "q": {`from(bucket:"telegraf") |> range(start:-1h)`}}.Encode()
+	dashboardPath := "/v1/dashboards/" + dashboard.ID.String()
+	cellPath := dashboardPath + "/cells/" + dashboard.Cells[0].ID.String()
+	taskPath := "/v1/tasks/" + task.ID.String()
+	runPath := taskPath + "/runs/" + run.ID.String()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		// op is the operation of the spec the request is of.
+		op          string
+		method      string
+		path        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{op: "GET /v1/audit", method: "GET", path: "/v1/audit?resourceType=bucket&limit=10", status: 200},
+		{op: "GET /v1/authorizations", method: "GET", path: "/v1/authorizations?user=user", status: 200},
+		{op: "POST /v1/authorizations", method: "POST", path: "/v1/authorizations", body: `{"user": "user", "permissions": [{"action": "read", "resource": "org"}]}`, status: 201},
+		{op: "GET /v1/authorizations/{authorizationId}", method: "GET", path: "/v1/authorizations/" + auth.ID.String(), status: 200},
+		{op: "GET /v1/backup", method: "GET", path: "/v1/backup", status: 200},
+		{op: "GET /v1/buckets", method: "GET", path: "/v1/buckets?orgID=" + org.ID.String(), status: 200},
+		{op: "POST /v1/buckets", method: "POST", path: "/v1/buckets", body: fmt.Sprintf(`{"organizationID": %q, "name": "metrics", "retentionPeriod": 3600000000000}`, org.ID), status: 201},
+		{op: "GET /v1/buckets/{bucketId}", method: "GET", path: "/v1/buckets/" + bucket.ID.String(), status: 200},
+		{op: "PATCH /v1/buckets/{bucketId}", method: "PATCH", path: "/v1/buckets/" + bucket.ID.String(), body: `{"retentionPeriod": 0}`, status: 200},
+		{op: "GET /v1/dashboards", method: "GET", path: "/v1/dashboards?org=org", status: 200},
+		{op: "POST /v1/dashboards", method: "POST", path: "/v1/dashboards", body: fmt.Sprintf(`{"organizationID": %q, "name": "stats", "cells": [{"w": 2, "h": 2, "visualization": {"type": "single-stat", "queries": [{"query": "from(bucket:\"telegraf\") |> range(start:-1h)"}], "decimalPlaces": 2}}]}`, org.ID), status: 201},
+		{op: "POST /v1/dashboards/import", method: "POST", path: "/v1/dashboards/import", body: fmt.Sprintf(`{"organization": "org", "name": "imported", "dashboard": %s}`, export), status: 201},
+		{op: "GET /v1/dashboards/{dashboardId}", method: "GET", path: dashboardPath, status: 200},
+		{op: "PATCH /v1/dashboards/{dashboardId}", method: "PATCH", path: dashboardPath, body: `{"name": "servers"}`, status: 200},
+		{op: "POST /v1/dashboards/{dashboardId}/cells", method: "POST", path: dashboardPath + "/cells", body: `{"name": "gauge", "w": 2, "h": 2, "visualization": {"type": "gauge", "queries": [{"query": "from(bucket:\"telegraf\") |> range(start:-1h)"}], "min": 0, "max": 100}}`, status: 201},
+		{op: "PUT /v1/dashboards/{dashboardId}/cells/{cellId}", method: "PUT", path: cellPath, body: string(replacement), status: 200},
+		{op: "POST /v1/dashboards/{dashboardId}/cells/{cellId}/query", method: "POST", path: cellPath + "/query", body: `{"index": 0, "variables": {"host": "b"}}`, status: 200},
+		{op: "POST /v1/dashboards/{dashboardId}/clone", method: "POST", path: dashboardPath + "/clone", body: `{"name": "copy"}`, status: 201},
+		{op: "GET /v1/dashboards/{dashboardId}/export", method: "GET", path: dashboardPath + "/export", status: 200},
+		{op: "GET /v1/dashboards/{dashboardId}/variables/{variableName}/values", method: "GET", path: dashboardPath + "/variables/host/values", status: 200},
+		{op: "GET /v1/orgs", method: "GET", path: "/v1/orgs?name=org", status: 200},
+		{op: "POST /v1/orgs", method: "POST", path: "/v1/orgs", body: `{"name": "other"}`, status: 201},
+		{op: "GET /v1/orgs/{orgId}", method: "GET", path: "/v1/orgs/" + org.ID.String(), status: 200},
+		{op: "PATCH /v1/orgs/{orgId}", method: "PATCH", path: "/v1/orgs/" + org.ID.String(), body: `{"name": "renamed"}`, status: 200},
+		{op: "POST /v1/query", method: "POST", path: "/v1/query?" + queryParams, status: 200},
+		{op: "POST /v1/query", method: "POST", path: "/v1/query", contentType: "application/x-www-form-urlencoded", body: queryParams, status: 200},
+		{op: "POST /v1/query", method: "POST", path: "/v1/query?orgID=" + org.ID.String(), body: `{"operations": [], "edges": []}`, status: 200},
+		{op: "GET /v1/swagger.json", method: "GET", path: "/v1/swagger.json", status: 200},
+		{op: "GET /v1/tasks", method: "GET", path: "/v1/tasks?organization=" + org.ID.String(), status: 200},
+		{op: "POST /v1/tasks", method: "POST", path: "/v1/tasks", body: fmt.Sprintf(`{"organizationID": %q, "flux": %s}`, org.ID, flux), status: 201},
+		{op: "GET /v1/tasks/{taskId}", method: "GET", path: taskPath, status: 200},
+		{op: "PATCH /v1/tasks/{taskId}", method: "PATCH", path: taskPath, body: `{"status": "disabled"}`, status: 200},
+		{op: "GET /v1/tasks/{taskId}/logs", method: "GET", path: taskPath + "/logs?run=" + run.ID.String(), status: 200},
+		{op: "GET /v1/tasks/{taskId}/runs", method: "GET", path: taskPath + "/runs?limit=10", status: 200},
+		{op: "GET /v1/tasks/{taskId}/runs/{runId}", method: "GET", path: runPath, status: 200},
+		{op: "POST /v1/tasks/{taskId}/runs/{runId}/retry", method: "POST", path: runPath + "/retry", status: 200},
+		{op: "GET /v1/usage", method: "GET", path: fmt.Sprintf("/v1/usage?orgID=%s&start=%s&stop=%s", org.ID, now.Add(-time.Hour).Format(time.RFC3339), now.Format(time.RFC3339)), status: 200},
+		{op: "GET /v1/users", method: "GET", path: "/v1/users?name=user", status: 200},
+		{op: "POST /v1/users", method: "POST", path: "/v1/users", body: `{"name": "other"}`, status: 201},
+		{op: "GET /v1/users/{userId}", method: "GET", path: "/v1/users/" + user.ID.String(), status: 200},
+		{op: "PATCH /v1/users/{userId}", method: "PATCH", path: "/v1/users/" + user.ID.String(), body: `{"name": "renamed"}`, status: 200},
+
+		{op: "DELETE /v1/authorizations/{authorizationId}", method: "DELETE", path: "/v1/authorizations/" + doomedAuth.ID.String(), status: 202},
+		{op: "DELETE /v1/buckets/{bucketId}", method: "DELETE", path: "/v1/buckets/" + doomedBucket.ID.String(), status: 202},
+		{op: "DELETE /v1/dashboards/{dashboardId}/cells/{cellId}", method: "DELETE", path: dashboardPath + "/cells/" + dashboard.Cells[1].ID.String(), status: 202},
+		{op: "DELETE /v1/dashboards/{dashboardId}", method: "DELETE", path: "/v1/dashboards/" + doomedDashboard.ID.String(), status: 202},
+		{op: "DELETE /v1/orgs/{orgId}", method: "DELETE", path: "/v1/orgs/" + doomedOrg.ID.String(), status: 202},
+		{op: "DELETE /v1/tasks/{taskId}", method: "DELETE", path: "/v1/tasks/" + doomedTask.ID.String(), status: 202},
+		{op: "DELETE /v1/users/{userId}", method: "DELETE", path: "/v1/users/" + doomedUser.ID.String(), status: 202},
+	}
+
+	spec := PlatformSpec()
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		covered[tt.op] = true
+		t.Run(tt.op, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			r.Header.Set("Authorization", tokenScheme+auth.Token)
+			if tt.body != "" {
+				contentType := tt.contentType
+				if contentType == "" {
+					contentType = "application/json"
+				}
+				r.Header.Set("Content-Type", contentType)
+			}
+
+			route, _, err := spec.route(r.Method, r.URL.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op := route.method + " " + spec.prefix + route.path; op != tt.op {
+				t.Fatalf("expected request of operation %s, got %s", tt.op, op)
+			}
+			if err := spec.ValidateRequest(r); err != nil {
+				t.Fatalf("invalid request: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, w.Code, w.Header().Get("X-Influx-Error"))
+			}
+			if err := spec.ValidateResponse(r, w.Code, w.Header(), w.Body.Bytes()); err != nil {
+				t.Errorf("invalid response: %v\n%s", err, w.Body.String())
+			}
+		})
+	}
+
+	for _, op := range spec.Operations() {
+		if !covered[op] {
+			t.Errorf("operation %s is not tested", op)
+		}
+		delete(covered, op)
+	}
+	for op := range covered {
+		t.Errorf("operation %s is not in the spec", op)
+	}
+}
+
+func TestPlatformHandler_Swagger(t *testing.T) {
+	h := &PlatformHandler{}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", swaggerPath, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if !bytes.Equal(w.Body.Bytes(), swaggerJSON) {
+		t.Error("expected the spec in JSON")
+	}
+}
+
+func TestHandler_ValidationSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{name: "valid", method: "POST", path: "/v1/buckets", body: `{"name": "telegraf", "retentionPeriod": 0}`, status: http.StatusTeapot},
+		{name: "preflight", method: "OPTIONS", path: "/v1/buckets", status: http.StatusTeapot},
+		{name: "unknown path", method: "GET", path: "/v1/things", status: http.StatusNotFound},
+		{name: "unknown method", method: "PUT", path: "/v1/buckets", status: http.StatusNotFound},
+		{name: "invalid query parameter", method: "GET", path: "/v1/buckets?limit=0", status: http.StatusUnprocessableEntity},
+		{name: "invalid property", method: "POST", path: "/v1/buckets", body: `{"name": 1, "retentionPeriod": 0}`, status: http.StatusUnprocessableEntity},
+		{name: "missing property", method: "POST", path: "/v1/buckets", body: `{"retentionPeriod": 0}`, status: http.StatusUnprocessableEntity},
+		{name: "missing body", method: "POST", path: "/v1/buckets", status: http.StatusUnprocessableEntity},
+		{name: "malformed body", method: "POST", path: "/v1/buckets", body: `{"name":`, status: http.StatusBadRequest},
+		{name: "unknown content type", method: "POST", path: "/v1/buckets", contentType: "text/plain", body: `name`, status: http.StatusUnprocessableEntity},
+		{name: "unknown visualization", method: "POST", path: "/v1/dashboards/0a/cells", body: `{"visualization": {"type": "pie"}}`, status: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler("test")
+			h.ValidationSpec = PlatformSpec()
+			h.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			})
+
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				contentType := tt.contentType
+				if contentType == "" {
+					contentType = "application/json"
+				}
+				r.Header.Set("Content-Type", contentType)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d: %s", tt.status, w.Code, w.Header().Get("X-Influx-Error"))
+			}
+		})
+	}
+}
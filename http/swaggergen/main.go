@@ -0,0 +1,89 @@
+// Command swaggergen converts the OpenAPI spec of the platform from YAML to JSON
+// and writes it to a Go file, so that it is compiled into the http package.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Println(os.Args)
+		fmt.Println("Usage: swaggergen <path to swagger.yml> <path to output file>")
+		os.Exit(1)
+	}
+
+	in, err := ioutil.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var spec interface{}
+	if err := yaml.Unmarshal(in, &spec); err != nil {
+		log.Fatal(err)
+	}
+	spec, err = jsonValue(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if strings.Contains(string(out), "`") {
+		log.Fatal("swagger.yml must not contain backquotes")
+	}
+
+	f, err := os.Create(os.Args[2])
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, "package http")
+	fmt.Fprintln(f)
+	fmt.Fprintln(f, "// DO NOT EDIT: This file is autogenerated via the swaggergen command from swagger.yml.")
+	fmt.Fprintln(f)
+	fmt.Fprintln(f, "// swaggerJSON is the OpenAPI spec of the platform in JSON.")
+	fmt.Fprintf(f, "var swaggerJSON = []byte(`%s`)\n", out)
+}
+
+// jsonValue converts the maps decoded from YAML, which may have keys of any type,
+// to maps with string keys that can be encoded as JSON.
+func jsonValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			s, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v of type %T is not a string", k, k)
+			}
+			x, err := jsonValue(x)
+			if err != nil {
+				return nil, err
+			}
+			m[s] = x
+		}
+		return m, nil
+	case []interface{}:
+		for i, x := range v {
+			x, err := jsonValue(x)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = x
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
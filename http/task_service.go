@@ -0,0 +1,682 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+)
+
+// TaskHandler represents an HTTP API handler for tasks.
+type TaskHandler struct {
+	*httprouter.Router
+
+	TaskService platform.TaskService
+}
+
+// NewTaskHandler returns a new instance of TaskHandler.
+func NewTaskHandler() *TaskHandler {
+	h := &TaskHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("POST", "/v1/tasks", h.handlePostTask)
+	h.HandlerFunc("GET", "/v1/tasks", h.handleGetTasks)
+	h.HandlerFunc("GET", "/v1/tasks/:tid", h.handleGetTask)
+	h.HandlerFunc("PATCH", "/v1/tasks/:tid", h.handlePatchTask)
+	h.HandlerFunc("DELETE", "/v1/tasks/:tid", h.handleDeleteTask)
+
+	h.HandlerFunc("GET", "/v1/tasks/:tid/runs", h.handleGetRuns)
+	h.HandlerFunc("GET", "/v1/tasks/:tid/runs/:rid", h.handleGetRun)
+	h.HandlerFunc("POST", "/v1/tasks/:tid/runs/:rid/retry", h.handlePostRunRetry)
+
+	h.HandlerFunc("GET", "/v1/tasks/:tid/logs", h.handleGetLogs)
+	return h
+}
+
+type tasksResponse struct {
+	Tasks []*platform.Task `json:"tasks"`
+	Links Links            `json:"links"`
+}
+
+type runsResponse struct {
+	Runs  []*platform.Run `json:"runs"`
+	Links Links           `json:"links"`
+}
+
+type logsResponse struct {
+	Logs []*platform.Log `json:"logs"`
+}
+
+// setRunLogLink sets the link to the logs of the run.
+func setRunLogLink(r *platform.Run) {
+	if r == nil {
+		return
+	}
+	r.Log = taskLogsPath(r.TaskID) + "?" + url.Values{"run": []string{r.ID.String()}}.Encode()
+}
+
+// handlePostTask is the HTTP handler for the POST /v1/tasks route.
+func (h *TaskHandler) handlePostTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostTaskRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskService.CreateTask(ctx, req.Task); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, req.Task); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type postTaskRequest struct {
+	Task *platform.Task
+}
+
+func decodePostTaskRequest(ctx context.Context, r *http.Request) (*postTaskRequest, error) {
+	t := &platform.Task{}
+	if err := json.NewDecoder(r.Body).Decode(t); err != nil {
+		return nil, err
+	}
+
+	return &postTaskRequest{
+		Task: t,
+	}, nil
+}
+
+// handleGetTasks is the HTTP handler for the GET /v1/tasks route.
+func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetTasksRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	ts, _, err := h.TaskService.FindTasks(ctx, req.filter, pageOptions(req.opts))
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	n, links := newPage(r, req.opts, len(ts), func(i int) platform.ID { return ts[i].ID })
+	ts = ts[:n]
+	for _, t := range ts {
+		setRunLogLink(t.Last)
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, tasksResponse{Tasks: ts, Links: links}); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type getTasksRequest struct {
+	filter platform.TaskFilter
+	opts   platform.FindOptions
+}
+
+func decodeGetTasksRequest(ctx context.Context, r *http.Request) (*getTasksRequest, error) {
+	qp := r.URL.Query()
+	req := &getTasksRequest{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
+	if id := qp.Get("user"); id != "" {
+		req.filter.User = &platform.ID{}
+		if err := req.filter.User.DecodeFromString(id); err != nil {
+			return nil, err
+		}
+	}
+
+	if id := qp.Get("organization"); id != "" {
+		req.filter.OrganizationID = &platform.ID{}
+		if err := req.filter.OrganizationID.DecodeFromString(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+// handleGetTask is the HTTP handler for the GET /v1/tasks/:tid route.
+func (h *TaskHandler) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetTaskRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	t, err := h.TaskService.FindTaskByID(ctx, req.TaskID)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+	setRunLogLink(t.Last)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, t); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type getTaskRequest struct {
+	TaskID platform.ID
+}
+
+func decodeGetTaskRequest(ctx context.Context, r *http.Request) (*getTaskRequest, error) {
+	req := &getTaskRequest{}
+	if err := decodeTaskID(ctx, &req.TaskID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeTaskID decodes the tid parameter of the url.
+func decodeTaskID(ctx context.Context, id *platform.ID) error {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return kerrors.InvalidDataf("url missing tid")
+	}
+	return id.DecodeFromString(tid)
+}
+
+// decodeRunID decodes the rid parameter of the url.
+func decodeRunID(ctx context.Context, id *platform.ID) error {
+	params := httprouter.ParamsFromContext(ctx)
+	rid := params.ByName("rid")
+	if rid == "" {
+		return kerrors.InvalidDataf("url missing rid")
+	}
+	return id.DecodeFromString(rid)
+}
+
+// handlePatchTask is the HTTP handler for the PATCH /v1/tasks/:tid route.
+func (h *TaskHandler) handlePatchTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePatchTaskRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	t, err := h.TaskService.UpdateTask(ctx, req.TaskID, req.Update)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+	setRunLogLink(t.Last)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, t); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type patchTaskRequest struct {
+	Update platform.TaskUpdate
+	TaskID platform.ID
+}
+
+func decodePatchTaskRequest(ctx context.Context, r *http.Request) (*patchTaskRequest, error) {
+	req := &patchTaskRequest{}
+	if err := decodeTaskID(ctx, &req.TaskID); err != nil {
+		return nil, err
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Update); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// handleDeleteTask is the HTTP handler for the DELETE /v1/tasks/:tid route.
+func (h *TaskHandler) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeDeleteTaskRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskService.DeleteTask(ctx, req.TaskID); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type deleteTaskRequest struct {
+	TaskID platform.ID
+}
+
+func decodeDeleteTaskRequest(ctx context.Context, r *http.Request) (*deleteTaskRequest, error) {
+	req := &deleteTaskRequest{}
+	if err := decodeTaskID(ctx, &req.TaskID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handleGetRuns is the HTTP handler for the GET /v1/tasks/:tid/runs route.
+func (h *TaskHandler) handleGetRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetRunsRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	// Runs are paged by their own filter, which supports the limit and after parameters.
+	opts := platform.FindOptions{Limit: req.filter.Limit}
+	req.filter.Limit++
+	rs, _, err := h.TaskService.FindRuns(ctx, req.filter)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	n, links := newPage(r, opts, len(rs), func(i int) platform.ID { return rs[i].ID })
+	rs = rs[:n]
+	for _, run := range rs {
+		setRunLogLink(run)
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, runsResponse{Runs: rs, Links: links}); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type getRunsRequest struct {
+	filter platform.RunFilter
+}
+
+func decodeGetRunsRequest(ctx context.Context, r *http.Request) (*getRunsRequest, error) {
+	req := &getRunsRequest{}
+	if err := decodeTaskID(ctx, &req.filter.Task); err != nil {
+		return nil, err
+	}
+
+	qp := r.URL.Query()
+
+	if id := qp.Get("after"); id != "" {
+		req.filter.After = &platform.ID{}
+		if err := req.filter.After.DecodeFromString(id); err != nil {
+			return nil, err
+		}
+	}
+
+	req.filter.Limit = DefaultPageSize
+	if limit := qp.Get("limit"); limit != "" {
+		i, err := strconv.Atoi(limit)
+		if err != nil || i < 1 || i > MaxPageSize {
+			return nil, kerrors.InvalidDataf("limit must be between 1 and %d", MaxPageSize)
+		}
+		req.filter.Limit = i
+	}
+
+	if at := qp.Get("afterTime"); at != "" {
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return nil, kerrors.InvalidDataf("invalid afterTime: %v", err)
+		}
+		req.filter.AfterTime = &t
+	}
+
+	if bt := qp.Get("beforeTime"); bt != "" {
+		t, err := time.Parse(time.RFC3339, bt)
+		if err != nil {
+			return nil, kerrors.InvalidDataf("invalid beforeTime: %v", err)
+		}
+		req.filter.BeforeTime = &t
+	}
+
+	return req, nil
+}
+
+// handleGetRun is the HTTP handler for the GET /v1/tasks/:tid/runs/:rid route.
+func (h *TaskHandler) handleGetRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetRunRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	run, err := h.TaskService.FindRunByID(ctx, req.TaskID, req.RunID)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+	setRunLogLink(run)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, run); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type getRunRequest struct {
+	TaskID platform.ID
+	RunID  platform.ID
+}
+
+func decodeGetRunRequest(ctx context.Context, r *http.Request) (*getRunRequest, error) {
+	req := &getRunRequest{}
+	if err := decodeTaskID(ctx, &req.TaskID); err != nil {
+		return nil, err
+	}
+	if err := decodeRunID(ctx, &req.RunID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handlePostRunRetry is the HTTP handler for the POST /v1/tasks/:tid/runs/:rid/retry route.
+func (h *TaskHandler) handlePostRunRetry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostRunRetryRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	run, err := h.TaskService.RetryRun(ctx, req.TaskID, req.RunID)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+	setRunLogLink(run)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, run); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type postRunRetryRequest struct {
+	TaskID platform.ID
+	RunID  platform.ID
+}
+
+func decodePostRunRetryRequest(ctx context.Context, r *http.Request) (*postRunRetryRequest, error) {
+	req := &postRunRetryRequest{}
+	if err := decodeTaskID(ctx, &req.TaskID); err != nil {
+		return nil, err
+	}
+	if err := decodeRunID(ctx, &req.RunID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handleGetLogs is the HTTP handler for the GET /v1/tasks/:tid/logs route.
+func (h *TaskHandler) handleGetLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetLogsRequest(ctx, r)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	ls, _, err := h.TaskService.FindLogs(ctx, req.filter)
+	if err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, logsResponse{Logs: ls}); err != nil {
+		kerrors.EncodeHTTP(ctx, err, w)
+		return
+	}
+}
+
+type getLogsRequest struct {
+	filter platform.LogFilter
+}
+
+func decodeGetLogsRequest(ctx context.Context, r *http.Request) (*getLogsRequest, error) {
+	req := &getLogsRequest{}
+	if err := decodeTaskID(ctx, &req.filter.Task); err != nil {
+		return nil, err
+	}
+
+	if id := r.URL.Query().Get("run"); id != "" {
+		req.filter.Run = &platform.ID{}
+		if err := req.filter.Run.DecodeFromString(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+const (
+	taskPath = "/v1/tasks"
+)
+
+// TaskService connects to Influx via HTTP using tokens to manage tasks.
+type TaskService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// do sends a request to the task API and decodes the response into v, if v is not nil.
+func (s *TaskService) do(ctx context.Context, method, p string, query url.Values, body, v interface{}) error {
+	u, err := newURL(s.Addr, p)
+	if err != nil {
+		return err
+	}
+	u.RawQuery = query.Encode()
+
+	var octets []byte
+	if body != nil {
+		if octets, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", s.Token)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// FindTaskByID returns a single task by ID.
+func (s *TaskService) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
+	var t platform.Task
+	if err := s.do(ctx, "GET", taskIDPath(id), nil, nil, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// FindTasks returns a list of tasks that match filter and the total count of matching tasks.
+// Additional options request a single page of tasks, otherwise all pages are requested.
+func (s *TaskService) FindTasks(ctx context.Context, filter platform.TaskFilter, opt ...platform.FindOptions) ([]*platform.Task, int, error) {
+	if filter.ID != nil {
+		t, err := s.FindTaskByID(ctx, *filter.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return []*platform.Task{t}, 1, nil
+	}
+
+	query := url.Values{}
+	if filter.User != nil {
+		query.Add("user", filter.User.String())
+	}
+	if filter.OrganizationID != nil {
+		query.Add("organization", filter.OrganizationID.String())
+	}
+
+	var ts []*platform.Task
+	err := getPages(ctx, s.Addr, s.Token, s.InsecureSkipVerify, taskPath, query, opt, func(dec *json.Decoder) (*Links, error) {
+		var resp tasksResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		ts = append(ts, resp.Tasks...)
+		return &resp.Links, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return ts, len(ts), nil
+}
+
+// CreateTask creates a new task and sets t.ID with the new identifier.
+func (s *TaskService) CreateTask(ctx context.Context, t *platform.Task) error {
+	return s.do(ctx, "POST", taskPath, nil, t, t)
+}
+
+// UpdateTask updates a single task with changeset.
+// Returns the new task state after update.
+func (s *TaskService) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+	var t platform.Task
+	if err := s.do(ctx, "PATCH", taskIDPath(id), nil, upd, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// DeleteTask removes a task by ID.
+func (s *TaskService) DeleteTask(ctx context.Context, id platform.ID) error {
+	return s.do(ctx, "DELETE", taskIDPath(id), nil, nil, nil)
+}
+
+// FindRuns returns a list of runs that match filter and the total count of matching runs.
+// The server returns at most 100 runs, and 20 if no limit is set.
+func (s *TaskService) FindRuns(ctx context.Context, filter platform.RunFilter) ([]*platform.Run, int, error) {
+	query := url.Values{}
+	if filter.After != nil {
+		query.Add("after", filter.After.String())
+	}
+	if filter.Limit > 0 {
+		query.Add("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.AfterTime != nil {
+		query.Add("afterTime", filter.AfterTime.Format(time.RFC3339))
+	}
+	if filter.BeforeTime != nil {
+		query.Add("beforeTime", filter.BeforeTime.Format(time.RFC3339))
+	}
+
+	var resp runsResponse
+	if err := s.do(ctx, "GET", taskRunsPath(filter.Task), query, nil, &resp); err != nil {
+		return nil, 0, err
+	}
+
+	rs := resp.Runs
+	if filter.Status != nil {
+		rs = rs[:0]
+		for _, r := range resp.Runs {
+			if r.Status == *filter.Status {
+				rs = append(rs, r)
+			}
+		}
+	}
+	return rs, len(rs), nil
+}
+
+// FindRunByID returns a single run of a task.
+func (s *TaskService) FindRunByID(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	var r platform.Run
+	if err := s.do(ctx, "GET", taskRunPath(taskID, runID), nil, nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// RetryRun queues a new run of the task which ran as runID.
+func (s *TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	var r platform.Run
+	if err := s.do(ctx, "POST", path.Join(taskRunPath(taskID, runID), "retry"), nil, nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// FindLogs returns a list of logs that match filter and the total count of matching logs.
+func (s *TaskService) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*platform.Log, int, error) {
+	query := url.Values{}
+	if filter.Run != nil {
+		query.Add("run", filter.Run.String())
+	}
+
+	var resp logsResponse
+	if err := s.do(ctx, "GET", taskLogsPath(filter.Task), query, nil, &resp); err != nil {
+		return nil, 0, err
+	}
+	return resp.Logs, len(resp.Logs), nil
+}
+
+func taskIDPath(id platform.ID) string {
+	return path.Join(taskPath, id.String())
+}
+
+func taskRunsPath(taskID platform.ID) string {
+	return path.Join(taskIDPath(taskID), "runs")
+}
+
+func taskRunPath(taskID, runID platform.ID) string {
+	return path.Join(taskRunsPath(taskID), runID.String())
+}
+
+func taskLogsPath(taskID platform.ID) string {
+	return path.Join(taskIDPath(taskID), "logs")
+}
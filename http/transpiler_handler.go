@@ -1,7 +1,6 @@
 package http
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/influxdata/platform"
@@ -38,7 +37,7 @@ func (h *TranspilerQueryHandler) handlePostQuery(w http.ResponseWriter, r *http.
 
 	queryStr := r.FormValue("q")
 	if queryStr == "" {
-		kerrors.EncodeHTTP(ctx, errors.New("must pass query string in q parameter"), w)
+		kerrors.EncodeHTTP(ctx, kerrors.InvalidDataf("must pass query string in q parameter"), w)
 		return
 	}
 
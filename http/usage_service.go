@@ -2,7 +2,6 @@ package http
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"time"
 
@@ -80,10 +79,10 @@ func decodeGetUsageRequest(ctx context.Context, r *http.Request) (*getUsageReque
 	stop := qp.Get("stop")
 
 	if start == "" && stop != "" {
-		return nil, errors.New("start query param required")
+		return nil, kerrors.InvalidDataf("start query param required")
 	}
-	if start == "" && stop != "" {
-		return nil, errors.New("stop query param required")
+	if start != "" && stop == "" {
+		return nil, kerrors.InvalidDataf("stop query param required")
 	}
 
 	if start == "" && stop == "" {
@@ -102,7 +101,7 @@ func decodeGetUsageRequest(ctx context.Context, r *http.Request) (*getUsageReque
 			return nil, err
 		}
 
-		stopTime, err := time.Parse(time.RFC3339, start)
+		stopTime, err := time.Parse(time.RFC3339, stop)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDecodeGetUsageRequest(t *testing.T) {
+	start := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	stop := time.Date(2018, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	r := httptest.NewRequest("GET", "/v1/usage?start="+start.Format(time.RFC3339)+"&stop="+stop.Format(time.RFC3339), nil)
+	req, err := decodeGetUsageRequest(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rng := req.filter.Range; !rng.Start.Equal(start) || !rng.Stop.Equal(stop) {
+		t.Errorf("unexpected range: got %v to %v want %v to %v", rng.Start, rng.Stop, start, stop)
+	}
+
+	for _, q := range []string{"?start=" + start.Format(time.RFC3339), "?stop=" + stop.Format(time.RFC3339)} {
+		if _, err := decodeGetUsageRequest(context.Background(), httptest.NewRequest("GET", "/v1/usage"+q, nil)); err == nil {
+			t.Errorf("expected an error for %s", q)
+		}
+	}
+}
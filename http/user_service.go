@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
+	"net/url"
 	"path"
 
 	"github.com/influxdata/platform"
@@ -163,13 +163,14 @@ func (h *UserHandler) handleGetUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	users, _, err := h.UserService.FindUsers(ctx, req.filter)
+	users, _, err := h.UserService.FindUsers(ctx, req.filter, pageOptions(req.opts))
 	if err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, users); err != nil {
+	n, links := newPage(r, req.opts, len(users), func(i int) platform.ID { return users[i].ID })
+	if err := encodeResponse(ctx, w, http.StatusOK, usersResponse{Users: users[:n], Links: links}); err != nil {
 		kerrors.EncodeHTTP(ctx, err, w)
 		return
 	}
@@ -177,12 +178,24 @@ func (h *UserHandler) handleGetUsers(w http.ResponseWriter, r *http.Request) {
 
 type getUsersRequest struct {
 	filter platform.UserFilter
+	opts   platform.FindOptions
+}
+
+type usersResponse struct {
+	Users []*platform.User `json:"users"`
+	Links Links            `json:"links"`
 }
 
 func decodeGetUsersRequest(ctx context.Context, r *http.Request) (*getUsersRequest, error) {
 	qp := r.URL.Query()
 	req := &getUsersRequest{}
 
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = opts
+
 	if id := qp.Get("id"); id != "" {
 		req.filter.ID = &platform.ID{}
 		if err := req.filter.ID.DecodeFromString(id); err != nil {
@@ -294,26 +307,16 @@ func (s *UserService) FindUser(ctx context.Context, filter platform.UserFilter)
 	}
 
 	if n == 0 {
-		return nil, errors.New("found no matching user")
+		return nil, kerrors.NotFoundf("found no matching user")
 	}
 
 	return users[0], nil
 }
 
 // FindUsers returns a list of users that match filter and the total count of matching users.
-// Additional options provide pagination & sorting.
+// Additional options request a single page of users, otherwise all pages are requested.
 func (s *UserService) FindUsers(ctx context.Context, filter platform.UserFilter, opt ...platform.FindOptions) ([]*platform.User, int, error) {
-	url, err := newURL(s.Addr, userPath)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	query := url.Query()
-
-	req, err := http.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, 0, err
-	}
+	query := url.Values{}
 	if filter.ID != nil {
 		query.Add("id", filter.ID.String())
 	}
@@ -321,25 +324,20 @@ func (s *UserService) FindUsers(ctx context.Context, filter platform.UserFilter,
 		query.Add("name", *filter.Name)
 	}
 
-	req.URL.RawQuery = query.Encode()
-	req.Header.Set("Authorization", s.Token)
-
-	hc := newClient(url.Scheme, s.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	var us []*platform.User
+	err := getPages(ctx, s.Addr, s.Token, s.InsecureSkipVerify, userPath, query, opt, func(dec *json.Decoder) (*Links, error) {
+		var resp usersResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, err
+		}
+		us = append(us, resp.Users...)
+		return &resp.Links, nil
+	})
 	if err != nil {
 		return nil, 0, err
 	}
 
-	if err := CheckError(resp); err != nil {
-		return nil, 0, err
-	}
-
-	var bs []*platform.User
-	if err := json.NewDecoder(resp.Body).Decode(&bs); err != nil {
-		return nil, 0, err
-	}
-
-	return bs, len(bs), nil
+	return us, len(us), nil
 }
 
 const (
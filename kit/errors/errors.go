@@ -16,6 +16,15 @@ const (
 	InvalidData = 3
 	// Forbidden indicates a forbidden operation.
 	Forbidden = 4
+	// NotFound indicates that a resource does not exist.
+	NotFound = 5
+	// Conflict indicates that an operation conflicts with the current state of a resource,
+	// such as a duplicate name.
+	Conflict = 6
+	// Unauthorized indicates missing or invalid credentials.
+	Unauthorized = 7
+	// Unavailable indicates that a service is temporarily unable to handle the operation.
+	Unavailable = 8
 )
 
 // Error indicates an error with a reference code and an HTTP status code.
@@ -36,6 +45,14 @@ func (e *Error) SetCode() {
 		e.Code = http.StatusBadRequest
 	case Forbidden:
 		e.Code = http.StatusForbidden
+	case NotFound:
+		e.Code = http.StatusNotFound
+	case Conflict:
+		e.Code = http.StatusConflict
+	case Unauthorized:
+		e.Code = http.StatusUnauthorized
+	case Unavailable:
+		e.Code = http.StatusServiceUnavailable
 	default:
 		e.Reference = InternalError
 		e.Code = http.StatusInternalServerError
@@ -74,3 +91,57 @@ func InvalidDataf(format string, i ...interface{}) error {
 func Forbiddenf(format string, i ...interface{}) error {
 	return Errorf(Forbidden, format, i...)
 }
+
+// NotFoundf constructs a NotFound error with the given format.
+func NotFoundf(format string, i ...interface{}) error {
+	return Errorf(NotFound, format, i...)
+}
+
+// Conflictf constructs a Conflict error with the given format.
+func Conflictf(format string, i ...interface{}) error {
+	return Errorf(Conflict, format, i...)
+}
+
+// Unauthorizedf constructs an Unauthorized error with the given format.
+func Unauthorizedf(format string, i ...interface{}) error {
+	return Errorf(Unauthorized, format, i...)
+}
+
+// Unavailablef constructs an Unavailable error with the given format.
+func Unavailablef(format string, i ...interface{}) error {
+	return Errorf(Unavailable, format, i...)
+}
+
+// Reference returns the reference code of err.
+// Errors that are not an *Error are internal errors, and a nil error has no reference code.
+func Reference(err error) int {
+	if err == nil {
+		return 0
+	}
+	if e, ok := err.(*Error); ok {
+		return e.Reference
+	}
+	return InternalError
+}
+
+// ReferenceFromStatus returns the reference code of an HTTP status code.
+func ReferenceFromStatus(code int) int {
+	switch code {
+	case http.StatusBadRequest:
+		return MalformedData
+	case http.StatusUnprocessableEntity:
+		return InvalidData
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusServiceUnavailable:
+		return Unavailable
+	default:
+		return InternalError
+	}
+}
@@ -6,6 +6,7 @@ import "context"
 type Organization struct {
 	ID   ID     `json:"id"`
 	Name string `json:"name"`
+	Meta
 }
 
 // OrganizationService represents a service for managing organization data.
@@ -32,9 +32,14 @@ type Node interface {
 }
 
 func (*Program) node() {}
+func (*Comment) node() {}
+
+func (*PackageClause) node()     {}
+func (*ImportDeclaration) node() {}
 
 func (*BlockStatement) node()      {}
 func (*ExpressionStatement) node() {}
+func (*OptionStatement) node()     {}
 func (*ReturnStatement) node()     {}
 func (*VariableDeclaration) node() {}
 func (*VariableDeclarator) node()  {}
@@ -44,12 +49,17 @@ func (*ArrowFunctionExpression) node() {}
 func (*BinaryExpression) node()        {}
 func (*CallExpression) node()          {}
 func (*ConditionalExpression) node()   {}
+func (*IndexExpression) node()         {}
 func (*LogicalExpression) node()       {}
 func (*MemberExpression) node()        {}
 func (*PipeExpression) node()          {}
 func (*ObjectExpression) node()        {}
+func (*StringExpression) node()        {}
 func (*UnaryExpression) node()         {}
 
+func (*TextPart) node()         {}
+func (*InterpolatedPart) node() {}
+
 func (*Property) node()   {}
 func (*Identifier) node() {}
 
@@ -69,12 +79,21 @@ type BaseNode struct {
 }
 
 // Location is the source location of the Node
-func (b *BaseNode) Location() *SourceLocation { return b.Loc }
+func (b *BaseNode) Location() *SourceLocation {
+	if b == nil {
+		return nil
+	}
+	return b.Loc
+}
 
 // Program represents a complete program source tree
 type Program struct {
 	*BaseNode
-	Body []Statement `json:"body"`
+	Package *PackageClause       `json:"package,omitempty"`
+	Imports []*ImportDeclaration `json:"imports,omitempty"`
+	Body    []Statement          `json:"body"`
+	// Comments are the comments of the program in source order.
+	Comments []*Comment `json:"comments,omitempty"`
 }
 
 // Type is the abstract type
@@ -83,15 +102,94 @@ func (*Program) Type() string { return "Program" }
 func (p *Program) Copy() Node {
 	np := new(Program)
 	*np = *p
+	if p.Package != nil {
+		np.Package = p.Package.Copy().(*PackageClause)
+	}
+	if len(p.Imports) > 0 {
+		np.Imports = make([]*ImportDeclaration, len(p.Imports))
+		for i, imp := range p.Imports {
+			np.Imports[i] = imp.Copy().(*ImportDeclaration)
+		}
+	}
 	if len(p.Body) > 0 {
 		np.Body = make([]Statement, len(p.Body))
 		for i, s := range p.Body {
 			np.Body[i] = s.Copy().(Statement)
 		}
 	}
+	if len(p.Comments) > 0 {
+		np.Comments = make([]*Comment, len(p.Comments))
+		for i, c := range p.Comments {
+			np.Comments[i] = c.Copy().(*Comment)
+		}
+	}
 	return np
 }
 
+// Comment is a line comment, the text includes the leading slashes
+type Comment struct {
+	*BaseNode
+	Text string `json:"text"`
+}
+
+// Type is the abstract type
+func (*Comment) Type() string { return "Comment" }
+
+func (c *Comment) Copy() Node {
+	if c == nil {
+		return c
+	}
+	nc := new(Comment)
+	*nc = *c
+	return nc
+}
+
+// PackageClause declares the name of the package a program belongs to
+type PackageClause struct {
+	*BaseNode
+	Name *Identifier `json:"name"`
+}
+
+// Type is the abstract type
+func (*PackageClause) Type() string { return "PackageClause" }
+
+func (c *PackageClause) Copy() Node {
+	if c == nil {
+		return c
+	}
+	nc := new(PackageClause)
+	*nc = *c
+
+	nc.Name = c.Name.Copy().(*Identifier)
+
+	return nc
+}
+
+// ImportDeclaration imports the package with the path, optionally under a different name
+type ImportDeclaration struct {
+	*BaseNode
+	As   *Identifier    `json:"as,omitempty"`
+	Path *StringLiteral `json:"path"`
+}
+
+// Type is the abstract type
+func (*ImportDeclaration) Type() string { return "ImportDeclaration" }
+
+func (d *ImportDeclaration) Copy() Node {
+	if d == nil {
+		return d
+	}
+	nd := new(ImportDeclaration)
+	*nd = *d
+
+	if d.As != nil {
+		nd.As = d.As.Copy().(*Identifier)
+	}
+	nd.Path = d.Path.Copy().(*StringLiteral)
+
+	return nd
+}
+
 // Statement Perhaps we don't even want statements nor expression statements
 type Statement interface {
 	Node
@@ -100,6 +198,7 @@ type Statement interface {
 
 func (*BlockStatement) stmt()      {}
 func (*ExpressionStatement) stmt() {}
+func (*OptionStatement) stmt()     {}
 func (*ReturnStatement) stmt()     {}
 func (*VariableDeclaration) stmt() {}
 
@@ -146,6 +245,27 @@ func (s *ExpressionStatement) Copy() Node {
 	return ns
 }
 
+// OptionStatement sets the value of an option for the whole program
+type OptionStatement struct {
+	*BaseNode
+	Declaration *VariableDeclarator `json:"declaration"`
+}
+
+// Type is the abstract type
+func (*OptionStatement) Type() string { return "OptionStatement" }
+
+func (s *OptionStatement) Copy() Node {
+	if s == nil {
+		return s
+	}
+	ns := new(OptionStatement)
+	*ns = *s
+
+	ns.Declaration = s.Declaration.Copy().(*VariableDeclarator)
+
+	return ns
+}
+
 // ReturnStatement defines an Expression to return
 type ReturnStatement struct {
 	*BaseNode
@@ -230,6 +350,7 @@ func (*DateTimeLiteral) expression()         {}
 func (*DurationLiteral) expression()         {}
 func (*FloatLiteral) expression()            {}
 func (*Identifier) expression()              {}
+func (*IndexExpression) expression()         {}
 func (*IntegerLiteral) expression()          {}
 func (*LogicalExpression) expression()       {}
 func (*MemberExpression) expression()        {}
@@ -237,6 +358,7 @@ func (*ObjectExpression) expression()        {}
 func (*PipeExpression) expression()          {}
 func (*PipeLiteral) expression()             {}
 func (*RegexpLiteral) expression()           {}
+func (*StringExpression) expression()        {}
 func (*StringLiteral) expression()           {}
 func (*UnaryExpression) expression()         {}
 func (*UnsignedIntegerLiteral) expression()  {}
@@ -315,6 +437,29 @@ func (e *MemberExpression) Copy() Node {
 	return ne
 }
 
+// IndexExpression represents indexing into an array
+type IndexExpression struct {
+	*BaseNode
+	Array Expression `json:"array"`
+	Index Expression `json:"index"`
+}
+
+// Type is the abstract type
+func (*IndexExpression) Type() string { return "IndexExpression" }
+
+func (e *IndexExpression) Copy() Node {
+	if e == nil {
+		return e
+	}
+	ne := new(IndexExpression)
+	*ne = *e
+
+	ne.Array = e.Array.Copy().(Expression)
+	ne.Index = e.Index.Copy().(Expression)
+
+	return ne
+}
+
 type ArrowFunctionExpression struct {
 	*BaseNode
 	Params []*Property `json:"params"`
@@ -677,6 +822,77 @@ func (l *StringLiteral) Copy() Node {
 	return nl
 }
 
+// StringExpression is a string literal containing interpolated expressions, i.e. "${host}-${region}".
+type StringExpression struct {
+	*BaseNode
+	Parts []StringExpressionPart `json:"parts"`
+}
+
+func (*StringExpression) Type() string { return "StringExpression" }
+
+func (e *StringExpression) Copy() Node {
+	if e == nil {
+		return e
+	}
+	ne := new(StringExpression)
+	*ne = *e
+
+	if len(e.Parts) > 0 {
+		ne.Parts = make([]StringExpressionPart, len(e.Parts))
+		for i, p := range e.Parts {
+			ne.Parts[i] = p.Copy().(StringExpressionPart)
+		}
+	}
+
+	return ne
+}
+
+// StringExpressionPart is either a TextPart or an InterpolatedPart of a StringExpression
+type StringExpressionPart interface {
+	Node
+	stringPart()
+}
+
+func (*TextPart) stringPart()         {}
+func (*InterpolatedPart) stringPart() {}
+
+// TextPart is the literal text of a StringExpression
+type TextPart struct {
+	*BaseNode
+	Value string `json:"value"`
+}
+
+func (*TextPart) Type() string { return "TextPart" }
+
+func (p *TextPart) Copy() Node {
+	if p == nil {
+		return p
+	}
+	np := new(TextPart)
+	*np = *p
+	return np
+}
+
+// InterpolatedPart is an expression of a StringExpression whose value is inserted into the string
+type InterpolatedPart struct {
+	*BaseNode
+	Expression Expression `json:"expression"`
+}
+
+func (*InterpolatedPart) Type() string { return "InterpolatedPart" }
+
+func (p *InterpolatedPart) Copy() Node {
+	if p == nil {
+		return p
+	}
+	np := new(InterpolatedPart)
+	*np = *p
+
+	np.Expression = p.Expression.Copy().(Expression)
+
+	return np
+}
+
 // BooleanLiteral represent boolean values
 type BooleanLiteral struct {
 	*BaseNode
@@ -0,0 +1,522 @@
+package ast
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// indentation is the indentation of a nested block or pipe expression.
+const indentation = "    "
+
+// Format returns the Flux source of the node in the canonical format.
+// The comments of a program are kept on the line of, or on the lines preceding,
+// the statement or pipe expression they annotate.
+func Format(n Node) string {
+	f := new(formatter)
+	if p, ok := n.(*Program); ok {
+		f.comments = p.Comments
+	}
+	f.formatNode(n)
+	return f.b.String()
+}
+
+// Precedence levels of expressions, an expression must be wrapped in parentheses
+// when it appears where an expression of higher precedence is expected.
+const (
+	precConditional = iota + 1
+	precLogical
+	precEquality
+	precRelational
+	precAdditive
+	precMultiplicative
+	precUnary
+	precPrimary
+)
+
+type formatter struct {
+	b      strings.Builder
+	indent int
+	// comments are the comments not yet formatted.
+	comments []*Comment
+	// lastLine is the source line on which the last formatted statement or comment ended.
+	lastLine int
+}
+
+func (f *formatter) writeString(s string) {
+	f.b.WriteString(s)
+}
+
+func (f *formatter) writeIndent() {
+	for i := 0; i < f.indent; i++ {
+		f.writeString(indentation)
+	}
+}
+
+// separate writes a blank line before an element starting on the line,
+// if the previous element was separated from it by blank lines in the source.
+func (f *formatter) separate(line int) {
+	if f.lastLine > 0 && line > f.lastLine+1 {
+		f.writeString("\n")
+	}
+}
+
+// formatCommentsBefore formats the comments starting before the line on their own lines.
+// A comment on the end line of the previous element is formatted at the end of that line instead.
+func (f *formatter) formatCommentsBefore(line, prevEnd int) {
+	for len(f.comments) > 0 && (line == 0 || commentLine(f.comments[0]) < line) {
+		c := f.comments[0]
+		f.comments = f.comments[1:]
+		l := commentLine(c)
+		if prevEnd > 0 && l == prevEnd {
+			f.writeString(" ")
+			f.writeString(c.Text)
+			continue
+		}
+		f.writeString("\n")
+		f.writeIndent()
+		f.writeString(c.Text)
+	}
+}
+
+// formatLeadingComments formats the comments starting before the line as lines preceding an element.
+func (f *formatter) formatLeadingComments(line int) {
+	for len(f.comments) > 0 && (line == 0 || commentLine(f.comments[0]) < line) {
+		c := f.comments[0]
+		f.comments = f.comments[1:]
+		l := commentLine(c)
+		f.separate(l)
+		f.writeIndent()
+		f.writeString(c.Text)
+		f.writeString("\n")
+		f.lastLine = l
+	}
+}
+
+// formatTrailingComments formats the comments of an element ending on the line.
+// A single comment on the last line stays at the end of the line,
+// any other comments within the element follow it on their own lines.
+func (f *formatter) formatTrailingComments(line int) {
+	n := 0
+	for n < len(f.comments) && commentLine(f.comments[n]) <= line {
+		n++
+	}
+	if n == 1 && commentLine(f.comments[0]) == line {
+		f.writeString(" ")
+		f.writeString(f.comments[0].Text)
+		f.comments = f.comments[1:]
+		return
+	}
+	for _, c := range f.comments[:n] {
+		f.writeString("\n")
+		f.writeIndent()
+		f.writeString(c.Text)
+	}
+	f.comments = f.comments[n:]
+}
+
+// formatElement formats a statement, package clause or import declaration on its own lines.
+func (f *formatter) formatElement(n Node) {
+	start, end := startLine(n), endLine(n)
+	f.formatLeadingComments(start)
+	f.separate(start)
+	f.writeIndent()
+	f.formatNode(n)
+	f.formatTrailingComments(end)
+	f.writeString("\n")
+	if end > 0 {
+		f.lastLine = end
+	}
+}
+
+func (f *formatter) formatNode(n Node) {
+	switch n := n.(type) {
+	case *Program:
+		f.formatProgram(n)
+	case *PackageClause:
+		f.writeString("package ")
+		f.formatNode(n.Name)
+	case *ImportDeclaration:
+		f.writeString("import ")
+		if n.As != nil {
+			f.formatNode(n.As)
+			f.writeString(" ")
+		}
+		f.formatNode(n.Path)
+	case *BlockStatement:
+		f.formatBlock(n)
+	case *ExpressionStatement:
+		f.formatExpression(n.Expression, precConditional, true)
+	case *OptionStatement:
+		f.writeString("option ")
+		f.formatNode(n.Declaration)
+	case *ReturnStatement:
+		f.writeString("return ")
+		f.formatExpression(n.Argument, precConditional, true)
+	case *VariableDeclaration:
+		for i, d := range n.Declarations {
+			if i > 0 {
+				f.writeString("\n")
+				f.writeIndent()
+			}
+			f.formatNode(d)
+		}
+	case *VariableDeclarator:
+		f.formatNode(n.ID)
+		f.writeString(" = ")
+		f.formatExpression(n.Init, precConditional, true)
+	case *Property:
+		f.formatNode(n.Key)
+		if n.Value != nil {
+			f.writeString(": ")
+			f.formatExpression(n.Value, precConditional, false)
+		}
+	case *Comment:
+		f.writeString(n.Text)
+	case *TextPart:
+		f.writeString(escapeString(n.Value))
+	case *InterpolatedPart:
+		f.writeString("${")
+		f.writeString(strings.Replace(Format(n.Expression), `"`, `\"`, -1))
+		f.writeString("}")
+	case Expression:
+		f.formatExpression(n, precConditional, false)
+	}
+}
+
+func (f *formatter) formatProgram(p *Program) {
+	if p.Package != nil {
+		f.formatElement(p.Package)
+	}
+	for i, imp := range p.Imports {
+		if i == 0 && p.Package != nil {
+			f.writeString("\n")
+			f.lastLine = 0
+		}
+		f.formatElement(imp)
+	}
+	for i, s := range p.Body {
+		if i == 0 && (p.Package != nil || len(p.Imports) > 0) {
+			f.writeString("\n")
+			f.lastLine = 0
+		}
+		f.formatElement(s)
+	}
+	// Format the comments following the last statement.
+	f.formatLeadingComments(0)
+}
+
+func (f *formatter) formatBlock(b *BlockStatement) {
+	f.writeString("{\n")
+	f.indent++
+	f.lastLine = 0
+	for _, s := range b.Body {
+		f.formatElement(s)
+	}
+	// Format the comments following the last statement of the block.
+	if end := endLine(b); end > 0 {
+		f.formatLeadingComments(end)
+	}
+	f.indent--
+	f.writeIndent()
+	f.writeString("}")
+}
+
+// formatExpression formats the expression wrapped in parentheses if its precedence is lower than prec.
+// The pipe expressions of a multiline expression are formatted on their own lines.
+func (f *formatter) formatExpression(e Expression, prec int, multiline bool) {
+	if precedence(e) < prec {
+		f.writeString("(")
+		defer f.writeString(")")
+	}
+	switch e := e.(type) {
+	case *PipeExpression:
+		if multiline {
+			f.formatPipes(e)
+			return
+		}
+		if _, ok := e.Argument.(*PipeExpression); ok {
+			f.formatExpression(e.Argument, precPrimary, false)
+		} else {
+			f.formatPipeHead(e.Argument)
+		}
+		f.writeString(" |> ")
+		f.formatExpression(e.Call, precPrimary, false)
+	case *CallExpression:
+		f.formatCallee(e.Callee)
+		f.writeString("(")
+		for _, arg := range e.Arguments {
+			if obj, ok := arg.(*ObjectExpression); ok {
+				f.formatProperties(obj.Properties)
+			} else {
+				f.formatExpression(arg, precConditional, false)
+			}
+		}
+		f.writeString(")")
+	case *MemberExpression:
+		f.formatCallee(e.Object)
+		if s, ok := e.Property.(*StringLiteral); ok {
+			f.writeString("[")
+			f.formatExpression(s, precPrimary, false)
+			f.writeString("]")
+		} else {
+			f.writeString(".")
+			f.formatExpression(e.Property, precPrimary, false)
+		}
+	case *IndexExpression:
+		f.formatCallee(e.Array)
+		f.writeString("[")
+		f.formatExpression(e.Index, precPrimary, false)
+		f.writeString("]")
+	case *ArrowFunctionExpression:
+		f.writeString("(")
+		for i, p := range e.Params {
+			if i > 0 {
+				f.writeString(", ")
+			}
+			f.formatNode(p.Key)
+			if p.Value != nil {
+				f.writeString("=")
+				f.formatExpression(p.Value, precPrimary, false)
+			}
+		}
+		f.writeString(") => ")
+		switch body := e.Body.(type) {
+		case *BlockStatement:
+			f.formatBlock(body)
+		case Expression:
+			f.formatExpression(body, precConditional, multiline)
+		}
+	case *BinaryExpression:
+		p := precedence(e)
+		f.formatExpression(e.Left, p, false)
+		f.writeString(" ")
+		f.writeString(e.Operator.String())
+		f.writeString(" ")
+		f.formatExpression(e.Right, p+1, false)
+	case *LogicalExpression:
+		f.formatExpression(e.Left, precLogical, false)
+		f.writeString(" ")
+		f.writeString(e.Operator.String())
+		f.writeString(" ")
+		f.formatExpression(e.Right, precLogical+1, false)
+	case *UnaryExpression:
+		f.writeString(e.Operator.String())
+		if e.Operator != SubtractionOperator {
+			f.writeString(" ")
+		}
+		f.formatExpression(e.Argument, precPrimary, false)
+	case *ConditionalExpression:
+		f.writeString("if ")
+		f.formatExpression(e.Test, precConditional, false)
+		f.writeString(" then ")
+		f.formatExpression(e.Consequent, precConditional, false)
+		f.writeString(" else ")
+		f.formatExpression(e.Alternate, precConditional, false)
+	case *ArrayExpression:
+		f.writeString("[")
+		for i, el := range e.Elements {
+			if i > 0 {
+				f.writeString(", ")
+			}
+			f.formatExpression(el, precPrimary, false)
+		}
+		f.writeString("]")
+	case *ObjectExpression:
+		f.writeString("{")
+		f.formatProperties(e.Properties)
+		f.writeString("}")
+	case *Identifier:
+		f.writeString(e.Name)
+	case *PipeLiteral:
+		f.writeString("<-")
+	case *StringLiteral:
+		f.writeString(`"`)
+		f.writeString(escapeString(e.Value))
+		f.writeString(`"`)
+	case *StringExpression:
+		f.writeString(`"`)
+		for _, p := range e.Parts {
+			f.formatNode(p)
+		}
+		f.writeString(`"`)
+	case *BooleanLiteral:
+		f.writeString(strconv.FormatBool(e.Value))
+	case *FloatLiteral:
+		s := strconv.FormatFloat(e.Value, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		f.writeString(s)
+	case *IntegerLiteral:
+		f.writeString(strconv.FormatInt(e.Value, 10))
+	case *UnsignedIntegerLiteral:
+		f.writeString(strconv.FormatUint(e.Value, 10))
+	case *RegexpLiteral:
+		f.writeString("/")
+		f.writeString(strings.Replace(e.Value.String(), "/", `\/`, -1))
+		f.writeString("/")
+	case *DurationLiteral:
+		f.writeString(formatDuration(e.Value))
+	case *DateTimeLiteral:
+		f.writeString(e.Value.Format(time.RFC3339Nano))
+	}
+}
+
+// formatPipes formats the pipe expressions of a chain of pipe expressions on their own lines,
+// along with the comments between them.
+func (f *formatter) formatPipes(e *PipeExpression) {
+	var pipes []*PipeExpression
+	var head Expression = e
+	for {
+		p, ok := head.(*PipeExpression)
+		if !ok {
+			break
+		}
+		pipes = append([]*PipeExpression{p}, pipes...)
+		head = p.Argument
+	}
+	f.formatPipeHead(head)
+	prevEnd := endLine(head)
+	f.indent++
+	for _, p := range pipes {
+		if line := startLine(p); line > 0 {
+			f.formatCommentsBefore(line, prevEnd)
+		}
+		f.writeString("\n")
+		f.writeIndent()
+		f.writeString("|> ")
+		f.formatExpression(p.Call, precPrimary, false)
+		prevEnd = endLine(p)
+	}
+	f.indent--
+}
+
+// formatPipeHead formats the argument of the first pipe expression of a chain.
+func (f *formatter) formatPipeHead(e Expression) {
+	if _, ok := e.(*ArrowFunctionExpression); ok {
+		// The body of the function would include the pipe expression.
+		f.writeString("(")
+		defer f.writeString(")")
+	}
+	f.formatExpression(e, precPrimary, false)
+}
+
+// formatCallee formats the callee of a call, or the object of a member or index expression.
+func (f *formatter) formatCallee(e Expression) {
+	switch e.(type) {
+	case *Identifier, *MemberExpression, *IndexExpression, *CallExpression:
+		f.formatExpression(e, precPrimary, false)
+	default:
+		f.writeString("(")
+		f.formatExpression(e, precConditional, false)
+		f.writeString(")")
+	}
+}
+
+func (f *formatter) formatProperties(properties []*Property) {
+	for i, p := range properties {
+		if i > 0 {
+			f.writeString(", ")
+		}
+		f.formatNode(p)
+	}
+}
+
+// precedence returns the precedence of the expression.
+func precedence(e Expression) int {
+	switch e := e.(type) {
+	case *ConditionalExpression, *ArrowFunctionExpression:
+		return precConditional
+	case *LogicalExpression:
+		return precLogical
+	case *BinaryExpression:
+		switch e.Operator {
+		case MultiplicationOperator, DivisionOperator:
+			return precMultiplicative
+		case AdditionOperator, SubtractionOperator:
+			return precAdditive
+		case EqualOperator, NotEqualOperator, RegexpMatchOperator, NotRegexpMatchOperator:
+			return precEquality
+		default:
+			return precRelational
+		}
+	case *UnaryExpression:
+		return precUnary
+	default:
+		return precPrimary
+	}
+}
+
+// escapeString escapes the double quotes and interpolations within a string literal.
+func escapeString(s string) string {
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return strings.Replace(s, "${", `\${`, -1)
+}
+
+// durationUnits are the units of duration literals from largest to smallest.
+var durationUnits = []struct {
+	d    time.Duration
+	unit string
+}{
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+	{time.Millisecond, "ms"},
+	{time.Microsecond, "us"},
+	{time.Nanosecond, "ns"},
+}
+
+// formatDuration formats the duration as a sequence of integer durations of decreasing units.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+	for _, u := range durationUnits {
+		if n := d / u.d; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10))
+			b.WriteString(u.unit)
+			d -= n * u.d
+		}
+	}
+	return b.String()
+}
+
+// startLine returns the line on which the node starts in the source, or 0 if the location is not known.
+func startLine(n Node) int {
+	loc := n.Location()
+	if loc == nil {
+		return 0
+	}
+	return loc.Start.Line
+}
+
+// endLine returns the line on which the node ends in the source, or 0 if the location is not known.
+// The source of a node may include trailing blank lines and comments, those lines are not part of the node.
+func endLine(n Node) int {
+	loc := n.Location()
+	if loc == nil {
+		return 0
+	}
+	if loc.Source == nil {
+		return loc.Start.Line
+	}
+	lines := strings.Split(*loc.Source, "\n")
+	for len(lines) > 1 {
+		l := strings.TrimSpace(lines[len(lines)-1])
+		if l != "" && !strings.HasPrefix(l, "//") {
+			break
+		}
+		lines = lines[:len(lines)-1]
+	}
+	return loc.Start.Line + len(lines) - 1
+}
+
+func commentLine(c *Comment) int {
+	return startLine(c)
+}
@@ -0,0 +1,169 @@
+package ast_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/query/ast"
+	"github.com/influxdata/platform/query/ast/asttest"
+	"github.com/influxdata/platform/query/parser"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		name string
+		flux string
+		want string
+	}{
+		{
+			name: "pipe expressions",
+			flux: `from(db:"telegraf")|>range(start:-5m)   |> filter(fn: (r) => r._measurement ==  "cpu" AND r._field=="usage")`,
+			want: `from(db: "telegraf")
+    |> range(start: -5m)
+    |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage")
+`,
+		},
+		{
+			name: "comments",
+			flux: `// the source
+a = from(db:"telegraf") // of cpu
+
+    // for the last hour
+    |> range(start:-1h) // relative
+// the result
+a |> yield()
+// done
+`,
+			want: `// the source
+a = from(db: "telegraf") // of cpu
+    // for the last hour
+    |> range(start: -1h) // relative
+// the result
+a
+    |> yield()
+// done
+`,
+		},
+		{
+			name: "blank lines",
+			flux: `a = 1
+
+
+b = 2
+c = 3`,
+			want: `a = 1
+
+b = 2
+c = 3
+`,
+		},
+		{
+			name: "package and imports",
+			flux: `package foo
+import "math"
+import s "strings"
+option now = () => 2018-05-22T19:53:26Z
+`,
+			want: `package foo
+
+import "math"
+import s "strings"
+
+option now = () => 2018-05-22T19:53:26Z
+`,
+		},
+		{
+			name: "functions",
+			flux: `f = (table=<-, n=10, fn) => {
+  // the limit
+  l = n+1
+
+
+  return table|>limit(n: l) |> map(fn: (r) => ({v: fn(x: r._value)}))
+  // end
+}`,
+			want: `f = (table=<-, n=10, fn) => {
+    // the limit
+    l = n + 1
+
+    return table
+        |> limit(n: l)
+        |> map(fn: (r) => {v: fn(x: r._value)})
+    // end
+}
+`,
+		},
+		{
+			name: "precedence",
+			flux: `a = (1 + 2) * 3 - (4 - 5) / -x
+b = not (a > 1 or true) and (a == 1) or (if a then (c) => c else 1)
+c = x[(1 + 2)] == [(1 + 2), "a"]`,
+			want: `a = (1 + 2) * 3 - (4 - 5) / -x
+b = not (a > 1 or true) and a == 1 or (if a then (c) => c else 1)
+c = x[(1 + 2)] == [(1 + 2), "a"]
+`,
+		},
+		{
+			name: "literals",
+			flux: `a = {s: "say \"${name}\" \${x}", r: /a\/b/, d: 1h90m, t: 2018-05-22T19:53:26.5+01:00, f: 10.0, i: 10, b: true}
+b = a["s"] =~ /^a.*$/`,
+			want: `a = {s: "say \"${name}\" \${x}", r: /a\/b/, d: 2h30m, t: 2018-05-22T19:53:26.5+01:00, f: 10.0, i: 10, b: true}
+b = a["s"] =~ /^a.*$/
+`,
+		},
+		{
+			name: "string interpolation with strings",
+			flux: `"a ${f(x: \"b\")}"`,
+			want: `"a ${f(x: \"b\")}"
+`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := parser.NewAST(tc.flux)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ast.Format(program); got != tc.want {
+				t.Errorf("unexpected format -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../querytest/test_cases/*.flux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no test cases found")
+	}
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			src, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := parser.NewAST(string(src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			formatted := ast.Format(want)
+			got, err := parser.NewAST(formatted)
+			if err != nil {
+				t.Fatalf("failed to parse formatted source: %v\n%s", err, formatted)
+			}
+			if !cmp.Equal(want, got, asttest.CompareOptions...) {
+				t.Errorf("unexpected program after formatting -want/+got\n%s", cmp.Diff(want, got, asttest.CompareOptions...))
+			}
+			if again := ast.Format(got); again != formatted {
+				t.Errorf("formatting is not idempotent -want/+got\n%s", cmp.Diff(formatted, again))
+			}
+		})
+	}
+}
@@ -42,6 +42,39 @@ func (p *Program) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+func (c *Comment) MarshalJSON() ([]byte, error) {
+	type Alias Comment
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  c.Type(),
+		Alias: (*Alias)(c),
+	}
+	return json.Marshal(raw)
+}
+func (c *PackageClause) MarshalJSON() ([]byte, error) {
+	type Alias PackageClause
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  c.Type(),
+		Alias: (*Alias)(c),
+	}
+	return json.Marshal(raw)
+}
+func (d *ImportDeclaration) MarshalJSON() ([]byte, error) {
+	type Alias ImportDeclaration
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  d.Type(),
+		Alias: (*Alias)(d),
+	}
+	return json.Marshal(raw)
+}
 func (s *BlockStatement) MarshalJSON() ([]byte, error) {
 	type Alias BlockStatement
 	raw := struct {
@@ -107,6 +140,17 @@ func (s *ExpressionStatement) UnmarshalJSON(data []byte) error {
 	s.Expression = e
 	return nil
 }
+func (s *OptionStatement) MarshalJSON() ([]byte, error) {
+	type Alias OptionStatement
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  s.Type(),
+		Alias: (*Alias)(s),
+	}
+	return json.Marshal(raw)
+}
 func (s *ReturnStatement) MarshalJSON() ([]byte, error) {
 	type Alias ReturnStatement
 	raw := struct {
@@ -521,6 +565,124 @@ func (e *ConditionalExpression) UnmarshalJSON(data []byte) error {
 	e.Consequent = consequent
 	return nil
 }
+func (e *IndexExpression) MarshalJSON() ([]byte, error) {
+	type Alias IndexExpression
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  e.Type(),
+		Alias: (*Alias)(e),
+	}
+	return json.Marshal(raw)
+}
+func (e *IndexExpression) UnmarshalJSON(data []byte) error {
+	type Alias IndexExpression
+	raw := struct {
+		*Alias
+		Array json.RawMessage `json:"array"`
+		Index json.RawMessage `json:"index"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Alias != nil {
+		*e = *(*IndexExpression)(raw.Alias)
+	}
+
+	array, err := unmarshalExpression(raw.Array)
+	if err != nil {
+		return err
+	}
+	e.Array = array
+
+	index, err := unmarshalExpression(raw.Index)
+	if err != nil {
+		return err
+	}
+	e.Index = index
+	return nil
+}
+func (e *StringExpression) MarshalJSON() ([]byte, error) {
+	type Alias StringExpression
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  e.Type(),
+		Alias: (*Alias)(e),
+	}
+	return json.Marshal(raw)
+}
+func (e *StringExpression) UnmarshalJSON(data []byte) error {
+	type Alias StringExpression
+	raw := struct {
+		*Alias
+		Parts []json.RawMessage `json:"parts"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Alias != nil {
+		*e = *(*StringExpression)(raw.Alias)
+	}
+
+	e.Parts = make([]StringExpressionPart, len(raw.Parts))
+	for i, r := range raw.Parts {
+		n, err := unmarshalNode(r)
+		if err != nil {
+			return err
+		}
+		part, ok := n.(StringExpressionPart)
+		if !ok {
+			return fmt.Errorf("node %q is not a string expression part", n.Type())
+		}
+		e.Parts[i] = part
+	}
+	return nil
+}
+func (p *TextPart) MarshalJSON() ([]byte, error) {
+	type Alias TextPart
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  p.Type(),
+		Alias: (*Alias)(p),
+	}
+	return json.Marshal(raw)
+}
+func (p *InterpolatedPart) MarshalJSON() ([]byte, error) {
+	type Alias InterpolatedPart
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  p.Type(),
+		Alias: (*Alias)(p),
+	}
+	return json.Marshal(raw)
+}
+func (p *InterpolatedPart) UnmarshalJSON(data []byte) error {
+	type Alias InterpolatedPart
+	raw := struct {
+		*Alias
+		Expression json.RawMessage `json:"expression"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Alias != nil {
+		*p = *(*InterpolatedPart)(raw.Alias)
+	}
+
+	expr, err := unmarshalExpression(raw.Expression)
+	if err != nil {
+		return err
+	}
+	p.Expression = expr
+	return nil
+}
 func (p *Property) MarshalJSON() ([]byte, error) {
 	type Alias Property
 	raw := struct {
@@ -828,10 +990,18 @@ func unmarshalNode(msg json.RawMessage) (Node, error) {
 	switch typ.Type {
 	case "Program":
 		node = new(Program)
+	case "Comment":
+		node = new(Comment)
+	case "PackageClause":
+		node = new(PackageClause)
+	case "ImportDeclaration":
+		node = new(ImportDeclaration)
 	case "BlockStatement":
 		node = new(BlockStatement)
 	case "ExpressionStatement":
 		node = new(ExpressionStatement)
+	case "OptionStatement":
+		node = new(OptionStatement)
 	case "ReturnStatement":
 		node = new(ReturnStatement)
 	case "VariableDeclaration":
@@ -854,6 +1024,14 @@ func unmarshalNode(msg json.RawMessage) (Node, error) {
 		node = new(ObjectExpression)
 	case "ConditionalExpression":
 		node = new(ConditionalExpression)
+	case "IndexExpression":
+		node = new(IndexExpression)
+	case "StringExpression":
+		node = new(StringExpression)
+	case "TextPart":
+		node = new(TextPart)
+	case "InterpolatedPart":
+		node = new(InterpolatedPart)
 	case "ArrayExpression":
 		node = new(ArrayExpression)
 	case "Identifier":
@@ -29,6 +29,38 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"Program","body":[{"type":"ExpressionStatement","expression":{"type":"StringLiteral","value":"hello"}}]}`,
 		},
+		{
+			name: "program with package and imports",
+			node: &ast.Program{
+				Package: &ast.PackageClause{
+					Name: &ast.Identifier{Name: "foo"},
+				},
+				Imports: []*ast.ImportDeclaration{{
+					As:   &ast.Identifier{Name: "m"},
+					Path: &ast.StringLiteral{Value: "math"},
+				}},
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.StringLiteral{Value: "hello"},
+					},
+				},
+			},
+			want: `{"type":"Program","package":{"type":"PackageClause","name":{"type":"Identifier","name":"foo"}},"imports":[{"type":"ImportDeclaration","as":{"type":"Identifier","name":"m"},"path":{"type":"StringLiteral","value":"math"}}],"body":[{"type":"ExpressionStatement","expression":{"type":"StringLiteral","value":"hello"}}]}`,
+		},
+		{
+			name: "program with comments",
+			node: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.StringLiteral{Value: "hello"},
+					},
+				},
+				Comments: []*ast.Comment{{
+					Text: "// say hello",
+				}},
+			},
+			want: `{"type":"Program","body":[{"type":"ExpressionStatement","expression":{"type":"StringLiteral","value":"hello"}}],"comments":[{"type":"Comment","text":"// say hello"}]}`,
+		},
 		{
 			name: "block statement",
 			node: &ast.BlockStatement{
@@ -47,6 +79,16 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"ExpressionStatement","expression":{"type":"StringLiteral","value":"hello"}}`,
 		},
+		{
+			name: "option statement",
+			node: &ast.OptionStatement{
+				Declaration: &ast.VariableDeclarator{
+					ID:   &ast.Identifier{Name: "location"},
+					Init: &ast.StringLiteral{Value: "UTC"},
+				},
+			},
+			want: `{"type":"OptionStatement","declaration":{"type":"VariableDeclarator","id":{"type":"Identifier","name":"location"},"init":{"type":"StringLiteral","value":"UTC"}}}`,
+		},
 		{
 			name: "return statement",
 			node: &ast.ReturnStatement{
@@ -161,6 +203,24 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"ConditionalExpression","test":{"type":"BooleanLiteral","value":true},"alternate":{"type":"StringLiteral","value":"false"},"consequent":{"type":"StringLiteral","value":"true"}}`,
 		},
+		{
+			name: "index expression",
+			node: &ast.IndexExpression{
+				Array: &ast.Identifier{Name: "a"},
+				Index: &ast.IntegerLiteral{Value: 3},
+			},
+			want: `{"type":"IndexExpression","array":{"type":"Identifier","name":"a"},"index":{"type":"IntegerLiteral","value":"3"}}`,
+		},
+		{
+			name: "string expression",
+			node: &ast.StringExpression{
+				Parts: []ast.StringExpressionPart{
+					&ast.TextPart{Value: "a = "},
+					&ast.InterpolatedPart{Expression: &ast.Identifier{Name: "a"}},
+				},
+			},
+			want: `{"type":"StringExpression","parts":[{"type":"TextPart","value":"a = "},{"type":"InterpolatedPart","expression":{"type":"Identifier","name":"a"}}]}`,
+		},
 		{
 			name: "property",
 			node: &ast.Property{
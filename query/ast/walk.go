@@ -0,0 +1,108 @@
+package ast
+
+// Walk visits the node and its descendants in depth first order.
+func Walk(v Visitor, node Node) {
+	walk(v, node)
+}
+
+// Visitor visits the nodes of a tree.
+// The visitor returned by Visit visits the children of the node, the children are skipped if it is nil.
+// Done is called on the returned visitor once all the children of the node have been visited.
+type Visitor interface {
+	Visit(node Node) Visitor
+	Done()
+}
+
+func walk(v Visitor, n Node) {
+	w := v.Visit(n)
+	if w == nil {
+		return
+	}
+	defer w.Done()
+	switch n := n.(type) {
+	case *Program:
+		if n.Package != nil {
+			walk(w, n.Package)
+		}
+		for _, imp := range n.Imports {
+			walk(w, imp)
+		}
+		for _, s := range n.Body {
+			walk(w, s)
+		}
+	case *PackageClause:
+		walk(w, n.Name)
+	case *ImportDeclaration:
+		if n.As != nil {
+			walk(w, n.As)
+		}
+		walk(w, n.Path)
+	case *BlockStatement:
+		for _, s := range n.Body {
+			walk(w, s)
+		}
+	case *ExpressionStatement:
+		walk(w, n.Expression)
+	case *OptionStatement:
+		walk(w, n.Declaration)
+	case *ReturnStatement:
+		walk(w, n.Argument)
+	case *VariableDeclaration:
+		for _, d := range n.Declarations {
+			walk(w, d)
+		}
+	case *VariableDeclarator:
+		walk(w, n.ID)
+		walk(w, n.Init)
+	case *CallExpression:
+		walk(w, n.Callee)
+		for _, arg := range n.Arguments {
+			walk(w, arg)
+		}
+	case *PipeExpression:
+		walk(w, n.Argument)
+		walk(w, n.Call)
+	case *MemberExpression:
+		walk(w, n.Object)
+		walk(w, n.Property)
+	case *IndexExpression:
+		walk(w, n.Array)
+		walk(w, n.Index)
+	case *ArrowFunctionExpression:
+		for _, p := range n.Params {
+			walk(w, p)
+		}
+		walk(w, n.Body)
+	case *BinaryExpression:
+		walk(w, n.Left)
+		walk(w, n.Right)
+	case *UnaryExpression:
+		walk(w, n.Argument)
+	case *LogicalExpression:
+		walk(w, n.Left)
+		walk(w, n.Right)
+	case *ConditionalExpression:
+		walk(w, n.Test)
+		walk(w, n.Consequent)
+		walk(w, n.Alternate)
+	case *ArrayExpression:
+		for _, e := range n.Elements {
+			walk(w, e)
+		}
+	case *ObjectExpression:
+		for _, p := range n.Properties {
+			walk(w, p)
+		}
+	case *Property:
+		walk(w, n.Key)
+		if n.Value != nil {
+			walk(w, n.Value)
+		}
+	case *StringExpression:
+		for _, p := range n.Parts {
+			walk(w, p)
+		}
+	case *InterpolatedPart:
+		walk(w, n.Expression)
+	}
+}
@@ -0,0 +1,189 @@
+package benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/execute"
+	"github.com/influxdata/platform/query/execute/executetest"
+	"github.com/influxdata/platform/query/functions"
+	"github.com/influxdata/platform/query/id"
+	"github.com/influxdata/platform/query/plan"
+	"github.com/influxdata/platform/query/values"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	seriesN = 1000
+	pointsN = 100
+)
+
+// seriesBlocks is a block for each of seriesN series with pointsN points each.
+var seriesBlocks []query.Block
+
+func init() {
+	execute.RegisterSource(benchFromKind, createBenchFromSource)
+
+	start := execute.Time(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano())
+	stop := start + execute.Time(pointsN*time.Second)
+	seriesBlocks = make([]query.Block, seriesN)
+	for i := range seriesBlocks {
+		host := fmt.Sprintf("host%04d", i)
+		key := execute.NewPartitionKey(
+			[]query.ColMeta{
+				{Label: execute.DefaultStartColLabel, Type: query.TTime},
+				{Label: execute.DefaultStopColLabel, Type: query.TTime},
+				{Label: "host", Type: query.TString},
+			},
+			[]values.Value{
+				values.NewTimeValue(start),
+				values.NewTimeValue(stop),
+				values.NewStringValue(host),
+			},
+		)
+		builder := execute.NewColListBlockBuilder(key, executetest.UnlimitedAllocator)
+		builder.AddCol(query.ColMeta{Label: execute.DefaultStartColLabel, Type: query.TTime})
+		builder.AddCol(query.ColMeta{Label: execute.DefaultStopColLabel, Type: query.TTime})
+		builder.AddCol(query.ColMeta{Label: execute.DefaultTimeColLabel, Type: query.TTime})
+		builder.AddCol(query.ColMeta{Label: execute.DefaultValueColLabel, Type: query.TFloat})
+		builder.AddCol(query.ColMeta{Label: "host", Type: query.TString})
+		for j := 0; j < pointsN; j++ {
+			builder.AppendTime(0, start)
+			builder.AppendTime(1, stop)
+			builder.AppendTime(2, start+execute.Time(time.Duration(j)*time.Second))
+			builder.AppendFloat(3, float64(i*j))
+			builder.AppendString(4, host)
+		}
+		b, _ := builder.Block()
+		seriesBlocks[i] = staticBlock{Block: b}
+	}
+}
+
+// staticBlock is a block that is never freed so that it can be reused by every benchmark iteration.
+type staticBlock struct {
+	query.Block
+}
+
+func (staticBlock) RefCount(n int) {}
+
+const benchFromKind = "from-bench"
+
+type benchFromProcedureSpec struct {
+	ts []execute.Transformation
+}
+
+func (s *benchFromProcedureSpec) Kind() plan.ProcedureKind {
+	return benchFromKind
+}
+
+func (s *benchFromProcedureSpec) Copy() plan.ProcedureSpec {
+	return s
+}
+
+func (s *benchFromProcedureSpec) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *benchFromProcedureSpec) Run(ctx context.Context) {
+	id := execute.DatasetID(uuid.NewV4())
+	for _, t := range s.ts {
+		for _, b := range seriesBlocks {
+			t.Process(id, b)
+		}
+		t.UpdateWatermark(id, execute.MaxTime)
+		t.Finish(id, nil)
+	}
+}
+
+func createBenchFromSource(spec plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	return spec.(*benchFromProcedureSpec), nil
+}
+
+// benchPlan creates a plan reading all series and applying the procedures one after another.
+func benchPlan(concurrency int, specs ...plan.ProcedureSpec) *plan.PlanSpec {
+	now := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+	p := &plan.PlanSpec{
+		Now: now,
+		Resources: query.ResourceManagement{
+			ConcurrencyQuota: concurrency,
+			MemoryBytesQuota: math.MaxInt64,
+		},
+		Bounds: plan.BoundsSpec{
+			Start: query.Time{Absolute: now.Add(-24 * time.Hour)},
+			Stop:  query.Time{Absolute: now},
+		},
+		Procedures: make(map[plan.ProcedureID]*plan.Procedure),
+	}
+	parent := plan.ProcedureIDFromOperationID("from")
+	p.Procedures[parent] = &plan.Procedure{
+		ID:   parent,
+		Spec: new(benchFromProcedureSpec),
+	}
+	for i, spec := range specs {
+		id := plan.ProcedureIDFromOperationID(query.OperationID(fmt.Sprintf("op%d", i)))
+		p.Procedures[id] = &plan.Procedure{
+			ID:      id,
+			Spec:    spec,
+			Parents: []plan.ProcedureID{parent},
+		}
+		p.Procedures[parent].Children = []plan.ProcedureID{id}
+		parent = id
+	}
+	p.Results = map[string]plan.YieldSpec{
+		plan.DefaultYieldName: {ID: parent},
+	}
+	return p
+}
+
+func benchmarkExecute(b *testing.B, newSpecs func() []plan.ProcedureSpec) {
+	var orgID id.ID
+	orgID.DecodeFromString("aaaa")
+	for _, concurrency := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("concurrency-%d", concurrency), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				exe := execute.NewExecutor(nil)
+				results, err := exe.Execute(context.Background(), orgID, benchPlan(concurrency, newSpecs()...))
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, r := range results {
+					if err := r.Blocks().Do(func(query.Block) error { return nil }); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkExecute_Sum(b *testing.B) {
+	benchmarkExecute(b, func() []plan.ProcedureSpec {
+		return []plan.ProcedureSpec{
+			&functions.SumProcedureSpec{AggregateConfig: execute.DefaultAggregateConfig},
+		}
+	})
+}
+
+func BenchmarkExecute_WindowMean(b *testing.B) {
+	benchmarkExecute(b, func() []plan.ProcedureSpec {
+		return []plan.ProcedureSpec{
+			&functions.WindowProcedureSpec{
+				Window: plan.WindowSpec{
+					Every:  query.Duration(time.Minute),
+					Period: query.Duration(time.Minute),
+				},
+				Triggering:    query.DefaultTrigger,
+				TimeCol:       execute.DefaultTimeColLabel,
+				StartColLabel: execute.DefaultStartColLabel,
+				StopColLabel:  execute.DefaultStopColLabel,
+			},
+			&functions.MeanProcedureSpec{AggregateConfig: execute.DefaultAggregateConfig},
+		}
+	})
+}
@@ -7,15 +7,22 @@ import (
 	"github.com/influxdata/platform/query/complete"
 	_ "github.com/influxdata/platform/query/functions" // Import the built-in functions
 	"github.com/influxdata/platform/query/interpreter"
+	"github.com/influxdata/platform/query/semantic"
 )
 
 func init() {
 	query.FinalizeBuiltIns()
 }
 
-// DefaultCompleter creates a completer with builtin scope and declarations
+// DefaultCompleter creates a completer with builtin scope and declarations.
+// Every builtin package is available by its name so that its members can be suggested.
 func DefaultCompleter() complete.Completer {
 	scope, declarations := query.BuiltIns()
 	interpScope := interpreter.NewScopeWithValues(scope)
+	for path, pkg := range query.BuiltInPackages() {
+		name := query.PackageName(path)
+		interpScope.Set(name, pkg)
+		declarations[name] = semantic.NewExternalVariableDeclaration(name, pkg.Type())
+	}
 	return complete.NewCompleter(interpScope, declarations)
 }
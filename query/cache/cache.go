@@ -0,0 +1,376 @@
+// Package cache provides a result cache in front of the query services.
+//
+// Results are keyed by the organization, the normalized query spec and the absolute time bounds of the query.
+// Relative time bounds are resolved against the current time truncated to the configured resolution,
+// so queries relative to now share cached results only while now falls within the same interval.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/execute"
+	"github.com/influxdata/platform/query/plan"
+)
+
+const (
+	// DefaultTTL is the default maximum age of a cached result.
+	DefaultTTL = 10 * time.Second
+	// DefaultResolution is the default granularity used to resolve relative time bounds.
+	DefaultResolution = 5 * time.Second
+	// DefaultMaxEntries is the default maximum number of cached results.
+	DefaultMaxEntries = 1000
+	// DefaultMaxBytes is the default maximum number of bytes used by all cached results.
+	DefaultMaxBytes = 256 * 1024 * 1024
+	// DefaultMaxEntryBytes is the default maximum number of bytes used by a single cached result.
+	DefaultMaxEntryBytes = 16 * 1024 * 1024
+)
+
+// Config configures a Cache.
+// Zero values are replaced with their defaults.
+type Config struct {
+	// TTL is the maximum amount of time a result is served from the cache.
+	TTL time.Duration
+	// Resolution is the interval to which now is truncated before resolving relative time bounds.
+	Resolution time.Duration
+	// MaxEntries is the maximum number of cached results.
+	MaxEntries int
+	// MaxBytes is the maximum number of bytes used by all cached results.
+	MaxBytes int64
+	// MaxEntryBytes is the maximum number of bytes a single query result may use and still be cached.
+	MaxEntryBytes int64
+}
+
+// DefaultConfig returns the default cache configuration.
+func DefaultConfig() Config {
+	return Config{
+		TTL:           DefaultTTL,
+		Resolution:    DefaultResolution,
+		MaxEntries:    DefaultMaxEntries,
+		MaxBytes:      DefaultMaxBytes,
+		MaxEntryBytes: DefaultMaxEntryBytes,
+	}
+}
+
+func (c Config) withDefaults() Config {
+	d := DefaultConfig()
+	if c.TTL <= 0 {
+		c.TTL = d.TTL
+	}
+	if c.Resolution <= 0 {
+		c.Resolution = d.Resolution
+	}
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = d.MaxEntries
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = d.MaxBytes
+	}
+	if c.MaxEntryBytes <= 0 {
+		c.MaxEntryBytes = d.MaxEntryBytes
+	}
+	return c
+}
+
+// Cache stores query results in memory, evicting the least recently used results first.
+type Cache struct {
+	config Config
+
+	// Now returns the current time, it may be replaced in tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	bytes   int64
+}
+
+// New creates a new cache with the given configuration.
+func New(c Config) *Cache {
+	return &Cache{
+		config:  c.withDefaults(),
+		Now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+type entry struct {
+	key     string
+	org     string
+	expires time.Time
+	size    int64
+	results []*cachedResult
+}
+
+// Key computes the cache key for the query.
+// The second return value is false if the query cannot be cached, for example because it is unbounded.
+func (c *Cache) Key(orgID platform.ID, spec *query.Spec) (string, bool) {
+	now := c.Now().Truncate(c.config.Resolution)
+	bounds, ok := resolveBounds(spec, now)
+	if !ok {
+		return "", false
+	}
+	normalized, err := json.Marshal(spec)
+	if err != nil {
+		return "", false
+	}
+	h := sha256.New()
+	h.Write(orgID)
+	h.Write(normalized)
+	b, _ := bounds.Start.MarshalBinary()
+	h.Write(b)
+	b, _ = bounds.Stop.MarshalBinary()
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// resolveBounds plans the query in order to find its absolute time bounds.
+func resolveBounds(spec *query.Spec, now time.Time) (plan.TimeRange, bool) {
+	lp, err := plan.NewLogicalPlanner().Plan(spec)
+	if err != nil {
+		return plan.TimeRange{}, false
+	}
+	pp, err := plan.NewPlanner().Plan(lp, nil, now)
+	if err != nil {
+		return plan.TimeRange{}, false
+	}
+	r := plan.TimeRange{
+		Start: pp.Bounds.Start.Time(now),
+		Stop:  pp.Bounds.Stop.Time(now),
+	}
+	if pp.Bounds.Stop.IsZero() {
+		r.Stop = now
+	}
+	return r, true
+}
+
+// get returns the cached results for key if they have not expired.
+func (c *Cache) get(key string) ([]*cachedResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if !c.Now().Before(e.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return e.results, true
+}
+
+// put stores the results under key, evicting older results as needed to stay within the limits.
+func (c *Cache) put(key, org string, results []*cachedResult, size int64) {
+	if size > c.config.MaxEntryBytes || size > c.config.MaxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	for c.lru.Len() >= c.config.MaxEntries || c.bytes+size > c.config.MaxBytes {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			break
+		}
+		evictionsCounter.WithLabelValues(oldest.Value.(*entry).org).Inc()
+		c.remove(oldest)
+	}
+	e := &entry{
+		key:     key,
+		org:     org,
+		expires: c.Now().Add(c.config.TTL),
+		size:    size,
+		results: results,
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	c.bytes += size
+	entriesGauge.Set(float64(c.lru.Len()))
+	bytesGauge.Set(float64(c.bytes))
+}
+
+// remove deletes the element from the cache, the lock must be held.
+func (c *Cache) remove(elem *list.Element) {
+	e := elem.Value.(*entry)
+	c.lru.Remove(elem)
+	delete(c.entries, e.key)
+	c.bytes -= e.size
+	entriesGauge.Set(float64(c.lru.Len()))
+	bytesGauge.Set(float64(c.bytes))
+}
+
+// Len reports the number of cached results.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+type bypassKey struct{}
+
+// WithBypass returns a context that instructs the cache to execute the query
+// without serving or storing cached results.
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// BypassFromContext reports whether the cache should be bypassed for the request.
+func BypassFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassKey{}).(bool)
+	return bypass
+}
+
+// cachedResult is a fully materialized query result.
+type cachedResult struct {
+	name   string
+	blocks []query.Block
+}
+
+func (r *cachedResult) Name() string {
+	return r.name
+}
+
+func (r *cachedResult) Blocks() query.BlockIterator {
+	return r
+}
+
+func (r *cachedResult) Do(f func(query.Block) error) error {
+	for _, b := range r.blocks {
+		if err := f(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cachedBlock is a block that is shared between all readers of a cached result.
+// Reference counting is ignored so that readers cannot free the cached data.
+type cachedBlock struct {
+	query.Block
+}
+
+func (cachedBlock) RefCount(n int) {}
+
+func toResults(cached []*cachedResult) []query.Result {
+	results := make([]query.Result, len(cached))
+	for i, r := range cached {
+		results[i] = r
+	}
+	return results
+}
+
+// recorder copies the blocks of the results as they are read,
+// so that complete results can be stored in the cache.
+type recorder struct {
+	cache *Cache
+	key   string
+	org   string
+	alloc *execute.Allocator
+
+	mu       sync.Mutex
+	results  []*recordingResult
+	overflow bool
+	failed   bool
+}
+
+func newRecorder(c *Cache, key, org string) *recorder {
+	return &recorder{
+		cache: c,
+		key:   key,
+		org:   org,
+		alloc: &execute.Allocator{Limit: math.MaxInt64},
+	}
+}
+
+func (r *recorder) wrap(res query.Result) *recordingResult {
+	rr := &recordingResult{
+		Result: res,
+		rec:    r,
+		cached: &cachedResult{name: res.Name()},
+	}
+	r.mu.Lock()
+	r.results = append(r.results, rr)
+	r.mu.Unlock()
+	return rr
+}
+
+// record copies the block unless the recording has been abandoned.
+// The returned block should be passed on to the reader.
+func (r *recorder) record(rr *recordingResult, b query.Block) query.Block {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.overflow || r.failed {
+		return b
+	}
+	cpy := cachedBlock{Block: execute.CopyBlock(b, r.alloc)}
+	if r.alloc.Max() > r.cache.config.MaxEntryBytes {
+		// The results are too large to cache, stop recording.
+		r.overflow = true
+		r.results = nil
+		return cpy
+	}
+	rr.cached.blocks = append(rr.cached.blocks, cpy)
+	return cpy
+}
+
+func (r *recorder) fail() {
+	r.mu.Lock()
+	r.failed = true
+	r.results = nil
+	r.mu.Unlock()
+}
+
+// finish stores the results in the cache if all of them were read completely.
+func (r *recorder) finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil || r.overflow || r.failed {
+		return
+	}
+	cached := make([]*cachedResult, len(r.results))
+	for i, rr := range r.results {
+		if !rr.complete {
+			return
+		}
+		cached[i] = rr.cached
+	}
+	r.cache.put(r.key, r.org, cached, r.alloc.Max())
+}
+
+// recordingResult records the blocks of a result as they are read.
+type recordingResult struct {
+	query.Result
+	rec      *recorder
+	cached   *cachedResult
+	complete bool
+}
+
+func (r *recordingResult) Blocks() query.BlockIterator {
+	return r
+}
+
+func (r *recordingResult) Do(f func(query.Block) error) error {
+	err := r.Result.Blocks().Do(func(b query.Block) error {
+		return f(r.rec.record(r, b))
+	})
+	if err != nil {
+		r.rec.fail()
+		return err
+	}
+	r.rec.mu.Lock()
+	r.complete = true
+	r.rec.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,239 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/cache"
+	"github.com/influxdata/platform/query/execute"
+	"github.com/influxdata/platform/query/execute/executetest"
+)
+
+var orgID = platform.ID("org")
+
+type fakeQueryService struct {
+	calls  int
+	err    error
+	blocks []*executetest.Block
+}
+
+func (s *fakeQueryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.ResultIterator, error) {
+	s.calls++
+	results := []query.Result{&errResult{
+		Result: &executetest.Result{Nm: "_result", Blks: s.blocks},
+		err:    s.err,
+	}}
+	return query.NewSliceResultIterator(results), nil
+}
+
+func (s *fakeQueryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.ResultIterator, error) {
+	spec, err := query.Compile(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return s.Query(ctx, orgID, spec)
+}
+
+// errResult returns err after all blocks have been read.
+type errResult struct {
+	*executetest.Result
+	err error
+}
+
+func (r *errResult) Blocks() query.BlockIterator {
+	return r
+}
+
+func (r *errResult) Do(f func(query.Block) error) error {
+	if err := r.Result.Blocks().Do(f); err != nil {
+		return err
+	}
+	return r.err
+}
+
+var testBlocks = []*executetest.Block{{
+	KeyCols: []string{"t0"},
+	ColMeta: []query.ColMeta{
+		{Label: "_time", Type: query.TTime},
+		{Label: "_value", Type: query.TFloat},
+		{Label: "t0", Type: query.TString},
+	},
+	Data: [][]interface{}{
+		{execute.Time(1), 1.0, "a"},
+		{execute.Time(2), 2.0, "a"},
+	},
+}}
+
+func readAll(t *testing.T, s query.QueryService, ctx context.Context, q string) ([]*executetest.Block, error) {
+	t.Helper()
+	results, err := s.QueryWithCompile(ctx, orgID, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer results.Cancel()
+	var blocks []*executetest.Block
+	for results.More() {
+		err := results.Next().Blocks().Do(func(b query.Block) error {
+			blk, err := executetest.ConvertBlock(b)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, blk)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+	executetest.NormalizeBlocks(blocks)
+	return blocks, nil
+}
+
+func newService(config cache.Config, now *time.Time) (*cache.QueryService, *fakeQueryService) {
+	c := cache.New(config)
+	c.Now = func() time.Time { return *now }
+	fake := &fakeQueryService{blocks: testBlocks}
+	return cache.NewQueryService(c, fake), fake
+}
+
+const relativeQuery = `from(db:"test") |> range(start:-1h)`
+
+func TestQueryService(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 1, 0, time.UTC)
+	testCases := []struct {
+		name      string
+		config    cache.Config
+		query     string
+		ctx       func() context.Context
+		fail      bool
+		advance   time.Duration
+		wantCalls int
+	}{
+		{
+			name:      "hit",
+			query:     relativeQuery,
+			wantCalls: 1,
+		},
+		{
+			name:      "absolute bounds",
+			query:     `from(db:"test") |> range(start:2018-01-01T00:00:00Z, stop:2018-01-02T00:00:00Z)`,
+			advance:   time.Minute,
+			config:    cache.Config{TTL: time.Hour},
+			wantCalls: 1,
+		},
+		{
+			name:      "unbounded",
+			query:     `from(db:"test")`,
+			wantCalls: 2,
+		},
+		{
+			name:      "bypass",
+			query:     relativeQuery,
+			ctx:       func() context.Context { return cache.WithBypass(context.Background()) },
+			wantCalls: 2,
+		},
+		{
+			name:      "error",
+			query:     relativeQuery,
+			fail:      true,
+			wantCalls: 2,
+		},
+		{
+			name:      "expired",
+			query:     relativeQuery,
+			config:    cache.Config{TTL: time.Second},
+			advance:   2 * time.Second,
+			wantCalls: 2,
+		},
+		{
+			name:      "relative bounds within resolution",
+			query:     relativeQuery,
+			config:    cache.Config{TTL: time.Hour, Resolution: 5 * time.Second},
+			advance:   2 * time.Second,
+			wantCalls: 1,
+		},
+		{
+			name:      "relative bounds across resolution",
+			query:     relativeQuery,
+			config:    cache.Config{TTL: time.Hour, Resolution: 5 * time.Second},
+			advance:   5 * time.Second,
+			wantCalls: 2,
+		},
+		{
+			name:      "too large",
+			query:     relativeQuery,
+			config:    cache.Config{MaxEntryBytes: 8},
+			wantCalls: 2,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			now := now
+			s, fake := newService(tc.config, &now)
+			ctx := context.Background()
+			if tc.ctx != nil {
+				ctx = tc.ctx()
+			}
+			if tc.fail {
+				fake.err = errors.New("expected error")
+				if _, err := readAll(t, s, ctx, tc.query); err == nil {
+					t.Fatal("expected error")
+				}
+				fake.err = nil
+			} else if _, err := readAll(t, s, ctx, tc.query); err != nil {
+				t.Fatal(err)
+			}
+
+			now = now.Add(tc.advance)
+
+			got, err := readAll(t, s, ctx, tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make([]*executetest.Block, len(testBlocks))
+			for i, b := range testBlocks {
+				cpy := *b
+				want[i] = &cpy
+			}
+			executetest.NormalizeBlocks(want)
+			if !cmp.Equal(want, got) {
+				t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(want, got))
+			}
+			if fake.calls != tc.wantCalls {
+				t.Errorf("unexpected number of calls: want %d got %d", tc.wantCalls, fake.calls)
+			}
+		})
+	}
+}
+
+func TestCache_Evict(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, fake := newService(cache.Config{MaxEntries: 1}, &now)
+	ctx := context.Background()
+	queries := []string{
+		`from(db:"a") |> range(start:-1h)`,
+		`from(db:"b") |> range(start:-1h)`,
+		`from(db:"a") |> range(start:-1h)`,
+	}
+	for _, q := range queries {
+		if _, err := readAll(t, s, ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := s.Cache.Len(), 1; got != want {
+		t.Errorf("unexpected number of entries: want %d got %d", want, got)
+	}
+	if got, want := fake.calls, 3; got != want {
+		t.Errorf("unexpected number of calls: want %d got %d", want, got)
+	}
+}
@@ -0,0 +1,66 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	namespace = "query"
+	subsystem = "cache"
+)
+
+var (
+	labels = []string{"org"}
+)
+
+var (
+	hitsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "hits_total",
+		Help:      "Number of queries served from the cache",
+	}, labels)
+
+	missesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "misses_total",
+		Help:      "Number of cacheable queries not found in the cache",
+	}, labels)
+
+	bypassCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "bypass_total",
+		Help:      "Number of queries that bypassed the cache",
+	}, labels)
+
+	evictionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "evictions_total",
+		Help:      "Number of results evicted from the cache to stay within its limits",
+	}, labels)
+
+	entriesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "entries",
+		Help:      "Number of results in the cache",
+	})
+
+	bytesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "bytes",
+		Help:      "Number of bytes used by the results in the cache",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(hitsCounter)
+	prometheus.MustRegister(missesCounter)
+	prometheus.MustRegister(bypassCounter)
+	prometheus.MustRegister(evictionsCounter)
+
+	prometheus.MustRegister(entriesGauge)
+	prometheus.MustRegister(bytesGauge)
+}
@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+)
+
+// QueryService implements query.QueryService by serving results from the cache when possible
+// and otherwise delegating to the wrapped QueryService.
+type QueryService struct {
+	Cache        *Cache
+	QueryService query.QueryService
+}
+
+// NewQueryService creates a QueryService that caches the results of s.
+func NewQueryService(c *Cache, s query.QueryService) *QueryService {
+	return &QueryService{
+		Cache:        c,
+		QueryService: s,
+	}
+}
+
+func (s *QueryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.ResultIterator, error) {
+	org := orgID.String()
+	if BypassFromContext(ctx) {
+		bypassCounter.WithLabelValues(org).Inc()
+		return s.QueryService.Query(ctx, orgID, spec)
+	}
+	key, ok := s.Cache.Key(orgID, spec)
+	if !ok {
+		return s.QueryService.Query(ctx, orgID, spec)
+	}
+	if cached, ok := s.Cache.get(key); ok {
+		hitsCounter.WithLabelValues(org).Inc()
+		return query.NewSliceResultIterator(toResults(cached)), nil
+	}
+	missesCounter.WithLabelValues(org).Inc()
+
+	results, err := s.QueryService.Query(ctx, orgID, spec)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingIterator{
+		ResultIterator: results,
+		rec:            newRecorder(s.Cache, key, org),
+	}, nil
+}
+
+func (s *QueryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.ResultIterator, error) {
+	if BypassFromContext(ctx) {
+		bypassCounter.WithLabelValues(orgID.String()).Inc()
+		return s.QueryService.QueryWithCompile(ctx, orgID, q)
+	}
+	spec, err := query.Compile(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return s.Query(ctx, orgID, spec)
+}
+
+// recordingIterator records results as they are read and caches them once all results have been read.
+type recordingIterator struct {
+	query.ResultIterator
+	rec      *recorder
+	finished bool
+}
+
+func (r *recordingIterator) More() bool {
+	if r.ResultIterator.More() {
+		return true
+	}
+	if !r.finished {
+		r.finished = true
+		r.rec.finish(r.ResultIterator.Err())
+	}
+	return false
+}
+
+func (r *recordingIterator) Next() query.Result {
+	return r.rec.wrap(r.ResultIterator.Next())
+}
+
+func (r *recordingIterator) Cancel() {
+	r.rec.fail()
+	r.ResultIterator.Cancel()
+}
+
+// AsyncQueryService implements query.AsyncQueryService by serving results from the cache when possible
+// and otherwise delegating to the wrapped AsyncQueryService.
+type AsyncQueryService struct {
+	Cache             *Cache
+	AsyncQueryService query.AsyncQueryService
+}
+
+// NewAsyncQueryService creates an AsyncQueryService that caches the results of s.
+func NewAsyncQueryService(c *Cache, s query.AsyncQueryService) *AsyncQueryService {
+	return &AsyncQueryService{
+		Cache:             c,
+		AsyncQueryService: s,
+	}
+}
+
+func (s *AsyncQueryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.Query, error) {
+	org := orgID.String()
+	if BypassFromContext(ctx) {
+		bypassCounter.WithLabelValues(org).Inc()
+		return s.AsyncQueryService.Query(ctx, orgID, spec)
+	}
+	key, ok := s.Cache.Key(orgID, spec)
+	if !ok {
+		return s.AsyncQueryService.Query(ctx, orgID, spec)
+	}
+	if cached, ok := s.Cache.get(key); ok {
+		hitsCounter.WithLabelValues(org).Inc()
+		return newCachedQuery(spec, cached), nil
+	}
+	missesCounter.WithLabelValues(org).Inc()
+
+	q, err := s.AsyncQueryService.Query(ctx, orgID, spec)
+	if err != nil {
+		return nil, err
+	}
+	return newRecordingQuery(q, newRecorder(s.Cache, key, org)), nil
+}
+
+func (s *AsyncQueryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.Query, error) {
+	if BypassFromContext(ctx) {
+		bypassCounter.WithLabelValues(orgID.String()).Inc()
+		return s.AsyncQueryService.QueryWithCompile(ctx, orgID, q)
+	}
+	spec, err := query.Compile(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return s.Query(ctx, orgID, spec)
+}
+
+// cachedQuery implements query.Query for results served from the cache.
+type cachedQuery struct {
+	spec  *query.Spec
+	ready chan map[string]query.Result
+	once  sync.Once
+}
+
+func newCachedQuery(spec *query.Spec, cached []*cachedResult) *cachedQuery {
+	results := make(map[string]query.Result, len(cached))
+	for _, r := range cached {
+		results[r.Name()] = r
+	}
+	ready := make(chan map[string]query.Result, 1)
+	ready <- results
+	return &cachedQuery{
+		spec:  spec,
+		ready: ready,
+	}
+}
+
+func (q *cachedQuery) Spec() *query.Spec {
+	return q.spec
+}
+
+func (q *cachedQuery) Ready() <-chan map[string]query.Result {
+	return q.ready
+}
+
+func (q *cachedQuery) Done() {
+	q.once.Do(func() {
+		close(q.ready)
+	})
+}
+
+func (q *cachedQuery) Cancel() {
+	q.Done()
+}
+
+func (q *cachedQuery) Err() error {
+	return nil
+}
+
+// recordingQuery records the results of the wrapped query as they are read
+// and caches them once the query is done.
+type recordingQuery struct {
+	query.Query
+	rec   *recorder
+	ready chan map[string]query.Result
+	once  sync.Once
+}
+
+func newRecordingQuery(q query.Query, rec *recorder) *recordingQuery {
+	rq := &recordingQuery{
+		Query: q,
+		rec:   rec,
+		ready: make(chan map[string]query.Result, 1),
+	}
+	go rq.wrapResults()
+	return rq
+}
+
+func (q *recordingQuery) wrapResults() {
+	defer close(q.ready)
+	results, ok := <-q.Query.Ready()
+	if !ok {
+		return
+	}
+	wrapped := make(map[string]query.Result, len(results))
+	for name, r := range results {
+		wrapped[name] = q.rec.wrap(r)
+	}
+	q.ready <- wrapped
+}
+
+func (q *recordingQuery) Ready() <-chan map[string]query.Result {
+	return q.ready
+}
+
+func (q *recordingQuery) Done() {
+	q.Query.Done()
+	q.once.Do(func() {
+		q.rec.finish(q.Query.Err())
+	})
+}
+
+func (q *recordingQuery) Cancel() {
+	q.rec.fail()
+	q.Query.Cancel()
+}
@@ -32,8 +32,17 @@ func Verbose(v bool) Option {
 	}
 }
 
+// Variables declares the variables in the scope of the Flux script, as if they were declared before it.
+// Variables must not have the name of a builtin.
+func Variables(vars map[string]values.Value) Option {
+	return func(o *options) {
+		o.variables = vars
+	}
+}
+
 type options struct {
-	verbose bool
+	verbose   bool
+	variables map[string]values.Value
 }
 
 // Compile evaluates a Flux script producing a query Spec.
@@ -53,18 +62,37 @@ func Compile(ctx context.Context, q string, opts ...Option) (*Spec, error) {
 
 	qd := new(queryDomain)
 	scope, decls := builtIns(qd)
+	for name, v := range o.variables {
+		if _, ok := scope[name]; ok {
+			return nil, fmt.Errorf("variable %q has the name of a builtin", name)
+		}
+		scope[name] = v
+		decls[name] = semantic.NewExternalVariableDeclaration(name, v.Type())
+	}
 	interpScope := interpreter.NewScopeWithValues(scope)
 
+	imp := newPackageImporter(qd, scope, decls)
+	if err := imp.ResolveImports(astProg, interpScope, decls); err != nil {
+		return nil, err
+	}
+
 	// Convert AST program to a semantic program
+	builtinDecls := decls.Copy()
 	semProg, err := semantic.New(astProg, decls)
 	if err != nil {
 		return nil, err
 	}
+	if err := semantic.Infer(semProg, builtinDecls); err != nil {
+		return nil, err
+	}
 
 	if err := interpreter.Eval(semProg, interpScope); err != nil {
 		return nil, err
 	}
 	spec := qd.ToSpec()
+	if err := applyOptions(spec, interpScope); err != nil {
+		return nil, err
+	}
 
 	if o.verbose {
 		log.Println("Query Spec: ", Formatted(spec, FmtJSON))
@@ -112,15 +140,16 @@ func RegisterBuiltInValue(name string, v values.Value) {
 }
 
 // FinalizeBuiltIns must be called to complete registration.
-// Future calls to RegisterFunction, RegisterBuiltIn or RegisterBuiltInValue will panic.
+// Future calls to RegisterFunction, RegisterBuiltIn, RegisterBuiltInValue or any of the package registration functions will panic.
 func FinalizeBuiltIns() {
 	if finalized {
 		panic("already finalized")
 	}
 	finalized = true
-	// Call BuiltIns to validate all built-in values are valid.
+	// Call BuiltIns and BuiltInPackages to validate all built-in values are valid.
 	// A panic will occur if any value is invalid.
 	_, _ = BuiltIns()
+	_ = BuiltInPackages()
 }
 
 var TableObjectType = semantic.NewObjectType(map[string]semantic.Type{
@@ -201,6 +230,9 @@ func (t TableObject) Time() values.Time {
 func (t TableObject) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Duration))
 }
+func (t TableObject) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Object, semantic.Bytes))
+}
 func (t TableObject) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Regexp))
 }
@@ -261,17 +293,22 @@ func BuiltIns() (map[string]values.Value, semantic.DeclarationScope) {
 	return builtIns(qd)
 }
 
+// BuiltInsWithImporter is like BuiltIns but also returns an importer for the builtin packages.
+// The functions of the imported packages and of the builtin scope share the same query domain.
+func BuiltInsWithImporter() (map[string]values.Value, semantic.DeclarationScope, *PackageImporter) {
+	if !finalized {
+		panic("builtins not finalized")
+	}
+	qd := new(queryDomain)
+	scope, decls := builtIns(qd)
+	return scope, decls, newPackageImporter(qd, scope, decls)
+}
+
 func builtIns(qd *queryDomain) (map[string]values.Value, semantic.DeclarationScope) {
 	decls := builtinDeclarations.Copy()
 	scope := make(map[string]values.Value, len(builtinScope))
 	for k, v := range builtinScope {
-		if v.Type().Kind() == semantic.Function {
-			if f, ok := v.Function().(function); ok {
-				f.qd = qd
-				v = f
-			}
-		}
-		scope[k] = v
+		scope[k] = bindDomain(v, qd)
 	}
 	interpScope := interpreter.NewScopeWithValues(scope)
 	for name, script := range builtins {
@@ -291,6 +328,17 @@ func builtIns(qd *queryDomain) (map[string]values.Value, semantic.DeclarationSco
 	return scope, decls
 }
 
+// bindDomain returns the value with any builtin function bound to the query domain.
+func bindDomain(v values.Value, qd *queryDomain) values.Value {
+	if v.Type().Kind() == semantic.Function {
+		if f, ok := v.Function().(function); ok {
+			f.qd = qd
+			return f
+		}
+	}
+	return v
+}
+
 type Administration struct {
 	id      OperationID
 	parents values.Array
@@ -399,6 +447,9 @@ func (f function) Time() values.Time {
 func (f function) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Function, semantic.Duration))
 }
+func (f function) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Function, semantic.Bytes))
+}
 func (f function) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Function, semantic.Regexp))
 }
@@ -0,0 +1,34 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/semantic"
+)
+
+func TestCompile_TypeErrors(t *testing.T) {
+	q := `inc = (n) => n + 1
+from(db: "mydb")
+	|> limit(n: inc(n: "10"))
+	|> filter(fn: (r) => r._measurement == "cpu" and 1)`
+	_, err := query.Compile(context.Background(), q)
+	typeErrs, ok := err.(semantic.TypeErrors)
+	if !ok {
+		t.Fatalf("expected type errors, got %v", err)
+	}
+	var got []string
+	for _, e := range typeErrs {
+		got = append(got, e.Error())
+	}
+	want := []string{
+		`3:21: invalid argument "n": expected int but found string`,
+		`4:51: expected bool but found int`,
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected type errors -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
@@ -23,7 +23,11 @@ func Compile(f *semantic.FunctionExpression, inTypes map[string]semantic.Type, b
 	f = f.Copy().(*semantic.FunctionExpression)
 	semantic.ApplyNewDeclarations(f, declarations)
 
-	root, err := compile(f.Body, builtinScope)
+	env := newCompileEnv(builtinScope)
+	for k, t := range inTypes {
+		env.types[k] = t
+	}
+	root, err := compile(f.Body, env)
 	if err != nil {
 		return nil, err
 	}
@@ -37,25 +41,83 @@ func Compile(f *semantic.FunctionExpression, inTypes map[string]semantic.Type, b
 	}, nil
 }
 
-func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
+// compileEnv is the environment in which a node is compiled.
+type compileEnv struct {
+	builtIns Scope
+	// types are the types of the variables declared within the compiled function.
+	types map[string]semantic.Type
+	// functions are the functions declared within the compiled function.
+	functions map[string]*semantic.FunctionExpression
+	// calls are the functions whose calls are being compiled, used to detect recursion.
+	calls []*semantic.FunctionExpression
+}
+
+func newCompileEnv(builtIns Scope) *compileEnv {
+	return &compileEnv{
+		builtIns:  builtIns,
+		types:     make(map[string]semantic.Type),
+		functions: make(map[string]*semantic.FunctionExpression),
+	}
+}
+
+// nest returns a new environment in which declarations do not affect the parent environment.
+func (env *compileEnv) nest() *compileEnv {
+	nested := &compileEnv{
+		builtIns:  env.builtIns,
+		types:     make(map[string]semantic.Type, len(env.types)),
+		functions: make(map[string]*semantic.FunctionExpression, len(env.functions)),
+		calls:     env.calls,
+	}
+	for k, t := range env.types {
+		nested.types[k] = t
+	}
+	for k, f := range env.functions {
+		nested.functions[k] = f
+	}
+	return nested
+}
+
+// declare records the type of a variable, and the function it was declared with if any.
+func (env *compileEnv) declare(name string, t semantic.Type, f *semantic.FunctionExpression) {
+	env.types[name] = t
+	if f != nil {
+		env.functions[name] = f
+	} else {
+		delete(env.functions, name)
+	}
+}
+
+func compile(n semantic.Node, env *compileEnv) (Evaluator, error) {
 	switch n := n.(type) {
 	case *semantic.BlockStatement:
-		body := make([]Evaluator, len(n.Body))
-		for i, s := range n.Body {
-			node, err := compile(s, builtIns)
+		env = env.nest()
+		body := make([]Evaluator, 0, len(n.Body))
+		for _, s := range n.Body {
+			// Functions declared in the block are compiled where they are called,
+			// once the types of their arguments are known.
+			if d, ok := s.(*semantic.NativeVariableDeclaration); ok {
+				if f, ok := d.Init.(*semantic.FunctionExpression); ok {
+					env.declare(d.Identifier.Name, semantic.Function, f)
+					continue
+				}
+			}
+			node, err := compile(s, env)
 			if err != nil {
 				return nil, err
 			}
-			body[i] = node
+			body = append(body, node)
+		}
+		if len(body) == 0 {
+			return nil, errors.New("block has no return statement")
 		}
 		return &blockEvaluator{
-			t:    n.ReturnStatement().Argument.Type(),
+			t:    body[len(body)-1].Type(),
 			body: body,
 		}, nil
 	case *semantic.ExpressionStatement:
 		return nil, errors.New("statement does nothing, sideffects are not supported by the compiler")
 	case *semantic.ReturnStatement:
-		node, err := compile(n.Argument, builtIns)
+		node, err := compile(n.Argument, env)
 		if err != nil {
 			return nil, err
 		}
@@ -63,12 +125,13 @@ func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
 			Evaluator: node,
 		}, nil
 	case *semantic.NativeVariableDeclaration:
-		node, err := compile(n.Init, builtIns)
+		node, err := compile(n.Init, env)
 		if err != nil {
 			return nil, err
 		}
+		env.declare(n.Identifier.Name, node.Type(), nil)
 		return &declarationEvaluator{
-			t:    n.Init.Type(),
+			t:    node.Type(),
 			id:   n.Identifier.Name,
 			init: node,
 		}, nil
@@ -76,7 +139,7 @@ func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
 		properties := make(map[string]Evaluator, len(n.Properties))
 		propertyTypes := make(map[string]semantic.Type, len(n.Properties))
 		for _, p := range n.Properties {
-			node, err := compile(p.Value, builtIns)
+			node, err := compile(p.Value, env)
 			if err != nil {
 				return nil, err
 			}
@@ -88,26 +151,59 @@ func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
 			properties: properties,
 		}, nil
 	case *semantic.IdentifierExpression:
-		if v, ok := builtIns[n.Name]; ok {
+		if _, ok := env.functions[n.Name]; ok {
+			return nil, fmt.Errorf("function %q can only be called", n.Name)
+		}
+		t, declared := env.types[n.Name]
+		if v, ok := env.builtIns[n.Name]; ok && !declared {
 			//Resolve any built in identifiers now
 			return &valueEvaluator{
 				value: v,
 			}, nil
 		}
+		if !declared {
+			t = n.Type()
+		}
 		return &identifierEvaluator{
-			t:    n.Type(),
+			t:    t,
 			name: n.Name,
 		}, nil
 	case *semantic.MemberExpression:
-		object, err := compile(n.Object, builtIns)
+		object, err := compile(n.Object, env)
 		if err != nil {
 			return nil, err
 		}
+		t := n.Type()
+		if ot := object.Type(); ot.Kind() == semantic.Object {
+			if pt := ot.PropertyType(n.Property); pt != nil {
+				t = pt
+			}
+		}
 		return &memberEvaluator{
-			t:        n.Type(),
+			t:        t,
 			object:   object,
 			property: n.Property,
 		}, nil
+	case *semantic.IndexExpression:
+		array, err := compile(n.Array, env)
+		if err != nil {
+			return nil, err
+		}
+		if k := array.Type().Kind(); k != semantic.Array {
+			return nil, fmt.Errorf("cannot index into a value of kind %v", k)
+		}
+		index, err := compile(n.Index, env)
+		if err != nil {
+			return nil, err
+		}
+		if k := index.Type().Kind(); k != semantic.Int {
+			return nil, fmt.Errorf("array index must be an integer, got kind %v", k)
+		}
+		return &indexEvaluator{
+			t:     array.Type().ElementType(),
+			array: array,
+			index: index,
+		}, nil
 	case *semantic.BooleanLiteral:
 		return &booleanEvaluator{
 			t: n.Type(),
@@ -139,36 +235,61 @@ func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
 			time: values.ConvertTime(n.Value),
 		}, nil
 	case *semantic.UnaryExpression:
-		node, err := compile(n.Argument, builtIns)
+		node, err := compile(n.Argument, env)
 		if err != nil {
 			return nil, err
 		}
 		return &unaryEvaluator{
-			t:    n.Type(),
+			t:    node.Type(),
 			node: node,
 		}, nil
 	case *semantic.LogicalExpression:
-		l, err := compile(n.Left, builtIns)
+		l, err := compile(n.Left, env)
 		if err != nil {
 			return nil, err
 		}
-		r, err := compile(n.Right, builtIns)
+		r, err := compile(n.Right, env)
 		if err != nil {
 			return nil, err
 		}
 		return &logicalEvaluator{
-			t:        n.Type(),
+			t:        semantic.Bool,
 			operator: n.Operator,
 			left:     l,
 			right:    r,
 		}, nil
+	case *semantic.ConditionalExpression:
+		test, err := compile(n.Test, env)
+		if err != nil {
+			return nil, err
+		}
+		if k := test.Type().Kind(); k != semantic.Bool {
+			return nil, fmt.Errorf("test of conditional expression must be a boolean, got kind %v", k)
+		}
+		c, err := compile(n.Consequent, env)
+		if err != nil {
+			return nil, err
+		}
+		a, err := compile(n.Alternate, env)
+		if err != nil {
+			return nil, err
+		}
+		if c.Type() != a.Type() {
+			return nil, fmt.Errorf("branches of conditional expression have different types, %v and %v", c.Type(), a.Type())
+		}
+		return &conditionalEvaluator{
+			t:          c.Type(),
+			test:       test,
+			consequent: c,
+			alternate:  a,
+		}, nil
 	case *semantic.BinaryExpression:
-		l, err := compile(n.Left, builtIns)
+		l, err := compile(n.Left, env)
 		if err != nil {
 			return nil, err
 		}
 		lt := l.Type()
-		r, err := compile(n.Right, builtIns)
+		r, err := compile(n.Right, env)
 		if err != nil {
 			return nil, err
 		}
@@ -182,27 +303,34 @@ func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
 			return nil, err
 		}
 		return &binaryEvaluator{
-			t:     n.Type(),
+			t:     semantic.BinaryType(n.Operator, lt.Kind(), rt.Kind()),
 			left:  l,
 			right: r,
 			f:     f,
 		}, nil
 	case *semantic.CallExpression:
-		callee, err := compile(n.Callee, builtIns)
+		args, err := compile(n.Arguments, env)
 		if err != nil {
 			return nil, err
 		}
-		args, err := compile(n.Arguments, builtIns)
+		if f := env.function(n.Callee); f != nil {
+			return compileCall(f, args, env)
+		}
+		callee, err := compile(n.Callee, env)
 		if err != nil {
 			return nil, err
 		}
+		t := n.Type()
+		if ct := callee.Type(); ct.Kind() == semantic.Function && ct.ReturnType() != nil {
+			t = ct.ReturnType()
+		}
 		return &callEvaluator{
-			t:      n.Type(),
+			t:      t,
 			callee: callee,
 			args:   args,
 		}, nil
 	case *semantic.FunctionExpression:
-		body, err := compile(n.Body, builtIns)
+		body, err := compile(n.Body, env)
 		if err != nil {
 			return nil, err
 		}
@@ -213,7 +341,7 @@ func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
 				Type: param.Type(),
 			}
 			if param.Default != nil {
-				d, err := compile(param.Default, builtIns)
+				d, err := compile(param.Default, env)
 				if err != nil {
 					return nil, err
 				}
@@ -230,6 +358,94 @@ func compile(n semantic.Node, builtIns Scope) (Evaluator, error) {
 	}
 }
 
+// function returns the function expression that is called by the callee, or nil if the callee is not a function expression.
+func (env *compileEnv) function(callee semantic.Expression) *semantic.FunctionExpression {
+	switch callee := callee.(type) {
+	case *semantic.FunctionExpression:
+		return callee
+	case *semantic.IdentifierExpression:
+		return env.functions[callee.Name]
+	default:
+		return nil
+	}
+}
+
+// compileCall compiles a call of a function expression.
+// Since the types of the parameters of a function expression are only known once it is called,
+// the function is compiled for the types of the arguments of each call.
+func compileCall(f *semantic.FunctionExpression, args Evaluator, env *compileEnv) (Evaluator, error) {
+	for _, c := range env.calls {
+		if c == f {
+			return nil, errors.New("recursive function calls are not supported")
+		}
+	}
+	env = env.nest()
+	env.calls = append(env.calls[:len(env.calls):len(env.calls)], f)
+
+	argTypes := args.Type().Properties()
+	for name := range argTypes {
+		found := false
+		for _, p := range f.Params {
+			if p.Key.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("function does not have a parameter %q", name)
+		}
+	}
+
+	params := make([]functionParam, len(f.Params))
+	paramTypes := make(map[string]semantic.Type, len(f.Params))
+	for i, p := range f.Params {
+		params[i].Key = p.Key.Name
+		if p.Default != nil {
+			d, err := compile(p.Default, env)
+			if err != nil {
+				return nil, err
+			}
+			params[i].Default = d
+		}
+		t, ok := argTypes[p.Key.Name]
+		switch {
+		case ok:
+		case params[i].Default != nil:
+			t = params[i].Default.Type()
+		default:
+			return nil, fmt.Errorf("missing required argument %q", p.Key.Name)
+		}
+		params[i].Type = t
+		paramTypes[p.Key.Name] = t
+	}
+	for _, p := range params {
+		env.declare(p.Key, p.Type, nil)
+	}
+	body, err := compile(f.Body, env)
+	if err != nil {
+		return nil, err
+	}
+	var pipe string
+	for _, p := range f.Params {
+		if p.Piped {
+			pipe = p.Key.Name
+		}
+	}
+	return &callEvaluator{
+		t: body.Type(),
+		callee: &functionEvaluator{
+			t: semantic.NewFunctionType(semantic.FunctionSignature{
+				Params:       paramTypes,
+				ReturnType:   body.Type(),
+				PipeArgument: pipe,
+			}),
+			params: params,
+			body:   body,
+		},
+		args: args,
+	}, nil
+}
+
 // CompilationCache caches compilation results based on the types of the input parameters.
 type CompilationCache struct {
 	fn   *semantic.FunctionExpression
@@ -22,6 +22,9 @@ func ValueEqual(x, y values.Value) bool {
 	if x.Type() != y.Type() {
 		return false
 	}
+	if values.IsNull(x) || values.IsNull(y) {
+		return values.IsNull(x) && values.IsNull(y)
+	}
 	switch k := x.Type().Kind(); k {
 	case semantic.Bool:
 		return x.Bool() == y.Bool()
@@ -240,6 +243,203 @@ func TestCompileAndEval(t *testing.T) {
 			want:    values.NewIntValue(5),
 			wantErr: false,
 		},
+		{
+			name: "call function with different types",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.BlockStatement{
+					Body: []semantic.Statement{
+						&semantic.NativeVariableDeclaration{
+							Identifier: &semantic.Identifier{Name: "identity"}, Init: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{
+									{Key: &semantic.Identifier{Name: "a"}},
+								},
+								Body: &semantic.IdentifierExpression{Name: "a"},
+							},
+						},
+						&semantic.ReturnStatement{
+							Argument: &semantic.ConditionalExpression{
+								Test: &semantic.BinaryExpression{
+									Operator: ast.GreaterThanOperator,
+									Left: &semantic.CallExpression{
+										Callee: &semantic.IdentifierExpression{Name: "identity"},
+										Arguments: &semantic.ObjectExpression{
+											Properties: []*semantic.Property{
+												{Key: &semantic.Identifier{Name: "a"}, Value: &semantic.IdentifierExpression{Name: "r"}},
+											},
+										},
+									},
+									Right: &semantic.FloatLiteral{Value: 0},
+								},
+								Consequent: &semantic.CallExpression{
+									Callee: &semantic.IdentifierExpression{Name: "identity"},
+									Arguments: &semantic.ObjectExpression{
+										Properties: []*semantic.Property{
+											{Key: &semantic.Identifier{Name: "a"}, Value: &semantic.StringLiteral{Value: "positive"}},
+										},
+									},
+								},
+								Alternate: &semantic.StringLiteral{Value: "negative"},
+							},
+						},
+					},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			scope: map[string]values.Value{
+				"r": values.NewFloatValue(2.5),
+			},
+			want:    values.NewStringValue("positive"),
+			wantErr: false,
+		},
+		{
+			name: "conditional consequent",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.ConditionalExpression{
+					Test: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     &semantic.IdentifierExpression{Name: "r"},
+						Right:    &semantic.IntegerLiteral{Value: 0},
+					},
+					Consequent: &semantic.StringLiteral{Value: "positive"},
+					Alternate:  &semantic.StringLiteral{Value: "negative"},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Int,
+			},
+			scope: map[string]values.Value{
+				"r": values.NewIntValue(4),
+			},
+			want:    values.NewStringValue("positive"),
+			wantErr: false,
+		},
+		{
+			name: "conditional alternate",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.ConditionalExpression{
+					Test: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     &semantic.IdentifierExpression{Name: "r"},
+						Right:    &semantic.IntegerLiteral{Value: 0},
+					},
+					Consequent: &semantic.StringLiteral{Value: "positive"},
+					Alternate:  &semantic.StringLiteral{Value: "negative"},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Int,
+			},
+			scope: map[string]values.Value{
+				"r": values.NewIntValue(-4),
+			},
+			want:    values.NewStringValue("negative"),
+			wantErr: false,
+		},
+		{
+			name: "index expression",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.IndexExpression{
+					Array: &semantic.IdentifierExpression{Name: "r"},
+					Index: &semantic.IntegerLiteral{Value: 1},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.NewArrayType(semantic.Float),
+			},
+			scope: map[string]values.Value{
+				"r": values.NewArrayWithBacking(semantic.Float, []values.Value{
+					values.NewFloatValue(0.5),
+					values.NewFloatValue(1.5),
+				}),
+			},
+			want:    values.NewFloatValue(1.5),
+			wantErr: false,
+		},
+		{
+			name: "null propagates",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.AdditionOperator,
+					Left:     &semantic.IdentifierExpression{Name: "r"},
+					Right:    &semantic.IntegerLiteral{Value: 1},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Int,
+			},
+			scope: map[string]values.Value{
+				"r": values.NewNull(semantic.Int),
+			},
+			want:    values.NewNull(semantic.Int),
+			wantErr: false,
+		},
+		{
+			name: "null or true",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.LogicalExpression{
+					Operator: ast.OrOperator,
+					Left: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     &semantic.IdentifierExpression{Name: "r"},
+						Right:    &semantic.IntegerLiteral{Value: 0},
+					},
+					Right: &semantic.BooleanLiteral{Value: true},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Int,
+			},
+			scope: map[string]values.Value{
+				"r": values.NewNull(semantic.Int),
+			},
+			want:    values.NewBoolValue(true),
+			wantErr: false,
+		},
+		{
+			name: "null and true",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.LogicalExpression{
+					Operator: ast.AndOperator,
+					Left: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     &semantic.IdentifierExpression{Name: "r"},
+						Right:    &semantic.IntegerLiteral{Value: 0},
+					},
+					Right: &semantic.BooleanLiteral{Value: true},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Int,
+			},
+			scope: map[string]values.Value{
+				"r": values.NewNull(semantic.Int),
+			},
+			want:    values.NewNull(semantic.Bool),
+			wantErr: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -261,3 +461,30 @@ func TestCompileAndEval(t *testing.T) {
 		})
 	}
 }
+
+func TestCompileAndEval_IndexOutOfRange(t *testing.T) {
+	fn := &semantic.FunctionExpression{
+		Params: []*semantic.FunctionParam{
+			{Key: &semantic.Identifier{Name: "r"}},
+		},
+		Body: &semantic.IndexExpression{
+			Array: &semantic.IdentifierExpression{Name: "r"},
+			Index: &semantic.IntegerLiteral{Value: 2},
+		},
+	}
+	f, err := compiler.Compile(fn, map[string]semantic.Type{
+		"r": semantic.NewArrayType(semantic.Int),
+	}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.Eval(map[string]values.Value{
+		"r": values.NewArrayWithBacking(semantic.Int, []values.Value{
+			values.NewIntValue(1),
+			values.NewIntValue(2),
+		}),
+	})
+	if want := "array index 2 out of range [0:2]"; err == nil || err.Error() != want {
+		t.Errorf("unexpected error: want %q got %v", want, err)
+	}
+}
@@ -18,6 +18,7 @@ type Evaluator interface {
 	EvalBool(scope Scope) bool
 	EvalTime(scope Scope) values.Time
 	EvalDuration(scope Scope) values.Duration
+	EvalBytes(scope Scope) []byte
 	EvalRegexp(scope Scope) *regexp.Regexp
 	EvalArray(scope Scope) values.Array
 	EvalObject(scope Scope) values.Object
@@ -34,6 +35,7 @@ type Func interface {
 	EvalBool(scope Scope) (bool, error)
 	EvalTime(scope Scope) (values.Time, error)
 	EvalDuration(scope Scope) (values.Duration, error)
+	EvalBytes(scope Scope) ([]byte, error)
 	EvalRegexp(scope Scope) (*regexp.Regexp, error)
 	EvalArray(scope Scope) (values.Array, error)
 	EvalObject(scope Scope) (values.Object, error)
@@ -59,7 +61,17 @@ func (c compiledFn) Type() semantic.Type {
 	return c.root.Type()
 }
 
+// Eval evaluates the function, returning a null value when the function evaluates to null.
 func (c compiledFn) Eval(scope Scope) (values.Value, error) {
+	v, err := c.eval(scope)
+	if err == values.ErrNull {
+		return values.NewNull(c.Type()), nil
+	}
+	return v, err
+}
+
+func (c compiledFn) eval(scope Scope) (_ values.Value, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return nil, err
 	}
@@ -78,6 +90,8 @@ func (c compiledFn) Eval(scope Scope) (values.Value, error) {
 		return values.NewTimeValue(c.root.EvalTime(scope)), nil
 	case semantic.Duration:
 		return values.NewDurationValue(c.root.EvalDuration(scope)), nil
+	case semantic.Bytes:
+		return values.NewBytesValue(c.root.EvalBytes(scope)), nil
 	case semantic.Regexp:
 		return values.NewRegexpValue(c.root.EvalRegexp(scope)), nil
 	case semantic.Array:
@@ -91,73 +105,144 @@ func (c compiledFn) Eval(scope Scope) (values.Value, error) {
 	}
 }
 
-func (c compiledFn) EvalString(scope Scope) (string, error) {
+func (c compiledFn) EvalString(scope Scope) (_ string, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return "", err
 	}
 	return c.root.EvalString(scope), nil
 }
-func (c compiledFn) EvalBool(scope Scope) (bool, error) {
+func (c compiledFn) EvalBool(scope Scope) (_ bool, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return false, err
 	}
 	return c.root.EvalBool(scope), nil
 }
-func (c compiledFn) EvalInt(scope Scope) (int64, error) {
+func (c compiledFn) EvalInt(scope Scope) (_ int64, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalInt(scope), nil
 }
-func (c compiledFn) EvalUInt(scope Scope) (uint64, error) {
+func (c compiledFn) EvalUInt(scope Scope) (_ uint64, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalUInt(scope), nil
 }
-func (c compiledFn) EvalFloat(scope Scope) (float64, error) {
+func (c compiledFn) EvalFloat(scope Scope) (_ float64, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalFloat(scope), nil
 }
-func (c compiledFn) EvalTime(scope Scope) (values.Time, error) {
+func (c compiledFn) EvalTime(scope Scope) (_ values.Time, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalTime(scope), nil
 }
-func (c compiledFn) EvalDuration(scope Scope) (values.Duration, error) {
+func (c compiledFn) EvalDuration(scope Scope) (_ values.Duration, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalDuration(scope), nil
 }
-func (c compiledFn) EvalRegexp(scope Scope) (*regexp.Regexp, error) {
+func (c compiledFn) EvalBytes(scope Scope) (_ []byte, err error) {
+	defer recoverRuntimeError(&err)
+	if err := c.validate(scope); err != nil {
+		return nil, err
+	}
+	return c.root.EvalBytes(scope), nil
+}
+func (c compiledFn) EvalRegexp(scope Scope) (_ *regexp.Regexp, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return nil, err
 	}
 	return c.root.EvalRegexp(scope), nil
 }
-func (c compiledFn) EvalArray(scope Scope) (values.Array, error) {
+func (c compiledFn) EvalArray(scope Scope) (_ values.Array, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return nil, err
 	}
 	return c.root.EvalArray(scope), nil
 }
-func (c compiledFn) EvalObject(scope Scope) (values.Object, error) {
+func (c compiledFn) EvalObject(scope Scope) (_ values.Object, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return nil, err
 	}
 	return c.root.EvalObject(scope), nil
 }
-func (c compiledFn) EvalFunction(scope Scope) (values.Function, error) {
+func (c compiledFn) EvalFunction(scope Scope) (_ values.Function, err error) {
+	defer recoverRuntimeError(&err)
 	if err := c.validate(scope); err != nil {
 		return nil, err
 	}
 	return c.root.EvalFunction(scope), nil
 }
 
+// runtimeError is an error that occurred while evaluating a compiled function.
+// Evaluators cannot return errors, instead they panic with a runtimeError
+// which is recovered and returned by the compiled function.
+type runtimeError struct {
+	error
+}
+
+// recoverRuntimeError recovers runtime errors and the values.ErrNull panic of an expression that evaluates to null.
+func recoverRuntimeError(err *error) {
+	if r := recover(); r != nil {
+		if r == values.ErrNull {
+			*err = values.ErrNull
+			return
+		}
+		re, ok := r.(runtimeError)
+		if !ok {
+			panic(r)
+		}
+		*err = re.error
+	}
+}
+
+// catchNull calls f and reports whether f read a null value.
+// Reading a null value panics with values.ErrNull, so that an expression with a null operand evaluates to null.
+// The panic unwinds to the closest evaluator able to represent a null, such as an object property.
+func catchNull(f func()) (null bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != values.ErrNull {
+				panic(r)
+			}
+			null = true
+		}
+	}()
+	f()
+	return false
+}
+
+// evalNullable evaluates e, returning a null value of the type of e if e evaluates to null.
+func evalNullable(e Evaluator, scope Scope) values.Value {
+	var v values.Value
+	if catchNull(func() { v = eval(e, scope) }) {
+		return values.NewNull(e.Type())
+	}
+	return v
+}
+
+// evalBool evaluates the boolean e and reports whether it evaluates to null.
+func evalBool(e Evaluator, scope Scope) (v, null bool) {
+	null = catchNull(func() { v = e.EvalBool(scope) })
+	return v, null
+}
+
 type Scope map[string]values.Value
 
 func (s Scope) Type(name string) semantic.Type {
@@ -188,6 +273,9 @@ func (s Scope) GetTime(name string) values.Time {
 func (s Scope) GetDuration(name string) values.Duration {
 	return s[name].Duration()
 }
+func (s Scope) GetBytes(name string) []byte {
+	return s[name].Bytes()
+}
 func (s Scope) GetRegexp(name string) *regexp.Regexp {
 	return s[name].Regexp()
 }
@@ -225,6 +313,8 @@ func eval(e Evaluator, scope Scope) values.Value {
 		return values.NewTimeValue(e.EvalTime(scope))
 	case semantic.Duration:
 		return values.NewDurationValue(e.EvalDuration(scope))
+	case semantic.Bytes:
+		return values.NewBytesValue(e.EvalBytes(scope))
 	case semantic.Regexp:
 		return values.NewRegexpValue(e.EvalRegexp(scope))
 	case semantic.Array:
@@ -289,6 +379,11 @@ func (e *blockEvaluator) EvalDuration(scope Scope) values.Duration {
 	e.eval(scope)
 	return e.value.Duration()
 }
+func (e *blockEvaluator) EvalBytes(scope Scope) []byte {
+	values.CheckKind(e.t.Kind(), semantic.Bytes)
+	e.eval(scope)
+	return e.value.Bytes()
+}
 func (e *blockEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	values.CheckKind(e.t.Kind(), semantic.Regexp)
 	e.eval(scope)
@@ -325,7 +420,7 @@ func (e *declarationEvaluator) Type() semantic.Type {
 }
 
 func (e *declarationEvaluator) eval(scope Scope) {
-	scope.Set(e.id, eval(e.init, scope))
+	scope.Set(e.id, evalNullable(e.init, scope))
 }
 
 func (e *declarationEvaluator) EvalString(scope Scope) string {
@@ -356,6 +451,10 @@ func (e *declarationEvaluator) EvalDuration(scope Scope) values.Duration {
 	e.eval(scope)
 	return scope.GetDuration(e.id)
 }
+func (e *declarationEvaluator) EvalBytes(scope Scope) []byte {
+	e.eval(scope)
+	return scope.GetBytes(e.id)
+}
 func (e *declarationEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	e.eval(scope)
 	return scope.GetRegexp(e.id)
@@ -403,6 +502,9 @@ func (e *objEvaluator) EvalTime(scope Scope) values.Time {
 func (e *objEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *objEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *objEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -412,7 +514,7 @@ func (e *objEvaluator) EvalArray(scope Scope) values.Array {
 func (e *objEvaluator) EvalObject(scope Scope) values.Object {
 	obj := values.NewObject()
 	for k, node := range e.properties {
-		v := eval(node, scope)
+		v := evalNullable(node, scope)
 		obj.Set(k, v)
 	}
 	return obj
@@ -443,15 +545,31 @@ func (e *logicalEvaluator) EvalUInt(scope Scope) uint64 {
 func (e *logicalEvaluator) EvalFloat(scope Scope) float64 {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Float))
 }
+
+// EvalBool evaluates the operator with three-valued logic:
+// the result is null only if it depends on the value of a null operand.
 func (e *logicalEvaluator) EvalBool(scope Scope) bool {
+	var short bool
 	switch e.operator {
 	case ast.AndOperator:
-		return e.left.EvalBool(scope) && e.right.EvalBool(scope)
+		short = false
 	case ast.OrOperator:
-		return e.left.EvalBool(scope) || e.right.EvalBool(scope)
+		short = true
 	default:
 		panic(fmt.Errorf("unknown logical operator %v", e.operator))
 	}
+	l, lnull := evalBool(e.left, scope)
+	if !lnull && l == short {
+		return short
+	}
+	r, rnull := evalBool(e.right, scope)
+	if !rnull && r == short {
+		return short
+	}
+	if lnull || rnull {
+		panic(values.ErrNull)
+	}
+	return !short
 }
 func (e *logicalEvaluator) EvalTime(scope Scope) values.Time {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Time))
@@ -459,6 +577,9 @@ func (e *logicalEvaluator) EvalTime(scope Scope) values.Time {
 func (e *logicalEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *logicalEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *logicalEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -472,6 +593,117 @@ func (e *logicalEvaluator) EvalFunction(scope Scope) values.Function {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Function))
 }
 
+type conditionalEvaluator struct {
+	t                     semantic.Type
+	test                  Evaluator
+	consequent, alternate Evaluator
+}
+
+func (e *conditionalEvaluator) Type() semantic.Type {
+	return e.t
+}
+
+// branch returns the evaluator of the branch selected by the test.
+// A null test selects the alternate.
+func (e *conditionalEvaluator) branch(scope Scope) Evaluator {
+	if test, null := evalBool(e.test, scope); test && !null {
+		return e.consequent
+	}
+	return e.alternate
+}
+
+func (e *conditionalEvaluator) EvalString(scope Scope) string {
+	return e.branch(scope).EvalString(scope)
+}
+func (e *conditionalEvaluator) EvalInt(scope Scope) int64 {
+	return e.branch(scope).EvalInt(scope)
+}
+func (e *conditionalEvaluator) EvalUInt(scope Scope) uint64 {
+	return e.branch(scope).EvalUInt(scope)
+}
+func (e *conditionalEvaluator) EvalFloat(scope Scope) float64 {
+	return e.branch(scope).EvalFloat(scope)
+}
+func (e *conditionalEvaluator) EvalBool(scope Scope) bool {
+	return e.branch(scope).EvalBool(scope)
+}
+func (e *conditionalEvaluator) EvalTime(scope Scope) values.Time {
+	return e.branch(scope).EvalTime(scope)
+}
+func (e *conditionalEvaluator) EvalDuration(scope Scope) values.Duration {
+	return e.branch(scope).EvalDuration(scope)
+}
+func (e *conditionalEvaluator) EvalBytes(scope Scope) []byte {
+	return e.branch(scope).EvalBytes(scope)
+}
+func (e *conditionalEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
+	return e.branch(scope).EvalRegexp(scope)
+}
+func (e *conditionalEvaluator) EvalArray(scope Scope) values.Array {
+	return e.branch(scope).EvalArray(scope)
+}
+func (e *conditionalEvaluator) EvalObject(scope Scope) values.Object {
+	return e.branch(scope).EvalObject(scope)
+}
+func (e *conditionalEvaluator) EvalFunction(scope Scope) values.Function {
+	return e.branch(scope).EvalFunction(scope)
+}
+
+type indexEvaluator struct {
+	t            semantic.Type
+	array, index Evaluator
+}
+
+func (e *indexEvaluator) Type() semantic.Type {
+	return e.t
+}
+
+func (e *indexEvaluator) eval(scope Scope) values.Value {
+	a := e.array.EvalArray(scope)
+	i := e.index.EvalInt(scope)
+	if i < 0 || i >= int64(a.Len()) {
+		panic(runtimeError{fmt.Errorf("array index %d out of range [0:%d]", i, a.Len())})
+	}
+	return a.Get(int(i))
+}
+
+func (e *indexEvaluator) EvalString(scope Scope) string {
+	return e.eval(scope).Str()
+}
+func (e *indexEvaluator) EvalInt(scope Scope) int64 {
+	return e.eval(scope).Int()
+}
+func (e *indexEvaluator) EvalUInt(scope Scope) uint64 {
+	return e.eval(scope).UInt()
+}
+func (e *indexEvaluator) EvalFloat(scope Scope) float64 {
+	return e.eval(scope).Float()
+}
+func (e *indexEvaluator) EvalBool(scope Scope) bool {
+	return e.eval(scope).Bool()
+}
+func (e *indexEvaluator) EvalTime(scope Scope) values.Time {
+	return e.eval(scope).Time()
+}
+func (e *indexEvaluator) EvalDuration(scope Scope) values.Duration {
+	return e.eval(scope).Duration()
+}
+func (e *indexEvaluator) EvalBytes(scope Scope) []byte {
+	return e.eval(scope).Bytes()
+}
+func (e *indexEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
+	return e.eval(scope).Regexp()
+}
+func (e *indexEvaluator) EvalArray(scope Scope) values.Array {
+	return e.eval(scope).Array()
+}
+func (e *indexEvaluator) EvalObject(scope Scope) values.Object {
+	return e.eval(scope).Object()
+}
+func (e *indexEvaluator) EvalFunction(scope Scope) values.Function {
+	return e.eval(scope).Function()
+}
+
 type binaryFunc func(scope Scope, left, right Evaluator) values.Value
 
 type binarySignature struct {
@@ -514,6 +746,9 @@ func (e *binaryEvaluator) EvalTime(scope Scope) values.Time {
 func (e *binaryEvaluator) EvalDuration(scope Scope) values.Duration {
 	return e.f(e.eval(scope)).Duration()
 }
+func (e *binaryEvaluator) EvalBytes(scope Scope) []byte {
+	return e.f(e.eval(scope)).Bytes()
+}
 func (e *binaryEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -561,6 +796,9 @@ func (e *unaryEvaluator) EvalDuration(scope Scope) values.Duration {
 	// There is only one duration unary operator
 	return -e.node.EvalDuration(scope)
 }
+func (e *unaryEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *unaryEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -604,6 +842,9 @@ func (e *integerEvaluator) EvalTime(scope Scope) values.Time {
 func (e *integerEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *integerEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *integerEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -647,6 +888,9 @@ func (e *stringEvaluator) EvalTime(scope Scope) values.Time {
 func (e *stringEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *stringEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *stringEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -690,6 +934,9 @@ func (e *regexpEvaluator) EvalTime(scope Scope) values.Time {
 func (e *regexpEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *regexpEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *regexpEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	return e.r
 }
@@ -733,6 +980,9 @@ func (e *booleanEvaluator) EvalTime(scope Scope) values.Time {
 func (e *booleanEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *booleanEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *booleanEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -776,6 +1026,9 @@ func (e *floatEvaluator) EvalTime(scope Scope) values.Time {
 func (e *floatEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *floatEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *floatEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -819,6 +1072,9 @@ func (e *timeEvaluator) EvalTime(scope Scope) values.Time {
 func (e *timeEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *timeEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *timeEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -862,6 +1118,9 @@ func (e *identifierEvaluator) EvalTime(scope Scope) values.Time {
 func (e *identifierEvaluator) EvalDuration(scope Scope) values.Duration {
 	return scope.GetDuration(e.name)
 }
+func (e *identifierEvaluator) EvalBytes(scope Scope) []byte {
+	return scope.GetBytes(e.name)
+}
 func (e *identifierEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	return scope.GetRegexp(e.name)
 }
@@ -904,6 +1163,9 @@ func (e *valueEvaluator) EvalTime(scope Scope) values.Time {
 func (e *valueEvaluator) EvalDuration(scope Scope) values.Duration {
 	return e.value.Duration()
 }
+func (e *valueEvaluator) EvalBytes(scope Scope) []byte {
+	return e.value.Bytes()
+}
 func (e *valueEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	return e.value.Regexp()
 }
@@ -955,6 +1217,10 @@ func (e *memberEvaluator) EvalDuration(scope Scope) values.Duration {
 	v, _ := e.object.EvalObject(scope).Get(e.property)
 	return v.Duration()
 }
+func (e *memberEvaluator) EvalBytes(scope Scope) []byte {
+	v, _ := e.object.EvalObject(scope).Get(e.property)
+	return v.Bytes()
+}
 func (e *memberEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	v, _ := e.object.EvalObject(scope).Get(e.property)
 	return v.Regexp()
@@ -1011,6 +1277,9 @@ func (e *callEvaluator) EvalTime(scope Scope) values.Time {
 func (e *callEvaluator) EvalDuration(scope Scope) values.Duration {
 	return e.eval(scope).Duration()
 }
+func (e *callEvaluator) EvalBytes(scope Scope) []byte {
+	return e.eval(scope).Bytes()
+}
 func (e *callEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	return e.eval(scope).Regexp()
 }
@@ -1055,6 +1324,9 @@ func (e *functionEvaluator) EvalTime(scope Scope) values.Time {
 func (e *functionEvaluator) EvalDuration(scope Scope) values.Duration {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Duration))
 }
+func (e *functionEvaluator) EvalBytes(scope Scope) []byte {
+	panic(values.UnexpectedKind(e.t.Kind(), semantic.Bytes))
+}
 func (e *functionEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Kind(), semantic.Regexp))
 }
@@ -1111,6 +1383,9 @@ func (f functionValue) Time() values.Time {
 func (f functionValue) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Function, semantic.Duration))
 }
+func (f functionValue) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Function, semantic.Bytes))
+}
 func (f functionValue) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Function, semantic.Regexp))
 }
@@ -1133,5 +1408,5 @@ func (f functionValue) Call(args values.Object) (values.Value, error) {
 		}
 		scope.Set(p.Key, v)
 	}
-	return eval(f.body, scope), nil
+	return evalNullable(f.body, scope), nil
 }
@@ -61,6 +61,28 @@ func (c Completer) FunctionNames() []string {
 	return funcs
 }
 
+// Members returns the sorted names of the members of an object, such as an imported package
+func (c Completer) Members(name string) ([]string, error) {
+	d, err := c.Declaration(name)
+	if err != nil {
+		return nil, err
+	}
+
+	t := d.InitType()
+	if t.Kind() != semantic.Object {
+		return nil, fmt.Errorf("name ( %s ) is not an object", name)
+	}
+
+	members := []string{}
+	for k := range t.Properties() {
+		members = append(members, k)
+	}
+
+	sort.Strings(members)
+
+	return members, nil
+}
+
 // FunctionSuggestion returns information needed for autocomplete suggestions for a function
 func (c Completer) FunctionSuggestion(name string) (FunctionSuggestion, error) {
 	var s FunctionSuggestion
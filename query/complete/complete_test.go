@@ -7,6 +7,7 @@ import (
 	"github.com/influxdata/platform/query/complete"
 	"github.com/influxdata/platform/query/interpreter"
 	"github.com/influxdata/platform/query/semantic"
+	"github.com/influxdata/platform/query/semantic/semantictest"
 	"github.com/influxdata/platform/query/values"
 )
 
@@ -41,8 +42,8 @@ func TestDeclaration(t *testing.T) {
 	declaration, _ := complete.NewCompleter(scope, declarations).Declaration(name)
 	result := declaration.ID()
 
-	if !cmp.Equal(result, expected) {
-		t.Error(cmp.Diff(result, expected), "unexpected declaration for name")
+	if !cmp.Equal(result, expected, semantictest.CmpOptions...) {
+		t.Error(cmp.Diff(result, expected, semantictest.CmpOptions...), "unexpected declaration for name")
 	}
 }
 
@@ -94,3 +95,34 @@ func TestFunctionSuggestion(t *testing.T) {
 		t.Error(cmp.Diff(result, expected), "does not match expected suggestion")
 	}
 }
+
+func TestMembers(t *testing.T) {
+	name := "math"
+	scope := interpreter.NewScope()
+	declarations := make(semantic.DeclarationScope)
+	declarations[name] = semantic.NewExternalVariableDeclaration(
+		name,
+		semantic.NewObjectType(map[string]semantic.Type{
+			"pi":  semantic.Float,
+			"abs": semantic.NewFunctionType(semantic.FunctionSignature{}),
+		}),
+	)
+	declarations["foo"] = semantic.NewExternalVariableDeclaration("foo", semantic.Int)
+	c := complete.NewCompleter(scope, declarations)
+
+	result, err := c.Members(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"abs",
+		"pi",
+	}
+	if !cmp.Equal(result, expected) {
+		t.Error(cmp.Diff(result, expected), "unexpected members")
+	}
+
+	if _, err := c.Members("foo"); err == nil {
+		t.Error("expected error for members of non object")
+	}
+}
@@ -31,6 +31,7 @@ type Controller struct {
 	lplanner plan.LogicalPlanner
 	pplanner plan.Planner
 	executor execute.Executor
+	storage  plan.Storage
 
 	maxConcurrency       int
 	availableConcurrency int
@@ -41,7 +42,10 @@ type Config struct {
 	ConcurrencyQuota     int
 	MemoryBytesQuota     int64
 	ExecutorDependencies execute.Dependencies
-	Verbose              bool
+	// Storage provides the planner with metadata about where data is stored.
+	// It is optional, without it queries are planned without knowledge of the shards.
+	Storage plan.Storage
+	Verbose bool
 }
 
 type QueryID uint64
@@ -58,6 +62,7 @@ func New(c Config) *Controller {
 		lplanner:             plan.NewLogicalPlanner(),
 		pplanner:             plan.NewPlanner(),
 		executor:             execute.NewExecutor(c.ExecutorDependencies),
+		storage:              c.Storage,
 		verbose:              c.Verbose,
 	}
 	go ctrl.run()
@@ -201,7 +206,7 @@ func (c *Controller) processQuery(pq *PriorityQueue, q *Query) error {
 			log.Println("logical plan", plan.Formatted(lp))
 		}
 
-		p, err := c.pplanner.Plan(lp, nil, q.now)
+		p, err := c.pplanner.Plan(lp, c.storage, q.now)
 		if err != nil {
 			return errors.Wrap(err, "failed to create physical plan")
 		}
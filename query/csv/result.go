@@ -1,6 +1,7 @@
 package csv
 
 import (
+	"encoding/base64"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -33,12 +34,14 @@ const (
 
 	commentPrefix = "#"
 
-	stringDatatype = "string"
-	timeDatatype   = "dateTime"
-	floatDatatype  = "double"
-	boolDatatype   = "boolean"
-	intDatatype    = "long"
-	uintDatatype   = "unsignedLong"
+	stringDatatype   = "string"
+	timeDatatype     = "dateTime"
+	floatDatatype    = "double"
+	boolDatatype     = "boolean"
+	intDatatype      = "long"
+	uintDatatype     = "unsignedLong"
+	durationDatatype = "duration"
+	bytesDatatype    = "base64Binary"
 
 	timeDataTypeWithFmt = "dateTime:RFC3339"
 )
@@ -551,10 +554,16 @@ func (b *blockDecoder) init(line []string) error {
 	return nil
 }
 
+// appendRecord appends the values of the record to the block.
+// Empty values take the default value of their column, or are missing if the column has no default.
 func (b *blockDecoder) appendRecord(record []string) error {
 	b.empty = false
 	for j, c := range b.meta.Cols {
-		if record[j] == "" && b.meta.Defaults[j] != nil {
+		if record[j] == "" && b.meta.Defaults[j] == nil {
+			b.builder.AppendNil(j)
+			continue
+		}
+		if record[j] == "" {
 			switch c.Type {
 			case query.TBool:
 				v := b.meta.Defaults[j].Bool()
@@ -574,6 +583,12 @@ func (b *blockDecoder) appendRecord(record []string) error {
 			case query.TTime:
 				v := b.meta.Defaults[j].Time()
 				b.builder.AppendTime(j, v)
+			case query.TDuration:
+				v := b.meta.Defaults[j].Duration()
+				b.builder.AppendDuration(j, v)
+			case query.TBytes:
+				v := b.meta.Defaults[j].Bytes()
+				b.builder.AppendBytes(j, v)
 			default:
 				return fmt.Errorf("unsupported column type %v", c.Type)
 			}
@@ -650,87 +665,94 @@ func (e *ResultEncoder) csvWriter(w io.Writer) *csv.Writer {
 }
 
 func (e *ResultEncoder) Encode(w io.Writer, result query.Result) error {
-	tableID := 0
-	tableIDStr := "0"
-	metaCols := []colMeta{
+	writer := e.csvWriter(w)
+	state := &resultState{name: result.Name()}
+	return result.Blocks().Do(func(b query.Block) error {
+		return e.encodeBlock(writer, state, b)
+	})
+}
+
+// resultState is the state of encoding a single result.
+type resultState struct {
+	name      string
+	tableID   int
+	lastCols  []colMeta
+	lastEmpty bool
+}
+
+// encodeBlock encodes the block as the next table of the result.
+func (e *ResultEncoder) encodeBlock(writer *csv.Writer, state *resultState, b query.Block) error {
+	e.written = true
+	tableIDStr := strconv.Itoa(state.tableID)
+	// Update cols with block cols
+	cols := []colMeta{
 		{ColMeta: query.ColMeta{Label: "", Type: query.TInvalid}},
 		{ColMeta: query.ColMeta{Label: resultLabel, Type: query.TString}},
 		{ColMeta: query.ColMeta{Label: tableLabel, Type: query.TInt}},
 	}
-	writer := e.csvWriter(w)
-
-	var lastCols []colMeta
-	var lastEmpty bool
-
-	return result.Blocks().Do(func(b query.Block) error {
-		e.written = true
-		// Update cols with block cols
-		cols := metaCols
-		for _, c := range b.Cols() {
-			cm := colMeta{ColMeta: c}
-			if c.Type == query.TTime {
-				cm.fmt = time.RFC3339Nano
-			}
-			cols = append(cols, cm)
+	for _, c := range b.Cols() {
+		cm := colMeta{ColMeta: c}
+		if c.Type == query.TTime {
+			cm.fmt = time.RFC3339Nano
 		}
-		// pre-allocate row slice
-		row := make([]string, len(cols))
+		cols = append(cols, cm)
+	}
+	// pre-allocate row slice
+	row := make([]string, len(cols))
 
-		schemaChanged := !equalCols(cols, lastCols)
+	schemaChanged := !equalCols(cols, state.lastCols)
 
-		if lastEmpty || schemaChanged || b.Empty() {
-			if len(lastCols) > 0 {
-				// Write out empty line if not first block
-				writer.Write(nil)
-			}
+	if state.lastEmpty || schemaChanged || b.Empty() {
+		if len(state.lastCols) > 0 {
+			// Write out empty line if not first block
+			writer.Write(nil)
+		}
 
-			if err := writeSchema(writer, &e.c, row, cols, b.Empty(), b.Key(), result.Name(), tableIDStr); err != nil {
-				return err
-			}
+		if err := writeSchema(writer, &e.c, row, cols, b.Empty(), b.Key(), state.name, tableIDStr); err != nil {
+			return err
 		}
+	}
 
-		if execute.ContainsStr(e.c.Annotations, defaultAnnotation) {
-			for j := range cols {
-				switch j {
-				case annotationIdx:
-					row[j] = ""
-				case resultIdx:
-					row[j] = ""
-				case tableIdx:
-					row[j] = tableIDStr
-				default:
-					row[j] = ""
-				}
+	if execute.ContainsStr(e.c.Annotations, defaultAnnotation) {
+		for j := range cols {
+			switch j {
+			case annotationIdx:
+				row[j] = ""
+			case resultIdx:
+				row[j] = ""
+			case tableIdx:
+				row[j] = tableIDStr
+			default:
+				row[j] = ""
 			}
 		}
+	}
 
-		err := b.Do(func(cr query.ColReader) error {
-			record := row[recordStartIdx:]
-			l := cr.Len()
-			for i := 0; i < l; i++ {
-				for j, c := range cols[recordStartIdx:] {
-					v, err := encodeValueFrom(i, j, c, cr)
-					if err != nil {
-						return err
-					}
-					record[j] = v
+	err := b.Do(func(cr query.ColReader) error {
+		record := row[recordStartIdx:]
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			for j, c := range cols[recordStartIdx:] {
+				v, err := encodeValueFrom(i, j, c, cr)
+				if err != nil {
+					return err
 				}
-				writer.Write(row)
+				record[j] = v
 			}
-			writer.Flush()
-			return writer.Error()
-		})
-		if err != nil {
-			return err
+			writer.Write(row)
 		}
-
-		tableID++
-		tableIDStr = strconv.Itoa(tableID)
-		lastCols = cols
-		lastEmpty = b.Empty()
 		writer.Flush()
 		return writer.Error()
 	})
+	if err != nil {
+		return err
+	}
+
+	state.tableID++
+	state.lastCols = cols
+	state.lastEmpty = b.Empty()
+	writer.Flush()
+	return writer.Error()
 }
 
 func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
@@ -833,6 +855,10 @@ func writeDatatypes(writer *csv.Writer, row []string, cols []colMeta) error {
 			row[j] = stringDatatype
 		case query.TTime:
 			row[j] = timeDataTypeWithFmt
+		case query.TDuration:
+			row[j] = durationDatatype
+		case query.TBytes:
+			row[j] = bytesDatatype
 		default:
 			return fmt.Errorf("unknown column type %v", c.Type)
 		}
@@ -898,6 +924,18 @@ func decodeValue(value string, c colMeta) (values.Value, error) {
 			return nil, err
 		}
 		val = values.NewTimeValue(v)
+	case query.TDuration:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		val = values.NewDurationValue(values.Duration(v))
+	case query.TBytes:
+		v, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		val = values.NewBytesValue(v)
 	default:
 		return nil, fmt.Errorf("unsupported type %v", c.Type)
 	}
@@ -938,6 +976,18 @@ func decodeValueInto(j int, c colMeta, value string, builder execute.BlockBuilde
 			return err
 		}
 		builder.AppendTime(j, t)
+	case query.TDuration:
+		d, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		builder.AppendDuration(j, values.Duration(d))
+	case query.TBytes:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return err
+		}
+		builder.AppendBytes(j, b)
 	default:
 		return fmt.Errorf("unsupported type %v", c.Type)
 	}
@@ -958,12 +1008,20 @@ func encodeValue(value values.Value, c colMeta) (string, error) {
 		return value.Str(), nil
 	case query.TTime:
 		return encodeTime(value.Time(), c.fmt), nil
+	case query.TDuration:
+		return strconv.FormatInt(int64(value.Duration()), 10), nil
+	case query.TBytes:
+		return base64.StdEncoding.EncodeToString(value.Bytes()), nil
 	default:
 		return "", fmt.Errorf("unknown type %v", c.Type)
 	}
 }
 
+// encodeValueFrom encodes the value of row i and column j, missing values are encoded as empty values.
 func encodeValueFrom(i, j int, c colMeta, cr query.ColReader) (string, error) {
+	if execute.IsNull(cr, j, i) {
+		return "", nil
+	}
 	switch c.Type {
 	case query.TBool:
 		return strconv.FormatBool(cr.Bools(j)[i]), nil
@@ -977,6 +1035,10 @@ func encodeValueFrom(i, j int, c colMeta, cr query.ColReader) (string, error) {
 		return cr.Strings(j)[i], nil
 	case query.TTime:
 		return encodeTime(cr.Times(j)[i], c.fmt), nil
+	case query.TDuration:
+		return strconv.FormatInt(int64(cr.Durations(j)[i]), 10), nil
+	case query.TBytes:
+		return base64.StdEncoding.EncodeToString(cr.Bytes(j)[i]), nil
 	default:
 		return "", fmt.Errorf("unknown type %v", c.Type)
 	}
@@ -1020,6 +1082,10 @@ func decodeType(datatype string) (t query.DataType, desc string, err error) {
 		t = query.TString
 	case timeDatatype:
 		t = query.TTime
+	case durationDatatype:
+		t = query.TDuration
+	case bytesDatatype:
+		t = query.TBytes
 	default:
 		err = fmt.Errorf("unsupported data type %q", typ)
 	}
@@ -1038,9 +1104,80 @@ func equalCols(a, b []colMeta) bool {
 	return true
 }
 
+// MultiResultEncoder encodes multiple results into a single csv stream, delimiting results with an empty row.
+//
+// If the results implement query.StreamingResultIterator, tables are encoded as soon as they are produced.
+// The tables of different results are then interleaved,
+// each time the result changes a new section delimited by an empty row is started.
+type MultiResultEncoder struct {
+	c ResultEncoderConfig
+}
+
+// NewMultiResultEncoder creates a new MultiResultEncoder.
 func NewMultiResultEncoder(c ResultEncoderConfig) query.MultiResultEncoder {
-	return &query.DelimitedMultiResultEncoder{
-		Delimiter: []byte("\r\n"),
-		Encoder:   NewResultEncoder(c),
+	return &MultiResultEncoder{
+		c: c,
+	}
+}
+
+var resultDelimiter = []byte("\r\n")
+
+func (e *MultiResultEncoder) Encode(w io.Writer, results query.ResultIterator) error {
+	streaming, ok := results.(query.StreamingResultIterator)
+	if !ok {
+		enc := &query.DelimitedMultiResultEncoder{
+			Delimiter: resultDelimiter,
+			Encoder:   NewResultEncoder(e.c),
+		}
+		return enc.Encode(w, results)
 	}
+
+	enc := NewResultEncoder(e.c)
+	writer := enc.csvWriter(w)
+	states := make(map[string]*resultState)
+	var current string
+	var writeErr error
+	err := streaming.Stream(func(name string, b query.Block) error {
+		state, ok := states[name]
+		if !ok {
+			state = &resultState{name: name}
+			states[name] = state
+		}
+		if enc.written && name != current {
+			// End the section of the previous result, the schema must be written again.
+			if _, err := w.Write(resultDelimiter); err != nil {
+				writeErr = err
+				return err
+			}
+			state.lastCols = nil
+		}
+		current = name
+		if err := enc.encodeBlock(writer, state, b); err != nil {
+			writeErr = err
+			return err
+		}
+		if f, ok := w.(flusher); ok {
+			f.Flush()
+		}
+		return nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if enc.written {
+		if _, err := w.Write(resultDelimiter); err != nil {
+			return err
+		}
+		if f, ok := w.(flusher); ok {
+			f.Flush()
+		}
+	}
+	if err != nil {
+		return enc.EncodeError(w, err)
+	}
+	return nil
+}
+
+type flusher interface {
+	Flush()
 }
@@ -68,6 +68,92 @@ var symetricalTestCases = []TestCase{
 			}},
 		},
 	},
+	{
+		name:          "single table with nulls",
+		encoderConfig: csv.DefaultEncoderConfig(),
+		encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,string,string,double
+#partition,false,false,true,true,false,true,true,false
+#default,_result,,,,,,,
+,result,table,_start,_stop,_time,_measurement,host,_value
+,,0,2018-04-17T00:00:00Z,2018-04-17T00:05:00Z,2018-04-17T00:00:00Z,cpu,A,
+,,0,2018-04-17T00:00:00Z,2018-04-17T00:05:00Z,2018-04-17T00:00:01Z,cpu,A,43
+`),
+		result: &executetest.Result{
+			Nm: "_result",
+			Blks: []*executetest.Block{{
+				KeyCols: []string{"_start", "_stop", "_measurement", "host"},
+				ColMeta: []query.ColMeta{
+					{Label: "_start", Type: query.TTime},
+					{Label: "_stop", Type: query.TTime},
+					{Label: "_time", Type: query.TTime},
+					{Label: "_measurement", Type: query.TString},
+					{Label: "host", Type: query.TString},
+					{Label: "_value", Type: query.TFloat},
+				},
+				Data: [][]interface{}{
+					{
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 5, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+						"cpu",
+						"A",
+						nil,
+					},
+					{
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 5, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 1, 0, time.UTC)),
+						"cpu",
+						"A",
+						43.0,
+					},
+				},
+			}},
+		},
+	},
+	{
+		name:          "single table with duration and bytes",
+		encoderConfig: csv.DefaultEncoderConfig(),
+		encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,string,duration,base64Binary
+#partition,false,false,true,true,false,true,false,false
+#default,_result,,,,,,,
+,result,table,_start,_stop,_time,host,elapsed,payload
+,,0,2018-04-17T00:00:00Z,2018-04-17T00:05:00Z,2018-04-17T00:00:00Z,A,90000000000,aGVsbG8=
+,,0,2018-04-17T00:00:00Z,2018-04-17T00:05:00Z,2018-04-17T00:00:01Z,A,-1,
+`),
+		result: &executetest.Result{
+			Nm: "_result",
+			Blks: []*executetest.Block{{
+				KeyCols: []string{"_start", "_stop", "host"},
+				ColMeta: []query.ColMeta{
+					{Label: "_start", Type: query.TTime},
+					{Label: "_stop", Type: query.TTime},
+					{Label: "_time", Type: query.TTime},
+					{Label: "host", Type: query.TString},
+					{Label: "elapsed", Type: query.TDuration},
+					{Label: "payload", Type: query.TBytes},
+				},
+				Data: [][]interface{}{
+					{
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 5, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+						"A",
+						values.Duration(90 * time.Second),
+						[]byte("hello"),
+					},
+					{
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 5, 0, 0, time.UTC)),
+						values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 1, 0, time.UTC)),
+						"A",
+						values.Duration(-1),
+						nil,
+					},
+				},
+			}},
+		},
+	},
 	{
 		name:          "single empty table",
 		encoderConfig: csv.DefaultEncoderConfig(),
@@ -662,6 +748,70 @@ func TestMutliResultEncoder(t *testing.T) {
 ,,0,2018-04-17T00:00:00Z,2018-04-17T00:05:00Z,2018-04-17T00:00:00Z,cpu,A,40
 ,,0,2018-04-17T00:00:00Z,2018-04-17T00:05:00Z,2018-04-17T00:00:01Z,cpu,A,40.1
 
+`),
+		},
+		{
+			name:   "interleaved results",
+			config: csv.DefaultEncoderConfig(),
+			results: &streamingResultIterator{
+				names: []string{"_result", "mean", "_result"},
+				blocks: []*executetest.Block{
+					{
+						KeyCols: []string{"_measurement", "host"},
+						ColMeta: []query.ColMeta{
+							{Label: "_time", Type: query.TTime},
+							{Label: "_measurement", Type: query.TString},
+							{Label: "host", Type: query.TString},
+							{Label: "_value", Type: query.TFloat},
+						},
+						Data: [][]interface{}{
+							{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), "cpu", "A", 42.0},
+						},
+					},
+					{
+						KeyCols: []string{"_measurement", "host"},
+						ColMeta: []query.ColMeta{
+							{Label: "_time", Type: query.TTime},
+							{Label: "_measurement", Type: query.TString},
+							{Label: "host", Type: query.TString},
+							{Label: "_value", Type: query.TFloat},
+						},
+						Data: [][]interface{}{
+							{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), "cpu", "A", 40.0},
+						},
+					},
+					{
+						KeyCols: []string{"_measurement", "host"},
+						ColMeta: []query.ColMeta{
+							{Label: "_time", Type: query.TTime},
+							{Label: "_measurement", Type: query.TString},
+							{Label: "host", Type: query.TString},
+							{Label: "_value", Type: query.TFloat},
+						},
+						Data: [][]interface{}{
+							{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), "cpu", "B", 43.0},
+						},
+					},
+				},
+			},
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,string,string,double
+#partition,false,false,false,true,true,false
+#default,_result,,,,,
+,result,table,_time,_measurement,host,_value
+,,0,2018-04-17T00:00:00Z,cpu,A,42
+
+#datatype,string,long,dateTime:RFC3339,string,string,double
+#partition,false,false,false,true,true,false
+#default,mean,,,,,
+,result,table,_time,_measurement,host,_value
+,,0,2018-04-17T00:00:00Z,cpu,A,40
+
+#datatype,string,long,dateTime:RFC3339,string,string,double
+#partition,false,false,false,true,true,false
+#default,_result,,,,,
+,result,table,_time,_measurement,host,_value
+,,1,2018-04-17T00:00:00Z,cpu,B,43
+
 `),
 		},
 		{
@@ -716,3 +866,34 @@ func (r errorResultIterator) Cancel() {
 func (r errorResultIterator) Err() error {
 	return r.Error
 }
+
+// streamingResultIterator streams its blocks in order, block i belongs to the result names[i].
+type streamingResultIterator struct {
+	names  []string
+	blocks []*executetest.Block
+}
+
+func (r *streamingResultIterator) More() bool {
+	return false
+}
+
+func (r *streamingResultIterator) Next() query.Result {
+	panic("no results")
+}
+
+func (r *streamingResultIterator) Cancel() {
+}
+
+func (r *streamingResultIterator) Err() error {
+	return nil
+}
+
+func (r *streamingResultIterator) Stream(f func(name string, b query.Block) error) error {
+	for i, b := range r.blocks {
+		b.Normalize()
+		if err := f(r.names[i], b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
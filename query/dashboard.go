@@ -0,0 +1,227 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query/ast"
+	"github.com/influxdata/platform/query/parser"
+	"github.com/influxdata/platform/query/values"
+)
+
+// DashboardVariables returns the values of the variables of a dashboard, to be declared in the scope
+// of the queries of its cells with the Variables option.
+//
+// The selected values are the values of the variables by name. Constant variables default to their
+// first value and a selected value must be one of their values. Query variables must have a selected value.
+// Time range variables are objects with start and stop times. They default to their time range,
+// a selected value is a duration ending at now, and they are overridden by the time range of a query.
+func DashboardVariables(vars []platform.DashboardVariable, selected map[string]string, override *platform.TimeRange, now time.Time) (map[string]values.Value, error) {
+	scope := make(map[string]values.Value, len(vars))
+	for _, v := range vars {
+		s, ok := selected[v.Name]
+		switch v.Type {
+		case platform.ConstantVariableType:
+			if !ok {
+				s = v.Values[0]
+			} else if !contains(v.Values, s) {
+				return nil, fmt.Errorf("%q is not a value of variable %q", s, v.Name)
+			}
+			scope[v.Name] = values.NewStringValue(s)
+		case platform.QueryVariableType:
+			if !ok {
+				return nil, fmt.Errorf("no value selected for variable %q", v.Name)
+			}
+			scope[v.Name] = values.NewStringValue(s)
+		case platform.TimeRangeVariableType:
+			tr := *v.TimeRange
+			switch {
+			case override != nil:
+				tr = *override
+			case ok:
+				tr = platform.TimeRange{Duration: s}
+			}
+			obj, err := timeRangeValue(tr, now)
+			if err != nil {
+				return nil, fmt.Errorf("variable %q: %v", v.Name, err)
+			}
+			scope[v.Name] = obj
+		default:
+			return nil, fmt.Errorf("unknown type %q of variable %q", v.Type, v.Name)
+		}
+	}
+
+	for name := range selected {
+		if _, ok := scope[name]; !ok {
+			return nil, fmt.Errorf("unknown variable %q", name)
+		}
+	}
+	return scope, nil
+}
+
+// timeRangeValue returns an object with the start and stop times of tr.
+func timeRangeValue(tr platform.TimeRange, now time.Time) (values.Value, error) {
+	if err := tr.Validate(); err != nil {
+		return nil, err
+	}
+
+	start, stop := now, now
+	if tr.Duration != "" {
+		d, err := time.ParseDuration(tr.Duration)
+		if err != nil {
+			return nil, err
+		}
+		start = now.Add(-d)
+	} else {
+		start = *tr.Start
+		if tr.Stop != nil {
+			stop = *tr.Stop
+		}
+	}
+
+	obj := values.NewObject()
+	obj.Set("start", values.NewTimeValue(values.ConvertTime(start)))
+	obj.Set("stop", values.NewTimeValue(values.ConvertTime(stop)))
+	return obj, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// DashboardVariableValues returns the values a dashboard variable may be assigned.
+// The values of a query variable are the distinct string values of the _value column
+// of the results of its query, in order.
+func DashboardVariableValues(ctx context.Context, qs QueryService, orgID platform.ID, v platform.DashboardVariable) ([]string, error) {
+	switch v.Type {
+	case platform.ConstantVariableType:
+		return v.Values, nil
+	case platform.QueryVariableType:
+	default:
+		return nil, fmt.Errorf("variable %q of type %s has no values", v.Name, v.Type)
+	}
+
+	results, err := qs.QueryWithCompile(ctx, orgID, v.Query)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Cancel()
+
+	distinct := make(map[string]bool)
+	for results.More() {
+		err := results.Next().Blocks().Do(func(b Block) error {
+			j := -1
+			for i, c := range b.Cols() {
+				if c.Label == "_value" {
+					if c.Type != TString {
+						return fmt.Errorf("values of variable %q must be strings, got %s", v.Name, c.Type)
+					}
+					j = i
+				}
+			}
+			if j < 0 {
+				return nil
+			}
+			return b.Do(func(cr ColReader) error {
+				nulls := cr.Nulls(j)
+				for i, s := range cr.Strings(j) {
+					if nulls == nil || !nulls[i] {
+						distinct[s] = true
+					}
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+
+	vs := make([]string, 0, len(distinct))
+	for s := range distinct {
+		vs = append(vs, s)
+	}
+	sort.Strings(vs)
+	return vs, nil
+}
+
+// fromBucketVisitor visits the bucket names read by the from calls of a Flux program.
+// Buckets referenced by anything but a string literal, such as a variable, are not visited.
+type fromBucketVisitor struct {
+	fn func(name *ast.StringLiteral)
+}
+
+func (v fromBucketVisitor) Visit(node ast.Node) ast.Visitor {
+	call, ok := node.(*ast.CallExpression)
+	if !ok {
+		return v
+	}
+	if callee, ok := call.Callee.(*ast.Identifier); !ok || callee.Name != "from" || len(call.Arguments) == 0 {
+		return v
+	}
+	args, ok := call.Arguments[0].(*ast.ObjectExpression)
+	if !ok {
+		return v
+	}
+	for _, p := range args.Properties {
+		if p.Key.Name != "bucket" && p.Key.Name != "db" {
+			continue
+		}
+		if name, ok := p.Value.(*ast.StringLiteral); ok {
+			v.fn(name)
+		}
+	}
+	return v
+}
+
+func (v fromBucketVisitor) Done() {}
+
+// QueryBuckets returns the names of the buckets read by the from calls of the Flux query, in order of first use.
+func QueryBuckets(q string) ([]string, error) {
+	prog, err := parser.NewAST(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	ast.Walk(fromBucketVisitor{fn: func(name *ast.StringLiteral) {
+		if !seen[name.Value] {
+			seen[name.Value] = true
+			names = append(names, name.Value)
+		}
+	}}, prog)
+	return names, nil
+}
+
+// RenameQueryBuckets returns the Flux query with the buckets read by its from calls renamed.
+// The query is formatted only if a bucket is renamed.
+func RenameQueryBuckets(q string, names map[string]string) (string, error) {
+	prog, err := parser.NewAST(q)
+	if err != nil {
+		return "", err
+	}
+
+	renamed := false
+	ast.Walk(fromBucketVisitor{fn: func(name *ast.StringLiteral) {
+		if to, ok := names[name.Value]; ok && to != name.Value {
+			name.Value = to
+			renamed = true
+		}
+	}}, prog)
+	if !renamed {
+		return q, nil
+	}
+	return ast.Format(prog), nil
+}
@@ -0,0 +1,236 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/execute/executetest"
+	"github.com/influxdata/platform/query/functions"
+)
+
+var dashboardVariables = []platform.DashboardVariable{
+	{Name: "bucket", Type: platform.ConstantVariableType, Values: []string{"telegraf", "metrics"}},
+	{Name: "host", Type: platform.QueryVariableType, Query: `from(db:"telegraf") |> range(start:-1h) |> keys()`},
+	{Name: "timeRange", Type: platform.TimeRangeVariableType, TimeRange: &platform.TimeRange{Duration: "1h"}},
+}
+
+func TestCompile_DashboardVariables(t *testing.T) {
+	now := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	vars, err := query.DashboardVariables(dashboardVariables, map[string]string{"bucket": "metrics", "host": "server01"}, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := `from(db: bucket)
+	|> range(start: timeRange.start, stop: timeRange.stop)
+	|> filter(fn: (r) => r.host == host)`
+	spec, err := query.Compile(context.Background(), q, query.Variables(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var from *functions.FromOpSpec
+	var rng *functions.RangeOpSpec
+	for _, op := range spec.Operations {
+		switch s := op.Spec.(type) {
+		case *functions.FromOpSpec:
+			from = s
+		case *functions.RangeOpSpec:
+			rng = s
+		}
+	}
+	if from == nil || from.Database != "metrics" {
+		t.Errorf("expected from the selected bucket, got %+v", from)
+	}
+	if rng == nil || !rng.Start.Absolute.Equal(now.Add(-time.Hour)) || !rng.Stop.Absolute.Equal(now) {
+		t.Errorf("expected range of the last hour, got %+v", rng)
+	}
+}
+
+func TestCompile_VariableShadowsBuiltin(t *testing.T) {
+	vars, err := query.DashboardVariables([]platform.DashboardVariable{
+		{Name: "from", Type: platform.ConstantVariableType, Values: []string{"a"}},
+	}, nil, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := query.Compile(context.Background(), `from(db: "telegraf")`, query.Variables(vars)); err == nil {
+		t.Error("expected a variable named after a builtin to fail compiling")
+	}
+}
+
+func TestDashboardVariables(t *testing.T) {
+	now := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		selected  map[string]string
+		override  *platform.TimeRange
+		wantStart time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "default time range",
+			selected:  map[string]string{"host": "server01"},
+			wantStart: now.Add(-time.Hour),
+		},
+		{
+			name:      "selected time range",
+			selected:  map[string]string{"host": "server01", "timeRange": "15m"},
+			wantStart: now.Add(-15 * time.Minute),
+		},
+		{
+			name:      "time range of the query",
+			selected:  map[string]string{"host": "server01", "timeRange": "15m"},
+			override:  &platform.TimeRange{Start: &start},
+			wantStart: start,
+		},
+		{
+			name:     "value not in constant values",
+			selected: map[string]string{"host": "server01", "bucket": "other"},
+			wantErr:  true,
+		},
+		{
+			name:     "no selected query value",
+			selected: map[string]string{},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown variable",
+			selected: map[string]string{"host": "server01", "region": "us-west"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid time range",
+			selected: map[string]string{"host": "server01", "timeRange": "yesterday"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, err := query.DashboardVariables(dashboardVariables, tt.selected, tt.override, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := vars["bucket"].Str(); got != "telegraf" {
+				t.Errorf("expected default bucket telegraf, got %s", got)
+			}
+			tr := vars["timeRange"].Object()
+			start, _ := tr.Get("start")
+			if got := start.Time().Time(); !got.Equal(tt.wantStart) {
+				t.Errorf("unexpected start: got %v want %v", got, tt.wantStart)
+			}
+		})
+	}
+}
+
+type valuesQueryService struct {
+	query  string
+	blocks []*executetest.Block
+}
+
+func (s *valuesQueryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.ResultIterator, error) {
+	results := []query.Result{&executetest.Result{Nm: "_result", Blks: s.blocks}}
+	return query.NewSliceResultIterator(results), nil
+}
+
+func (s *valuesQueryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.ResultIterator, error) {
+	s.query = q
+	return s.Query(ctx, orgID, nil)
+}
+
+func TestDashboardVariableValues(t *testing.T) {
+	ctx := context.Background()
+	qs := &valuesQueryService{
+		blocks: []*executetest.Block{
+			{
+				KeyCols: []string{"region"},
+				ColMeta: []query.ColMeta{
+					{Label: "region", Type: query.TString},
+					{Label: "_value", Type: query.TString},
+				},
+				Data: [][]interface{}{
+					{"west", "server02"},
+					{"west", "server01"},
+				},
+			},
+			{
+				KeyCols: []string{"region"},
+				ColMeta: []query.ColMeta{
+					{Label: "region", Type: query.TString},
+					{Label: "_value", Type: query.TString},
+				},
+				Data: [][]interface{}{
+					{"east", "server01"},
+					{"east", nil},
+					{"east", "server03"},
+				},
+			},
+		},
+	}
+
+	got, err := query.DashboardVariableValues(ctx, qs, platform.ID("org"), dashboardVariables[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"server01", "server02", "server03"}; !cmp.Equal(got, want) {
+		t.Errorf("unexpected values -want/+got\n%s", cmp.Diff(want, got))
+	}
+	if qs.query != dashboardVariables[1].Query {
+		t.Errorf("expected query of the variable to be run, got %q", qs.query)
+	}
+
+	if got, err := query.DashboardVariableValues(ctx, qs, platform.ID("org"), dashboardVariables[0]); err != nil || !cmp.Equal(got, dashboardVariables[0].Values) {
+		t.Errorf("expected values of constant variable, got %v %v", got, err)
+	}
+	if _, err := query.DashboardVariableValues(ctx, qs, platform.ID("org"), dashboardVariables[2]); err == nil {
+		t.Error("expected time range variable to have no values")
+	}
+}
+
+func TestQueryBuckets(t *testing.T) {
+	q := `a = from(bucket: "telegraf") |> range(start: -1h)
+b = from(db: "metrics") |> range(start: -1h)
+c = from(bucket: bucket) |> range(start: -1h)
+join(tables: {a: a, b: b, t: from(bucket: "telegraf")}, on: ["host"])`
+	got, err := query.QueryBuckets(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"telegraf", "metrics"}; !cmp.Equal(got, want) {
+		t.Errorf("unexpected buckets -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	if names, _ := query.QueryBuckets("SELECT * FROM cpu"); len(names) != 0 {
+		t.Errorf("expected InfluxQL to read no buckets, got %v", names)
+	}
+}
+
+func TestRenameQueryBuckets(t *testing.T) {
+	q := `from(bucket:"telegraf") |> range(start:-1h)`
+
+	got, err := query.RenameQueryBuckets(q, map[string]string{"other": "metrics"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != q {
+		t.Errorf("expected query without renamed buckets to be unchanged, got %q", got)
+	}
+
+	got, err = query.RenameQueryBuckets(q, map[string]string{"telegraf": "metrics"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names, err := query.QueryBuckets(got); err != nil || !cmp.Equal(names, []string{"metrics"}) {
+		t.Errorf("expected renamed bucket in %q, got %v %v", got, names, err)
+	}
+}
@@ -60,6 +60,38 @@ func TestAggregate_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name:   "single with nulls",
+			config: execute.DefaultAggregateConfig,
+			agg:    countAgg,
+			data: []*executetest.Block{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []query.ColMeta{
+					{Label: "_start", Type: query.TTime},
+					{Label: "_stop", Type: query.TTime},
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 0.0},
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Block{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []query.ColMeta{
+					{Label: "_start", Type: query.TTime},
+					{Label: "_stop", Type: query.TTime},
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(100), int64(2)},
+				},
+			}},
+		},
 		{
 			name: "single use start time",
 			config: execute.AggregateConfig{
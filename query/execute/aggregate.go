@@ -23,6 +23,9 @@ type AggregateConfig struct {
 	TimeDst string   `json:"time_dst"`
 }
 
+// PartitionIndependent reports that each table is aggregated independently of all other tables.
+func (c AggregateConfig) PartitionIndependent() {}
+
 var DefaultAggregateConfig = AggregateConfig{
 	Columns: []string{DefaultValueColLabel},
 	TimeSrc: DefaultStopColLabel,
@@ -153,6 +156,8 @@ func (t *aggregateTransformation) Process(id DatasetID, b query.Block) error {
 
 			tj := blockColMap[j]
 			c := b.Cols()[tj]
+			// Missing values are not aggregated.
+			cr := SkipNulls(cr, tj)
 
 			switch c.Type {
 			case query.TBool:
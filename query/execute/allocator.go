@@ -12,6 +12,7 @@ const (
 	float64Size = 8
 	stringSize  = 16
 	timeSize    = 8
+	bytesSize   = 24
 )
 
 // Allocator tracks the amount of memory being consumed by a query.
@@ -159,6 +160,40 @@ func (a *Allocator) AppendTimes(slice []Time, vs ...Time) []Time {
 	return s
 }
 
+// Durations makes a slice of Duration values.
+func (a *Allocator) Durations(l, c int) []Duration {
+	a.account(c, int64Size)
+	return make([]Duration, l, c)
+}
+
+// AppendDurations appends Durations to a slice
+func (a *Allocator) AppendDurations(slice []Duration, vs ...Duration) []Duration {
+	if cap(slice)-len(slice) > len(vs) {
+		return append(slice, vs...)
+	}
+	s := append(slice, vs...)
+	diff := cap(s) - cap(slice)
+	a.account(diff, int64Size)
+	return s
+}
+
+// ByteSlices makes a slice of byte slices.
+func (a *Allocator) ByteSlices(l, c int) [][]byte {
+	a.account(c, bytesSize)
+	return make([][]byte, l, c)
+}
+
+// AppendByteSlices appends byte slices to a slice
+func (a *Allocator) AppendByteSlices(slice [][]byte, vs ...[]byte) [][]byte {
+	if cap(slice)-len(slice) > len(vs) {
+		return append(slice, vs...)
+	}
+	s := append(slice, vs...)
+	diff := cap(s) - cap(slice)
+	a.account(diff, bytesSize)
+	return s
+}
+
 type AllocError struct {
 	Limit     int64
 	Allocated int64
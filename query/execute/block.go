@@ -1,6 +1,7 @@
 package execute
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"sync/atomic"
@@ -39,6 +40,10 @@ func PartitionKeyForRow(i int, cr query.ColReader) query.PartitionKey {
 			vs = append(vs, values.NewStringValue(cr.Strings(j)[i]))
 		case query.TTime:
 			vs = append(vs, values.NewTimeValue(cr.Times(j)[i]))
+		case query.TDuration:
+			vs = append(vs, values.NewDurationValue(cr.Durations(j)[i]))
+		case query.TBytes:
+			vs = append(vs, values.NewBytesValue(cr.Bytes(j)[i]))
 		}
 	}
 	return &partitionKey{
@@ -68,6 +73,10 @@ func PartitionKeyForRowOn(i int, cr query.ColReader, on map[string]bool) query.P
 			vs = append(vs, values.NewStringValue(cr.Strings(j)[i]))
 		case query.TTime:
 			vs = append(vs, values.NewTimeValue(cr.Times(j)[i]))
+		case query.TDuration:
+			vs = append(vs, values.NewDurationValue(cr.Durations(j)[i]))
+		case query.TBytes:
+			vs = append(vs, values.NewBytesValue(cr.Bytes(j)[i]))
 		}
 	}
 	return NewPartitionKey(cols, vs)
@@ -169,6 +178,14 @@ func AppendCols(cr query.ColReader, builder BlockBuilder, colMap []int) {
 // AppendCol append a column from cr onto builder
 // The indexes bj and cj are builder and col reader indexes respectively.
 func AppendCol(bj, cj int, cr query.ColReader, builder BlockBuilder) {
+	if cr.Nulls(cj) != nil {
+		// Append the values one at a time to preserve the missing values.
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			AppendValueForRow(bj, i, cj, cr, builder)
+		}
+		return
+	}
 	c := cr.Cols()[cj]
 	switch c.Type {
 	case query.TBool:
@@ -183,6 +200,10 @@ func AppendCol(bj, cj int, cr query.ColReader, builder BlockBuilder) {
 		builder.AppendStrings(bj, cr.Strings(cj))
 	case query.TTime:
 		builder.AppendTimes(bj, cr.Times(cj))
+	case query.TDuration:
+		builder.AppendDurations(bj, cr.Durations(cj))
+	case query.TBytes:
+		builder.AppendByteSlices(bj, cr.Bytes(cj))
 	default:
 		PanicUnknownType(c.Type)
 	}
@@ -190,67 +211,51 @@ func AppendCol(bj, cj int, cr query.ColReader, builder BlockBuilder) {
 
 // AppendMappedRecord appends the record from cr onto builder assuming matching columns.
 func AppendRecord(i int, cr query.ColReader, builder BlockBuilder) {
-	for j, c := range builder.Cols() {
-		switch c.Type {
-		case query.TBool:
-			builder.AppendBool(j, cr.Bools(j)[i])
-		case query.TInt:
-			builder.AppendInt(j, cr.Ints(j)[i])
-		case query.TUInt:
-			builder.AppendUInt(j, cr.UInts(j)[i])
-		case query.TFloat:
-			builder.AppendFloat(j, cr.Floats(j)[i])
-		case query.TString:
-			builder.AppendString(j, cr.Strings(j)[i])
-		case query.TTime:
-			builder.AppendTime(j, cr.Times(j)[i])
-		default:
-			PanicUnknownType(c.Type)
-		}
+	for j := range builder.Cols() {
+		AppendValueForRow(j, i, j, cr, builder)
 	}
 }
 
 // AppendMappedRecord appends the records from cr onto builder, using colMap as a map of builder index to cr index.
 func AppendMappedRecord(i int, cr query.ColReader, builder BlockBuilder, colMap []int) {
-	for j, c := range builder.Cols() {
-		switch c.Type {
-		case query.TBool:
-			builder.AppendBool(j, cr.Bools(colMap[j])[i])
-		case query.TInt:
-			builder.AppendInt(j, cr.Ints(colMap[j])[i])
-		case query.TUInt:
-			builder.AppendUInt(j, cr.UInts(colMap[j])[i])
-		case query.TFloat:
-			builder.AppendFloat(j, cr.Floats(colMap[j])[i])
-		case query.TString:
-			builder.AppendString(j, cr.Strings(colMap[j])[i])
-		case query.TTime:
-			builder.AppendTime(j, cr.Times(colMap[j])[i])
-		default:
-			PanicUnknownType(c.Type)
-		}
+	for j := range builder.Cols() {
+		AppendValueForRow(j, i, colMap[j], cr, builder)
 	}
 }
 
 // AppendRecordForCols appends the only the columns provided from cr onto builder.
 func AppendRecordForCols(i int, cr query.ColReader, builder BlockBuilder, cols []query.ColMeta) {
-	for j, c := range cols {
-		switch c.Type {
-		case query.TBool:
-			builder.AppendBool(j, cr.Bools(j)[i])
-		case query.TInt:
-			builder.AppendInt(j, cr.Ints(j)[i])
-		case query.TUInt:
-			builder.AppendUInt(j, cr.UInts(j)[i])
-		case query.TFloat:
-			builder.AppendFloat(j, cr.Floats(j)[i])
-		case query.TString:
-			builder.AppendString(j, cr.Strings(j)[i])
-		case query.TTime:
-			builder.AppendTime(j, cr.Times(j)[i])
-		default:
-			PanicUnknownType(c.Type)
-		}
+	for j := range cols {
+		AppendValueForRow(j, i, j, cr, builder)
+	}
+}
+
+// AppendValueForRow appends the value of row i and column cj from cr onto the column bj of builder.
+// Missing values are appended as missing values.
+func AppendValueForRow(bj, i, cj int, cr query.ColReader, builder BlockBuilder) {
+	if IsNull(cr, cj, i) {
+		builder.AppendNil(bj)
+		return
+	}
+	switch c := cr.Cols()[cj]; c.Type {
+	case query.TBool:
+		builder.AppendBool(bj, cr.Bools(cj)[i])
+	case query.TInt:
+		builder.AppendInt(bj, cr.Ints(cj)[i])
+	case query.TUInt:
+		builder.AppendUInt(bj, cr.UInts(cj)[i])
+	case query.TFloat:
+		builder.AppendFloat(bj, cr.Floats(cj)[i])
+	case query.TString:
+		builder.AppendString(bj, cr.Strings(cj)[i])
+	case query.TTime:
+		builder.AppendTime(bj, cr.Times(cj)[i])
+	case query.TDuration:
+		builder.AppendDuration(bj, cr.Durations(cj)[i])
+	case query.TBytes:
+		builder.AppendBytes(bj, cr.Bytes(cj)[i])
+	default:
+		PanicUnknownType(c.Type)
 	}
 }
 
@@ -270,6 +275,10 @@ func AppendKeyValues(key query.PartitionKey, builder BlockBuilder) {
 			builder.AppendString(idx, key.ValueString(j))
 		case query.TTime:
 			builder.AppendTime(idx, key.ValueTime(j))
+		case query.TDuration:
+			builder.AppendDuration(idx, key.ValueDuration(j))
+		case query.TBytes:
+			builder.AppendBytes(idx, key.ValueBytes(j))
 		default:
 			PanicUnknownType(c.Type)
 		}
@@ -317,6 +326,8 @@ type BlockBuilder interface {
 	SetFloat(i, j int, value float64)
 	SetString(i, j int, value string)
 	SetTime(i, j int, value Time)
+	SetDuration(i, j int, value Duration)
+	SetBytes(i, j int, value []byte)
 
 	AppendBool(j int, value bool)
 	AppendInt(j int, value int64)
@@ -324,6 +335,8 @@ type BlockBuilder interface {
 	AppendFloat(j int, value float64)
 	AppendString(j int, value string)
 	AppendTime(j int, value Time)
+	AppendDuration(j int, value Duration)
+	AppendBytes(j int, value []byte)
 
 	AppendBools(j int, values []bool)
 	AppendInts(j int, values []int64)
@@ -331,6 +344,14 @@ type BlockBuilder interface {
 	AppendFloats(j int, values []float64)
 	AppendStrings(j int, values []string)
 	AppendTimes(j int, values []Time)
+	AppendDurations(j int, values []Duration)
+	AppendByteSlices(j int, values [][]byte)
+
+	// AppendNil appends a missing value to the column.
+	AppendNil(j int)
+	// SetNil marks the value at the specified coordinates as missing.
+	// Setting a value with one of the Set methods marks it as present again.
+	SetNil(i, j int)
 
 	// Sort the rows of the by the values of the columns in the order listed.
 	Sort(cols []string, desc bool)
@@ -402,6 +423,16 @@ func (b ColListBlockBuilder) AddCol(c query.ColMeta) int {
 			ColMeta: c,
 			alloc:   b.alloc,
 		}
+	case query.TDuration:
+		col = &durationColumn{
+			ColMeta: c,
+			alloc:   b.alloc,
+		}
+	case query.TBytes:
+		col = &bytesColumn{
+			ColMeta: c,
+			alloc:   b.alloc,
+		}
 	default:
 		PanicUnknownType(c.Type)
 	}
@@ -412,107 +443,211 @@ func (b ColListBlockBuilder) AddCol(c query.ColMeta) int {
 
 func (b ColListBlockBuilder) SetBool(i int, j int, value bool) {
 	b.checkColType(j, query.TBool)
-	b.blk.cols[j].(*boolColumn).data[i] = value
+	col := b.blk.cols[j].(*boolColumn)
+	col.data[i] = value
+	col.setPresent(i)
 }
 func (b ColListBlockBuilder) AppendBool(j int, value bool) {
 	b.checkColType(j, query.TBool)
 	col := b.blk.cols[j].(*boolColumn)
 	col.data = b.alloc.AppendBools(col.data, value)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 func (b ColListBlockBuilder) AppendBools(j int, values []bool) {
 	b.checkColType(j, query.TBool)
 	col := b.blk.cols[j].(*boolColumn)
 	col.data = b.alloc.AppendBools(col.data, values...)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 
 func (b ColListBlockBuilder) SetInt(i int, j int, value int64) {
 	b.checkColType(j, query.TInt)
-	b.blk.cols[j].(*intColumn).data[i] = value
+	col := b.blk.cols[j].(*intColumn)
+	col.data[i] = value
+	col.setPresent(i)
 }
 func (b ColListBlockBuilder) AppendInt(j int, value int64) {
 	b.checkColType(j, query.TInt)
 	col := b.blk.cols[j].(*intColumn)
 	col.data = b.alloc.AppendInts(col.data, value)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 func (b ColListBlockBuilder) AppendInts(j int, values []int64) {
 	b.checkColType(j, query.TInt)
 	col := b.blk.cols[j].(*intColumn)
 	col.data = b.alloc.AppendInts(col.data, values...)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 
 func (b ColListBlockBuilder) SetUInt(i int, j int, value uint64) {
 	b.checkColType(j, query.TUInt)
-	b.blk.cols[j].(*uintColumn).data[i] = value
+	col := b.blk.cols[j].(*uintColumn)
+	col.data[i] = value
+	col.setPresent(i)
 }
 func (b ColListBlockBuilder) AppendUInt(j int, value uint64) {
 	b.checkColType(j, query.TUInt)
 	col := b.blk.cols[j].(*uintColumn)
 	col.data = b.alloc.AppendUInts(col.data, value)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 func (b ColListBlockBuilder) AppendUInts(j int, values []uint64) {
 	b.checkColType(j, query.TUInt)
 	col := b.blk.cols[j].(*uintColumn)
 	col.data = b.alloc.AppendUInts(col.data, values...)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 
 func (b ColListBlockBuilder) SetFloat(i int, j int, value float64) {
 	b.checkColType(j, query.TFloat)
-	b.blk.cols[j].(*floatColumn).data[i] = value
+	col := b.blk.cols[j].(*floatColumn)
+	col.data[i] = value
+	col.setPresent(i)
 }
 func (b ColListBlockBuilder) AppendFloat(j int, value float64) {
 	b.checkColType(j, query.TFloat)
 	col := b.blk.cols[j].(*floatColumn)
 	col.data = b.alloc.AppendFloats(col.data, value)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 func (b ColListBlockBuilder) AppendFloats(j int, values []float64) {
 	b.checkColType(j, query.TFloat)
 	col := b.blk.cols[j].(*floatColumn)
 	col.data = b.alloc.AppendFloats(col.data, values...)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 
 func (b ColListBlockBuilder) SetString(i int, j int, value string) {
 	b.checkColType(j, query.TString)
-	b.blk.cols[j].(*stringColumn).data[i] = value
+	col := b.blk.cols[j].(*stringColumn)
+	col.data[i] = value
+	col.setPresent(i)
 }
 func (b ColListBlockBuilder) AppendString(j int, value string) {
 	meta := b.blk.cols[j].Meta()
 	CheckColType(meta, query.TString)
 	col := b.blk.cols[j].(*stringColumn)
 	col.data = b.alloc.AppendStrings(col.data, value)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 func (b ColListBlockBuilder) AppendStrings(j int, values []string) {
 	b.checkColType(j, query.TString)
 	col := b.blk.cols[j].(*stringColumn)
 	col.data = b.alloc.AppendStrings(col.data, values...)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 
 func (b ColListBlockBuilder) SetTime(i int, j int, value Time) {
 	b.checkColType(j, query.TTime)
-	b.blk.cols[j].(*timeColumn).data[i] = value
+	col := b.blk.cols[j].(*timeColumn)
+	col.data[i] = value
+	col.setPresent(i)
 }
 func (b ColListBlockBuilder) AppendTime(j int, value Time) {
 	b.checkColType(j, query.TTime)
 	col := b.blk.cols[j].(*timeColumn)
 	col.data = b.alloc.AppendTimes(col.data, value)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 func (b ColListBlockBuilder) AppendTimes(j int, values []Time) {
 	b.checkColType(j, query.TTime)
 	col := b.blk.cols[j].(*timeColumn)
 	col.data = b.alloc.AppendTimes(col.data, values...)
+	col.grow(len(col.data), b.alloc)
 	b.blk.nrows = len(col.data)
 }
 
+func (b ColListBlockBuilder) SetDuration(i int, j int, value Duration) {
+	b.checkColType(j, query.TDuration)
+	col := b.blk.cols[j].(*durationColumn)
+	col.data[i] = value
+	col.setPresent(i)
+}
+func (b ColListBlockBuilder) AppendDuration(j int, value Duration) {
+	b.checkColType(j, query.TDuration)
+	col := b.blk.cols[j].(*durationColumn)
+	col.data = b.alloc.AppendDurations(col.data, value)
+	col.grow(len(col.data), b.alloc)
+	b.blk.nrows = len(col.data)
+}
+func (b ColListBlockBuilder) AppendDurations(j int, values []Duration) {
+	b.checkColType(j, query.TDuration)
+	col := b.blk.cols[j].(*durationColumn)
+	col.data = b.alloc.AppendDurations(col.data, values...)
+	col.grow(len(col.data), b.alloc)
+	b.blk.nrows = len(col.data)
+}
+
+func (b ColListBlockBuilder) SetBytes(i int, j int, value []byte) {
+	b.checkColType(j, query.TBytes)
+	col := b.blk.cols[j].(*bytesColumn)
+	col.data[i] = value
+	col.setPresent(i)
+}
+func (b ColListBlockBuilder) AppendBytes(j int, value []byte) {
+	b.checkColType(j, query.TBytes)
+	col := b.blk.cols[j].(*bytesColumn)
+	col.data = b.alloc.AppendByteSlices(col.data, value)
+	col.grow(len(col.data), b.alloc)
+	b.blk.nrows = len(col.data)
+}
+func (b ColListBlockBuilder) AppendByteSlices(j int, values [][]byte) {
+	b.checkColType(j, query.TBytes)
+	col := b.blk.cols[j].(*bytesColumn)
+	col.data = b.alloc.AppendByteSlices(col.data, values...)
+	col.grow(len(col.data), b.alloc)
+	b.blk.nrows = len(col.data)
+}
+
+func (b ColListBlockBuilder) AppendNil(j int) {
+	var n int
+	switch col := b.blk.cols[j].(type) {
+	case *boolColumn:
+		col.data = b.alloc.AppendBools(col.data, false)
+		n = len(col.data)
+	case *intColumn:
+		col.data = b.alloc.AppendInts(col.data, 0)
+		n = len(col.data)
+	case *uintColumn:
+		col.data = b.alloc.AppendUInts(col.data, 0)
+		n = len(col.data)
+	case *floatColumn:
+		col.data = b.alloc.AppendFloats(col.data, 0)
+		n = len(col.data)
+	case *stringColumn:
+		col.data = b.alloc.AppendStrings(col.data, "")
+		n = len(col.data)
+	case *timeColumn:
+		col.data = b.alloc.AppendTimes(col.data, 0)
+		n = len(col.data)
+	case *durationColumn:
+		col.data = b.alloc.AppendDurations(col.data, 0)
+		n = len(col.data)
+	case *bytesColumn:
+		col.data = b.alloc.AppendByteSlices(col.data, nil)
+		n = len(col.data)
+	default:
+		PanicUnknownType(b.blk.colMeta[j].Type)
+	}
+	b.blk.cols[j].mask().setNull(n-1, n, b.alloc)
+	b.blk.nrows = n
+}
+
+func (b ColListBlockBuilder) SetNil(i, j int) {
+	b.blk.cols[j].mask().setNull(i, b.blk.nrows, b.alloc)
+}
+
 func (b ColListBlockBuilder) checkColType(j int, typ query.DataType) {
 	CheckColType(b.blk.colMeta[j], typ)
 }
@@ -627,6 +762,18 @@ func (b *ColListBlock) Times(j int) []Time {
 	CheckColType(b.colMeta[j], query.TTime)
 	return b.cols[j].(*timeColumn).data
 }
+func (b *ColListBlock) Durations(j int) []Duration {
+	CheckColType(b.colMeta[j], query.TDuration)
+	return b.cols[j].(*durationColumn).data
+}
+func (b *ColListBlock) Bytes(j int) [][]byte {
+	CheckColType(b.colMeta[j], query.TBytes)
+	return b.cols[j].(*bytesColumn).data
+}
+
+func (b *ColListBlock) Nulls(j int) []bool {
+	return b.cols[j].mask().nulls
+}
 
 func (b *ColListBlock) Copy() *ColListBlock {
 	cpy := new(ColListBlock)
@@ -677,13 +824,88 @@ type column interface {
 	Meta() query.ColMeta
 	Clear()
 	Copy() column
+	// Equal reports whether the values are equal, missing values are equal to each other.
 	Equal(i, j int) bool
+	// Less reports whether value i sorts before value j, missing values sort last.
 	Less(i, j int) bool
 	Swap(i, j int)
+	mask() *nullMask
+}
+
+// nullMask records which values of a column are missing.
+// The mask is nil while no value of the column is missing, as is the case for most columns,
+// otherwise it has a flag for each value of the column.
+type nullMask struct {
+	nulls []bool
+}
+
+func (m *nullMask) mask() *nullMask {
+	return m
+}
+
+func (m *nullMask) isNull(i int) bool {
+	return m.nulls != nil && m.nulls[i]
+}
+
+// setNull marks the value i of a column of n values as missing.
+func (m *nullMask) setNull(i, n int, a *Allocator) {
+	if m.nulls == nil {
+		m.nulls = a.Bools(0, n)
+	}
+	m.grow(n, a)
+	m.nulls[i] = true
+}
+
+// setPresent marks the value i as present.
+func (m *nullMask) setPresent(i int) {
+	if m.nulls != nil {
+		m.nulls[i] = false
+	}
+}
+
+// grow extends the mask to a column of n values, the new values are present.
+func (m *nullMask) grow(n int, a *Allocator) {
+	if m.nulls == nil {
+		return
+	}
+	for len(m.nulls) < n {
+		m.nulls = a.AppendBools(m.nulls, false)
+	}
+}
+
+func (m *nullMask) clear(a *Allocator) {
+	if m.nulls != nil {
+		a.Free(len(m.nulls), boolSize)
+		m.nulls = nil
+	}
+}
+
+func (m *nullMask) copy(a *Allocator) nullMask {
+	if m.nulls == nil {
+		return nullMask{}
+	}
+	l := len(m.nulls)
+	cpy := a.Bools(l, l)
+	copy(cpy, m.nulls)
+	return nullMask{nulls: cpy}
+}
+
+// compare compares the missing values i and j.
+// It reports whether either value is missing, in which case the values are compared by the mask alone.
+func (m *nullMask) compare(i, j int) (equal, less, null bool) {
+	ni, nj := m.isNull(i), m.isNull(j)
+	return ni == nj, !ni && nj, ni || nj
+}
+
+func (m *nullMask) swap(i, j int) {
+	if m.nulls != nil {
+		m.nulls[i], m.nulls[j] = m.nulls[j], m.nulls[i]
+	}
 }
 
 type boolColumn struct {
 	query.ColMeta
+	nullMask
 	data  []bool
 	alloc *Allocator
 }
@@ -695,6 +917,7 @@ func (c *boolColumn) Meta() query.ColMeta {
 func (c *boolColumn) Clear() {
 	c.alloc.Free(len(c.data), boolSize)
 	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
 }
 func (c *boolColumn) Copy() column {
 	cpy := &boolColumn{
@@ -704,12 +927,19 @@ func (c *boolColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Bools(l, l)
 	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
 	return cpy
 }
 func (c *boolColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *boolColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
 	if c.data[i] == c.data[j] {
 		return false
 	}
@@ -717,10 +947,12 @@ func (c *boolColumn) Less(i, j int) bool {
 }
 func (c *boolColumn) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
 }
 
 type intColumn struct {
 	query.ColMeta
+	nullMask
 	data  []int64
 	alloc *Allocator
 }
@@ -732,6 +964,7 @@ func (c *intColumn) Meta() query.ColMeta {
 func (c *intColumn) Clear() {
 	c.alloc.Free(len(c.data), int64Size)
 	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
 }
 func (c *intColumn) Copy() column {
 	cpy := &intColumn{
@@ -741,20 +974,29 @@ func (c *intColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Ints(l, l)
 	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
 	return cpy
 }
 func (c *intColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *intColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *intColumn) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
 }
 
 type uintColumn struct {
 	query.ColMeta
+	nullMask
 	data  []uint64
 	alloc *Allocator
 }
@@ -766,6 +1008,7 @@ func (c *uintColumn) Meta() query.ColMeta {
 func (c *uintColumn) Clear() {
 	c.alloc.Free(len(c.data), uint64Size)
 	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
 }
 func (c *uintColumn) Copy() column {
 	cpy := &uintColumn{
@@ -775,20 +1018,29 @@ func (c *uintColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.UInts(l, l)
 	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
 	return cpy
 }
 func (c *uintColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *uintColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *uintColumn) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
 }
 
 type floatColumn struct {
 	query.ColMeta
+	nullMask
 	data  []float64
 	alloc *Allocator
 }
@@ -800,6 +1052,7 @@ func (c *floatColumn) Meta() query.ColMeta {
 func (c *floatColumn) Clear() {
 	c.alloc.Free(len(c.data), float64Size)
 	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
 }
 func (c *floatColumn) Copy() column {
 	cpy := &floatColumn{
@@ -809,20 +1062,29 @@ func (c *floatColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Floats(l, l)
 	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
 	return cpy
 }
 func (c *floatColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *floatColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *floatColumn) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
 }
 
 type stringColumn struct {
 	query.ColMeta
+	nullMask
 	data  []string
 	alloc *Allocator
 }
@@ -834,6 +1096,7 @@ func (c *stringColumn) Meta() query.ColMeta {
 func (c *stringColumn) Clear() {
 	c.alloc.Free(len(c.data), stringSize)
 	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
 }
 func (c *stringColumn) Copy() column {
 	cpy := &stringColumn{
@@ -844,20 +1107,29 @@ func (c *stringColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Strings(l, l)
 	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
 	return cpy
 }
 func (c *stringColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *stringColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *stringColumn) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
 }
 
 type timeColumn struct {
 	query.ColMeta
+	nullMask
 	data  []Time
 	alloc *Allocator
 }
@@ -869,6 +1141,7 @@ func (c *timeColumn) Meta() query.ColMeta {
 func (c *timeColumn) Clear() {
 	c.alloc.Free(len(c.data), timeSize)
 	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
 }
 func (c *timeColumn) Copy() column {
 	cpy := &timeColumn{
@@ -878,16 +1151,112 @@ func (c *timeColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Times(l, l)
 	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
 	return cpy
 }
 func (c *timeColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *timeColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *timeColumn) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
+}
+
+type durationColumn struct {
+	query.ColMeta
+	nullMask
+	data  []Duration
+	alloc *Allocator
+}
+
+func (c *durationColumn) Meta() query.ColMeta {
+	return c.ColMeta
+}
+
+func (c *durationColumn) Clear() {
+	c.alloc.Free(len(c.data), int64Size)
+	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
+}
+func (c *durationColumn) Copy() column {
+	cpy := &durationColumn{
+		ColMeta: c.ColMeta,
+		alloc:   c.alloc,
+	}
+	l := len(c.data)
+	cpy.data = c.alloc.Durations(l, l)
+	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
+	return cpy
+}
+func (c *durationColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
+	return c.data[i] == c.data[j]
+}
+func (c *durationColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
+	return c.data[i] < c.data[j]
+}
+func (c *durationColumn) Swap(i, j int) {
+	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
+}
+
+type bytesColumn struct {
+	query.ColMeta
+	nullMask
+	data  [][]byte
+	alloc *Allocator
+}
+
+func (c *bytesColumn) Meta() query.ColMeta {
+	return c.ColMeta
+}
+
+func (c *bytesColumn) Clear() {
+	c.alloc.Free(len(c.data), bytesSize)
+	c.data = c.data[0:0]
+	c.nullMask.clear(c.alloc)
+}
+func (c *bytesColumn) Copy() column {
+	cpy := &bytesColumn{
+		ColMeta: c.ColMeta,
+		alloc:   c.alloc,
+	}
+	l := len(c.data)
+	cpy.data = c.alloc.ByteSlices(l, l)
+	copy(cpy.data, c.data)
+	cpy.nullMask = c.nullMask.copy(c.alloc)
+	return cpy
+}
+func (c *bytesColumn) Equal(i, j int) bool {
+	if equal, _, null := c.compare(i, j); null {
+		return equal
+	}
+	return bytes.Equal(c.data[i], c.data[j])
+}
+func (c *bytesColumn) Less(i, j int) bool {
+	if _, less, null := c.compare(i, j); null {
+		return less
+	}
+	return bytes.Compare(c.data[i], c.data[j]) < 0
+}
+func (c *bytesColumn) Swap(i, j int) {
+	c.data[i], c.data[j] = c.data[j], c.data[i]
+	c.nullMask.swap(i, j)
 }
 
 type BlockBuilderCache interface {
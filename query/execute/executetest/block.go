@@ -25,6 +25,7 @@ type Block struct {
 	ColMeta []query.ColMeta
 	// Data is a list of rows, i.e. Data[row][col]
 	// Each row must be a list with length equal to len(ColMeta)
+	// Missing values are nil.
 	Data [][]interface{}
 }
 
@@ -101,27 +102,50 @@ func (cr ColReader) Len() int {
 }
 
 func (cr ColReader) Bools(j int) []bool {
-	return []bool{cr.row[j].(bool)}
+	v, _ := cr.row[j].(bool)
+	return []bool{v}
 }
 
 func (cr ColReader) Ints(j int) []int64 {
-	return []int64{cr.row[j].(int64)}
+	v, _ := cr.row[j].(int64)
+	return []int64{v}
 }
 
 func (cr ColReader) UInts(j int) []uint64 {
-	return []uint64{cr.row[j].(uint64)}
+	v, _ := cr.row[j].(uint64)
+	return []uint64{v}
 }
 
 func (cr ColReader) Floats(j int) []float64 {
-	return []float64{cr.row[j].(float64)}
+	v, _ := cr.row[j].(float64)
+	return []float64{v}
 }
 
 func (cr ColReader) Strings(j int) []string {
-	return []string{cr.row[j].(string)}
+	v, _ := cr.row[j].(string)
+	return []string{v}
 }
 
 func (cr ColReader) Times(j int) []execute.Time {
-	return []execute.Time{cr.row[j].(execute.Time)}
+	v, _ := cr.row[j].(execute.Time)
+	return []execute.Time{v}
+}
+
+func (cr ColReader) Durations(j int) []execute.Duration {
+	v, _ := cr.row[j].(execute.Duration)
+	return []execute.Duration{v}
+}
+
+func (cr ColReader) Bytes(j int) [][]byte {
+	v, _ := cr.row[j].([]byte)
+	return [][]byte{v}
+}
+
+func (cr ColReader) Nulls(j int) []bool {
+	if cr.row[j] == nil {
+		return []bool{true}
+	}
+	return nil
 }
 
 func BlocksFromCache(c execute.DataCache) (blocks []*Block, err error) {
@@ -171,6 +195,10 @@ func ConvertBlock(b query.Block) (*Block, error) {
 				v = key.ValueString(j)
 			case query.TTime:
 				v = key.ValueTime(j)
+			case query.TDuration:
+				v = key.ValueDuration(j)
+			case query.TBytes:
+				v = key.ValueBytes(j)
 			default:
 				return nil, fmt.Errorf("unsupported column type %v", c.Type)
 			}
@@ -183,6 +211,9 @@ func ConvertBlock(b query.Block) (*Block, error) {
 		for i := 0; i < l; i++ {
 			row := make([]interface{}, len(blk.ColMeta))
 			for j, c := range blk.ColMeta {
+				if execute.IsNull(cr, j, i) {
+					continue
+				}
 				var v interface{}
 				switch c.Type {
 				case query.TBool:
@@ -197,6 +228,10 @@ func ConvertBlock(b query.Block) (*Block, error) {
 					v = cr.Strings(j)[i]
 				case query.TTime:
 					v = cr.Times(j)[i]
+				case query.TDuration:
+					v = cr.Durations(j)[i]
+				case query.TBytes:
+					v = cr.Bytes(j)[i]
 				default:
 					panic(fmt.Errorf("unknown column type %s", c.Type))
 				}
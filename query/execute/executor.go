@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/query/id"
@@ -135,18 +136,23 @@ func (es *executionState) createNode(ctx context.Context, pr *plan.Procedure, no
 		return nil, fmt.Errorf("unsupported procedure %v", pr.Spec.Kind())
 	}
 
+	// Setup triggering
+	var ts query.TriggerSpec = DefaultTriggerSpec
+	if t, ok := pr.Spec.(triggeringSpec); ok {
+		ts = t.TriggerSpec()
+	}
+
+	// Tables of partition independent procedures are processed concurrently by several instances of the transformation.
+	if _, ok := pr.Spec.(plan.PartitionIndependentProcedureSpec); ok && len(pr.Parents) == 1 && es.resources.ConcurrencyQuota > 1 {
+		return es.createParallelNode(ctx, pr, createT, ts, ec, nodes)
+	}
+
 	// Create the transformation
 	t, ds, err := createT(DatasetID(pr.ID), AccumulatingMode, pr.Spec, ec)
 	if err != nil {
 		return nil, err
 	}
 	nodes[pr.ID] = ds
-
-	// Setup triggering
-	var ts query.TriggerSpec = DefaultTriggerSpec
-	if t, ok := pr.Spec.(triggeringSpec); ok {
-		ts = t.TriggerSpec()
-	}
 	ds.SetTriggerSpec(ts)
 
 	// Recurse creating parents
@@ -163,6 +169,34 @@ func (es *executionState) createNode(ctx context.Context, pr *plan.Procedure, no
 	return ds, nil
 }
 
+// createParallelNode creates an instance of the transformation for each unit of the concurrency quota.
+// The tables of the parent are distributed among the instances and their output is merged into a single node.
+func (es *executionState) createParallelNode(ctx context.Context, pr *plan.Procedure, createT CreateTransformation, ts query.TriggerSpec, ec executionContext, nodes map[plan.ProcedureID]Node) (Node, error) {
+	n := es.resources.ConcurrencyQuota
+	merge := newMergeNode(DatasetID(pr.ID), n)
+	nodes[pr.ID] = merge
+
+	instances := make([]Transformation, n)
+	for i := range instances {
+		t, ds, err := createT(DatasetID(pr.ID), AccumulatingMode, pr.Spec, ec)
+		if err != nil {
+			return nil, err
+		}
+		ds.SetTriggerSpec(ts)
+		ds.AddTransformation(merge.input(i))
+		transport := newConescutiveTransport(es.dispatcher, t)
+		es.transports = append(es.transports, transport)
+		instances[i] = transport
+	}
+
+	parent, err := es.createNode(ctx, es.p.Procedures[pr.Parents[0]], nodes)
+	if err != nil {
+		return nil, err
+	}
+	parent.AddTransformation(newPartitionRouter(instances))
+	return merge, nil
+}
+
 func (es *executionState) abort(err error) {
 	for _, r := range es.results {
 		r.(*result).abort(err)
@@ -224,6 +258,12 @@ func (ec executionContext) OrganizationID() id.ID {
 func (ec executionContext) ResolveTime(qt query.Time) Time {
 	return Time(qt.Time(ec.es.p.Now).UnixNano())
 }
+func (ec executionContext) Location() *time.Location {
+	if ec.es.p.Location == nil {
+		return time.UTC
+	}
+	return ec.es.p.Location
+}
 func (ec executionContext) Bounds() Bounds {
 	return ec.es.bounds
 }
@@ -2,6 +2,7 @@ package execute_test
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"testing"
 	"time"
@@ -386,6 +387,101 @@ func TestExecutor_Execute(t *testing.T) {
 	}
 }
 
+func TestExecutor_Execute_Parallel(t *testing.T) {
+	const n = 50
+	data := make([]query.Block, n)
+	want := make([]*executetest.Block, n)
+	for i := range data {
+		// Produce the tables in reverse order of their partition keys.
+		tag := fmt.Sprintf("t%02d", n-i)
+		data[i] = &executetest.Block{
+			KeyCols: []string{"_start", "_stop", "t0"},
+			ColMeta: []query.ColMeta{
+				{Label: "_start", Type: query.TTime},
+				{Label: "_stop", Type: query.TTime},
+				{Label: "_time", Type: query.TTime},
+				{Label: "_value", Type: query.TFloat},
+				{Label: "t0", Type: query.TString},
+			},
+			Data: [][]interface{}{
+				{execute.Time(0), execute.Time(5), execute.Time(0), float64(i), tag},
+				{execute.Time(0), execute.Time(5), execute.Time(1), 1.0, tag},
+			},
+		}
+		want[n-i-1] = &executetest.Block{
+			KeyCols: []string{"_start", "_stop", "t0"},
+			ColMeta: []query.ColMeta{
+				{Label: "_start", Type: query.TTime},
+				{Label: "_stop", Type: query.TTime},
+				{Label: "t0", Type: query.TString},
+				{Label: "_time", Type: query.TTime},
+				{Label: "_value", Type: query.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(0), execute.Time(5), tag, execute.Time(5), float64(i) + 1},
+			},
+		}
+	}
+	for _, b := range want {
+		b.Normalize()
+	}
+
+	for _, concurrency := range []int{1, 2, 8} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			p := &plan.PlanSpec{
+				Now: epoch.Add(5),
+				Resources: query.ResourceManagement{
+					ConcurrencyQuota: concurrency,
+					MemoryBytesQuota: math.MaxInt64,
+				},
+				Bounds: plan.BoundsSpec{
+					Start: query.Time{Absolute: time.Unix(0, 1)},
+					Stop:  query.Time{Absolute: time.Unix(0, 5)},
+				},
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					plan.ProcedureIDFromOperationID("from"): {
+						ID:       plan.ProcedureIDFromOperationID("from"),
+						Spec:     &testFromProcedureSource{data: data},
+						Children: []plan.ProcedureID{plan.ProcedureIDFromOperationID("sum")},
+					},
+					plan.ProcedureIDFromOperationID("sum"): {
+						ID: plan.ProcedureIDFromOperationID("sum"),
+						Spec: &functions.SumProcedureSpec{
+							AggregateConfig: execute.DefaultAggregateConfig,
+						},
+						Parents: []plan.ProcedureID{plan.ProcedureIDFromOperationID("from")},
+					},
+				},
+				Results: map[string]plan.YieldSpec{
+					plan.DefaultYieldName: {ID: plan.ProcedureIDFromOperationID("sum")},
+				},
+			}
+			exe := execute.NewExecutor(nil)
+			results, err := exe.Execute(context.Background(), orgID, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []*executetest.Block
+			if err := results[plan.DefaultYieldName].Blocks().Do(func(b query.Block) error {
+				cb, err := executetest.ConvertBlock(b)
+				if err != nil {
+					return err
+				}
+				cb.Normalize()
+				got = append(got, cb)
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			// The blocks must be produced in the same order regardless of the concurrency.
+			if !cmp.Equal(want, got) {
+				t.Error("unexpected results -want/+got", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 type testFromProcedureSource struct {
 	data []query.Block
 	ts   []execute.Transformation
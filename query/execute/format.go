@@ -1,6 +1,7 @@
 package execute
 
 import (
+	"encoding/base64"
 	"io"
 	"sort"
 	"strconv"
@@ -66,13 +67,15 @@ func (w *writeToHelper) write(data []byte) {
 }
 
 var minWidthsByType = map[query.DataType]int{
-	query.TBool:    12,
-	query.TInt:     26,
-	query.TUInt:    27,
-	query.TFloat:   28,
-	query.TString:  22,
-	query.TTime:    len(fixedWidthTimeFmt),
-	query.TInvalid: 10,
+	query.TBool:     12,
+	query.TInt:      26,
+	query.TUInt:     27,
+	query.TFloat:    28,
+	query.TString:   22,
+	query.TTime:     len(fixedWidthTimeFmt),
+	query.TDuration: 22,
+	query.TBytes:    22,
+	query.TInvalid:  10,
 }
 
 // WriteTo writes the formatted block data to w.
@@ -227,6 +230,10 @@ func (f *Formatter) valueBuf(i, j int, typ query.DataType, cr query.ColReader) (
 		buf = []byte(cr.Strings(j)[i])
 	case query.TTime:
 		buf = []byte(cr.Times(j)[i].String())
+	case query.TDuration:
+		buf = []byte(cr.Durations(j)[i].String())
+	case query.TBytes:
+		buf = []byte(base64.StdEncoding.EncodeToString(cr.Bytes(j)[i]))
 	}
 	return
 }
@@ -0,0 +1,159 @@
+package execute
+
+import (
+	"github.com/influxdata/platform/query"
+)
+
+// IsNull reports whether the value of row i and column j is missing.
+func IsNull(cr query.ColReader, j, i int) bool {
+	nulls := cr.Nulls(j)
+	return nulls != nil && nulls[i]
+}
+
+// SkipNulls returns a ColReader of the rows of cr where the value of column j is present.
+// If no value of the column is missing cr is returned.
+func SkipNulls(cr query.ColReader, j int) query.ColReader {
+	nulls := cr.Nulls(j)
+	if nulls == nil {
+		return cr
+	}
+	rows := make([]int, 0, len(nulls))
+	for i, null := range nulls {
+		if !null {
+			rows = append(rows, i)
+		}
+	}
+	return &rowsColReader{
+		ColReader: cr,
+		rows:      rows,
+		cols:      make(map[int]interface{}),
+	}
+}
+
+// rowsColReader reads the given rows of a ColReader.
+// The values of the rows are copied the first time a column is read.
+type rowsColReader struct {
+	query.ColReader
+	rows []int
+	cols map[int]interface{}
+}
+
+func (cr *rowsColReader) Len() int {
+	return len(cr.rows)
+}
+
+func (cr *rowsColReader) Bools(j int) []bool {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([]bool)
+	}
+	src := cr.ColReader.Bools(j)
+	vs := make([]bool, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) Ints(j int) []int64 {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([]int64)
+	}
+	src := cr.ColReader.Ints(j)
+	vs := make([]int64, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) UInts(j int) []uint64 {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([]uint64)
+	}
+	src := cr.ColReader.UInts(j)
+	vs := make([]uint64, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) Floats(j int) []float64 {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([]float64)
+	}
+	src := cr.ColReader.Floats(j)
+	vs := make([]float64, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) Strings(j int) []string {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([]string)
+	}
+	src := cr.ColReader.Strings(j)
+	vs := make([]string, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) Times(j int) []Time {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([]Time)
+	}
+	src := cr.ColReader.Times(j)
+	vs := make([]Time, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) Durations(j int) []Duration {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([]Duration)
+	}
+	src := cr.ColReader.Durations(j)
+	vs := make([]Duration, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) Bytes(j int) [][]byte {
+	if vs, ok := cr.cols[j]; ok {
+		return vs.([][]byte)
+	}
+	src := cr.ColReader.Bytes(j)
+	vs := make([][]byte, len(cr.rows))
+	for k, i := range cr.rows {
+		vs[k] = src[i]
+	}
+	cr.cols[j] = vs
+	return vs
+}
+
+func (cr *rowsColReader) Nulls(j int) []bool {
+	src := cr.ColReader.Nulls(j)
+	if src == nil {
+		return nil
+	}
+	nulls := make([]bool, len(cr.rows))
+	for k, i := range cr.rows {
+		nulls[k] = src[i]
+	}
+	return nulls
+}
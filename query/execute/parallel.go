@@ -0,0 +1,224 @@
+package execute
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/platform/query"
+)
+
+// partitionRouter implements Transformation by routing each table to one of several instances of a transformation.
+// Tables are routed by their partition key ignoring the time bounds columns,
+// so that tables which may produce the same partition key are always processed by the same instance.
+// All other messages are sent to every instance.
+type partitionRouter struct {
+	instances []Transformation
+}
+
+func newPartitionRouter(instances []Transformation) *partitionRouter {
+	return &partitionRouter{
+		instances: instances,
+	}
+}
+
+func (r *partitionRouter) route(key query.PartitionKey) Transformation {
+	h := fnv.New32a()
+	for j, c := range key.Cols() {
+		if c.Label == DefaultStartColLabel || c.Label == DefaultStopColLabel {
+			continue
+		}
+		fmt.Fprintf(h, "%s=%v,", c.Label, key.Value(j))
+	}
+	return r.instances[h.Sum32()%uint32(len(r.instances))]
+}
+
+func (r *partitionRouter) RetractBlock(id DatasetID, key query.PartitionKey) error {
+	return r.route(key).RetractBlock(id, key)
+}
+
+func (r *partitionRouter) Process(id DatasetID, b query.Block) error {
+	return r.route(b.Key()).Process(id, b)
+}
+
+func (r *partitionRouter) UpdateWatermark(id DatasetID, t Time) error {
+	for _, i := range r.instances {
+		if err := i.UpdateWatermark(id, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *partitionRouter) UpdateProcessingTime(id DatasetID, t Time) error {
+	for _, i := range r.instances {
+		if err := i.UpdateProcessingTime(id, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *partitionRouter) Finish(id DatasetID, err error) {
+	for _, i := range r.instances {
+		i.Finish(id, err)
+	}
+}
+
+// mergeNode merges the output of several instances of a transformation into a single dataset.
+//
+// In order to keep the output deterministic, blocks are buffered until every instance has advanced
+// its watermark or processing time, or has finished, and are then passed on sorted by partition key.
+// This is the same order in which a single instance would trigger the blocks.
+type mergeNode struct {
+	id DatasetID
+	ts []Transformation
+
+	mu              sync.Mutex
+	pending         []query.Block
+	watermarks      []Time
+	processingTimes []Time
+	watermark       Time
+	processingTime  Time
+	finished        int
+	err             error
+}
+
+func newMergeNode(id DatasetID, n int) *mergeNode {
+	return &mergeNode{
+		id:              id,
+		watermarks:      make([]Time, n),
+		processingTimes: make([]Time, n),
+	}
+}
+
+func (m *mergeNode) AddTransformation(t Transformation) {
+	m.ts = append(m.ts, t)
+}
+
+// input returns the transformation that receives the output of the ith instance.
+func (m *mergeNode) input(i int) Transformation {
+	return &mergeInput{m: m, i: i}
+}
+
+// flush passes on all pending blocks, the lock must be held.
+func (m *mergeNode) flush() error {
+	sort.SliceStable(m.pending, func(i, j int) bool {
+		return m.pending[i].Key().Less(m.pending[j].Key())
+	})
+	for i, b := range m.pending {
+		// The block was counted once when it was passed to the merge node.
+		b.RefCount(len(m.ts) - 1)
+		for _, t := range m.ts {
+			if err := t.Process(m.id, b); err != nil {
+				m.pending = m.pending[i+1:]
+				return err
+			}
+		}
+	}
+	m.pending = m.pending[:0]
+	return nil
+}
+
+func minTime(times []Time) Time {
+	min := times[0]
+	for _, t := range times[1:] {
+		if t < min {
+			min = t
+		}
+	}
+	return min
+}
+
+type mergeInput struct {
+	m *mergeNode
+	i int
+}
+
+func (in *mergeInput) RetractBlock(id DatasetID, key query.PartitionKey) error {
+	m := in.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.flush(); err != nil {
+		return err
+	}
+	for _, t := range m.ts {
+		if err := t.RetractBlock(m.id, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *mergeInput) Process(id DatasetID, b query.Block) error {
+	m := in.m
+	m.mu.Lock()
+	m.pending = append(m.pending, b)
+	m.mu.Unlock()
+	return nil
+}
+
+func (in *mergeInput) UpdateWatermark(id DatasetID, t Time) error {
+	m := in.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watermarks[in.i] = t
+	mark := minTime(m.watermarks)
+	if mark <= m.watermark {
+		return nil
+	}
+	m.watermark = mark
+	if err := m.flush(); err != nil {
+		return err
+	}
+	for _, t := range m.ts {
+		if err := t.UpdateWatermark(m.id, mark); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *mergeInput) UpdateProcessingTime(id DatasetID, t Time) error {
+	m := in.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.processingTimes[in.i] = t
+	pt := minTime(m.processingTimes)
+	if pt <= m.processingTime {
+		return nil
+	}
+	m.processingTime = pt
+	if err := m.flush(); err != nil {
+		return err
+	}
+	for _, t := range m.ts {
+		if err := t.UpdateProcessingTime(m.id, pt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *mergeInput) Finish(id DatasetID, err error) {
+	m := in.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil && m.err == nil {
+		m.err = err
+	}
+	// A finished instance will not produce any more blocks.
+	m.watermarks[in.i] = MaxTime
+	m.processingTimes[in.i] = MaxTime
+	m.finished++
+	if m.finished < len(m.watermarks) {
+		return
+	}
+	if m.err == nil {
+		m.err = m.flush()
+	}
+	for _, t := range m.ts {
+		t.Finish(m.id, m.err)
+	}
+}
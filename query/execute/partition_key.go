@@ -1,6 +1,7 @@
 package execute
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -47,6 +48,9 @@ func (k *partitionKey) ValueString(j int) string {
 func (k *partitionKey) ValueDuration(j int) Duration {
 	return k.values[j].Duration()
 }
+func (k *partitionKey) ValueBytes(j int) []byte {
+	return k.values[j].Bytes()
+}
 func (k *partitionKey) ValueTime(j int) Time {
 	return k.values[j].Time()
 }
@@ -107,6 +111,14 @@ func partitionKeyEqual(a, b query.PartitionKey) bool {
 			if a.ValueTime(j) != b.ValueTime(j) {
 				return false
 			}
+		case query.TDuration:
+			if a.ValueDuration(j) != b.ValueDuration(j) {
+				return false
+			}
+		case query.TBytes:
+			if !bytes.Equal(a.ValueBytes(j), b.ValueBytes(j)) {
+				return false
+			}
 		}
 	}
 	return true
@@ -150,6 +162,14 @@ func partitionKeyLess(a, b query.PartitionKey) bool {
 			if av, bv := a.ValueTime(j), b.ValueTime(j); av != bv {
 				return av < bv
 			}
+		case query.TDuration:
+			if av, bv := a.ValueDuration(j), b.ValueDuration(j); av != bv {
+				return av < bv
+			}
+		case query.TBytes:
+			if c := bytes.Compare(a.ValueBytes(j), b.ValueBytes(j)); c != 0 {
+				return c < 0
+			}
 		}
 	}
 	return false
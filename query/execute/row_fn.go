@@ -85,6 +85,10 @@ func ConvertToKind(t query.DataType) semantic.Kind {
 		return semantic.String
 	case query.TTime:
 		return semantic.Time
+	case query.TDuration:
+		return semantic.Duration
+	case query.TBytes:
+		return semantic.Bytes
 	default:
 		return semantic.Invalid
 	}
@@ -107,6 +111,10 @@ func ConvertFromKind(k semantic.Kind) query.DataType {
 		return query.TString
 	case semantic.Time:
 		return query.TTime
+	case semantic.Duration:
+		return query.TDuration
+	case semantic.Bytes:
+		return query.TBytes
 	default:
 		return query.TInvalid
 	}
@@ -150,6 +158,10 @@ func (f *RowPredicateFn) Eval(row int, cr query.ColReader) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	// A predicate that evaluates to null does not hold.
+	if values.IsNull(v) {
+		return false, nil
+	}
 	return v.Bool(), nil
 }
 
@@ -201,11 +213,17 @@ func (f *RowMapFn) Eval(row int, cr query.ColReader) (values.Object, error) {
 		f.wrapObj.Set(DefaultValueColLabel, v)
 		return f.wrapObj, nil
 	}
+	if values.IsNull(v) {
+		return nil, errors.New("map function evaluated to null")
+	}
 	return v.Object(), nil
 }
 
 func ValueForRow(i, j int, cr query.ColReader) values.Value {
 	t := cr.Cols()[j].Type
+	if IsNull(cr, j, i) {
+		return values.NewNull(ConvertToKind(t))
+	}
 	switch t {
 	case query.TString:
 		return values.NewStringValue(cr.Strings(j)[i])
@@ -219,6 +237,10 @@ func ValueForRow(i, j int, cr query.ColReader) values.Value {
 		return values.NewBoolValue(cr.Bools(j)[i])
 	case query.TTime:
 		return values.NewTimeValue(cr.Times(j)[i])
+	case query.TDuration:
+		return values.NewDurationValue(cr.Durations(j)[i])
+	case query.TBytes:
+		return values.NewBytesValue(cr.Bytes(j)[i])
 	default:
 		PanicUnknownType(t)
 		return nil
@@ -226,6 +248,10 @@ func ValueForRow(i, j int, cr query.ColReader) values.Value {
 }
 
 func AppendValue(builder BlockBuilder, j int, v values.Value) {
+	if values.IsNull(v) {
+		builder.AppendNil(j)
+		return
+	}
 	switch k := v.Type().Kind(); k {
 	case semantic.Bool:
 		builder.AppendBool(j, v.Bool())
@@ -239,6 +265,10 @@ func AppendValue(builder BlockBuilder, j int, v values.Value) {
 		builder.AppendString(j, v.Str())
 	case semantic.Time:
 		builder.AppendTime(j, v.Time())
+	case semantic.Duration:
+		builder.AppendDuration(j, v.Duration())
+	case semantic.Bytes:
+		builder.AppendBytes(j, v.Bytes())
 	default:
 		PanicUnknownType(ConvertFromKind(k))
 	}
@@ -304,6 +334,9 @@ func (r *Record) Time() values.Time {
 func (r *Record) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Duration))
 }
+func (r *Record) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Object, semantic.Bytes))
+}
 func (r *Record) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Regexp))
 }
@@ -17,6 +17,9 @@ type SelectorConfig struct {
 	Column string `json:"column"`
 }
 
+// PartitionIndependent reports that each table is selected from independently of all other tables.
+func (c SelectorConfig) PartitionIndependent() {}
+
 func (c *SelectorConfig) ReadArgs(args query.Arguments) error {
 	if col, ok, err := args.GetString("column"); err != nil {
 		return err
@@ -123,6 +126,8 @@ func (t *indexSelectorTransformation) Process(id DatasetID, b query.Block) error
 	}
 
 	return b.Do(func(cr query.ColReader) error {
+		// Rows with a missing value are never selected.
+		cr = SkipNulls(cr, valueIdx)
 		switch valueCol.Type {
 		case query.TBool:
 			selected := s.(DoBoolIndexSelector).DoBool(cr.Bools(valueIdx))
@@ -170,6 +175,8 @@ func (t *rowSelectorTransformation) Process(id DatasetID, b query.Block) error {
 	}
 
 	b.Do(func(cr query.ColReader) error {
+		// Rows with a missing value are never selected.
+		cr = SkipNulls(cr, valueIdx)
 		switch valueCol.Type {
 		case query.TBool:
 			rower.(DoBoolRowSelector).DoBool(cr.Bools(valueIdx), cr)
@@ -195,25 +202,9 @@ func (t *indexSelectorTransformation) appendSelected(selected []int, builder Blo
 	if len(selected) == 0 {
 		return
 	}
-	cols := builder.Cols()
-	for j, c := range cols {
+	for j := range builder.Cols() {
 		for _, i := range selected {
-			switch c.Type {
-			case query.TBool:
-				builder.AppendBool(j, cr.Bools(j)[i])
-			case query.TInt:
-				builder.AppendInt(j, cr.Ints(j)[i])
-			case query.TUInt:
-				builder.AppendUInt(j, cr.UInts(j)[i])
-			case query.TFloat:
-				builder.AppendFloat(j, cr.Floats(j)[i])
-			case query.TString:
-				builder.AppendString(j, cr.Strings(j)[i])
-			case query.TTime:
-				builder.AppendTime(j, cr.Times(j)[i])
-			default:
-				PanicUnknownType(c.Type)
-			}
+			AppendValueForRow(j, i, j, cr, builder)
 		}
 	}
 }
@@ -223,6 +214,10 @@ func (t *rowSelectorTransformation) appendRows(builder BlockBuilder, rows []Row)
 	for j, c := range cols {
 		for _, row := range rows {
 			v := row.Values[j]
+			if v == nil {
+				builder.AppendNil(j)
+				continue
+			}
 			switch c.Type {
 			case query.TBool:
 				builder.AppendBool(j, v.(bool))
@@ -236,6 +231,10 @@ func (t *rowSelectorTransformation) appendRows(builder BlockBuilder, rows []Row)
 				builder.AppendString(j, v.(string))
 			case query.TTime:
 				builder.AppendTime(j, v.(Time))
+			case query.TDuration:
+				builder.AppendDuration(j, v.(Duration))
+			case query.TBytes:
+				builder.AppendBytes(j, v.([]byte))
 			default:
 				PanicUnknownType(c.Type)
 			}
@@ -299,6 +298,7 @@ type DoStringRowSelector interface {
 	DoString(vs []string, cr query.ColReader)
 }
 
+// Row is a record of a block, missing values are nil.
 type Row struct {
 	Values []interface{}
 }
@@ -307,6 +307,9 @@ func ReadRow(i int, cr query.ColReader) (row Row) {
 	cols := cr.Cols()
 	row.Values = make([]interface{}, len(cols))
 	for j, c := range cols {
+		if IsNull(cr, j, i) {
+			continue
+		}
 		switch c.Type {
 		case query.TBool:
 			row.Values[j] = cr.Bools(j)[i]
@@ -320,6 +323,10 @@ func ReadRow(i int, cr query.ColReader) (row Row) {
 			row.Values[j] = cr.Strings(j)[i]
 		case query.TTime:
 			row.Values[j] = cr.Times(j)[i]
+		case query.TDuration:
+			row.Values[j] = cr.Durations(j)[i]
+		case query.TBytes:
+			row.Values[j] = cr.Bytes(j)[i]
 		}
 	}
 	return
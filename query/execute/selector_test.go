@@ -59,6 +59,39 @@ func TestRowSelector_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "single with nulls",
+			config: execute.SelectorConfig{
+				Column: "_value",
+			},
+			data: []*executetest.Block{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []query.ColMeta{
+					{Label: "_start", Type: query.TTime},
+					{Label: "_stop", Type: query.TTime},
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(1), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(10), 3.0},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Block{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []query.ColMeta{
+					{Label: "_start", Type: query.TTime},
+					{Label: "_stop", Type: query.TTime},
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0},
+				},
+			}},
+		},
 		{
 			name: "single custom column",
 			config: execute.SelectorConfig{
@@ -2,6 +2,7 @@ package execute
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/query/id"
@@ -20,6 +21,8 @@ type Administration interface {
 	OrganizationID() id.ID
 
 	ResolveTime(qt query.Time) Time
+	// Location returns the time zone of the query.
+	Location() *time.Location
 	Bounds() Bounds
 	Allocator() *Allocator
 	Parents() []DatasetID
@@ -13,6 +13,9 @@ import (
 
 const CovarianceKind = "covariance"
 
+// StatsPackagePath is the import path of the stats package.
+const StatsPackagePath = "stats"
+
 type CovarianceOpSpec struct {
 	PearsonCorrelation bool   `json:"pearsonr"`
 	ValueDst           string `json:"value_dst"`
@@ -25,7 +28,8 @@ func init() {
 	covarianceSignature.Params["pearsonr"] = semantic.Bool
 	covarianceSignature.Params["columns"] = semantic.Array
 
-	query.RegisterBuiltIn("covariance", covarianceBuiltIn)
+	query.RegisterPackageBuiltIn(StatsPackagePath, covarianceBuiltIn)
+	query.RegisterDeprecatedGlobalBuiltIn("covariance", covarianceBuiltIn)
 	query.RegisterFunction(CovarianceKind, createCovarianceOpSpec, covarianceSignature)
 	query.RegisterOpSpec(CovarianceKind, newCovarianceOp)
 	plan.RegisterProcedureSpec(CovarianceKind, newCovarianceProcedure, CovarianceKind)
@@ -34,6 +38,8 @@ func init() {
 
 // covarianceBuiltIn defines a `cov` function with an automatic join.
 var covarianceBuiltIn = `
+package stats
+
 cov = (x,y,on,pearsonr=false) =>
     join(
         tables:{x:x, y:y},
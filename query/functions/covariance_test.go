@@ -71,8 +71,9 @@ func TestCovariance_NewQuery(t *testing.T) {
 			},
 		},
 		{
-			Name: "global covariance",
-			Raw:  `cov(x: from(db:"mydb"), y:from(db:"mydb"), on:["host"], pearsonr:true)`,
+			Name: "stats covariance",
+			Raw: `import "stats"
+			stats.cov(x: from(db:"mydb"), y:from(db:"mydb"), on:["host"], pearsonr:true)`,
 			Want: &query.Spec{
 				Operations: []*query.Operation{
 					{
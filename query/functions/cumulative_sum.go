@@ -161,6 +161,10 @@ func (t *cumulativeSumTransformation) Process(id execute.DatasetID, b query.Bloc
 				builder.AppendStrings(j, cr.Strings(j))
 			case query.TTime:
 				builder.AppendTimes(j, cr.Times(j))
+			case query.TDuration:
+				builder.AppendDurations(j, cr.Durations(j))
+			case query.TBytes:
+				builder.AppendByteSlices(j, cr.Bytes(j))
 			}
 		}
 		return nil
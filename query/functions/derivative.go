@@ -239,6 +239,20 @@ func (t *derivativeTransformation) Process(id execute.DatasetID, b query.Block)
 				builder.AppendStrings(j, cr.Strings(j)[firstIdx:])
 			case query.TTime:
 				builder.AppendTimes(j, cr.Times(j)[firstIdx:])
+			case query.TDuration:
+				if d != nil {
+					for i := 0; i < l; i++ {
+						time := cr.Times(timeIdx)[i]
+						v := d.updateInt(time, int64(cr.Durations(j)[i]))
+						if i != 0 || firstIdx == 0 {
+							builder.AppendFloat(j, v)
+						}
+					}
+				} else {
+					builder.AppendDurations(j, cr.Durations(j)[firstIdx:])
+				}
+			case query.TBytes:
+				builder.AppendByteSlices(j, cr.Bytes(j)[firstIdx:])
 			}
 		}
 		// Now that we skipped the first row, start at 0 for the rest of the batches
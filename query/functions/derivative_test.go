@@ -149,6 +149,33 @@ func TestDerivative_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "duration with units",
+			spec: &functions.DerivativeProcedureSpec{
+				Columns: []string{execute.DefaultValueColLabel},
+				TimeCol: execute.DefaultTimeColLabel,
+				Unit:    query.Duration(time.Second),
+			},
+			data: []query.Block{&executetest.Block{
+				ColMeta: []query.ColMeta{
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TDuration},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1 * time.Second), execute.Duration(20 * time.Second)},
+					{execute.Time(3 * time.Second), execute.Duration(10 * time.Second)},
+				},
+			}},
+			want: []*executetest.Block{{
+				ColMeta: []query.ColMeta{
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3 * time.Second), -5e9},
+				},
+			}},
+		},
 		{
 			name: "int non negative",
 			spec: &functions.DerivativeProcedureSpec{
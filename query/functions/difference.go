@@ -213,6 +213,10 @@ func (t *differenceTransformation) Process(id execute.DatasetID, b query.Block)
 				builder.AppendStrings(j, cr.Strings(j)[firstIdx:])
 			case query.TTime:
 				builder.AppendTimes(j, cr.Times(j)[firstIdx:])
+			case query.TDuration:
+				builder.AppendDurations(j, cr.Durations(j)[firstIdx:])
+			case query.TBytes:
+				builder.AppendByteSlices(j, cr.Bytes(j)[firstIdx:])
 			}
 		}
 		// Now that we skipped the first row, start at 0 for the rest of the batches
@@ -189,6 +189,10 @@ func (t *distinctTransformation) Process(id execute.DatasetID, b query.Block) er
 			builder.AppendString(colIdx, b.Key().ValueString(j))
 		case query.TTime:
 			builder.AppendTime(colIdx, b.Key().ValueTime(j))
+		case query.TDuration:
+			builder.AppendDuration(colIdx, b.Key().ValueDuration(j))
+		case query.TBytes:
+			builder.AppendBytes(colIdx, b.Key().ValueBytes(j))
 		}
 
 		execute.AppendKeyValues(b.Key(), builder)
@@ -199,12 +203,14 @@ func (t *distinctTransformation) Process(id execute.DatasetID, b query.Block) er
 	}
 
 	var (
-		boolDistinct   map[bool]bool
-		intDistinct    map[int64]bool
-		uintDistinct   map[uint64]bool
-		floatDistinct  map[float64]bool
-		stringDistinct map[string]bool
-		timeDistinct   map[execute.Time]bool
+		boolDistinct     map[bool]bool
+		intDistinct      map[int64]bool
+		uintDistinct     map[uint64]bool
+		floatDistinct    map[float64]bool
+		stringDistinct   map[string]bool
+		timeDistinct     map[execute.Time]bool
+		durationDistinct map[execute.Duration]bool
+		bytesDistinct    map[string]bool
 	)
 	switch col.Type {
 	case query.TBool:
@@ -219,6 +225,10 @@ func (t *distinctTransformation) Process(id execute.DatasetID, b query.Block) er
 		stringDistinct = make(map[string]bool)
 	case query.TTime:
 		timeDistinct = make(map[execute.Time]bool)
+	case query.TDuration:
+		durationDistinct = make(map[execute.Duration]bool)
+	case query.TBytes:
+		bytesDistinct = make(map[string]bool)
 	}
 
 	return b.Do(func(cr query.ColReader) error {
@@ -268,6 +278,20 @@ func (t *distinctTransformation) Process(id execute.DatasetID, b query.Block) er
 				}
 				timeDistinct[v] = true
 				builder.AppendTime(colIdx, v)
+			case query.TDuration:
+				v := cr.Durations(colIdx)[i]
+				if durationDistinct[v] {
+					continue
+				}
+				durationDistinct[v] = true
+				builder.AppendDuration(colIdx, v)
+			case query.TBytes:
+				v := cr.Bytes(colIdx)[i]
+				if bytesDistinct[string(v)] {
+					continue
+				}
+				bytesDistinct[string(v)] = true
+				builder.AppendBytes(colIdx, v)
 			}
 
 			execute.AppendKeyValues(b.Key(), builder)
@@ -80,6 +80,10 @@ func (s *FilterProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// PartitionIndependent implements plan.PartitionIndependentProcedureSpec.
+// Filter processes each table independently of all other tables.
+func (s *FilterProcedureSpec) PartitionIndependent() {}
+
 func (s *FilterProcedureSpec) PushDownRules() []plan.PushDownRule {
 	return []plan.PushDownRule{
 		{
@@ -127,6 +127,39 @@ func (s *FromProcedureSpec) Kind() plan.ProcedureKind {
 func (s *FromProcedureSpec) TimeBounds() plan.BoundsSpec {
 	return s.Bounds
 }
+
+// ShardKey reports the database or bucket name the procedure reads from.
+func (s *FromProcedureSpec) ShardKey() string {
+	if s.Database != "" {
+		return s.Database
+	}
+	return s.Bucket
+}
+
+// AssignShards restricts the read to the storage hosts that own the shards.
+// Each host is read concurrently, so spreading shards across hosts parallelizes the read.
+// Explicitly requested hosts are honored by only keeping those that own a shard.
+func (s *FromProcedureSpec) AssignShards(shards []plan.Shard) []string {
+	nodes := plan.ShardNodes(shards)
+	if len(s.Hosts) == 0 {
+		s.Hosts = nodes
+		return s.Hosts
+	}
+	var hosts []string
+	for _, h := range s.Hosts {
+		for _, n := range nodes {
+			if h == n {
+				hosts = append(hosts, h)
+				break
+			}
+		}
+	}
+	if len(hosts) > 0 {
+		s.Hosts = hosts
+	}
+	return s.Hosts
+}
+
 func (s *FromProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(FromProcedureSpec)
 
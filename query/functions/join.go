@@ -1,6 +1,7 @@
 package functions
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"sort"
@@ -606,6 +607,14 @@ func equalRowKeys(x, y int, table *execute.ColListBlock, on map[string]bool) boo
 			if xv, yv := table.Times(j)[x], table.Times(j)[y]; xv != yv {
 				return false
 			}
+		case query.TDuration:
+			if xv, yv := table.Durations(j)[x], table.Durations(j)[y]; xv != yv {
+				return false
+			}
+		case query.TBytes:
+			if xv, yv := table.Bytes(j)[x], table.Bytes(j)[y]; !bytes.Equal(xv, yv) {
+				return false
+			}
 		default:
 			execute.PanicUnknownType(c.Type)
 		}
@@ -227,6 +227,22 @@ func (cr sliceColReader) Times(j int) []execute.Time {
 	return cr.ColReader.Times(j)[cr.start:cr.stop]
 }
 
+func (cr sliceColReader) Durations(j int) []execute.Duration {
+	return cr.ColReader.Durations(j)[cr.start:cr.stop]
+}
+
+func (cr sliceColReader) Bytes(j int) [][]byte {
+	return cr.ColReader.Bytes(j)[cr.start:cr.stop]
+}
+
+func (cr sliceColReader) Nulls(j int) []bool {
+	nulls := cr.ColReader.Nulls(j)
+	if nulls == nil {
+		return nil
+	}
+	return nulls[cr.start:cr.stop]
+}
+
 func (t *limitTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
 	return t.d.UpdateWatermark(mark)
 }
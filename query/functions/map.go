@@ -221,6 +221,10 @@ func partitionKeyForObject(i int, cr query.ColReader, obj values.Object, on map[
 				vs = append(vs, values.NewStringValue(cr.Strings(j)[i]))
 			case query.TTime:
 				vs = append(vs, values.NewTimeValue(cr.Times(j)[i]))
+			case query.TDuration:
+				vs = append(vs, values.NewDurationValue(cr.Durations(j)[i]))
+			case query.TBytes:
+				vs = append(vs, values.NewBytesValue(cr.Bytes(j)[i]))
 			}
 		}
 	}
@@ -683,6 +683,65 @@ func TestMap_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "duration(r._value) int",
+			spec: &functions.MapProcedureSpec{
+				MergeKey: false,
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+					Body: &semantic.ObjectExpression{
+						Properties: []*semantic.Property{
+							{
+								Key: &semantic.Identifier{Name: "_time"},
+								Value: &semantic.MemberExpression{
+									Object: &semantic.IdentifierExpression{
+										Name: "r",
+									},
+									Property: "_time",
+								},
+							},
+							{
+								Key: &semantic.Identifier{Name: "_value"},
+								Value: &semantic.CallExpression{
+									Callee: &semantic.IdentifierExpression{Name: "duration"},
+									Arguments: &semantic.ObjectExpression{
+										Properties: []*semantic.Property{{
+											Key: &semantic.Identifier{Name: "v"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_value",
+											},
+										}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			data: []query.Block{&executetest.Block{
+				ColMeta: []query.ColMeta{
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(1)},
+					{execute.Time(2), int64(6e9)},
+				},
+			}},
+			want: []*executetest.Block{{
+				ColMeta: []query.ColMeta{
+					{Label: "_time", Type: query.TTime},
+					{Label: "_value", Type: query.TDuration},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), execute.Duration(1)},
+					{execute.Time(2), execute.Duration(6e9)},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
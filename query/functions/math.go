@@ -0,0 +1,33 @@
+package functions
+
+import (
+	"math"
+
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/values"
+)
+
+// MathPackagePath is the import path of the math package.
+const MathPackagePath = "math"
+
+var mathBuiltIn = `
+package math
+
+pi = 3.141592653589793
+e = 2.718281828459045
+
+// abs returns the absolute value of x.
+abs = (x) => if x < 0.0 then -x else x
+
+_min = (a, b) => if a < b then a else b
+_max = (a, b) => if a > b then a else b
+
+// clamp limits x to the interval [min, max].
+clamp = (x, min, max) => _max(a: min, b: _min(a: x, b: max))
+`
+
+func init() {
+	query.RegisterPackageValue(MathPackagePath, "maxInt", values.NewIntValue(math.MaxInt64))
+	query.RegisterPackageValue(MathPackagePath, "minInt", values.NewIntValue(math.MinInt64))
+	query.RegisterPackageBuiltIn(MathPackagePath, mathBuiltIn)
+}
@@ -0,0 +1,86 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/functions"
+	"github.com/influxdata/platform/query/querytest"
+)
+
+func TestMath_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "import math",
+			Raw: `import "math"
+			from(db:"mydb") |> limit(n: int(v: math.clamp(x: math.abs(x: -12.0), min: 1.0, max: 10.0)))`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "limit1",
+						Spec: &functions.LimitOpSpec{
+							N: 10,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "limit1"},
+				},
+			},
+		},
+		{
+			Name: "import math with alias",
+			Raw: `import m "math"
+			from(db:"mydb") |> limit(n: m.maxInt)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "limit1",
+						Spec: &functions.LimitOpSpec{
+							N: 9223372036854775807,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "limit1"},
+				},
+			},
+		},
+		{
+			Name: "unexported member",
+			Raw: `import "math"
+			from(db:"mydb") |> limit(n: int(v: math._min(a: 1.0, b: 2.0)))`,
+			WantErr: true,
+		},
+		{
+			Name:    "not imported",
+			Raw:     `from(db:"mydb") |> limit(n: math.maxInt)`,
+			WantErr: true,
+		},
+		{
+			Name: "unknown package",
+			Raw: `import "nope"
+			from(db:"mydb") |> limit(n: nope.maxInt)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
@@ -29,7 +29,8 @@ func init() {
 	percentileSignature.Params["p"] = semantic.Float
 
 	query.RegisterFunction(PercentileKind, createPercentileOpSpec, percentileSignature)
-	query.RegisterBuiltIn("percentile", percentileBuiltin)
+	query.RegisterPackageBuiltIn(StatsPackagePath, percentileBuiltin)
+	query.RegisterDeprecatedGlobalBuiltIn("percentile", percentileBuiltin)
 
 	query.RegisterOpSpec(PercentileKind, newPercentileOp)
 	plan.RegisterProcedureSpec(PercentileKind, newPercentileProcedure, PercentileKind)
@@ -38,6 +39,8 @@ func init() {
 }
 
 var percentileBuiltin = `
+package stats
+
 // median returns the 50th percentile.
 // By default an approximate percentile is computed, this can be disabled by passing exact:true.
 // Using the exact method requires that the entire data set can fit in memory.
@@ -23,6 +23,69 @@ func TestPercentileOperation_Marshaling(t *testing.T) {
 	querytest.OperationMarshalingTestHelper(t, data, op)
 }
 
+func TestPercentile_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "stats median",
+			Raw: `import "stats"
+			from(db:"mydb") |> stats.median(exact:true)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "percentile1",
+						Spec: &functions.PercentileOpSpec{
+							Percentile:      0.5,
+							Exact:           true,
+							AggregateConfig: execute.DefaultAggregateConfig,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "percentile1"},
+				},
+			},
+		},
+		{
+			Name: "deprecated global median",
+			Raw:  `from(db:"mydb") |> median(exact:true)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "percentile1",
+						Spec: &functions.PercentileOpSpec{
+							Percentile:      0.5,
+							Exact:           true,
+							AggregateConfig: execute.DefaultAggregateConfig,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "percentile1"},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
 func TestPercentile_Process(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -15,6 +15,9 @@ import (
 
 const StateTrackingKind = "stateTracking"
 
+// StatePackagePath is the import path of the state package.
+const StatePackagePath = "state"
+
 type StateTrackingOpSpec struct {
 	Fn            *semantic.FunctionExpression `json:"fn"`
 	CountLabel    string                       `json:"count_label"`
@@ -32,13 +35,16 @@ func init() {
 	stateTrackingSignature.Params["durationUnit"] = semantic.Duration
 
 	query.RegisterFunction(StateTrackingKind, createStateTrackingOpSpec, stateTrackingSignature)
-	query.RegisterBuiltIn("state-tracking", stateTrackingBuiltin)
+	query.RegisterPackageBuiltIn(StatePackagePath, stateTrackingBuiltin)
+	query.RegisterDeprecatedGlobalBuiltIn("state-tracking", stateTrackingBuiltin)
 	query.RegisterOpSpec(StateTrackingKind, newStateTrackingOp)
 	plan.RegisterProcedureSpec(StateTrackingKind, newStateTrackingProcedure, StateTrackingKind)
 	execute.RegisterTransformation(StateTrackingKind, createStateTrackingTransformation)
 }
 
 var stateTrackingBuiltin = `
+package state
+
 // stateCount computes the number of consecutive records in a given state.
 // The state is defined via the function fn. For each consecutive point for
 // which the expression evaluates as true, the state count will be incremented
@@ -57,15 +63,15 @@ stateCount = (fn, label="stateCount", table=<-) =>
 // incremented by the duration between points. When a point evaluates as false,
 // the state duration is reset.
 //
-// The state duration will be added as an additional column to each record. If the
-// expression evaluates as false, the value will be -1. If the expression
+// The state duration will be added as an additional duration column to each record. If the
+// expression evaluates as false, the value will be -1 unit. If the expression
 // generates an error during evaluation, the point is discarded, and does not
 // affect the state duration.
 //
 // Note that as the first point in the given state has no previous point, its
 // state duration will be 0.
 //
-// The duration is represented as an integer in the units specified.
+// The duration is truncated to a whole number of the units specified.
 stateDuration = (fn, label="stateDuration", unit=1s, table=<-) =>
 	stateTracking(table:table, durationLabel:label, fn:fn, durationUnit:unit)
 `
@@ -236,7 +242,7 @@ func (t *stateTrackingTransformation) Process(id execute.DatasetID, b query.Bloc
 	if t.durationLabel != "" {
 		durationCol = builder.AddCol(query.ColMeta{
 			Label: t.durationLabel,
-			Type:  query.TInt,
+			Type:  query.TDuration,
 		})
 	}
 
@@ -282,7 +288,11 @@ func (t *stateTrackingTransformation) Process(id execute.DatasetID, b query.Bloc
 				builder.AppendInt(countCol, count)
 			}
 			if durationCol > 0 {
-				builder.AppendInt(durationCol, duration)
+				d := execute.Duration(duration)
+				if t.durationUnit > 0 {
+					d *= execute.Duration(t.durationUnit)
+				}
+				builder.AppendDuration(durationCol, d)
 			}
 		}
 		return nil
@@ -72,15 +72,15 @@ func TestStateTracking_Process(t *testing.T) {
 					{Label: "_time", Type: query.TTime},
 					{Label: "_value", Type: query.TFloat},
 					{Label: "count", Type: query.TInt},
-					{Label: "duration", Type: query.TInt},
+					{Label: "duration", Type: query.TDuration},
 				},
 				Data: [][]interface{}{
-					{execute.Time(1), 2.0, int64(-1), int64(-1)},
-					{execute.Time(2), 1.0, int64(-1), int64(-1)},
-					{execute.Time(3), 6.0, int64(1), int64(0)},
-					{execute.Time(4), 7.0, int64(2), int64(1)},
-					{execute.Time(5), 8.0, int64(3), int64(2)},
-					{execute.Time(6), 1.0, int64(-1), int64(-1)},
+					{execute.Time(1), 2.0, int64(-1), execute.Duration(-1)},
+					{execute.Time(2), 1.0, int64(-1), execute.Duration(-1)},
+					{execute.Time(3), 6.0, int64(1), execute.Duration(0)},
+					{execute.Time(4), 7.0, int64(2), execute.Duration(1)},
+					{execute.Time(5), 8.0, int64(3), execute.Duration(2)},
+					{execute.Time(6), 1.0, int64(-1), execute.Duration(-1)},
 				},
 			}},
 		},
@@ -110,15 +110,15 @@ func TestStateTracking_Process(t *testing.T) {
 				ColMeta: []query.ColMeta{
 					{Label: "_time", Type: query.TTime},
 					{Label: "_value", Type: query.TFloat},
-					{Label: "duration", Type: query.TInt},
+					{Label: "duration", Type: query.TDuration},
 				},
 				Data: [][]interface{}{
-					{execute.Time(1), 2.0, int64(-1)},
-					{execute.Time(2), 1.0, int64(-1)},
-					{execute.Time(3), 6.0, int64(0)},
-					{execute.Time(4), 7.0, int64(1)},
-					{execute.Time(5), 8.0, int64(2)},
-					{execute.Time(6), 1.0, int64(-1)},
+					{execute.Time(1), 2.0, execute.Duration(-1)},
+					{execute.Time(2), 1.0, execute.Duration(-1)},
+					{execute.Time(3), 6.0, execute.Duration(0)},
+					{execute.Time(4), 7.0, execute.Duration(1)},
+					{execute.Time(5), 8.0, execute.Duration(2)},
+					{execute.Time(6), 1.0, execute.Duration(-1)},
 				},
 			}},
 		},
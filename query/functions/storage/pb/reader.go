@@ -510,6 +510,19 @@ func (b *block) Times(j int) []execute.Time {
 	execute.CheckColType(b.cols[j], query.TTime)
 	return b.colBufs[j].([]execute.Time)
 }
+func (b *block) Durations(j int) []execute.Duration {
+	execute.CheckColType(b.cols[j], query.TDuration)
+	return b.colBufs[j].([]execute.Duration)
+}
+func (b *block) Bytes(j int) [][]byte {
+	execute.CheckColType(b.cols[j], query.TBytes)
+	return b.colBufs[j].([][]byte)
+}
+
+// Nulls returns nil, the values read from storage are never missing.
+func (b *block) Nulls(j int) []bool {
+	return nil
+}
 
 // readTags populates b.tags with the provided tags
 func (b *block) readTags(tags []Tag) {
@@ -0,0 +1,51 @@
+package pb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/platform/query/plan"
+	"github.com/pkg/errors"
+)
+
+// ShardsCapability is the capability key under which a storage host reports the shards it owns.
+// The value is a JSON encoded list of shardInfo objects.
+const ShardsCapability = "shards"
+
+type shardInfo struct {
+	Database string    `json:"db"`
+	Start    time.Time `json:"start"`
+	Stop     time.Time `json:"stop"`
+}
+
+// Shards requests the shards owned by each storage host.
+// Hosts that do not report the shards capability are omitted from the mapping.
+func (sr *reader) Shards(ctx context.Context) (plan.ShardMap, error) {
+	shards := make(plan.ShardMap)
+	for _, c := range sr.conns {
+		resp, err := c.client.Capabilities(ctx, &types.Empty{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read capabilities of host %q", c.host)
+		}
+		caps, ok := resp.Caps[ShardsCapability]
+		if !ok {
+			continue
+		}
+		var infos []shardInfo
+		if err := json.Unmarshal([]byte(caps), &infos); err != nil {
+			return nil, errors.Wrapf(err, "invalid shards capability from host %q", c.host)
+		}
+		for _, info := range infos {
+			shards[info.Database] = append(shards[info.Database], plan.Shard{
+				Node: c.host,
+				Range: plan.TimeRange{
+					Start: info.Start,
+					Stop:  info.Stop,
+				},
+			})
+		}
+	}
+	return shards, nil
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform/query/plan"
+)
+
+// DefaultShardRefreshInterval is the interval at which shard metadata is refreshed
+// when no interval is specified.
+const DefaultShardRefreshInterval = time.Minute
+
+// MetaClient reports the shards held by the storage hosts.
+type MetaClient interface {
+	Shards(ctx context.Context) (plan.ShardMap, error)
+}
+
+// ShardStorage implements plan.Storage using the shard metadata of the storage hosts.
+// The metadata is cached and refreshed periodically or whenever the set of hosts changes.
+type ShardStorage struct {
+	client  MetaClient
+	watch   <-chan struct{}
+	refresh time.Duration
+	timeout time.Duration
+
+	mu          sync.Mutex
+	shards      plan.ShardMap
+	lastRefresh time.Time
+	// fetching is closed when the fetch in flight completes, it is nil when there is none.
+	fetching chan struct{}
+}
+
+// NewShardStorage creates a ShardStorage that reads shard metadata from mc.
+// The hosts lookup is watched in order to refresh the metadata when the hosts change.
+func NewShardStorage(mc MetaClient, hl HostLookup, refresh time.Duration) *ShardStorage {
+	if refresh <= 0 {
+		refresh = DefaultShardRefreshInterval
+	}
+	s := &ShardStorage{
+		client:  mc,
+		refresh: refresh,
+		timeout: 10 * time.Second,
+	}
+	if hl != nil {
+		s.watch = hl.Watch()
+	}
+	return s
+}
+
+// ShardMapping returns the most recently known shard metadata.
+// Stale metadata is refreshed in the background, at most one fetch at a time, and is served
+// until the refresh completes. Only the first call waits for metadata to be fetched.
+// If the metadata cannot be refreshed the previously known metadata is returned.
+func (s *ShardStorage) ShardMapping() plan.ShardMap {
+	s.mu.Lock()
+	if s.fetching == nil && s.stale() {
+		s.fetching = make(chan struct{})
+		go s.fetch(s.fetching)
+	}
+	if done := s.fetching; done != nil && s.lastRefresh.IsZero() {
+		s.mu.Unlock()
+		<-done
+		s.mu.Lock()
+	}
+	shards := s.shards
+	s.mu.Unlock()
+	return shards
+}
+
+// fetch refreshes the shard metadata without holding the lock during the request
+// and closes done once it has been stored.
+func (s *ShardStorage) fetch(done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	shards, err := s.client.Shards(ctx)
+	cancel()
+
+	s.mu.Lock()
+	if err != nil {
+		log.Println("E! failed to refresh shard metadata:", err)
+	} else {
+		s.shards = shards
+	}
+	s.lastRefresh = time.Now()
+	s.fetching = nil
+	s.mu.Unlock()
+	close(done)
+}
+
+func (s *ShardStorage) stale() bool {
+	select {
+	case <-s.watch:
+		return true
+	default:
+	}
+	return s.lastRefresh.IsZero() || time.Since(s.lastRefresh) >= s.refresh
+}
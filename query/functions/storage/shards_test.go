@@ -0,0 +1,72 @@
+package storage_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/query/functions/storage"
+	"github.com/influxdata/platform/query/plan"
+)
+
+type metaClient struct {
+	calls   int32
+	release chan struct{}
+	shards  plan.ShardMap
+}
+
+func (c *metaClient) Shards(ctx context.Context) (plan.ShardMap, error) {
+	atomic.AddInt32(&c.calls, 1)
+	if c.release != nil {
+		<-c.release
+	}
+	return c.shards, nil
+}
+
+func TestShardStorage_ShardMapping(t *testing.T) {
+	old := plan.ShardMap{"db": {{Node: "host1"}}}
+	mc := &metaClient{shards: old}
+	s := storage.NewShardStorage(mc, nil, time.Nanosecond)
+
+	// The first call waits for the metadata.
+	if got := s.ShardMapping(); got["db"][0].Node != "host1" {
+		t.Fatalf("unexpected shards: %v", got)
+	}
+
+	// A refresh in flight does not block callers, which are served the cached metadata.
+	mc.release = make(chan struct{})
+	mc.shards = plan.ShardMap{"db": {{Node: "host2"}}}
+	for i := 0; i < 3; i++ {
+		got := make(chan plan.ShardMap)
+		go func() { got <- s.ShardMapping() }()
+		select {
+		case shards := <-got:
+			if shards["db"][0].Node != "host1" {
+				t.Fatalf("expected the cached shards while refreshing, got %v", shards)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the cached shards")
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&mc.calls) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the refresh")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	s.ShardMapping()
+	if calls := atomic.LoadInt32(&mc.calls); calls != 2 {
+		t.Fatalf("expected a single refresh in flight, got %d fetches", calls)
+	}
+
+	close(mc.release)
+	deadline = time.Now().Add(time.Second)
+	for s.ShardMapping()["db"][0].Node != "host2" {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the refreshed shards")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -4,13 +4,19 @@ import (
 	"github.com/influxdata/platform/query"
 )
 
+// SelectorsPackagePath is the import path of the selectors package.
+const SelectorsPackagePath = "selectors"
+
 func init() {
-	query.RegisterBuiltIn("top-bottom", topBottomBuiltIn)
+	query.RegisterPackageBuiltIn(SelectorsPackagePath, topBottomBuiltIn)
+	query.RegisterDeprecatedGlobalBuiltIn("top-bottom", topBottomBuiltIn)
 	// TODO(nathanielc): Provide an implementation of top/bottom transformation that can use a more efficient sort based on the limit.
 	// This transformation should be used when ever the planner sees a sort |> limit pair of procedures.
 }
 
 var topBottomBuiltIn = `
+package selectors
+
 // _sortLimit is a helper function, which sorts and limits a table.
 _sortLimit = (n, desc, cols=["_value"], table=<-) =>
 	table
@@ -10,6 +10,21 @@ import (
 	"github.com/influxdata/platform/query/values"
 )
 
+// TypeconvPackagePath is the import path of the typeconv package.
+const TypeconvPackagePath = "typeconv"
+
+var typeconvBuiltIn = `
+package typeconv
+
+toString = (table=<-) => table |> map(fn:(r) => string(v:r._value))
+toInt = (table=<-) => table |> map(fn:(r) => int(v:r._value))
+toUInt = (table=<-) => table |> map(fn:(r) => uint(v:r._value))
+toFloat = (table=<-) => table |> map(fn:(r) => float(v:r._value))
+toBool = (table=<-) => table |> map(fn:(r) => bool(v:r._value))
+toTime = (table=<-) => table |> map(fn:(r) => time(v:r._value))
+toDuration = (table=<-) => table |> map(fn:(r) => duration(v:r._value))
+`
+
 func init() {
 	query.RegisterBuiltInValue("string", stringConv{})
 	query.RegisterBuiltInValue("int", intConv{})
@@ -18,15 +33,8 @@ func init() {
 	query.RegisterBuiltInValue("bool", boolConv{})
 	query.RegisterBuiltInValue("time", timeConv{})
 	query.RegisterBuiltInValue("duration", durationConv{})
-	query.RegisterBuiltIn("typeconv", `
-	toString = (table=<-) => table |> map(fn:(r) => string(v:r._value))
-	toInt = (table=<-) => table |> map(fn:(r) => int(v:r._value))
-	toUInt = (table=<-) => table |> map(fn:(r) => uint(v:r._value))
-	toFloat = (table=<-) => table |> map(fn:(r) => float(v:r._value))
-	toBool = (table=<-) => table |> map(fn:(r) => bool(v:r._value))
-	toTime = (table=<-) => table |> map(fn:(r) => time(v:r._value))
-	toDuration = (table=<-) => table |> map(fn:(r) => duration(v:r._value))
-`)
+	query.RegisterPackageBuiltIn(TypeconvPackagePath, typeconvBuiltIn)
+	query.RegisterDeprecatedGlobalBuiltIn("typeconv", typeconvBuiltIn)
 }
 
 const (
@@ -66,6 +74,9 @@ func (c stringConv) Time() values.Time {
 func (c stringConv) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Duration))
 }
+func (c stringConv) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Float, semantic.Bytes))
+}
 func (c stringConv) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Regexp))
 }
@@ -100,6 +111,8 @@ func (c stringConv) Call(args values.Object) (values.Value, error) {
 		str = v.Time().String()
 	case semantic.Duration:
 		str = v.Duration().String()
+	case semantic.Bytes:
+		str = string(v.Bytes())
 	default:
 		return nil, fmt.Errorf("cannot convert %v to string", v.Type())
 	}
@@ -137,6 +150,9 @@ func (c intConv) Time() values.Time {
 func (c intConv) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Duration))
 }
+func (c intConv) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Float, semantic.Bytes))
+}
 func (c intConv) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Regexp))
 }
@@ -216,6 +232,9 @@ func (c uintConv) Time() values.Time {
 func (c uintConv) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Duration))
 }
+func (c uintConv) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Float, semantic.Bytes))
+}
 func (c uintConv) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Regexp))
 }
@@ -295,6 +314,9 @@ func (c floatConv) Time() values.Time {
 func (c floatConv) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Duration))
 }
+func (c floatConv) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Float, semantic.Bytes))
+}
 func (c floatConv) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Regexp))
 }
@@ -370,6 +392,9 @@ func (c boolConv) Time() values.Time {
 func (c boolConv) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Duration))
 }
+func (c boolConv) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Float, semantic.Bytes))
+}
 func (c boolConv) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Regexp))
 }
@@ -465,6 +490,9 @@ func (c timeConv) Time() values.Time {
 func (c timeConv) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Duration))
 }
+func (c timeConv) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Float, semantic.Bytes))
+}
 func (c timeConv) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Regexp))
 }
@@ -532,6 +560,9 @@ func (c durationConv) Time() values.Time {
 func (c durationConv) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Duration))
 }
+func (c durationConv) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Float, semantic.Bytes))
+}
 func (c durationConv) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Float, semantic.Regexp))
 }
@@ -562,6 +593,8 @@ func (c durationConv) Call(args values.Object) (values.Value, error) {
 		d = values.Duration(v.Int())
 	case semantic.UInt:
 		d = values.Duration(v.UInt())
+	case semantic.Duration:
+		d = v.Duration()
 	default:
 		return nil, fmt.Errorf("cannot convert %v to duration", v.Type())
 	}
@@ -122,12 +122,14 @@ func (t *uniqueTransformation) Process(id execute.DatasetID, b query.Block) erro
 	col := builder.Cols()[colIdx]
 
 	var (
-		boolUnique   map[bool]bool
-		intUnique    map[int64]bool
-		uintUnique   map[uint64]bool
-		floatUnique  map[float64]bool
-		stringUnique map[string]bool
-		timeUnique   map[execute.Time]bool
+		boolUnique     map[bool]bool
+		intUnique      map[int64]bool
+		uintUnique     map[uint64]bool
+		floatUnique    map[float64]bool
+		stringUnique   map[string]bool
+		timeUnique     map[execute.Time]bool
+		durationUnique map[execute.Duration]bool
+		bytesUnique    map[string]bool
 	)
 	switch col.Type {
 	case query.TBool:
@@ -142,6 +144,10 @@ func (t *uniqueTransformation) Process(id execute.DatasetID, b query.Block) erro
 		stringUnique = make(map[string]bool)
 	case query.TTime:
 		timeUnique = make(map[execute.Time]bool)
+	case query.TDuration:
+		durationUnique = make(map[execute.Duration]bool)
+	case query.TBytes:
+		bytesUnique = make(map[string]bool)
 	}
 
 	return b.Do(func(cr query.ColReader) error {
@@ -185,6 +191,18 @@ func (t *uniqueTransformation) Process(id execute.DatasetID, b query.Block) erro
 					continue
 				}
 				timeUnique[v] = true
+			case query.TDuration:
+				v := cr.Durations(colIdx)[i]
+				if durationUnique[v] {
+					continue
+				}
+				durationUnique[v] = true
+			case query.TBytes:
+				v := cr.Bytes(colIdx)[i]
+				if bytesUnique[string(v)] {
+					continue
+				}
+				bytesUnique[string(v)] = true
 			}
 
 			execute.AppendRecord(i, cr, builder)
@@ -158,6 +158,10 @@ func (s *WindowProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// PartitionIndependent implements plan.PartitionIndependentProcedureSpec.
+// Window only modifies the time bounds of the partition key of each table.
+func (s *WindowProcedureSpec) PartitionIndependent() {}
+
 func (s *WindowProcedureSpec) TriggerSpec() query.TriggerSpec {
 	return s.Triggering
 }
@@ -171,7 +175,7 @@ func createWindowTransformation(id execute.DatasetID, mode execute.AccumulationM
 	d := execute.NewDataset(id, mode, cache)
 	var start execute.Time
 	if s.Window.Start.IsZero() {
-		start = a.ResolveTime(query.Now).Truncate(execute.Duration(s.Window.Every))
+		start = a.ResolveTime(query.Now).TruncateIn(execute.Duration(s.Window.Every), a.Location())
 	} else {
 		start = a.ResolveTime(s.Window.Start)
 	}
@@ -334,6 +338,10 @@ func (t *fixedWindowTransformation) Process(id execute.DatasetID, b query.Block)
 							builder.AppendString(j, cr.Strings(j)[i])
 						case query.TTime:
 							builder.AppendTime(j, cr.Times(j)[i])
+						case query.TDuration:
+							builder.AppendDuration(j, cr.Durations(j)[i])
+						case query.TBytes:
+							builder.AppendBytes(j, cr.Bytes(j)[i])
 						default:
 							execute.PanicUnknownType(c.Type)
 						}
@@ -34,6 +34,10 @@ func (itrp interpreter) doStatement(stmt semantic.Statement, scope *Scope) error
 		if err := itrp.doVariableDeclaration(s, scope); err != nil {
 			return err
 		}
+	case *semantic.OptionStatement:
+		if err := itrp.doOptionStatement(s, scope); err != nil {
+			return err
+		}
 	case *semantic.ExpressionStatement:
 		v, err := itrp.doExpression(s.Expression, scope)
 		if err != nil {
@@ -68,6 +72,16 @@ func (itrp interpreter) doStatement(stmt semantic.Statement, scope *Scope) error
 	return nil
 }
 
+func (itrp interpreter) doOptionStatement(s *semantic.OptionStatement, scope *Scope) error {
+	value, err := itrp.doExpression(s.Declaration.Init, scope)
+	if err != nil {
+		return err
+	}
+	scope.Set(s.Declaration.Identifier.Name, value)
+	scope.SetOption(s.Declaration.Identifier.Name, value)
+	return nil
+}
+
 func (itrp interpreter) doVariableDeclaration(declaration *semantic.NativeVariableDeclaration, scope *Scope) error {
 	value, err := itrp.doExpression(declaration.Init, scope)
 	if err != nil {
@@ -106,6 +120,26 @@ func (itrp interpreter) doExpression(expr semantic.Expression, scope *Scope) (va
 			return nil, fmt.Errorf("object has no property %q", e.Property)
 		}
 		return v, nil
+	case *semantic.IndexExpression:
+		arr, err := itrp.doExpression(e.Array, scope)
+		if err != nil {
+			return nil, err
+		}
+		if arr.Type().Kind() != semantic.Array {
+			return nil, fmt.Errorf("cannot index into a value of type %v", arr.Type())
+		}
+		idx, err := itrp.doExpression(e.Index, scope)
+		if err != nil {
+			return nil, err
+		}
+		if idx.Type() != semantic.Int {
+			return nil, fmt.Errorf("array index must be an integer value, got %v", idx.Type())
+		}
+		a, i := arr.Array(), idx.Int()
+		if i < 0 || i >= int64(a.Len()) {
+			return nil, fmt.Errorf("array index %d out of range [0:%d]", i, a.Len())
+		}
+		return a.Get(int(i)), nil
 	case *semantic.ObjectExpression:
 		return itrp.doObject(e, scope)
 	case *semantic.UnaryExpression:
@@ -189,6 +223,19 @@ func (itrp interpreter) doExpression(expr semantic.Expression, scope *Scope) (va
 		default:
 			return nil, fmt.Errorf("invalid logical operator %v", e.Operator)
 		}
+	case *semantic.ConditionalExpression:
+		t, err := itrp.doExpression(e.Test, scope)
+		if err != nil {
+			return nil, err
+		}
+		if t.Type() != semantic.Bool {
+			return nil, fmt.Errorf("test of conditional expression is not a boolean value, got %v", t.Type())
+		}
+		// Only the selected branch is evaluated.
+		if t.Bool() {
+			return itrp.doExpression(e.Consequent, scope)
+		}
+		return itrp.doExpression(e.Alternate, scope)
 	case *semantic.FunctionExpression:
 		return function{
 			e:     e,
@@ -326,6 +373,7 @@ type Scope struct {
 	parent      *Scope
 	values      map[string]values.Value
 	returnValue values.Value
+	options     map[string]values.Value
 }
 
 func NewScope() *Scope {
@@ -375,6 +423,28 @@ func (s *Scope) Names() []string {
 	return names
 }
 
+// SetOption records the value of an option set by the program.
+// Options apply to the whole program, so they are recorded on the outermost scope.
+func (s *Scope) SetOption(name string, value values.Value) {
+	root := s
+	for root.parent != nil {
+		root = root.parent
+	}
+	if root.options == nil {
+		root.options = make(map[string]values.Value)
+	}
+	root.options[name] = value
+}
+
+// Options returns the values of all options set by the program.
+func (s *Scope) Options() map[string]values.Value {
+	root := s
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root.options
+}
+
 // Nest returns a new nested scope.
 func (s *Scope) Nest() *Scope {
 	c := NewScope()
@@ -393,6 +463,12 @@ func (s *Scope) Copy() *Scope {
 		for k, v := range curr.values {
 			c.values[k] = v
 		}
+		if curr.parent == nil && len(curr.options) > 0 {
+			c.options = make(map[string]values.Value, len(curr.options))
+			for k, v := range curr.options {
+				c.options[k] = v
+			}
+		}
 		curr = curr.parent
 	}
 	return c
@@ -468,6 +544,9 @@ func (f function) Time() values.Time {
 func (f function) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Duration))
 }
+func (f function) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Object, semantic.Bytes))
+}
 func (f function) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Regexp))
 }
@@ -553,99 +632,160 @@ func ResolveFunction(f values.Function) (*semantic.FunctionExpression, error) {
 }
 
 // Resolve rewrites the function resolving any identifiers not listed in the function params.
+// Functions that the function calls are resolved as well, so that the function can be compiled without its scope.
 func (f function) Resolve() (semantic.Node, error) {
-	n := f.e.Copy()
-	node, err := f.resolveIdentifiers(n)
-	if err != nil {
-		return nil, err
+	return f.resolve(nil)
+}
+
+// resolve resolves the function, resolving lists the functions that are already being resolved.
+func (f function) resolve(resolving []*semantic.FunctionExpression) (semantic.Node, error) {
+	for _, e := range resolving {
+		if e == f.e {
+			return nil, errors.New("cannot resolve recursive function")
+		}
+	}
+	r := identifierResolver{
+		scope:     f.scope,
+		resolving: append(resolving[:len(resolving):len(resolving)], f.e),
 	}
-	return node, nil
+	return r.resolveIdentifiers(f.e.Copy(), nil)
 }
 
-func (f function) resolveIdentifiers(n semantic.Node) (semantic.Node, error) {
+// identifierResolver replaces the identifiers of a function that refer to values in its scope with the values.
+type identifierResolver struct {
+	scope     *Scope
+	resolving []*semantic.FunctionExpression
+}
+
+// resolveIdentifiers resolves the identifiers in the node, the locals are the names declared within the function.
+func (r identifierResolver) resolveIdentifiers(n semantic.Node, locals map[string]bool) (semantic.Node, error) {
 	switch n := n.(type) {
 	case *semantic.IdentifierExpression:
-		for _, p := range f.e.Params {
-			if n.Name == p.Key.Name {
-				// Identifier is a parameter do not resolve
-				return n, nil
-			}
+		if locals[n.Name] {
+			// Identifier is a parameter or a local variable do not resolve
+			return n, nil
 		}
-		v, ok := f.scope.Lookup(n.Name)
+		v, ok := r.scope.Lookup(n.Name)
 		if !ok {
 			return nil, fmt.Errorf("name %q does not exist in scope", n.Name)
 		}
-		return resolveValue(v)
+		if !r.resolvable(v) {
+			// Builtin functions are resolved by name when the function is compiled.
+			return n, nil
+		}
+		return resolveValue(v, r.resolving)
 	case *semantic.BlockStatement:
+		locals = copyLocals(locals)
 		for i, s := range n.Body {
-			node, err := f.resolveIdentifiers(s)
+			if d, ok := s.(*semantic.NativeVariableDeclaration); ok {
+				locals[d.Identifier.Name] = true
+			}
+			node, err := r.resolveIdentifiers(s, locals)
 			if err != nil {
 				return nil, err
 			}
 			n.Body[i] = node.(semantic.Statement)
 		}
 	case *semantic.ExpressionStatement:
-		node, err := f.resolveIdentifiers(n.Expression)
+		node, err := r.resolveIdentifiers(n.Expression, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Expression = node.(semantic.Expression)
 	case *semantic.ReturnStatement:
-		node, err := f.resolveIdentifiers(n.Argument)
+		node, err := r.resolveIdentifiers(n.Argument, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Argument = node.(semantic.Expression)
 	case *semantic.NativeVariableDeclaration:
-		node, err := f.resolveIdentifiers(n.Init)
+		node, err := r.resolveIdentifiers(n.Init, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Init = node.(semantic.Expression)
 	case *semantic.CallExpression:
-		node, err := f.resolveIdentifiers(n.Arguments)
+		node, err := r.resolveIdentifiers(n.Callee, locals)
+		if err != nil {
+			return nil, err
+		}
+		n.Callee = node.(semantic.Expression)
+		node, err = r.resolveIdentifiers(n.Arguments, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Arguments = node.(*semantic.ObjectExpression)
 	case *semantic.FunctionExpression:
-		node, err := f.resolveIdentifiers(n.Body)
+		locals = copyLocals(locals)
+		for _, p := range n.Params {
+			locals[p.Key.Name] = true
+		}
+		for _, p := range n.Params {
+			if p.Default == nil {
+				continue
+			}
+			node, err := r.resolveIdentifiers(p.Default, locals)
+			if err != nil {
+				return nil, err
+			}
+			p.Default = node.(semantic.Expression)
+		}
+		node, err := r.resolveIdentifiers(n.Body, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Body = node
+	case *semantic.MemberExpression:
+		// Members of objects in scope, such as the members of a package, are resolved individually.
+		if obj, ok := n.Object.(*semantic.IdentifierExpression); ok && !locals[obj.Name] {
+			if v, ok := r.scope.Lookup(obj.Name); ok && v.Type().Kind() == semantic.Object {
+				p, ok := v.Object().Get(n.Property)
+				if !ok {
+					return nil, fmt.Errorf("object %q has no property %q", obj.Name, n.Property)
+				}
+				if !r.resolvable(p) {
+					return n, nil
+				}
+				return resolveValue(p, r.resolving)
+			}
+		}
+		node, err := r.resolveIdentifiers(n.Object, locals)
+		if err != nil {
+			return nil, err
+		}
+		n.Object = node.(semantic.Expression)
 	case *semantic.BinaryExpression:
-		node, err := f.resolveIdentifiers(n.Left)
+		node, err := r.resolveIdentifiers(n.Left, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Left = node.(semantic.Expression)
 
-		node, err = f.resolveIdentifiers(n.Right)
+		node, err = r.resolveIdentifiers(n.Right, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Right = node.(semantic.Expression)
 	case *semantic.UnaryExpression:
-		node, err := f.resolveIdentifiers(n.Argument)
+		node, err := r.resolveIdentifiers(n.Argument, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Argument = node.(semantic.Expression)
 	case *semantic.LogicalExpression:
-		node, err := f.resolveIdentifiers(n.Left)
+		node, err := r.resolveIdentifiers(n.Left, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Left = node.(semantic.Expression)
-		node, err = f.resolveIdentifiers(n.Right)
+		node, err = r.resolveIdentifiers(n.Right, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Right = node.(semantic.Expression)
 	case *semantic.ArrayExpression:
 		for i, el := range n.Elements {
-			node, err := f.resolveIdentifiers(el)
+			node, err := r.resolveIdentifiers(el, locals)
 			if err != nil {
 				return nil, err
 			}
@@ -653,32 +793,43 @@ func (f function) resolveIdentifiers(n semantic.Node) (semantic.Node, error) {
 		}
 	case *semantic.ObjectExpression:
 		for i, p := range n.Properties {
-			node, err := f.resolveIdentifiers(p)
+			node, err := r.resolveIdentifiers(p, locals)
 			if err != nil {
 				return nil, err
 			}
 			n.Properties[i] = node.(*semantic.Property)
 		}
+	case *semantic.IndexExpression:
+		node, err := r.resolveIdentifiers(n.Array, locals)
+		if err != nil {
+			return nil, err
+		}
+		n.Array = node.(semantic.Expression)
+		node, err = r.resolveIdentifiers(n.Index, locals)
+		if err != nil {
+			return nil, err
+		}
+		n.Index = node.(semantic.Expression)
 	case *semantic.ConditionalExpression:
-		node, err := f.resolveIdentifiers(n.Test)
+		node, err := r.resolveIdentifiers(n.Test, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Test = node.(semantic.Expression)
 
-		node, err = f.resolveIdentifiers(n.Alternate)
+		node, err = r.resolveIdentifiers(n.Alternate, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Alternate = node.(semantic.Expression)
 
-		node, err = f.resolveIdentifiers(n.Consequent)
+		node, err = r.resolveIdentifiers(n.Consequent, locals)
 		if err != nil {
 			return nil, err
 		}
 		n.Consequent = node.(semantic.Expression)
 	case *semantic.Property:
-		node, err := f.resolveIdentifiers(n.Value)
+		node, err := r.resolveIdentifiers(n.Value, locals)
 		if err != nil {
 			return nil, err
 		}
@@ -687,7 +838,25 @@ func (f function) resolveIdentifiers(n semantic.Node) (semantic.Node, error) {
 	return n, nil
 }
 
-func resolveValue(v values.Value) (semantic.Node, error) {
+// resolvable reports whether the value can be resolved into a node.
+// Functions implemented in Go cannot be resolved.
+func (r identifierResolver) resolvable(v values.Value) bool {
+	if v.Type().Kind() != semantic.Function {
+		return true
+	}
+	_, ok := v.Function().(Resolver)
+	return ok
+}
+
+func copyLocals(locals map[string]bool) map[string]bool {
+	cpy := make(map[string]bool, len(locals))
+	for k := range locals {
+		cpy[k] = true
+	}
+	return cpy
+}
+
+func resolveValue(v values.Value, resolving []*semantic.FunctionExpression) (semantic.Node, error) {
 	switch k := v.Type().Kind(); k {
 	case semantic.String:
 		return &semantic.StringLiteral{
@@ -722,6 +891,9 @@ func resolveValue(v values.Value) (semantic.Node, error) {
 			Value: v.Duration().Duration(),
 		}, nil
 	case semantic.Function:
+		if f, ok := v.Function().(function); ok {
+			return f.resolve(resolving)
+		}
 		resolver, ok := v.Function().(Resolver)
 		if !ok {
 			return nil, fmt.Errorf("function is not resolvable %T", v.Function())
@@ -737,7 +909,7 @@ func resolveValue(v values.Value) (semantic.Node, error) {
 				return
 			}
 			var n semantic.Node
-			n, err = resolveValue(el)
+			n, err = resolveValue(el, resolving)
 			if err != nil {
 				return
 			}
@@ -757,7 +929,7 @@ func resolveValue(v values.Value) (semantic.Node, error) {
 				return
 			}
 			var n semantic.Node
-			n, err = resolveValue(v)
+			n, err = resolveValue(v, resolving)
 			if err != nil {
 				return
 			}
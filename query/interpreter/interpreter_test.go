@@ -2,11 +2,13 @@ package interpreter_test
 
 import (
 	"errors"
+	"reflect"
 	"regexp"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform/query/ast"
+	"github.com/influxdata/platform/query/compiler"
 	"github.com/influxdata/platform/query/interpreter"
 	"github.com/influxdata/platform/query/parser"
 	"github.com/influxdata/platform/query/semantic"
@@ -249,6 +251,72 @@ func TestEval(t *testing.T) {
 			"abba" !~ /^a.*a$/ and fail()
 			`,
 		},
+		{
+			name: "index expression",
+			query: `
+            a = [1.0, six(), nine()]
+            i = 1
+            a[i] == 6.0 or fail()
+            a[2] == 9.0 or fail()
+			`,
+		},
+		{
+			name: "index expression out of range",
+			query: `
+            a = [1, 2, 3]
+            a[3]
+			`,
+			wantErr: true,
+		},
+		{
+			name: "negative index expression",
+			query: `
+            a = [1, 2, 3]
+            i = -1
+            a[i]
+			`,
+			wantErr: true,
+		},
+		{
+			name: "string interpolation",
+			query: `
+            r = {host: "a", region: "b"}
+            "${r.host}-${r[\"region\"]}" == "a-b" or fail()
+            "\${r.host}" == "$" + "{r.host}" or fail()
+			`,
+		},
+		{
+			name: "string interpolation of non string",
+			query: `
+            f = (x) => "${x}"
+            f(x: six())
+			`,
+			wantErr: true,
+		},
+		{
+			name: "conditional",
+			query: `
+            sign = (x) => if x < 0.0 then -1.0 else if x > 0.0 then 1.0 else 0.0
+            sign(x: -six()) == -1.0 or fail()
+            sign(x: nine()) == 1.0 or fail()
+            sign(x: 0.0) == 0.0 or fail()
+			`,
+		},
+		{
+			name: "conditional evaluates only the selected branch",
+			query: `
+            if six() == 6.0 then true else fail()
+            if six() == 9.0 then fail() else true
+			`,
+		},
+		{
+			name: "conditional with non boolean test",
+			query: `
+            f = (x) => if x then 1 else 2
+            f(x: six())
+			`,
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -273,6 +341,38 @@ func TestEval(t *testing.T) {
 	}
 
 }
+func TestEval_Options(t *testing.T) {
+	program, err := parser.NewAST(`
+	option location = "UTC"
+	option x = 1 + 1
+	y = x + 1
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := semantic.New(program, testDeclarations.Copy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Options are recorded on the outermost scope.
+	scope := interpreter.NewScope()
+	if err := interpreter.Eval(graph, scope.Nest()); err != nil {
+		t.Fatal(err)
+	}
+
+	options := scope.Options()
+	if len(options) != 2 {
+		t.Fatalf("unexpected number of options: want 2 got %d", len(options))
+	}
+	if got := options["location"].Str(); got != "UTC" {
+		t.Errorf("unexpected location option: want %q got %q", "UTC", got)
+	}
+	if got := options["x"].Int(); got != 2 {
+		t.Errorf("unexpected x option: want 2 got %d", got)
+	}
+}
+
 func TestResolver(t *testing.T) {
 	var got semantic.Expression
 	scope := interpreter.NewScope()
@@ -340,6 +440,112 @@ func TestResolver(t *testing.T) {
 	}
 }
 
+func TestResolver_UserDefinedFunctions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		program string
+		r       values.Value
+		want    values.Value
+	}{
+		{
+			name: "captured function",
+			program: `
+	celsius = (f) => (f - 32.0) * 5.0 / 9.0
+	resolver(f: (r) => celsius(f: r))
+`,
+			r:    values.NewFloatValue(212),
+			want: values.NewFloatValue(100),
+		},
+		{
+			name: "default parameters",
+			program: `
+	add = (a, b=1) => a + b
+	resolver(f: (r) => add(a: r) + add(a: r, b: 10))
+`,
+			r:    values.NewIntValue(1),
+			want: values.NewIntValue(13),
+		},
+		{
+			name: "nested captured functions",
+			program: `
+	step = 10
+	addStep = (n) => n + step
+	twice = (n) => addStep(n: addStep(n: n))
+	resolver(f: (r) => twice(n: r))
+`,
+			r:    values.NewIntValue(1),
+			want: values.NewIntValue(21),
+		},
+		{
+			name: "local function",
+			program: `
+	resolver(f: (r) => {
+		double = (n) => n * 2.0
+		return double(n: r)
+	})
+`,
+			r:    values.NewFloatValue(1.5),
+			want: values.NewFloatValue(3),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var got values.Value
+			scope := interpreter.NewScope()
+			declarations := testDeclarations.Copy()
+			f := function{
+				name: "resolver",
+				t: semantic.NewFunctionType(semantic.FunctionSignature{
+					Params: map[string]semantic.Type{
+						"f": semantic.Function,
+					},
+					ReturnType: semantic.Int,
+				}),
+				call: func(args values.Object) (values.Value, error) {
+					f, ok := args.Get("f")
+					if !ok {
+						return nil, errors.New("missing argument f")
+					}
+					resolver, ok := f.Function().(interpreter.Resolver)
+					if !ok {
+						return nil, errors.New("function cannot be resolved")
+					}
+					g, err := resolver.Resolve()
+					if err != nil {
+						return nil, err
+					}
+					fn, err := compiler.Compile(g.(*semantic.FunctionExpression), map[string]semantic.Type{
+						"r": tc.r.Type(),
+					}, nil, nil)
+					if err != nil {
+						return nil, err
+					}
+					got, err = fn.Eval(map[string]values.Value{"r": tc.r})
+					return nil, err
+				},
+			}
+			scope.Set(f.name, f)
+			declarations[f.name] = semantic.NewExternalVariableDeclaration(f.name, f.t)
+
+			program, err := parser.NewAST(tc.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			graph, err := semantic.New(program, declarations)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := interpreter.Eval(graph, scope); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("unexpected value: want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
 type function struct {
 	name string
 	t    semantic.Type
@@ -371,6 +577,9 @@ func (f function) Time() values.Time {
 func (f function) Duration() values.Duration {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Duration))
 }
+func (f function) Bytes() []byte {
+	panic(values.UnexpectedKind(semantic.Object, semantic.Bytes))
+}
 func (f function) Regexp() *regexp.Regexp {
 	panic(values.UnexpectedKind(semantic.Object, semantic.Regexp))
 }
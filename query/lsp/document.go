@@ -0,0 +1,276 @@
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/ast"
+	"github.com/influxdata/platform/query/complete"
+	"github.com/influxdata/platform/query/interpreter"
+	"github.com/influxdata/platform/query/parser"
+	"github.com/influxdata/platform/query/semantic"
+)
+
+// diagnosticSource is the source of the diagnostics published by the server.
+const diagnosticSource = "flux"
+
+// document is an open Flux document and the result of its analysis.
+type document struct {
+	uri   string
+	lines []string
+	// program is the parsed document, it is nil if the document cannot be parsed.
+	program *ast.Program
+	// declarations are the builtin, imported and top level declarations of the document.
+	declarations semantic.DeclarationScope
+	completer    complete.Completer
+	diagnostics  []Diagnostic
+}
+
+// newDocument analyzes the text of a document.
+// When the text cannot be parsed the declarations of the previous version of the document, if any, are kept
+// so that names can still be completed while the document is being edited.
+func newDocument(uri, text string, prev *document) *document {
+	d := &document{
+		uri:   uri,
+		lines: strings.Split(text, "\n"),
+	}
+	scope, decls, importer := query.BuiltInsWithImporter()
+	interpScope := interpreter.NewScopeWithValues(scope)
+	d.declarations = decls
+	d.completer = complete.NewCompleter(interpScope, decls)
+
+	program, err := parser.NewAST(text)
+	if err != nil {
+		d.diagnostics = d.parseDiagnostics(err)
+		if prev != nil {
+			d.declarations = prev.declarations
+			d.completer = prev.completer
+		}
+		return d
+	}
+	d.program = program
+
+	for _, imp := range program.Imports {
+		p := &ast.Program{Imports: []*ast.ImportDeclaration{imp}}
+		if err := importer.ResolveImports(p, interpScope, decls); err != nil {
+			d.addDiagnostic(imp.Location(), err.Error())
+		}
+	}
+	builtinDecls := decls.Copy()
+	semProg, err := semantic.New(program, decls)
+	if err != nil {
+		d.addDiagnostic(nil, err.Error())
+		return d
+	}
+	if err := semantic.Infer(semProg, builtinDecls); err != nil {
+		typeErrs, ok := err.(semantic.TypeErrors)
+		if !ok {
+			d.addDiagnostic(nil, err.Error())
+			return d
+		}
+		for _, e := range typeErrs {
+			d.addDiagnostic(e.Loc, e.Msg)
+		}
+	}
+	return d
+}
+
+// parseErrorPattern matches the errors of the parser, which are prefixed with their line, column and offset.
+var parseErrorPattern = regexp.MustCompile(`^(\d+):(\d+) \(\d+\): (.*)$`)
+
+func (d *document) parseDiagnostics(err error) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, msg := range strings.Split(err.Error(), "\n") {
+		m := parseErrorPattern.FindStringSubmatch(msg)
+		if m == nil {
+			diagnostics = append(diagnostics, d.diagnostic(Range{}, msg))
+			continue
+		}
+		line, _ := strconv.Atoi(m[1])
+		col, _ := strconv.Atoi(m[2])
+		p := d.position(ast.Position{Line: line, Column: col})
+		diagnostics = append(diagnostics, d.diagnostic(Range{Start: p, End: p}, m[3]))
+	}
+	return diagnostics
+}
+
+// addDiagnostic adds an error at the location, errors without a location are reported at the start of the document.
+func (d *document) addDiagnostic(loc *ast.SourceLocation, msg string) {
+	var r Range
+	if loc != nil {
+		r = d.rangeOf(loc)
+	}
+	d.diagnostics = append(d.diagnostics, d.diagnostic(r, msg))
+}
+
+func (d *document) diagnostic(r Range, msg string) Diagnostic {
+	return Diagnostic{
+		Range:    r,
+		Severity: SeverityError,
+		Source:   diagnosticSource,
+		Message:  msg,
+	}
+}
+
+// position converts a position in the Flux source to a position in the document.
+func (d *document) position(p ast.Position) Position {
+	line := p.Line - 1
+	if line < 0 {
+		line = 0
+	}
+	char := 0
+	if line < len(d.lines) {
+		runes := []rune(d.lines[line])
+		n := p.Column - 1
+		if n > len(runes) {
+			n = len(runes)
+		}
+		if n > 0 {
+			char = len(utf16.Encode(runes[:n]))
+		}
+	}
+	return Position{Line: line, Character: char}
+}
+
+// sourcePosition converts a position in the document to a position in the Flux source.
+func (d *document) sourcePosition(p Position) ast.Position {
+	col := 1
+	if p.Line >= 0 && p.Line < len(d.lines) {
+		units := 0
+		for _, r := range d.lines[p.Line] {
+			if units >= p.Character {
+				break
+			}
+			units += len(utf16.Encode([]rune{r}))
+			col++
+		}
+	}
+	return ast.Position{Line: p.Line + 1, Column: col}
+}
+
+func (d *document) rangeOf(loc *ast.SourceLocation) Range {
+	start, end := sourceRange(loc)
+	return Range{Start: d.position(start), End: d.position(end)}
+}
+
+// sourceRange returns the start and end of a location.
+// The end is computed from the source of the location, as it may span several lines.
+func sourceRange(loc *ast.SourceLocation) (ast.Position, ast.Position) {
+	if loc.Source == nil {
+		return loc.Start, loc.End
+	}
+	lines := strings.Split(strings.TrimRightFunc(*loc.Source, unicode.IsSpace), "\n")
+	end := ast.Position{
+		Line:   loc.Start.Line + len(lines) - 1,
+		Column: 1,
+	}
+	if len(lines) == 1 {
+		end.Column = loc.Start.Column
+	}
+	end.Column += utf8.RuneCountInString(lines[len(lines)-1])
+	return loc.Start, end
+}
+
+// contains reports whether the position is within the node, or immediately follows it.
+func contains(n ast.Node, p ast.Position) bool {
+	loc := n.Location()
+	if loc == nil {
+		return false
+	}
+	start, end := sourceRange(loc)
+	return !before(p, start) && !before(end, p)
+}
+
+func before(a, b ast.Position) bool {
+	return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+}
+
+// lookupType returns the type of a declared name, or of a member of a declared object such as "strings.title".
+func (d *document) lookupType(name string) (semantic.Type, bool) {
+	parts := strings.Split(name, ".")
+	decl, err := d.completer.Declaration(parts[0])
+	if err != nil {
+		return nil, false
+	}
+	t := decl.InitType()
+	for _, p := range parts[1:] {
+		if !isComposite(t, semantic.Object) {
+			return nil, false
+		}
+		props := t.Properties()
+		pt, ok := props[p]
+		if !ok {
+			return nil, false
+		}
+		t = pt
+	}
+	return t, true
+}
+
+type functionType interface {
+	Params() map[string]semantic.Type
+}
+
+// signature formats the signature of a function, for example "filter(table=<-, fn: function) object".
+// Unknown types are omitted.
+func signature(name string, t semantic.Type) string {
+	var params []string
+	if ft, ok := t.(functionType); ok {
+		for k, pt := range ft.Params() {
+			if k == t.PipeArgument() {
+				continue
+			}
+			params = append(params, k+typeSuffix(pt))
+		}
+		sort.Strings(params)
+	}
+	if pipe := t.PipeArgument(); pipe != "" {
+		params = append([]string{pipe + "=<-"}, params...)
+	}
+	sig := name + "(" + strings.Join(params, ", ") + ")"
+	if rt := t.ReturnType(); rt != nil && rt.Kind() != semantic.Invalid {
+		sig += " " + typeString(rt)
+	}
+	return sig
+}
+
+// typeSuffix returns the type annotation of a parameter, which is omitted if the type is not known
+// such as for the parameters of functions declared in Flux.
+func typeSuffix(t semantic.Type) string {
+	if t == nil || t.Kind() == semantic.Invalid {
+		return ""
+	}
+	return ": " + typeString(t)
+}
+
+// typeString returns a short description of the type.
+func typeString(t semantic.Type) string {
+	if t == nil {
+		return semantic.Invalid.String()
+	}
+	if isComposite(t, semantic.Array) {
+		return "[" + typeString(t.ElementType()) + "]"
+	}
+	return t.Kind().String()
+}
+
+// isComposite reports whether the type is of the kind and describes its properties, elements or signature.
+// A bare kind used as a type, such as semantic.Array, does not.
+func isComposite(t semantic.Type, k semantic.Kind) bool {
+	_, bare := t.(semantic.Kind)
+	return !bare && t.Kind() == k
+}
+
+// describe returns the signature of a function or the name and type of any other value.
+func describe(name string, t semantic.Type) string {
+	if isComposite(t, semantic.Function) {
+		return signature(name, t)
+	}
+	return name + ": " + typeString(t)
+}
@@ -0,0 +1,338 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/influxdata/platform/query/ast"
+	"github.com/influxdata/platform/query/semantic"
+)
+
+// identRef is an identifier found in a document.
+type identRef struct {
+	ident *ast.Identifier
+	// name is the name of the identifier, qualified by its object for members such as "strings.title".
+	name string
+	// decl is the identifier declaring the name, it is nil for builtins and object members.
+	decl *ast.Identifier
+	// topLevel reports whether the name is declared by the program or is a builtin.
+	// Only the types of top level names are known to the document.
+	topLevel bool
+}
+
+// identFinder finds the identifier at a position in a program.
+type identFinder struct {
+	pos    ast.Position
+	scopes []map[string]*ast.Identifier
+	// keys are the property keys, which are not references to declarations.
+	keys map[*ast.Identifier]bool
+	// members are the properties of member expressions, mapped to the name of their object.
+	members map[*ast.Identifier]string
+	// declarations are the identifiers declaring a name.
+	declarations map[*ast.Identifier]bool
+	found        *identRef
+}
+
+func findIdent(program *ast.Program, pos ast.Position) *identRef {
+	f := &identFinder{
+		pos:          pos,
+		keys:         make(map[*ast.Identifier]bool),
+		members:      make(map[*ast.Identifier]string),
+		declarations: make(map[*ast.Identifier]bool),
+	}
+	ast.Walk(&scopeVisitor{f: f}, program)
+	return f.found
+}
+
+func (f *identFinder) declare(id *ast.Identifier) {
+	f.scopes[len(f.scopes)-1][id.Name] = id
+	f.declarations[id] = true
+}
+
+func (f *identFinder) lookup(name string) (*ast.Identifier, bool) {
+	for i := len(f.scopes) - 1; i >= 0; i-- {
+		if id, ok := f.scopes[i][name]; ok {
+			return id, i == 0
+		}
+	}
+	return nil, true
+}
+
+// scopeVisitor visits the nodes of a scope, the scope is popped once the node opening it is done.
+type scopeVisitor struct {
+	f   *identFinder
+	pop bool
+}
+
+func (v *scopeVisitor) Visit(node ast.Node) ast.Visitor {
+	f := v.f
+	if f.found != nil {
+		return nil
+	}
+	switch n := node.(type) {
+	case *ast.Program, *ast.BlockStatement:
+		f.scopes = append(f.scopes, make(map[string]*ast.Identifier))
+		return &scopeVisitor{f: f, pop: true}
+	case *ast.ArrowFunctionExpression:
+		f.scopes = append(f.scopes, make(map[string]*ast.Identifier))
+		for _, p := range n.Params {
+			f.declare(p.Key)
+			f.keys[p.Key] = true
+		}
+		return &scopeVisitor{f: f, pop: true}
+	case *ast.PackageClause:
+		f.keys[n.Name] = true
+	case *ast.ImportDeclaration:
+		if n.As != nil {
+			f.declare(n.As)
+		}
+	case *ast.VariableDeclarator:
+		f.declare(n.ID)
+	case *ast.ObjectExpression:
+		for _, p := range n.Properties {
+			f.keys[p.Key] = true
+		}
+	case *ast.MemberExpression:
+		if prop, ok := n.Property.(*ast.Identifier); ok {
+			if obj, ok := n.Object.(*ast.Identifier); ok {
+				f.members[prop] = obj.Name
+			} else {
+				f.keys[prop] = true
+			}
+		}
+	case *ast.Identifier:
+		if contains(n, f.pos) {
+			f.found = f.ref(n)
+		}
+		return nil
+	}
+	return &scopeVisitor{f: f}
+}
+
+func (v *scopeVisitor) Done() {
+	if v.pop {
+		v.f.scopes = v.f.scopes[:len(v.f.scopes)-1]
+	}
+}
+
+// ref returns the reference for the identifier, or nil if the identifier does not refer to a name.
+func (f *identFinder) ref(id *ast.Identifier) *identRef {
+	if obj, ok := f.members[id]; ok {
+		_, topLevel := f.lookup(obj)
+		return &identRef{ident: id, name: obj + "." + id.Name, topLevel: topLevel}
+	}
+	if f.declarations[id] {
+		return &identRef{ident: id, name: id.Name, decl: id, topLevel: len(f.scopes) == 1}
+	}
+	if f.keys[id] {
+		return nil
+	}
+	decl, topLevel := f.lookup(id.Name)
+	return &identRef{ident: id, name: id.Name, decl: decl, topLevel: topLevel}
+}
+
+// identAt returns the identifier at the position, or nil if there is none.
+func (d *document) identAt(p Position) *identRef {
+	if d.program == nil {
+		return nil
+	}
+	return findIdent(d.program, d.sourcePosition(p))
+}
+
+func (d *document) hover(p Position) *Hover {
+	ref := d.identAt(p)
+	if ref == nil || !ref.topLevel {
+		return nil
+	}
+	t, ok := d.lookupType(ref.name)
+	if !ok {
+		return nil
+	}
+	r := d.rangeOf(ref.ident.Location())
+	return &Hover{
+		Contents: markupContent{
+			Kind:  "markdown",
+			Value: "```flux\n" + describe(ref.name, t) + "\n```",
+		},
+		Range: &r,
+	}
+}
+
+func (d *document) definition(p Position) *Location {
+	ref := d.identAt(p)
+	if ref == nil || ref.decl == nil {
+		return nil
+	}
+	return &Location{
+		URI:   d.uri,
+		Range: d.rangeOf(ref.decl.Location()),
+	}
+}
+
+// completion suggests the members of an object after a dot,
+// the parameters of a function within the arguments of a call, or the declared names otherwise.
+// Completion works on the text of the document, as the document is usually incomplete while editing.
+func (d *document) completion(p Position) []CompletionItem {
+	text := d.textBefore(p)
+	prefix := text[len(strings.TrimRightFunc(text, isIdentRune)):]
+	text = text[:len(text)-len(prefix)]
+
+	if strings.HasSuffix(text, ".") {
+		obj := trailingName(text[:len(text)-1])
+		return d.memberCompletion(obj, prefix)
+	}
+	if callee, ok := argumentCallee(text); ok {
+		return d.parameterCompletion(callee, prefix)
+	}
+	return d.nameCompletion(prefix)
+}
+
+func (d *document) memberCompletion(obj, prefix string) []CompletionItem {
+	items := []CompletionItem{}
+	if t, ok := d.lookupType(obj); !ok || !isComposite(t, semantic.Object) {
+		return items
+	}
+	members, err := d.completer.Members(obj)
+	if err != nil {
+		return items
+	}
+	for _, m := range members {
+		if !strings.HasPrefix(m, prefix) {
+			continue
+		}
+		t, ok := d.lookupType(obj + "." + m)
+		if !ok {
+			continue
+		}
+		items = append(items, CompletionItem{
+			Label:  m,
+			Kind:   completionKind(t, CompletionField),
+			Detail: describe(m, t),
+		})
+	}
+	return items
+}
+
+func (d *document) parameterCompletion(callee, prefix string) []CompletionItem {
+	items := []CompletionItem{}
+	t, ok := d.lookupType(callee)
+	if !ok || !isComposite(t, semantic.Function) {
+		return items
+	}
+	ft, ok := t.(functionType)
+	if !ok {
+		return items
+	}
+	params := ft.Params()
+	names := make([]string, 0, len(params))
+	for k := range params {
+		if k != t.PipeArgument() && strings.HasPrefix(k, prefix) {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		items = append(items, CompletionItem{
+			Label:      k,
+			Kind:       CompletionField,
+			Detail:     k + ": " + typeString(params[k]),
+			InsertText: k + ": ",
+		})
+	}
+	return items
+}
+
+func (d *document) nameCompletion(prefix string) []CompletionItem {
+	items := []CompletionItem{}
+	names := make([]string, 0, len(d.declarations))
+	for name := range d.declarations {
+		if strings.HasPrefix(name, prefix) && !strings.HasPrefix(name, "_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := d.declarations[name].InitType()
+		items = append(items, CompletionItem{
+			Label:  name,
+			Kind:   completionKind(t, CompletionVariable),
+			Detail: describe(name, t),
+		})
+	}
+	return items
+}
+
+func completionKind(t semantic.Type, kind CompletionItemKind) CompletionItemKind {
+	switch t.Kind() {
+	case semantic.Function:
+		return CompletionFunction
+	case semantic.Object:
+		return CompletionModule
+	default:
+		return kind
+	}
+}
+
+// textBefore returns the text of the document preceding the position.
+func (d *document) textBefore(p Position) string {
+	if p.Line >= len(d.lines) {
+		return strings.Join(d.lines, "\n")
+	}
+	col := d.sourcePosition(p).Column - 1
+	line := []rune(d.lines[p.Line])
+	if col > len(line) {
+		col = len(line)
+	}
+	return strings.Join(append(d.lines[:p.Line:p.Line], string(line[:col])), "\n")
+}
+
+// argumentCallee returns the name of the function called when the text ends at the start of an argument name.
+func argumentCallee(text string) (string, bool) {
+	trimmed := strings.TrimRight(text, " \t\r\n")
+	if !strings.HasSuffix(trimmed, "(") && !strings.HasSuffix(trimmed, ",") {
+		return "", false
+	}
+	// Find the unclosed parenthesis of the call, skipping nested expressions and strings.
+	depth := 0
+	inString := false
+	for i := len(trimmed) - 1; i >= 0; i-- {
+		c := trimmed[i]
+		if inString {
+			if c == '"' && (i == 0 || trimmed[i-1] != '\\') {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case ')', ']', '}':
+			depth++
+		case '[', '{':
+			if depth == 0 {
+				return "", false
+			}
+			depth--
+		case '(':
+			if depth == 0 {
+				callee := trailingName(trimmed[:i])
+				return callee, callee != ""
+			}
+			depth--
+		}
+	}
+	return "", false
+}
+
+// trailingName returns the possibly dotted name, such as "strings.title", at the end of the text.
+func trailingName(text string) string {
+	name := text[len(strings.TrimRightFunc(text, func(r rune) bool {
+		return isIdentRune(r) || r == '.'
+	})):]
+	return strings.Trim(name, ".")
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,194 @@
+// Package lsp implements a Language Server Protocol server for Flux.
+//
+// The server provides diagnostics, completion, hover and go to definition for Flux documents,
+// communicating with the client using JSON-RPC messages over a reader and a writer, typically stdin and stdout.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Server is a Flux language server.
+type Server struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	documents map[string]*document
+	shutdown  bool
+}
+
+// NewServer creates a server reading requests from in and writing responses to out.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: make(map[string]*document),
+	}
+}
+
+// Serve handles messages until the client exits or the input is closed.
+// An error is returned if the client exits without requesting a shutdown first.
+func (s *Server) Serve() error {
+	for {
+		content, err := readMessage(s.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			if err := s.replyError(nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit without shutdown")
+			}
+			return nil
+		}
+		if err := s.handle(&req); err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles a request or a notification, an error is returned only if a message cannot be written.
+func (s *Server) handle(req *request) error {
+	result, err := s.dispatch(req)
+	if req.ID == nil {
+		// Notifications have no response, invalid notifications are dropped.
+		if _, ok := err.(*responseError); ok {
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		rerr, ok := err.(*responseError)
+		if !ok {
+			rerr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		return s.replyError(req.ID, rerr)
+	}
+	return writeMessage(s.out, response{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *Server) replyError(id *json.RawMessage, err *responseError) error {
+	return writeMessage(s.out, errorResponse{JSONRPC: "2.0", ID: id, Error: err})
+}
+
+func (s *Server) dispatch(req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncFull,
+				CompletionProvider: completionOptions{TriggerCharacters: []string{".", "(", ","}},
+				HoverProvider:      true,
+				DefinitionProvider: true,
+			},
+		}, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// Documents are synced in full, the last change has the whole content of the document.
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, s.update(params.TextDocument.URI, text)
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, s.publishDiagnostics(params.TextDocument.URI, []Diagnostic{})
+	case "textDocument/completion":
+		d, params, err := s.positionParams(req)
+		if err != nil {
+			return nil, err
+		}
+		return d.completion(params.Position), nil
+	case "textDocument/hover":
+		d, params, err := s.positionParams(req)
+		if err != nil {
+			return nil, err
+		}
+		if h := d.hover(params.Position); h != nil {
+			return h, nil
+		}
+		return nil, nil
+	case "textDocument/definition":
+		d, params, err := s.positionParams(req)
+		if err != nil {
+			return nil, err
+		}
+		if l := d.definition(params.Position); l != nil {
+			return l, nil
+		}
+		return nil, nil
+	default:
+		if req.ID == nil {
+			// Unknown notifications, such as initialized, are ignored.
+			return nil, nil
+		}
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+func decodeParams(req *request, params interface{}) error {
+	if err := json.Unmarshal(req.Params, params); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) positionParams(req *request) (*document, *textDocumentPositionParams, error) {
+	var params textDocumentPositionParams
+	if err := decodeParams(req, &params); err != nil {
+		return nil, nil, err
+	}
+	d, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil, nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown document: %s", params.TextDocument.URI)}
+	}
+	return d, &params, nil
+}
+
+// update analyzes the new text of a document and publishes its diagnostics.
+func (s *Server) update(uri, text string) error {
+	d := newDocument(uri, text, s.documents[uri])
+	s.documents[uri] = d
+	diagnostics := d.diagnostics
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	return s.publishDiagnostics(uri, diagnostics)
+}
+
+func (s *Server) publishDiagnostics(uri string, diagnostics []Diagnostic) error {
+	return writeMessage(s.out, notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+}
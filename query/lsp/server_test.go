@@ -0,0 +1,498 @@
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/lsp"
+)
+
+const uri = "file:///query.flux"
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int            `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  interface{}     `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func request(id int, method string, params interface{}) message {
+	return message{JSONRPC: "2.0", ID: &id, Method: method, Params: params}
+}
+
+func notification(method string, params interface{}) message {
+	return message{JSONRPC: "2.0", Method: method, Params: params}
+}
+
+func didOpen(text string) message {
+	return notification("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{
+			"uri":        uri,
+			"languageId": "flux",
+			"version":    1,
+			"text":       text,
+		},
+	})
+}
+
+func didChange(text string) message {
+	return notification("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 2},
+		"contentChanges": []map[string]string{{"text": text}},
+	})
+}
+
+func positionParams(line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+		"position":     lsp.Position{Line: line, Character: character},
+	}
+}
+
+// serve runs a server with the messages as input and returns the messages it writes.
+func serve(t *testing.T, msgs ...message) []message {
+	t.Helper()
+	var in bytes.Buffer
+	for _, msg := range msgs {
+		content, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+	var out bytes.Buffer
+	if err := lsp.NewServer(&in, &out).Serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	var written []message
+	r := bufio.NewReader(&out)
+	for {
+		header, err := textproto.NewReader(r).ReadMIMEHeader()
+		if err == io.EOF {
+			return written
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		length, err := strconv.Atoi(header.Get("Content-Length"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := make([]byte, length)
+		if _, err := io.ReadFull(r, content); err != nil {
+			t.Fatal(err)
+		}
+		var msg message
+		if err := json.Unmarshal(content, &msg); err != nil {
+			t.Fatal(err)
+		}
+		written = append(written, msg)
+	}
+}
+
+// result returns the result of the response to the request with the id.
+func result(t *testing.T, msgs []message, id int, v interface{}) {
+	t.Helper()
+	for _, msg := range msgs {
+		if msg.ID == nil || *msg.ID != id {
+			continue
+		}
+		if msg.Error != nil {
+			t.Fatalf("unexpected error response: %s", msg.Error.Message)
+		}
+		if err := json.Unmarshal(msg.Result, v); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	t.Fatalf("no response to request %d", id)
+}
+
+func diagnostics(t *testing.T, msgs []message) [][]lsp.Diagnostic {
+	t.Helper()
+	var published [][]lsp.Diagnostic
+	for _, msg := range msgs {
+		if msg.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		content, err := json.Marshal(msg.Params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var params struct {
+			URI         string           `json:"uri"`
+			Diagnostics []lsp.Diagnostic `json:"diagnostics"`
+		}
+		if err := json.Unmarshal(content, &params); err != nil {
+			t.Fatal(err)
+		}
+		if params.URI != uri {
+			t.Errorf("unexpected diagnostics uri: got %q", params.URI)
+		}
+		published = append(published, params.Diagnostics)
+	}
+	return published
+}
+
+func TestServer_Initialize(t *testing.T) {
+	msgs := serve(t,
+		request(1, "initialize", map[string]interface{}{}),
+		notification("initialized", map[string]interface{}{}),
+		request(2, "unknown", nil),
+		request(3, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	var got struct {
+		Capabilities struct {
+			TextDocumentSync   int  `json:"textDocumentSync"`
+			HoverProvider      bool `json:"hoverProvider"`
+			DefinitionProvider bool `json:"definitionProvider"`
+		} `json:"capabilities"`
+	}
+	result(t, msgs, 1, &got)
+	if got.Capabilities.TextDocumentSync != 1 || !got.Capabilities.HoverProvider || !got.Capabilities.DefinitionProvider {
+		t.Errorf("unexpected capabilities: %+v", got.Capabilities)
+	}
+	if len(msgs) != 3 {
+		t.Fatalf("unexpected number of responses: got %d want 3", len(msgs))
+	}
+	if msgs[1].Error == nil || msgs[1].Error.Code != -32601 {
+		t.Errorf("expected method not found error, got %+v", msgs[1].Error)
+	}
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	in := bytes.NewBufferString("Content-Length: 33\r\n\r\n{\"jsonrpc\":\"2.0\",\"method\":\"exit\"}")
+	if err := lsp.NewServer(in, new(bytes.Buffer)).Serve(); err == nil {
+		t.Error("expected an error when exiting without shutdown")
+	}
+}
+
+func TestServer_ContentLength(t *testing.T) {
+	for _, length := range []string{"-1", "1099511627776", "nope"} {
+		in := bytes.NewBufferString("Content-Length: " + length + "\r\n\r\n{}")
+		if err := lsp.NewServer(in, new(bytes.Buffer)).Serve(); err == nil {
+			t.Errorf("expected an error for Content-Length %s", length)
+		}
+	}
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []lsp.Diagnostic
+	}{
+		{
+			name: "valid",
+			text: `from(bucket: "telegraf") |> range(start: -5m)`,
+			want: []lsp.Diagnostic{},
+		},
+		{
+			name: "syntax error",
+			text: "a = 1\nb = (",
+			want: []lsp.Diagnostic{{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 1, Character: 5},
+					End:   lsp.Position{Line: 1, Character: 5},
+				},
+				Severity: lsp.SeverityError,
+				Source:   "flux",
+				Message:  `no match found, expected: "(", ")", "-", "/", "//", "0", "<-", "[", "\"", "false", "if", "not", "true", "{", [ \t\r\n], [0-9], [1-9] or [_\pL]`,
+			}},
+		},
+		{
+			name: "type error",
+			text: "a = 1\nb = a + \"s\"",
+			want: []lsp.Diagnostic{{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 1, Character: 4},
+					End:   lsp.Position{Line: 1, Character: 11},
+				},
+				Severity: lsp.SeverityError,
+				Source:   "flux",
+				Message:  "invalid binary operator + for types int and string",
+			}},
+		},
+		{
+			name: "unknown import",
+			text: "import \"unknown\"\n\na = 1",
+			want: []lsp.Diagnostic{{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 0, Character: 0},
+					End:   lsp.Position{Line: 0, Character: 16},
+				},
+				Severity: lsp.SeverityError,
+				Source:   "flux",
+				Message:  `unknown package "unknown"`,
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msgs := serve(t, didOpen(tc.text))
+			got := diagnostics(t, msgs)
+			if len(got) != 1 {
+				t.Fatalf("unexpected number of published diagnostics: got %d want 1", len(got))
+			}
+			if !cmp.Equal(tc.want, got[0]) {
+				t.Errorf("unexpected diagnostics -want/+got\n%s", cmp.Diff(tc.want, got[0]))
+			}
+		})
+	}
+}
+
+func TestServer_DidClose(t *testing.T) {
+	msgs := serve(t,
+		didOpen("a = 1 +"),
+		notification("textDocument/didClose", map[string]interface{}{
+			"textDocument": map[string]string{"uri": uri},
+		}),
+		request(1, "textDocument/hover", positionParams(0, 0)),
+	)
+	got := diagnostics(t, msgs)
+	if len(got) != 2 || len(got[0]) == 0 || len(got[1]) != 0 {
+		t.Errorf("expected diagnostics to be cleared when the document is closed, got %v", got)
+	}
+	if last := msgs[len(msgs)-1]; last.Error == nil {
+		t.Error("expected an error for a request on a closed document")
+	}
+}
+
+func TestServer_Completion(t *testing.T) {
+	testCases := []struct {
+		name string
+		// previous is the last version of the document that could be parsed, if any.
+		previous string
+		text     string
+		position lsp.Position
+		want     []string
+	}{
+		{
+			name:     "names",
+			text:     "fi",
+			position: lsp.Position{Line: 0, Character: 2},
+			want:     []string{"filter", "first"},
+		},
+		{
+			name:     "declared names",
+			text:     "celsius = 20.0\nfahrenheit = 68.0\nc",
+			position: lsp.Position{Line: 2, Character: 1},
+			want:     []string{"celsius", "count", "cov", "covariance", "cumulativeSum"},
+		},
+		{
+			name:     "members",
+			previous: "import \"math\"\n\nmath.pi",
+			text:     "import \"math\"\n\nmath.",
+			position: lsp.Position{Line: 2, Character: 5},
+			want:     []string{"abs", "clamp", "e", "maxInt", "minInt", "pi"},
+		},
+		{
+			name:     "members with prefix",
+			previous: "import \"math\"\n\nmath.pi",
+			text:     "import \"math\"\n\nmath.m",
+			position: lsp.Position{Line: 2, Character: 6},
+			want:     []string{"maxInt", "minInt"},
+		},
+		{
+			name:     "parameters",
+			text:     `from(bucket: "telegraf") |> range(`,
+			position: lsp.Position{Line: 0, Character: 34},
+			want:     []string{"start", "stop"},
+		},
+		{
+			name:     "parameters after argument",
+			text:     "from(bucket: \"telegraf\")\n    |> range(start: -5m, s",
+			position: lsp.Position{Line: 1, Character: 26},
+			want:     []string{"start", "stop"},
+		},
+		{
+			name:     "package function parameters",
+			previous: "import \"math\"\n\nmath.pi",
+			text:     "import \"math\"\n\nmath.clamp(x: 1.0, ",
+			position: lsp.Position{Line: 2, Character: 19},
+			want:     []string{"max", "min", "x"},
+		},
+		{
+			name:     "argument value",
+			previous: "celsius = 20.0\n",
+			text:     "celsius = 20.0\nfrom(bucket: cel",
+			position: lsp.Position{Line: 1, Character: 16},
+			want:     []string{"celsius"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			open := []message{didOpen(tc.text)}
+			if tc.previous != "" {
+				open = []message{didOpen(tc.previous), didChange(tc.text)}
+			}
+			msgs := serve(t, append(open,
+				request(1, "textDocument/completion", positionParams(tc.position.Line, tc.position.Character)),
+			)...)
+			var items []lsp.CompletionItem
+			result(t, msgs, 1, &items)
+			got := make([]string, len(items))
+			for i, item := range items {
+				got[i] = item.Label
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected completion -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestServer_Hover(t *testing.T) {
+	text := `import "math"
+
+celsius = 20.0
+toF = (c) => c * 9.0 / 5.0 + 32.0
+f = toF(c: celsius)
+x = math.abs(x: -1.0)
+from(bucket: "telegraf")
+    |> range(start: -5m)
+`
+	testCases := []struct {
+		name     string
+		position lsp.Position
+		want     string
+	}{
+		{
+			name:     "variable",
+			position: lsp.Position{Line: 2, Character: 3},
+			want:     "celsius: float",
+		},
+		{
+			name:     "variable reference",
+			position: lsp.Position{Line: 4, Character: 14},
+			want:     "celsius: float",
+		},
+		{
+			name:     "user function",
+			position: lsp.Position{Line: 4, Character: 5},
+			want:     "toF(c)",
+		},
+		{
+			name:     "builtin function",
+			position: lsp.Position{Line: 7, Character: 8},
+			want:     "range(table=<-, start: time, stop: time) object",
+		},
+		{
+			name:     "package",
+			position: lsp.Position{Line: 5, Character: 5},
+			want:     "math: object",
+		},
+		{
+			name:     "package function",
+			position: lsp.Position{Line: 5, Character: 10},
+			want:     "math.abs(x)",
+		},
+		{
+			name:     "argument name",
+			position: lsp.Position{Line: 6, Character: 7},
+		},
+		{
+			name:     "function parameter",
+			position: lsp.Position{Line: 3, Character: 13},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msgs := serve(t,
+				didOpen(text),
+				request(1, "textDocument/hover", positionParams(tc.position.Line, tc.position.Character)),
+			)
+			var got *lsp.Hover
+			result(t, msgs, 1, &got)
+			if tc.want == "" {
+				if got != nil {
+					t.Errorf("unexpected hover %q", got.Contents.Value)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("expected a hover")
+			}
+			if want := "```flux\n" + tc.want + "\n```"; got.Contents.Value != want {
+				t.Errorf("unexpected hover: got %q want %q", got.Contents.Value, want)
+			}
+		})
+	}
+}
+
+func TestServer_Definition(t *testing.T) {
+	text := `celsius = 20.0
+toF = (c) => {
+    f = c * 9.0 / 5.0
+    return f + 32.0
+}
+f = toF(c: celsius)
+range(start: -5m)
+`
+	testCases := []struct {
+		name     string
+		position lsp.Position
+		want     *lsp.Range
+	}{
+		{
+			name:     "variable",
+			position: lsp.Position{Line: 5, Character: 13},
+			want:     &lsp.Range{Start: lsp.Position{Line: 0, Character: 0}, End: lsp.Position{Line: 0, Character: 7}},
+		},
+		{
+			name:     "function",
+			position: lsp.Position{Line: 5, Character: 4},
+			want:     &lsp.Range{Start: lsp.Position{Line: 1, Character: 0}, End: lsp.Position{Line: 1, Character: 3}},
+		},
+		{
+			name:     "parameter",
+			position: lsp.Position{Line: 2, Character: 8},
+			want:     &lsp.Range{Start: lsp.Position{Line: 1, Character: 7}, End: lsp.Position{Line: 1, Character: 8}},
+		},
+		{
+			name:     "shadowed variable",
+			position: lsp.Position{Line: 3, Character: 11},
+			want:     &lsp.Range{Start: lsp.Position{Line: 2, Character: 4}, End: lsp.Position{Line: 2, Character: 5}},
+		},
+		{
+			name:     "builtin",
+			position: lsp.Position{Line: 6, Character: 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msgs := serve(t,
+				didOpen(text),
+				request(1, "textDocument/definition", positionParams(tc.position.Line, tc.position.Character)),
+			)
+			var got *lsp.Location
+			result(t, msgs, 1, &got)
+			if tc.want == nil {
+				if got != nil {
+					t.Errorf("unexpected definition %+v", got)
+				}
+				return
+			}
+			want := &lsp.Location{URI: uri, Range: *tc.want}
+			if !cmp.Equal(want, got) {
+				t.Errorf("unexpected definition -want/+got\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
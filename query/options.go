@@ -0,0 +1,138 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/platform/query/interpreter"
+	"github.com/influxdata/platform/query/semantic"
+	"github.com/influxdata/platform/query/values"
+	"github.com/pkg/errors"
+)
+
+const (
+	NowOption      = "now"
+	LocationOption = "location"
+	TaskOption     = "task"
+)
+
+// SetOptionFunc applies the value of an option to the spec.
+// The value is guaranteed to be of the kind the option was registered with.
+type SetOptionFunc func(spec *Spec, v values.Value) error
+
+type option struct {
+	kind semantic.Kind
+	set  SetOptionFunc
+}
+
+// list of options by name
+var builtinOptions = make(map[string]option)
+
+// RegisterOption adds an option that can be set with an option statement.
+// The value of the option must be of the given kind, f applies it to the spec produced by Compile.
+func RegisterOption(name string, kind semantic.Kind, f SetOptionFunc) {
+	if finalized {
+		panic(errors.New("already finalized, cannot register option"))
+	}
+	if _, ok := builtinOptions[name]; ok {
+		panic(fmt.Errorf("duplicate registration for option %q", name))
+	}
+	builtinOptions[name] = option{
+		kind: kind,
+		set:  f,
+	}
+}
+
+func init() {
+	RegisterOption(NowOption, semantic.Time, setNowOption)
+	RegisterOption(LocationOption, semantic.String, setLocationOption)
+	RegisterOption(TaskOption, semantic.Object, setTaskOption)
+}
+
+// applyOptions sets the options recorded on the scope on the spec.
+func applyOptions(spec *Spec, scope *interpreter.Scope) error {
+	for name, v := range scope.Options() {
+		o, ok := builtinOptions[name]
+		if !ok {
+			return fmt.Errorf("unknown option %q", name)
+		}
+		if k := v.Type().Kind(); k != o.kind {
+			return fmt.Errorf("option %q must be of kind %v, got %v", name, o.kind, k)
+		}
+		if err := o.set(spec, v); err != nil {
+			return errors.Wrapf(err, "invalid option %q", name)
+		}
+	}
+	return nil
+}
+
+func setNowOption(spec *Spec, v values.Value) error {
+	spec.Now = v.Time().Time()
+	return nil
+}
+
+func setLocationOption(spec *Spec, v values.Value) error {
+	name := v.Str()
+	if _, err := time.LoadLocation(name); err != nil {
+		return err
+	}
+	spec.Location = name
+	return nil
+}
+
+// TaskOptions are the options of a task, they determine when the task is run.
+type TaskOptions struct {
+	// Name is the name of the task.
+	Name string `json:"name"`
+	// Every is the interval at which the task is run.
+	Every Duration `json:"every,omitempty"`
+	// Cron is a cron expression describing when the task is run.
+	Cron string `json:"cron,omitempty"`
+	// Delay is how long to wait after the scheduled time before the task is run.
+	Delay Duration `json:"delay,omitempty"`
+}
+
+func setTaskOption(spec *Spec, v values.Value) error {
+	task := new(TaskOptions)
+	var err error
+	v.Object().Range(func(name string, v values.Value) {
+		if err != nil {
+			return
+		}
+		var want semantic.Kind
+		switch name {
+		case "name", "cron":
+			want = semantic.String
+		case "every", "delay":
+			want = semantic.Duration
+		default:
+			err = fmt.Errorf("unknown property %q", name)
+			return
+		}
+		if k := v.Type().Kind(); k != want {
+			err = fmt.Errorf("property %q must be of kind %v, got %v", name, want, k)
+			return
+		}
+		switch name {
+		case "name":
+			task.Name = v.Str()
+		case "cron":
+			task.Cron = v.Str()
+		case "every":
+			task.Every = Duration(v.Duration())
+		case "delay":
+			task.Delay = Duration(v.Duration())
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if task.Name == "" {
+		return errors.New("missing required property \"name\"")
+	}
+	if (task.Every == 0) == (task.Cron == "") {
+		return errors.New("exactly one of the properties \"every\" and \"cron\" must be set")
+	}
+	spec.Task = task
+	return nil
+}
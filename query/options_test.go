@@ -0,0 +1,117 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+)
+
+func TestCompile_Options(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		now      time.Time
+		location string
+		task     *query.TaskOptions
+		wantErr  bool
+	}{
+		{
+			name:  "no options",
+			query: `from(db:"mydb")`,
+		},
+		{
+			name: "now",
+			query: `option now = 2018-05-22T19:53:26Z
+			from(db:"mydb") |> range(start:-1h)`,
+			now: time.Date(2018, 5, 22, 19, 53, 26, 0, time.UTC),
+		},
+		{
+			name: "now is in scope",
+			query: `option now = 2018-05-22T19:53:26Z
+			from(db:"mydb") |> range(start: now)`,
+			now: time.Date(2018, 5, 22, 19, 53, 26, 0, time.UTC),
+		},
+		{
+			name: "location",
+			query: `option location = "UTC"
+			from(db:"mydb")`,
+			location: "UTC",
+		},
+		{
+			name: "task with every",
+			query: `option task = {name: "foo", every: 1h, delay: 10m}
+			from(db:"mydb")`,
+			task: &query.TaskOptions{
+				Name:  "foo",
+				Every: query.Duration(time.Hour),
+				Delay: query.Duration(10 * time.Minute),
+			},
+		},
+		{
+			name: "task with cron",
+			query: `option task = {name: "foo", cron: "0 * * * *"}
+			from(db:"mydb")`,
+			task: &query.TaskOptions{
+				Name: "foo",
+				Cron: "0 * * * *",
+			},
+		},
+		{
+			name: "task with every and cron",
+			query: `option task = {name: "foo", every: 1h, cron: "0 * * * *"}
+			from(db:"mydb")`,
+			wantErr: true,
+		},
+		{
+			name: "task without name",
+			query: `option task = {every: 1h}
+			from(db:"mydb")`,
+			wantErr: true,
+		},
+		{
+			name: "unknown option",
+			query: `option foo = 1
+			from(db:"mydb")`,
+			wantErr: true,
+		},
+		{
+			name: "wrong kind",
+			query: `option now = "yesterday"
+			from(db:"mydb")`,
+			wantErr: true,
+		},
+		{
+			name: "unknown location",
+			query: `option location = "Nowhere/Special"
+			from(db:"mydb")`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := query.Compile(context.Background(), tc.query)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatal(err)
+				}
+				return
+			} else if tc.wantErr {
+				t.Fatal("expected error")
+			}
+			if !spec.Now.Equal(tc.now) {
+				t.Errorf("unexpected now: want %v got %v", tc.now, spec.Now)
+			}
+			if spec.Location != tc.location {
+				t.Errorf("unexpected location: want %q got %q", tc.location, spec.Location)
+			}
+			if !cmp.Equal(tc.task, spec.Task) {
+				t.Errorf("unexpected task options -want/+got\n%s", cmp.Diff(tc.task, spec.Task))
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package query
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/platform/query/ast"
+	"github.com/influxdata/platform/query/interpreter"
+	"github.com/influxdata/platform/query/parser"
+	"github.com/influxdata/platform/query/semantic"
+	"github.com/influxdata/platform/query/values"
+	"github.com/pkg/errors"
+)
+
+// builtinPackage is a package of built-in values and scripts that can be imported by its path.
+type builtinPackage struct {
+	values  map[string]values.Value
+	scripts []string
+}
+
+// list of builtin packages by path
+var builtinPackages = make(map[string]*builtinPackage)
+
+func lookupBuiltinPackage(path string) *builtinPackage {
+	if finalized {
+		panic(errors.New("already finalized, cannot register builtin"))
+	}
+	p, ok := builtinPackages[path]
+	if !ok {
+		p = &builtinPackage{
+			values: make(map[string]values.Value),
+		}
+		builtinPackages[path] = p
+	}
+	return p
+}
+
+// PackageName returns the name under which the package with the given import path is imported by default.
+// It is the last element of the path.
+func PackageName(importPath string) string {
+	return path.Base(importPath)
+}
+
+// RegisterPackageBuiltIn adds a script to the package with the given import path.
+// The script must begin with a package clause naming the package.
+// All scripts of a package are evaluated in the same scope, and may import other packages.
+// Variables whose name does not begin with an underscore are exported as members of the package.
+func RegisterPackageBuiltIn(path, script string) {
+	p := lookupBuiltinPackage(path)
+	p.scripts = append(p.scripts, script)
+}
+
+// packageClause matches the package clause of a package script.
+var packageClause = regexp.MustCompile(`(?m)^package \w+$`)
+
+// RegisterDeprecatedGlobalBuiltIn adds a package script, without its package clause, as a builtin
+// of the global scope, so that functions which were global before moving into the package
+// remain callable without importing it.
+// The global functions are deprecated and are removed in the next release; queries should import the package.
+func RegisterDeprecatedGlobalBuiltIn(name, script string) {
+	RegisterBuiltIn(name, packageClause.ReplaceAllString(script, ""))
+}
+
+// RegisterPackageFunction adds a new function to the package with the given import path.
+func RegisterPackageFunction(path, name string, c CreateOperationSpec, sig semantic.FunctionSignature) {
+	f := function{
+		t:            semantic.NewFunctionType(sig),
+		name:         name,
+		createOpSpec: c,
+	}
+	RegisterPackageValue(path, name, f)
+}
+
+// RegisterPackageValue adds the value to the package with the given import path.
+func RegisterPackageValue(path, name string, v values.Value) {
+	p := lookupBuiltinPackage(path)
+	if _, ok := p.values[name]; ok {
+		panic(fmt.Errorf("duplicate registration for %q in package %q", name, path))
+	}
+	p.values[name] = v
+}
+
+// BuiltInPackages returns the members of every builtin package keyed by the import path of the package.
+func BuiltInPackages() map[string]values.Object {
+	_, _, imp := BuiltInsWithImporter()
+	pkgs := make(map[string]values.Object, len(builtinPackages))
+	for path := range builtinPackages {
+		obj, err := imp.importPackage(path)
+		if err != nil {
+			panic(err)
+		}
+		pkgs[path] = obj
+	}
+	return pkgs
+}
+
+// PackageImporter evaluates the builtin packages imported by Flux programs.
+// Each package is evaluated at most once per importer.
+type PackageImporter struct {
+	qd       *queryDomain
+	scope    map[string]values.Value
+	decls    semantic.DeclarationScope
+	packages map[string]values.Object
+}
+
+func newPackageImporter(qd *queryDomain, scope map[string]values.Value, decls semantic.DeclarationScope) *PackageImporter {
+	// Copy the builtins so that imports of the program are not visible to the packages.
+	s := make(map[string]values.Value, len(scope))
+	for k, v := range scope {
+		s[k] = v
+	}
+	return &PackageImporter{
+		qd:       qd,
+		scope:    s,
+		decls:    decls.Copy(),
+		packages: make(map[string]values.Object),
+	}
+}
+
+// ResolveImports adds the packages imported by the program to the scope and declarations.
+// An import binds the package to its name, or to the alias of the import if present.
+func (imp *PackageImporter) ResolveImports(prog *ast.Program, scope *interpreter.Scope, decls semantic.DeclarationScope) error {
+	for _, d := range prog.Imports {
+		obj, err := imp.importPackage(d.Path.Value)
+		if err != nil {
+			return err
+		}
+		name := PackageName(d.Path.Value)
+		if d.As != nil {
+			name = d.As.Name
+		}
+		decls[name] = semantic.NewExternalVariableDeclaration(name, obj.Type())
+		scope.Set(name, obj)
+	}
+	return nil
+}
+
+func (imp *PackageImporter) importPackage(path string) (values.Object, error) {
+	if obj, ok := imp.packages[path]; ok {
+		if obj == nil {
+			return nil, fmt.Errorf("import cycle not allowed involving package %q", path)
+		}
+		return obj, nil
+	}
+	p, ok := builtinPackages[path]
+	if !ok {
+		return nil, fmt.Errorf("unknown package %q", path)
+	}
+	// Mark the package as being imported in order to detect cycles.
+	imp.packages[path] = nil
+
+	// Imports are shared by all scripts of the package and are not exported.
+	importScope := interpreter.NewScopeWithValues(imp.scope).Nest()
+	scope := importScope.Nest()
+	decls := imp.decls.Copy()
+	var names []string
+	for k, v := range p.values {
+		scope.Set(k, bindDomain(v, imp.qd))
+		decls[k] = semantic.NewExternalVariableDeclaration(k, v.Type())
+		names = append(names, k)
+	}
+
+	name := PackageName(path)
+	for _, script := range p.scripts {
+		astProg, err := parser.NewAST(script)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse package %q", path)
+		}
+		if astProg.Package == nil || astProg.Package.Name.Name != name {
+			return nil, fmt.Errorf("script of package %q must begin with \"package %s\"", path, name)
+		}
+		if err := imp.ResolveImports(astProg, importScope, decls); err != nil {
+			return nil, errors.Wrapf(err, "failed to import dependencies of package %q", path)
+		}
+		scriptDecls := decls.Copy()
+		semProg, err := semantic.New(astProg, decls)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create semantic graph for package %q", path)
+		}
+		if err := semantic.Infer(semProg, scriptDecls); err != nil {
+			return nil, errors.Wrapf(err, "type error in package %q", path)
+		}
+		if err := interpreter.Eval(semProg, scope); err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate package %q", path)
+		}
+		for _, s := range astProg.Body {
+			if d, ok := s.(*ast.VariableDeclaration); ok {
+				for _, v := range d.Declarations {
+					names = append(names, v.ID.Name)
+				}
+			}
+		}
+	}
+
+	obj := values.NewObject()
+	sort.Strings(names)
+	for _, k := range names {
+		if strings.HasPrefix(k, "_") {
+			continue
+		}
+		v, _ := scope.Lookup(k)
+		obj.Set(k, v)
+	}
+	imp.packages[path] = obj
+	return obj, nil
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/influxdata/platform/query/ast"
+)
+
+// regexpKeywords are the keywords after which a slash starts a regular expression instead of a division.
+var regexpKeywords = map[string]bool{
+	"and":    true,
+	"or":     true,
+	"not":    true,
+	"in":     true,
+	"if":     true,
+	"then":   true,
+	"else":   true,
+	"return": true,
+}
+
+// comments returns the line comments of the Flux source in source order.
+// The grammar discards comments as whitespace, so they are found by scanning the source
+// while skipping over string and regular expression literals.
+func comments(flux string) []*ast.Comment {
+	var (
+		cs []*ast.Comment
+		// last is the last non space rune outside of a literal, and word the last identifier or keyword.
+		last rune
+		word strings.Builder
+		line = 1
+		col  = 1
+	)
+	for i := 0; i < len(flux); {
+		r, size := utf8.DecodeRuneInString(flux[i:])
+		switch {
+		case r == '\n':
+			i, line, col = i+1, line+1, 1
+			continue
+		case strings.HasPrefix(flux[i:], "//"):
+			end := strings.IndexByte(flux[i:], '\n')
+			if end < 0 {
+				end = len(flux) - i
+			}
+			text := strings.TrimRight(flux[i:i+end], "\r")
+			cs = append(cs, &ast.Comment{
+				BaseNode: &ast.BaseNode{
+					Loc: &ast.SourceLocation{
+						Start:  ast.Position{Line: line, Column: col},
+						End:    ast.Position{Line: line, Column: col + utf8.RuneCountInString(text)},
+						Source: &text,
+					},
+				},
+				Text: text,
+			})
+			i += end
+			continue
+		case r == '"':
+			i, col = skipLiteral(flux, i, col, '"')
+			last = r
+			word.Reset()
+			continue
+		case r == '/' && startsRegexp(last, word.String()):
+			i, col = skipLiteral(flux, i, col, '/')
+			last = r
+			word.Reset()
+			continue
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !isWordRune(last) {
+				word.Reset()
+			}
+			word.WriteRune(r)
+			last = r
+		case !unicode.IsSpace(r):
+			last = r
+			word.Reset()
+		}
+		i += size
+		col++
+	}
+	return cs
+}
+
+// skipLiteral skips the literal starting at i and ending with the unescaped delimiter or the end of the line.
+// It returns the index and column after the literal.
+func skipLiteral(flux string, i, col int, delim rune) (int, int) {
+	i++
+	col++
+	for i < len(flux) {
+		r, size := utf8.DecodeRuneInString(flux[i:])
+		if r == '\n' {
+			return i, col
+		}
+		i += size
+		col++
+		switch r {
+		case '\\':
+			if i < len(flux) && flux[i] != '\n' {
+				_, size := utf8.DecodeRuneInString(flux[i:])
+				i += size
+				col++
+			}
+		case delim:
+			return i, col
+		}
+	}
+	return i, col
+}
+
+// startsRegexp reports whether a slash following the rune and word starts a regular expression.
+// A slash following an operand is a division.
+func startsRegexp(last rune, word string) bool {
+	if isWordRune(last) {
+		return regexpKeywords[strings.ToLower(word)]
+	}
+	switch last {
+	case ')', ']', '}', '"':
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
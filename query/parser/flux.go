@@ -127,45 +127,76 @@ var g = &grammar{
 		},
 		{
 			name: "Program",
-			pos:  position{line: 13, col: 1, offset: 173},
+			pos:  position{line: 13, col: 1, offset: 261},
 			expr: &actionExpr{
-				pos: position{line: 14, col: 5, offset: 185},
+				pos: position{line: 14, col: 5, offset: 285},
 				run: (*parser).callonProgram1,
-				expr: &labeledExpr{
-					pos:   position{line: 14, col: 5, offset: 185},
-					label: "body",
-					expr: &ruleRefExpr{
-						pos:  position{line: 14, col: 10, offset: 190},
-						name: "SourceElements",
-					},
-				},
-			},
-		},
-		{
-			name: "SourceElements",
-			pos:  position{line: 18, col: 1, offset: 256},
-			expr: &actionExpr{
-				pos: position{line: 19, col: 5, offset: 275},
-				run: (*parser).callonSourceElements1,
 				expr: &seqExpr{
-					pos: position{line: 19, col: 5, offset: 275},
+					pos: position{line: 14, col: 5, offset: 285},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 19, col: 5, offset: 275},
-							label: "head",
-							expr: &ruleRefExpr{
-								pos:  position{line: 19, col: 10, offset: 280},
-								name: "SourceElement",
+							pos:   position{line: 14, col: 5, offset: 285},
+							label: "pkg",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 14, col: 9, offset: 289},
+								expr: &ruleRefExpr{
+									pos:  position{line: 14, col: 9, offset: 289},
+									name: "PackageClause",
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 19, col: 24, offset: 294},
-							label: "tail",
+							pos:   position{line: 14, col: 34, offset: 314},
+							label: "imports",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 19, col: 29, offset: 299},
+								pos: position{line: 14, col: 42, offset: 322},
 								expr: &seqExpr{
-									pos: position{line: 19, col: 30, offset: 300},
+									pos: position{line: 14, col: 43, offset: 323},
 									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 14, col: 43, offset: 323},
+											name: "ImportDeclaration",
+										},
 										&zeroOrMoreExpr{
 											pos: position{line: 468, col: 5, offset: 8728},
 											expr: &choiceExpr{
@@ -206,120 +237,82 @@ var g = &grammar{
 												},
 											},
 										},
-										&ruleRefExpr{
-											pos:  position{line: 19, col: 33, offset: 303},
-											name: "SourceElement",
-										},
-										&zeroOrMoreExpr{
-											pos: position{line: 468, col: 5, offset: 8728},
-											expr: &choiceExpr{
-												pos: position{line: 468, col: 7, offset: 8730},
-												alternatives: []interface{}{
-													&charClassMatcher{
-														pos:        position{line: 474, col: 5, offset: 8791},
-														val:        "[ \\t\\r\\n]",
-														chars:      []rune{' ', '\t', '\r', '\n'},
-														ignoreCase: false,
-														inverted:   false,
-													},
-													&seqExpr{
-														pos: position{line: 471, col: 5, offset: 8765},
-														exprs: []interface{}{
-															&litMatcher{
-																pos:        position{line: 471, col: 5, offset: 8765},
-																val:        "//",
-																ignoreCase: false,
-															},
-															&zeroOrMoreExpr{
-																pos: position{line: 471, col: 10, offset: 8770},
-																expr: &charClassMatcher{
-																	pos:        position{line: 471, col: 10, offset: 8770},
-																	val:        "[^\\r\\n]",
-																	chars:      []rune{'\r', '\n'},
-																	ignoreCase: false,
-																	inverted:   true,
-																},
-															},
-															&litMatcher{
-																pos:        position{line: 480, col: 5, offset: 8837},
-																val:        "\n",
-																ignoreCase: false,
-															},
-														},
-													},
+									},
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
 												},
 											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
 										},
 									},
 								},
 							},
 						},
+						&labeledExpr{
+							pos:   position{line: 14, col: 65, offset: 345},
+							label: "body",
+							expr: &ruleRefExpr{
+								pos:  position{line: 14, col: 70, offset: 350},
+								name: "SourceElements",
+							},
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "SourceElement",
-			pos:  position{line: 23, col: 1, offset: 365},
-			expr: &ruleRefExpr{
-				pos:  position{line: 24, col: 5, offset: 383},
-				name: "Statement",
-			},
-		},
-		{
-			name: "Statement",
-			pos:  position{line: 26, col: 1, offset: 394},
-			expr: &choiceExpr{
-				pos: position{line: 27, col: 5, offset: 408},
-				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 27, col: 5, offset: 408},
-						name: "VariableStatement",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 28, col: 5, offset: 430},
-						name: "ReturnStatement",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 29, col: 5, offset: 450},
-						name: "ExpressionStatement",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 30, col: 5, offset: 474},
-						name: "BlockStatement",
-					},
-				},
-			},
-		},
-		{
-			name: "VariableStatement",
-			pos:  position{line: 33, col: 1, offset: 491},
-			expr: &actionExpr{
-				pos: position{line: 34, col: 5, offset: 513},
-				run: (*parser).callonVariableStatement1,
-				expr: &labeledExpr{
-					pos:   position{line: 34, col: 5, offset: 513},
-					label: "declaration",
-					expr: &ruleRefExpr{
-						pos:  position{line: 34, col: 17, offset: 525},
-						name: "VariableDeclaration",
-					},
-				},
-			},
-		},
-		{
-			name: "ReturnStatement",
-			pos:  position{line: 38, col: 1, offset: 604},
+			name: "PackageClause",
+			pos:  position{line: 18, col: 1, offset: 361},
 			expr: &actionExpr{
-				pos: position{line: 39, col: 5, offset: 624},
-				run: (*parser).callonReturnStatement1,
+				pos: position{line: 19, col: 5, offset: 385},
+				run: (*parser).callonPackageClause1,
 				expr: &seqExpr{
-					pos: position{line: 39, col: 5, offset: 624},
+					pos: position{line: 19, col: 5, offset: 385},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 39, col: 5, offset: 624},
-							val:        "return",
+							pos:        position{line: 19, col: 5, offset: 385},
+							val:        "package",
+							ignoreCase: false,
+						},
+						&charClassMatcher{
+							pos:        position{line: 19, col: 15, offset: 395},
+							val:        "[ \\t\\r\\n]",
+							chars:      []rune{' ', '\t', '\r', '\n'},
 							ignoreCase: false,
+							inverted:   false,
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -362,11 +355,36 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 39, col: 17, offset: 636},
-							label: "argument",
-							expr: &ruleRefExpr{
-								pos:  position{line: 39, col: 26, offset: 645},
-								name: "Expr",
+							pos:   position{line: 19, col: 21, offset: 401},
+							label: "name",
+							expr: &actionExpr{
+								pos: position{line: 19, col: 26, offset: 406},
+								run: (*parser).callonPackageClause7,
+								expr: &seqExpr{
+									pos: position{line: 19, col: 26, offset: 406},
+									exprs: []interface{}{
+										&charClassMatcher{
+											pos:        position{line: 19, col: 26, offset: 406},
+											val:        "[_\\pL]",
+											chars:      []rune{'_'},
+											classes:    []*unicode.RangeTable{rangeTable("L")},
+											ignoreCase: false,
+											inverted:   false,
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 19, col: 32, offset: 412},
+											expr: &charClassMatcher{
+												pos:        position{line: 19, col: 32, offset: 412},
+												val:        "[_0-9\\pL]",
+												chars:      []rune{'_'},
+												ranges:     []rune{'0', '9'},
+												classes:    []*unicode.RangeTable{rangeTable("L")},
+												ignoreCase: false,
+												inverted:   false,
+											},
+										},
+									},
+								},
 							},
 						},
 					},
@@ -374,34 +392,25 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "ExpressionStatement",
-			pos:  position{line: 43, col: 1, offset: 708},
-			expr: &actionExpr{
-				pos: position{line: 44, col: 5, offset: 732},
-				run: (*parser).callonExpressionStatement1,
-				expr: &labeledExpr{
-					pos:   position{line: 44, col: 5, offset: 732},
-					label: "expr",
-					expr: &ruleRefExpr{
-						pos:  position{line: 44, col: 10, offset: 737},
-						name: "Expr",
-					},
-				},
-			},
-		},
-		{
-			name: "BlockStatement",
-			pos:  position{line: 48, col: 1, offset: 796},
+			name: "ImportDeclaration",
+			pos:  position{line: 23, col: 1, offset: 461},
 			expr: &actionExpr{
-				pos: position{line: 49, col: 5, offset: 815},
-				run: (*parser).callonBlockStatement1,
+				pos: position{line: 24, col: 5, offset: 485},
+				run: (*parser).callonImportDeclaration1,
 				expr: &seqExpr{
-					pos: position{line: 49, col: 5, offset: 815},
+					pos: position{line: 24, col: 5, offset: 485},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 49, col: 5, offset: 815},
-							val:        "{",
+							pos:        position{line: 24, col: 5, offset: 485},
+							val:        "import",
+							ignoreCase: false,
+						},
+						&charClassMatcher{
+							pos:        position{line: 24, col: 14, offset: 494},
+							val:        "[ \\t\\r\\n]",
+							chars:      []rune{' ', '\t', '\r', '\n'},
 							ignoreCase: false,
+							inverted:   false,
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -444,12 +453,154 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 49, col: 12, offset: 822},
-							label: "body",
+							pos:   position{line: 24, col: 21, offset: 501},
+							label: "alias",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 24, col: 27, offset: 507},
+								expr: &seqExpr{
+									pos: position{line: 24, col: 28, offset: 508},
+									exprs: []interface{}{
+										&actionExpr{
+											pos: position{line: 24, col: 28, offset: 508},
+											run: (*parser).callonImportDeclaration10,
+											expr: &seqExpr{
+												pos: position{line: 24, col: 28, offset: 508},
+												exprs: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 24, col: 28, offset: 508},
+														val:        "[_\\pL]",
+														chars:      []rune{'_'},
+														classes:    []*unicode.RangeTable{rangeTable("L")},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&zeroOrMoreExpr{
+														pos: position{line: 24, col: 34, offset: 514},
+														expr: &charClassMatcher{
+															pos:        position{line: 24, col: 34, offset: 514},
+															val:        "[_0-9\\pL]",
+															chars:      []rune{'_'},
+															ranges:     []rune{'0', '9'},
+															classes:    []*unicode.RangeTable{rangeTable("L")},
+															ignoreCase: false,
+															inverted:   false,
+														},
+													},
+												},
+											},
+										},
+										&charClassMatcher{
+											pos:        position{line: 24, col: 39, offset: 519},
+											val:        "[ \\t\\r\\n]",
+											chars:      []rune{' ', '\t', '\r', '\n'},
+											ignoreCase: false,
+											inverted:   false,
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 468, col: 5, offset: 8728},
+											expr: &choiceExpr{
+												pos: position{line: 468, col: 7, offset: 8730},
+												alternatives: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 474, col: 5, offset: 8791},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&seqExpr{
+														pos: position{line: 471, col: 5, offset: 8765},
+														exprs: []interface{}{
+															&litMatcher{
+																pos:        position{line: 471, col: 5, offset: 8765},
+																val:        "//",
+																ignoreCase: false,
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 471, col: 10, offset: 8770},
+																expr: &charClassMatcher{
+																	pos:        position{line: 471, col: 10, offset: 8770},
+																	val:        "[^\\r\\n]",
+																	chars:      []rune{'\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 480, col: 5, offset: 8837},
+																val:        "\n",
+																ignoreCase: false,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 24, col: 45, offset: 525},
+							label: "path",
+							expr: &actionExpr{
+								pos: position{line: 28, col: 5, offset: 565},
+								run: (*parser).callonImportDeclaration20,
+								expr: &seqExpr{
+									pos: position{line: 28, col: 5, offset: 565},
+									exprs: []interface{}{
+										&litMatcher{
+											pos:        position{line: 28, col: 5, offset: 565},
+											val:        "\"",
+											ignoreCase: false,
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 28, col: 9, offset: 569},
+											expr: &charClassMatcher{
+												pos:        position{line: 28, col: 9, offset: 569},
+												val:        "[^\"\\\\\\r\\n]",
+												chars:      []rune{'"', '\\', '\r', '\n'},
+												ignoreCase: false,
+												inverted:   true,
+											},
+										},
+										&litMatcher{
+											pos:        position{line: 28, col: 20, offset: 580},
+											val:        "\"",
+											ignoreCase: false,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SourceElements",
+			pos:  position{line: 18, col: 1, offset: 256},
+			expr: &actionExpr{
+				pos: position{line: 19, col: 5, offset: 275},
+				run: (*parser).callonSourceElements1,
+				expr: &seqExpr{
+					pos: position{line: 19, col: 5, offset: 275},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 19, col: 5, offset: 275},
+							label: "head",
+							expr: &ruleRefExpr{
+								pos:  position{line: 19, col: 10, offset: 280},
+								name: "SourceElement",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 19, col: 24, offset: 294},
+							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 49, col: 17, offset: 827},
+								pos: position{line: 19, col: 29, offset: 299},
 								expr: &seqExpr{
-									pos: position{line: 49, col: 19, offset: 829},
+									pos: position{line: 19, col: 30, offset: 300},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
 											pos: position{line: 468, col: 5, offset: 8728},
@@ -492,8 +643,8 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 49, col: 22, offset: 832},
-											name: "Statement",
+											pos:  position{line: 19, col: 33, offset: 303},
+											name: "SourceElement",
 										},
 										&zeroOrMoreExpr{
 											pos: position{line: 468, col: 5, offset: 8728},
@@ -539,6 +690,48 @@ var g = &grammar{
 								},
 							},
 						},
+					},
+				},
+			},
+		},
+		{
+			name: "SourceElement",
+			pos:  position{line: 40, col: 1, offset: 801},
+			expr: &choiceExpr{
+				pos: position{line: 41, col: 5, offset: 825},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 41, col: 5, offset: 825},
+						name: "OptionStatement",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 42, col: 5, offset: 845},
+						name: "Statement",
+					},
+				},
+			},
+		},
+		{
+			name: "OptionStatement",
+			pos:  position{line: 44, col: 1, offset: 881},
+			expr: &actionExpr{
+				pos: position{line: 45, col: 5, offset: 905},
+				run: (*parser).callonOptionStatement1,
+				expr: &seqExpr{
+					pos: position{line: 45, col: 5, offset: 905},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 45, col: 5, offset: 905},
+							val:        "option",
+							ignoreCase: false,
+						},
+						&charClassMatcher{
+							pos:        position{line: 45, col: 14, offset: 914},
+							val:        "[ \\t\\r\\n]",
+							chars:      []rune{' ', '\t', '\r', '\n'},
+							ignoreCase: false,
+							inverted:   false,
+						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
 							expr: &choiceExpr{
@@ -579,56 +772,72 @@ var g = &grammar{
 								},
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 49, col: 41, offset: 851},
-							val:        "}",
-							ignoreCase: false,
+						&labeledExpr{
+							pos:   position{line: 45, col: 20, offset: 920},
+							label: "declaration",
+							expr: &ruleRefExpr{
+								pos:  position{line: 45, col: 32, offset: 932},
+								name: "VariableDeclaration",
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "VariableDeclaration",
-			pos:  position{line: 53, col: 1, offset: 908},
+			name: "Statement",
+			pos:  position{line: 26, col: 1, offset: 394},
+			expr: &choiceExpr{
+				pos: position{line: 27, col: 5, offset: 408},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 27, col: 5, offset: 408},
+						name: "VariableStatement",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 28, col: 5, offset: 430},
+						name: "ReturnStatement",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 29, col: 5, offset: 450},
+						name: "ExpressionStatement",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 30, col: 5, offset: 474},
+						name: "BlockStatement",
+					},
+				},
+			},
+		},
+		{
+			name: "VariableStatement",
+			pos:  position{line: 33, col: 1, offset: 491},
 			expr: &actionExpr{
-				pos: position{line: 54, col: 5, offset: 932},
-				run: (*parser).callonVariableDeclaration1,
+				pos: position{line: 34, col: 5, offset: 513},
+				run: (*parser).callonVariableStatement1,
+				expr: &labeledExpr{
+					pos:   position{line: 34, col: 5, offset: 513},
+					label: "declaration",
+					expr: &ruleRefExpr{
+						pos:  position{line: 34, col: 17, offset: 525},
+						name: "VariableDeclaration",
+					},
+				},
+			},
+		},
+		{
+			name: "ReturnStatement",
+			pos:  position{line: 38, col: 1, offset: 604},
+			expr: &actionExpr{
+				pos: position{line: 39, col: 5, offset: 624},
+				run: (*parser).callonReturnStatement1,
 				expr: &seqExpr{
-					pos: position{line: 54, col: 5, offset: 932},
+					pos: position{line: 39, col: 5, offset: 624},
 					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 54, col: 5, offset: 932},
-							label: "id",
-							expr: &actionExpr{
-								pos: position{line: 460, col: 5, offset: 8638},
-								run: (*parser).callonVariableDeclaration4,
-								expr: &seqExpr{
-									pos: position{line: 460, col: 5, offset: 8638},
-									exprs: []interface{}{
-										&charClassMatcher{
-											pos:        position{line: 460, col: 5, offset: 8638},
-											val:        "[_\\pL]",
-											chars:      []rune{'_'},
-											classes:    []*unicode.RangeTable{rangeTable("L")},
-											ignoreCase: false,
-											inverted:   false,
-										},
-										&zeroOrMoreExpr{
-											pos: position{line: 460, col: 11, offset: 8644},
-											expr: &charClassMatcher{
-												pos:        position{line: 460, col: 11, offset: 8644},
-												val:        "[_0-9\\pL]",
-												chars:      []rune{'_'},
-												ranges:     []rune{'0', '9'},
-												classes:    []*unicode.RangeTable{rangeTable("L")},
-												ignoreCase: false,
-												inverted:   false,
-											},
-										},
-									},
-								},
-							},
+						&litMatcher{
+							pos:        position{line: 39, col: 5, offset: 624},
+							val:        "return",
+							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -670,15 +879,52 @@ var g = &grammar{
 								},
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 54, col: 22, offset: 949},
-							val:        "=",
-							ignoreCase: false,
+						&labeledExpr{
+							pos:   position{line: 39, col: 17, offset: 636},
+							label: "argument",
+							expr: &ruleRefExpr{
+								pos:  position{line: 39, col: 26, offset: 645},
+								name: "Expr",
+							},
 						},
-						&zeroOrMoreExpr{
-							pos: position{line: 468, col: 5, offset: 8728},
-							expr: &choiceExpr{
-								pos: position{line: 468, col: 7, offset: 8730},
+					},
+				},
+			},
+		},
+		{
+			name: "ExpressionStatement",
+			pos:  position{line: 43, col: 1, offset: 708},
+			expr: &actionExpr{
+				pos: position{line: 44, col: 5, offset: 732},
+				run: (*parser).callonExpressionStatement1,
+				expr: &labeledExpr{
+					pos:   position{line: 44, col: 5, offset: 732},
+					label: "expr",
+					expr: &ruleRefExpr{
+						pos:  position{line: 44, col: 10, offset: 737},
+						name: "Expr",
+					},
+				},
+			},
+		},
+		{
+			name: "BlockStatement",
+			pos:  position{line: 48, col: 1, offset: 796},
+			expr: &actionExpr{
+				pos: position{line: 49, col: 5, offset: 815},
+				run: (*parser).callonBlockStatement1,
+				expr: &seqExpr{
+					pos: position{line: 49, col: 5, offset: 815},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 49, col: 5, offset: 815},
+							val:        "{",
+							ignoreCase: false,
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
 								alternatives: []interface{}{
 									&charClassMatcher{
 										pos:        position{line: 474, col: 5, offset: 8791},
@@ -716,32 +962,165 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 54, col: 29, offset: 956},
-							label: "init",
-							expr: &ruleRefExpr{
-								pos:  position{line: 54, col: 34, offset: 961},
-								name: "Expr",
+							pos:   position{line: 49, col: 12, offset: 822},
+							label: "body",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 49, col: 17, offset: 827},
+								expr: &seqExpr{
+									pos: position{line: 49, col: 19, offset: 829},
+									exprs: []interface{}{
+										&zeroOrMoreExpr{
+											pos: position{line: 468, col: 5, offset: 8728},
+											expr: &choiceExpr{
+												pos: position{line: 468, col: 7, offset: 8730},
+												alternatives: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 474, col: 5, offset: 8791},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&seqExpr{
+														pos: position{line: 471, col: 5, offset: 8765},
+														exprs: []interface{}{
+															&litMatcher{
+																pos:        position{line: 471, col: 5, offset: 8765},
+																val:        "//",
+																ignoreCase: false,
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 471, col: 10, offset: 8770},
+																expr: &charClassMatcher{
+																	pos:        position{line: 471, col: 10, offset: 8770},
+																	val:        "[^\\r\\n]",
+																	chars:      []rune{'\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 480, col: 5, offset: 8837},
+																val:        "\n",
+																ignoreCase: false,
+															},
+														},
+													},
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 49, col: 22, offset: 832},
+											name: "Statement",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 468, col: 5, offset: 8728},
+											expr: &choiceExpr{
+												pos: position{line: 468, col: 7, offset: 8730},
+												alternatives: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 474, col: 5, offset: 8791},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&seqExpr{
+														pos: position{line: 471, col: 5, offset: 8765},
+														exprs: []interface{}{
+															&litMatcher{
+																pos:        position{line: 471, col: 5, offset: 8765},
+																val:        "//",
+																ignoreCase: false,
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 471, col: 10, offset: 8770},
+																expr: &charClassMatcher{
+																	pos:        position{line: 471, col: 10, offset: 8770},
+																	val:        "[^\\r\\n]",
+																	chars:      []rune{'\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 480, col: 5, offset: 8837},
+																val:        "\n",
+																ignoreCase: false,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
 							},
 						},
+						&litMatcher{
+							pos:        position{line: 49, col: 41, offset: 851},
+							val:        "}",
+							ignoreCase: false,
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "MemberExpressions",
-			pos:  position{line: 59, col: 1, offset: 1022},
+			name: "VariableDeclaration",
+			pos:  position{line: 53, col: 1, offset: 908},
 			expr: &actionExpr{
-				pos: position{line: 60, col: 5, offset: 1044},
-				run: (*parser).callonMemberExpressions1,
+				pos: position{line: 54, col: 5, offset: 932},
+				run: (*parser).callonVariableDeclaration1,
 				expr: &seqExpr{
-					pos: position{line: 60, col: 5, offset: 1044},
+					pos: position{line: 54, col: 5, offset: 932},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 60, col: 5, offset: 1044},
-							label: "head",
+							pos:   position{line: 54, col: 5, offset: 932},
+							label: "id",
 							expr: &actionExpr{
 								pos: position{line: 460, col: 5, offset: 8638},
-								run: (*parser).callonMemberExpressions4,
+								run: (*parser).callonVariableDeclaration4,
 								expr: &seqExpr{
 									pos: position{line: 460, col: 5, offset: 8638},
 									exprs: []interface{}{
@@ -769,48 +1148,187 @@ var g = &grammar{
 								},
 							},
 						},
-						&labeledExpr{
-							pos:   position{line: 61, col: 5, offset: 1091},
-							label: "tail",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 61, col: 10, offset: 1096},
-								expr: &actionExpr{
-									pos: position{line: 62, col: 10, offset: 1107},
-									run: (*parser).callonMemberExpressions11,
-									expr: &seqExpr{
-										pos: position{line: 62, col: 10, offset: 1107},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
 										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
 											&zeroOrMoreExpr{
-												pos: position{line: 468, col: 5, offset: 8728},
-												expr: &choiceExpr{
-													pos: position{line: 468, col: 7, offset: 8730},
-													alternatives: []interface{}{
-														&charClassMatcher{
-															pos:        position{line: 474, col: 5, offset: 8791},
-															val:        "[ \\t\\r\\n]",
-															chars:      []rune{' ', '\t', '\r', '\n'},
-															ignoreCase: false,
-															inverted:   false,
-														},
-														&seqExpr{
-															pos: position{line: 471, col: 5, offset: 8765},
-															exprs: []interface{}{
-																&litMatcher{
-																	pos:        position{line: 471, col: 5, offset: 8765},
-																	val:        "//",
-																	ignoreCase: false,
-																},
-																&zeroOrMoreExpr{
-																	pos: position{line: 471, col: 10, offset: 8770},
-																	expr: &charClassMatcher{
-																		pos:        position{line: 471, col: 10, offset: 8770},
-																		val:        "[^\\r\\n]",
-																		chars:      []rune{'\r', '\n'},
-																		ignoreCase: false,
-																		inverted:   true,
-																	},
-																},
-																&litMatcher{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 54, col: 22, offset: 949},
+							val:        "=",
+							ignoreCase: false,
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 54, col: 29, offset: 956},
+							label: "init",
+							expr: &ruleRefExpr{
+								pos:  position{line: 54, col: 34, offset: 961},
+								name: "Expr",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MemberExpressions",
+			pos:  position{line: 59, col: 1, offset: 1022},
+			expr: &actionExpr{
+				pos: position{line: 60, col: 5, offset: 1044},
+				run: (*parser).callonMemberExpressions1,
+				expr: &seqExpr{
+					pos: position{line: 60, col: 5, offset: 1044},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 60, col: 5, offset: 1044},
+							label: "head",
+							expr: &actionExpr{
+								pos: position{line: 460, col: 5, offset: 8638},
+								run: (*parser).callonMemberExpressions4,
+								expr: &seqExpr{
+									pos: position{line: 460, col: 5, offset: 8638},
+									exprs: []interface{}{
+										&charClassMatcher{
+											pos:        position{line: 460, col: 5, offset: 8638},
+											val:        "[_\\pL]",
+											chars:      []rune{'_'},
+											classes:    []*unicode.RangeTable{rangeTable("L")},
+											ignoreCase: false,
+											inverted:   false,
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 460, col: 11, offset: 8644},
+											expr: &charClassMatcher{
+												pos:        position{line: 460, col: 11, offset: 8644},
+												val:        "[_0-9\\pL]",
+												chars:      []rune{'_'},
+												ranges:     []rune{'0', '9'},
+												classes:    []*unicode.RangeTable{rangeTable("L")},
+												ignoreCase: false,
+												inverted:   false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 61, col: 5, offset: 1091},
+							label: "tail",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 61, col: 10, offset: 1096},
+								expr: &actionExpr{
+									pos: position{line: 62, col: 10, offset: 1107},
+									run: (*parser).callonMemberExpressions11,
+									expr: &seqExpr{
+										pos: position{line: 62, col: 10, offset: 1107},
+										exprs: []interface{}{
+											&zeroOrMoreExpr{
+												pos: position{line: 468, col: 5, offset: 8728},
+												expr: &choiceExpr{
+													pos: position{line: 468, col: 7, offset: 8730},
+													alternatives: []interface{}{
+														&charClassMatcher{
+															pos:        position{line: 474, col: 5, offset: 8791},
+															val:        "[ \\t\\r\\n]",
+															chars:      []rune{' ', '\t', '\r', '\n'},
+															ignoreCase: false,
+															inverted:   false,
+														},
+														&seqExpr{
+															pos: position{line: 471, col: 5, offset: 8765},
+															exprs: []interface{}{
+																&litMatcher{
+																	pos:        position{line: 471, col: 5, offset: 8765},
+																	val:        "//",
+																	ignoreCase: false,
+																},
+																&zeroOrMoreExpr{
+																	pos: position{line: 471, col: 10, offset: 8770},
+																	expr: &charClassMatcher{
+																		pos:        position{line: 471, col: 10, offset: 8770},
+																		val:        "[^\\r\\n]",
+																		chars:      []rune{'\r', '\n'},
+																		ignoreCase: false,
+																		inverted:   true,
+																	},
+																},
+																&litMatcher{
 																	pos:        position{line: 480, col: 5, offset: 8837},
 																	val:        "\n",
 																	ignoreCase: false,
@@ -1505,6 +2023,11 @@ var g = &grammar{
 																val:        "\"",
 																ignoreCase: false,
 															},
+															&litMatcher{
+																pos:        position{line: 390, col: 5, offset: 7423},
+																val:        "$",
+																ignoreCase: false,
+															},
 															&actionExpr{
 																pos: position{line: 391, col: 5, offset: 7431},
 																run: (*parser).callonPipeExpressionHead16,
@@ -1593,6 +2116,11 @@ var g = &grammar{
 																val:        "\"",
 																ignoreCase: false,
 															},
+															&litMatcher{
+																pos:        position{line: 390, col: 5, offset: 7423},
+																val:        "$",
+																ignoreCase: false,
+															},
 															&actionExpr{
 																pos: position{line: 391, col: 5, offset: 7431},
 																run: (*parser).callonPipeExpressionHead36,
@@ -3319,15 +3847,343 @@ var g = &grammar{
 			name: "ObjectExpression",
 			pos:  position{line: 153, col: 1, offset: 3224},
 			expr: &actionExpr{
-				pos: position{line: 154, col: 5, offset: 3245},
-				run: (*parser).callonObjectExpression1,
+				pos: position{line: 154, col: 5, offset: 3245},
+				run: (*parser).callonObjectExpression1,
+				expr: &seqExpr{
+					pos: position{line: 154, col: 5, offset: 3245},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 154, col: 5, offset: 3245},
+							val:        "{",
+							ignoreCase: false,
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 154, col: 12, offset: 3252},
+							label: "object",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 154, col: 19, offset: 3259},
+								expr: &ruleRefExpr{
+									pos:  position{line: 154, col: 20, offset: 3260},
+									name: "ObjectProperties",
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 154, col: 42, offset: 3282},
+							val:        "}",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ObjectProperties",
+			pos:  position{line: 158, col: 1, offset: 3316},
+			expr: &actionExpr{
+				pos: position{line: 159, col: 5, offset: 3337},
+				run: (*parser).callonObjectProperties1,
+				expr: &seqExpr{
+					pos: position{line: 159, col: 5, offset: 3337},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 159, col: 5, offset: 3337},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 159, col: 11, offset: 3343},
+								name: "Property",
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 159, col: 23, offset: 3355},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 159, col: 28, offset: 3360},
+								expr: &ruleRefExpr{
+									pos:  position{line: 159, col: 28, offset: 3360},
+									name: "PropertiesRest",
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 159, col: 47, offset: 3379},
+							expr: &litMatcher{
+								pos:        position{line: 159, col: 47, offset: 3379},
+								val:        ",",
+								ignoreCase: false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PropertiesRest",
+			pos:  position{line: 163, col: 1, offset: 3445},
+			expr: &actionExpr{
+				pos: position{line: 164, col: 5, offset: 3464},
+				run: (*parser).callonPropertiesRest1,
+				expr: &seqExpr{
+					pos: position{line: 164, col: 5, offset: 3464},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 164, col: 5, offset: 3464},
+							val:        ",",
+							ignoreCase: false,
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 468, col: 5, offset: 8728},
+							expr: &choiceExpr{
+								pos: position{line: 468, col: 7, offset: 8730},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 474, col: 5, offset: 8791},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 471, col: 5, offset: 8765},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 471, col: 5, offset: 8765},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 471, col: 10, offset: 8770},
+												expr: &charClassMatcher{
+													pos:        position{line: 471, col: 10, offset: 8770},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 480, col: 5, offset: 8837},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 164, col: 13, offset: 3472},
+							label: "arg",
+							expr: &ruleRefExpr{
+								pos:  position{line: 164, col: 17, offset: 3476},
+								name: "Property",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Property",
+			pos:  position{line: 168, col: 1, offset: 3516},
+			expr: &actionExpr{
+				pos: position{line: 169, col: 5, offset: 3529},
+				run: (*parser).callonProperty1,
 				expr: &seqExpr{
-					pos: position{line: 154, col: 5, offset: 3245},
+					pos: position{line: 169, col: 5, offset: 3529},
 					exprs: []interface{}{
-						&litMatcher{
-							pos:        position{line: 154, col: 5, offset: 3245},
-							val:        "{",
-							ignoreCase: false,
+						&labeledExpr{
+							pos:   position{line: 169, col: 5, offset: 3529},
+							label: "key",
+							expr: &actionExpr{
+								pos: position{line: 460, col: 5, offset: 8638},
+								run: (*parser).callonProperty4,
+								expr: &seqExpr{
+									pos: position{line: 460, col: 5, offset: 8638},
+									exprs: []interface{}{
+										&charClassMatcher{
+											pos:        position{line: 460, col: 5, offset: 8638},
+											val:        "[_\\pL]",
+											chars:      []rune{'_'},
+											classes:    []*unicode.RangeTable{rangeTable("L")},
+											ignoreCase: false,
+											inverted:   false,
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 460, col: 11, offset: 8644},
+											expr: &charClassMatcher{
+												pos:        position{line: 460, col: 11, offset: 8644},
+												val:        "[_0-9\\pL]",
+												chars:      []rune{'_'},
+												ranges:     []rune{'0', '9'},
+												classes:    []*unicode.RangeTable{rangeTable("L")},
+												ignoreCase: false,
+												inverted:   false,
+											},
+										},
+									},
+								},
+							},
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -3369,16 +4225,10 @@ var g = &grammar{
 								},
 							},
 						},
-						&labeledExpr{
-							pos:   position{line: 154, col: 12, offset: 3252},
-							label: "object",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 154, col: 19, offset: 3259},
-								expr: &ruleRefExpr{
-									pos:  position{line: 154, col: 20, offset: 3260},
-									name: "ObjectProperties",
-								},
-							},
+						&litMatcher{
+							pos:        position{line: 169, col: 24, offset: 3548},
+							val:        ":",
+							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -3420,31 +4270,55 @@ var g = &grammar{
 								},
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 154, col: 42, offset: 3282},
-							val:        "}",
-							ignoreCase: false,
+						&labeledExpr{
+							pos:   position{line: 169, col: 31, offset: 3555},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 169, col: 37, offset: 3561},
+								name: "Expr",
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "ObjectProperties",
-			pos:  position{line: 158, col: 1, offset: 3316},
+			name: "Expr",
+			pos:  position{line: 180, col: 1, offset: 3811},
+			expr: &choiceExpr{
+				pos: position{line: 181, col: 5, offset: 3820},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 181, col: 5, offset: 3820},
+						name: "ConditionalExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 182, col: 7, offset: 3848},
+						name: "LogicalExpression",
+					},
+				},
+			},
+		},
+		{
+			name: "ConditionalExpression",
+			pos:  position{line: 184, col: 1, offset: 3844},
 			expr: &actionExpr{
-				pos: position{line: 159, col: 5, offset: 3337},
-				run: (*parser).callonObjectProperties1,
+				pos: position{line: 185, col: 5, offset: 3870},
+				run: (*parser).callonConditionalExpression1,
 				expr: &seqExpr{
-					pos: position{line: 159, col: 5, offset: 3337},
+					pos: position{line: 185, col: 5, offset: 3870},
 					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 159, col: 5, offset: 3337},
-							label: "first",
-							expr: &ruleRefExpr{
-								pos:  position{line: 159, col: 11, offset: 3343},
-								name: "Property",
-							},
+						&litMatcher{
+							pos:        position{line: 185, col: 5, offset: 3870},
+							val:        "if",
+							ignoreCase: false,
+						},
+						&charClassMatcher{
+							pos:        position{line: 185, col: 10, offset: 3875},
+							val:        "[ \\t\\r\\n]",
+							chars:      []rune{' ', '\t', '\r', '\n'},
+							ignoreCase: false,
+							inverted:   false,
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -3487,14 +4361,11 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 23, offset: 3355},
-							label: "rest",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 159, col: 28, offset: 3360},
-								expr: &ruleRefExpr{
-									pos:  position{line: 159, col: 28, offset: 3360},
-									name: "PropertiesRest",
-								},
+							pos:   position{line: 185, col: 16, offset: 3881},
+							label: "test",
+							expr: &ruleRefExpr{
+								pos:  position{line: 185, col: 21, offset: 3886},
+								name: "Expr",
 							},
 						},
 						&zeroOrMoreExpr{
@@ -3537,31 +4408,17 @@ var g = &grammar{
 								},
 							},
 						},
-						&zeroOrOneExpr{
-							pos: position{line: 159, col: 47, offset: 3379},
-							expr: &litMatcher{
-								pos:        position{line: 159, col: 47, offset: 3379},
-								val:        ",",
-								ignoreCase: false,
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "PropertiesRest",
-			pos:  position{line: 163, col: 1, offset: 3445},
-			expr: &actionExpr{
-				pos: position{line: 164, col: 5, offset: 3464},
-				run: (*parser).callonPropertiesRest1,
-				expr: &seqExpr{
-					pos: position{line: 164, col: 5, offset: 3464},
-					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 164, col: 5, offset: 3464},
-							val:        ",",
+							pos:        position{line: 186, col: 5, offset: 3910},
+							val:        "then",
+							ignoreCase: false,
+						},
+						&charClassMatcher{
+							pos:        position{line: 186, col: 12, offset: 3917},
+							val:        "[ \\t\\r\\n]",
+							chars:      []rune{' ', '\t', '\r', '\n'},
 							ignoreCase: false,
+							inverted:   false,
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -3604,57 +4461,11 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 164, col: 13, offset: 3472},
-							label: "arg",
+							pos:   position{line: 186, col: 18, offset: 3923},
+							label: "consequent",
 							expr: &ruleRefExpr{
-								pos:  position{line: 164, col: 17, offset: 3476},
-								name: "Property",
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "Property",
-			pos:  position{line: 168, col: 1, offset: 3516},
-			expr: &actionExpr{
-				pos: position{line: 169, col: 5, offset: 3529},
-				run: (*parser).callonProperty1,
-				expr: &seqExpr{
-					pos: position{line: 169, col: 5, offset: 3529},
-					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 169, col: 5, offset: 3529},
-							label: "key",
-							expr: &actionExpr{
-								pos: position{line: 460, col: 5, offset: 8638},
-								run: (*parser).callonProperty4,
-								expr: &seqExpr{
-									pos: position{line: 460, col: 5, offset: 8638},
-									exprs: []interface{}{
-										&charClassMatcher{
-											pos:        position{line: 460, col: 5, offset: 8638},
-											val:        "[_\\pL]",
-											chars:      []rune{'_'},
-											classes:    []*unicode.RangeTable{rangeTable("L")},
-											ignoreCase: false,
-											inverted:   false,
-										},
-										&zeroOrMoreExpr{
-											pos: position{line: 460, col: 11, offset: 8644},
-											expr: &charClassMatcher{
-												pos:        position{line: 460, col: 11, offset: 8644},
-												val:        "[_0-9\\pL]",
-												chars:      []rune{'_'},
-												ranges:     []rune{'0', '9'},
-												classes:    []*unicode.RangeTable{rangeTable("L")},
-												ignoreCase: false,
-												inverted:   false,
-											},
-										},
-									},
-								},
+								pos:  position{line: 186, col: 29, offset: 3934},
+								name: "Expr",
 							},
 						},
 						&zeroOrMoreExpr{
@@ -3698,9 +4509,16 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 169, col: 24, offset: 3548},
-							val:        ":",
+							pos:        position{line: 187, col: 5, offset: 3960},
+							val:        "else",
+							ignoreCase: false,
+						},
+						&charClassMatcher{
+							pos:        position{line: 187, col: 12, offset: 3967},
+							val:        "[ \\t\\r\\n]",
+							chars:      []rune{' ', '\t', '\r', '\n'},
 							ignoreCase: false,
+							inverted:   false,
 						},
 						&zeroOrMoreExpr{
 							pos: position{line: 468, col: 5, offset: 8728},
@@ -3743,10 +4561,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 169, col: 31, offset: 3555},
-							label: "value",
+							pos:   position{line: 187, col: 18, offset: 3973},
+							label: "alternate",
 							expr: &ruleRefExpr{
-								pos:  position{line: 169, col: 37, offset: 3561},
+								pos:  position{line: 187, col: 28, offset: 3983},
 								name: "Expr",
 							},
 						},
@@ -3754,14 +4572,6 @@ var g = &grammar{
 				},
 			},
 		},
-		{
-			name: "Expr",
-			pos:  position{line: 180, col: 1, offset: 3811},
-			expr: &ruleRefExpr{
-				pos:  position{line: 181, col: 5, offset: 3820},
-				name: "LogicalExpression",
-			},
-		},
 		{
 			name: "LogicalExpression",
 			pos:  position{line: 188, col: 1, offset: 3921},
@@ -4703,6 +5513,11 @@ var g = &grammar{
 																val:        "\"",
 																ignoreCase: false,
 															},
+															&litMatcher{
+																pos:        position{line: 390, col: 5, offset: 7423},
+																val:        "$",
+																ignoreCase: false,
+															},
 															&actionExpr{
 																pos: position{line: 391, col: 5, offset: 7431},
 																run: (*parser).callonPrimary17,
@@ -4791,6 +5606,11 @@ var g = &grammar{
 																val:        "\"",
 																ignoreCase: false,
 															},
+															&litMatcher{
+																pos:        position{line: 390, col: 5, offset: 7423},
+																val:        "$",
+																ignoreCase: false,
+															},
 															&actionExpr{
 																pos: position{line: 391, col: 5, offset: 7431},
 																run: (*parser).callonPrimary37,
@@ -5978,15 +6798,70 @@ func (p *parser) callonStart1() (interface{}, error) {
 	return p.cur.onStart1(stack["program"])
 }
 
-func (c *current) onProgram1(body interface{}) (interface{}, error) {
-	return program(body, c.text, c.pos)
+func (c *current) onProgram1(pkg, imports, body interface{}) (interface{}, error) {
+	return program(pkg, imports, body, c.text, c.pos)
 
 }
 
 func (p *parser) callonProgram1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onProgram1(stack["body"])
+	return p.cur.onProgram1(stack["pkg"], stack["imports"], stack["body"])
+}
+
+func (c *current) onPackageClause7() (interface{}, error) {
+	return identifier(c.text, c.pos)
+
+}
+
+func (p *parser) callonPackageClause7() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPackageClause7()
+}
+
+func (c *current) onPackageClause1(name interface{}) (interface{}, error) {
+	return packageClause(name, c.text, c.pos)
+
+}
+
+func (p *parser) callonPackageClause1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPackageClause1(stack["name"])
+}
+
+func (c *current) onImportDeclaration10() (interface{}, error) {
+	return identifier(c.text, c.pos)
+
+}
+
+func (p *parser) callonImportDeclaration10() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportDeclaration10()
+}
+
+func (c *current) onImportDeclaration20() (interface{}, error) {
+	return stringLiteral(c.text, c.pos)
+
+}
+
+func (p *parser) callonImportDeclaration20() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportDeclaration20()
+}
+
+func (c *current) onImportDeclaration1(alias, path interface{}) (interface{}, error) {
+	return importDeclaration(alias, path, c.text, c.pos)
+
+}
+
+func (p *parser) callonImportDeclaration1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportDeclaration1(stack["alias"], stack["path"])
 }
 
 func (c *current) onSourceElements1(head, tail interface{}) (interface{}, error) {
@@ -6000,6 +6875,17 @@ func (p *parser) callonSourceElements1() (interface{}, error) {
 	return p.cur.onSourceElements1(stack["head"], stack["tail"])
 }
 
+func (c *current) onOptionStatement1(declaration interface{}) (interface{}, error) {
+	return optionstmt(declaration, c.text, c.pos)
+
+}
+
+func (p *parser) callonOptionStatement1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOptionStatement1(stack["declaration"])
+}
+
 func (c *current) onVariableStatement1(declaration interface{}) (interface{}, error) {
 	return varstmt(declaration, c.text, c.pos)
 
@@ -6122,7 +7008,7 @@ func (p *parser) callonMemberExpressionProperty2() (interface{}, error) {
 }
 
 func (c *current) onMemberExpressionProperty19(property interface{}) (interface{}, error) {
-	return property, nil
+	return bracketProperty(property)
 
 }
 
@@ -6572,6 +7458,17 @@ func (p *parser) callonProperty1() (interface{}, error) {
 	return p.cur.onProperty1(stack["key"], stack["value"])
 }
 
+func (c *current) onConditionalExpression1(test, consequent, alternate interface{}) (interface{}, error) {
+	return conditionalExpression(test, consequent, alternate, c.text, c.pos)
+
+}
+
+func (p *parser) callonConditionalExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onConditionalExpression1(stack["test"], stack["consequent"], stack["alternate"])
+}
+
 func (c *current) onLogicalExpression16() (interface{}, error) {
 	return logicalOp(c.text)
 
@@ -14,5 +14,7 @@ func NewAST(flux string, opts ...Option) (*ast.Program, error) {
 	if err != nil {
 		return nil, err
 	}
-	return f.(*ast.Program), nil
+	program := f.(*ast.Program)
+	program.Comments = comments(flux)
+	return program, nil
 }
@@ -47,6 +47,48 @@ func TestParse(t *testing.T) {
 						},
 					},
 				},
+				Comments: []*ast.Comment{
+					{Text: "// Comment"},
+				},
+			},
+		},
+		{
+			name: "comments are not found in literals",
+			raw: `a = "// not a comment" // string
+b = a / 2 // division
+a =~ /\/\/a/ // regexp
+`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.VariableDeclaration{
+						Declarations: []*ast.VariableDeclarator{{
+							ID:   &ast.Identifier{Name: "a"},
+							Init: &ast.StringLiteral{Value: "// not a comment"},
+						}},
+					},
+					&ast.VariableDeclaration{
+						Declarations: []*ast.VariableDeclarator{{
+							ID: &ast.Identifier{Name: "b"},
+							Init: &ast.BinaryExpression{
+								Operator: ast.DivisionOperator,
+								Left:     &ast.Identifier{Name: "a"},
+								Right:    &ast.IntegerLiteral{Value: 2},
+							},
+						}},
+					},
+					&ast.ExpressionStatement{
+						Expression: &ast.BinaryExpression{
+							Operator: ast.RegexpMatchOperator,
+							Left:     &ast.Identifier{Name: "a"},
+							Right:    &ast.RegexpLiteral{Value: regexp.MustCompile("//a")},
+						},
+					},
+				},
+				Comments: []*ast.Comment{
+					{Text: "// string"},
+					{Text: "// division"},
+					{Text: "// regexp"},
+				},
 			},
 		},
 		{
@@ -64,6 +106,106 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "package clause",
+			raw: `package foo
+			x = 1`,
+			want: &ast.Program{
+				Package: &ast.PackageClause{
+					Name: &ast.Identifier{Name: "foo"},
+				},
+				Body: []ast.Statement{
+					&ast.VariableDeclaration{
+						Declarations: []*ast.VariableDeclarator{{
+							ID:   &ast.Identifier{Name: "x"},
+							Init: &ast.IntegerLiteral{Value: 1},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "imports",
+			raw: `import "math"
+			import m "path/to/math"
+			m.pi`,
+			want: &ast.Program{
+				Imports: []*ast.ImportDeclaration{
+					{
+						Path: &ast.StringLiteral{Value: "math"},
+					},
+					{
+						As:   &ast.Identifier{Name: "m"},
+						Path: &ast.StringLiteral{Value: "path/to/math"},
+					},
+				},
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.MemberExpression{
+							Object:   &ast.Identifier{Name: "m"},
+							Property: &ast.Identifier{Name: "pi"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "package clause with imports",
+			raw: `package foo
+			import "math"
+			math.pi`,
+			want: &ast.Program{
+				Package: &ast.PackageClause{
+					Name: &ast.Identifier{Name: "foo"},
+				},
+				Imports: []*ast.ImportDeclaration{{
+					Path: &ast.StringLiteral{Value: "math"},
+				}},
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.MemberExpression{
+							Object:   &ast.Identifier{Name: "math"},
+							Property: &ast.Identifier{Name: "pi"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "option statement",
+			raw: `option now = 2018-05-22T19:53:26Z
+			x = now`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.OptionStatement{
+						Declaration: &ast.VariableDeclarator{
+							ID:   &ast.Identifier{Name: "now"},
+							Init: &ast.DateTimeLiteral{Value: time.Date(2018, 5, 22, 19, 53, 26, 0, time.UTC)},
+						},
+					},
+					&ast.VariableDeclaration{
+						Declarations: []*ast.VariableDeclarator{{
+							ID:   &ast.Identifier{Name: "x"},
+							Init: &ast.Identifier{Name: "now"},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "variable named option",
+			raw:  `option = 1`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.VariableDeclaration{
+						Declarations: []*ast.VariableDeclarator{{
+							ID:   &ast.Identifier{Name: "option"},
+							Init: &ast.IntegerLiteral{Value: 1},
+						}},
+					},
+				},
+			},
+		},
 		{
 			name: "regex literal",
 			raw:  `/.*/`,
@@ -577,6 +719,201 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "conditional expression",
+			raw:  `if a < 10 then "small" else "large"`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.ConditionalExpression{
+							Test: &ast.BinaryExpression{
+								Operator: ast.LessThanOperator,
+								Left:     &ast.Identifier{Name: "a"},
+								Right:    &ast.IntegerLiteral{Value: 10},
+							},
+							Consequent: &ast.StringLiteral{Value: "small"},
+							Alternate:  &ast.StringLiteral{Value: "large"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "nested conditional expressions",
+			raw: `x = if a then 1
+    else if b then 2
+    else 3`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.VariableDeclaration{
+						Declarations: []*ast.VariableDeclarator{{
+							ID: &ast.Identifier{Name: "x"},
+							Init: &ast.ConditionalExpression{
+								Test:       &ast.Identifier{Name: "a"},
+								Consequent: &ast.IntegerLiteral{Value: 1},
+								Alternate: &ast.ConditionalExpression{
+									Test:       &ast.Identifier{Name: "b"},
+									Consequent: &ast.IntegerLiteral{Value: 2},
+									Alternate:  &ast.IntegerLiteral{Value: 3},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "conditional expression in function body",
+			raw:  `f = (r) => if r._value > 0 and r.ok then r._value else -r._value`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.VariableDeclaration{
+						Declarations: []*ast.VariableDeclarator{{
+							ID: &ast.Identifier{Name: "f"},
+							Init: &ast.ArrowFunctionExpression{
+								Params: []*ast.Property{{Key: &ast.Identifier{Name: "r"}}},
+								Body: &ast.ConditionalExpression{
+									Test: &ast.LogicalExpression{
+										Operator: ast.AndOperator,
+										Left: &ast.BinaryExpression{
+											Operator: ast.GreaterThanOperator,
+											Left: &ast.MemberExpression{
+												Object:   &ast.Identifier{Name: "r"},
+												Property: &ast.Identifier{Name: "_value"},
+											},
+											Right: &ast.IntegerLiteral{Value: 0},
+										},
+										Right: &ast.MemberExpression{
+											Object:   &ast.Identifier{Name: "r"},
+											Property: &ast.Identifier{Name: "ok"},
+										},
+									},
+									Consequent: &ast.MemberExpression{
+										Object:   &ast.Identifier{Name: "r"},
+										Property: &ast.Identifier{Name: "_value"},
+									},
+									Alternate: &ast.UnaryExpression{
+										Operator: ast.SubtractionOperator,
+										Argument: &ast.MemberExpression{
+											Object:   &ast.Identifier{Name: "r"},
+											Property: &ast.Identifier{Name: "_value"},
+										},
+									},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "identifier starting with if",
+			raw:  `iffy == 1`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.BinaryExpression{
+							Operator: ast.EqualOperator,
+							Left:     &ast.Identifier{Name: "iffy"},
+							Right:    &ast.IntegerLiteral{Value: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "index expression",
+			raw:  `a[3]`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.IndexExpression{
+							Array: &ast.Identifier{Name: "a"},
+							Index: &ast.IntegerLiteral{Value: 3},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "index and member expressions",
+			raw:  `a.b[i]["c"]`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.MemberExpression{
+							Object: &ast.IndexExpression{
+								Array: &ast.MemberExpression{
+									Object:   &ast.Identifier{Name: "a"},
+									Property: &ast.Identifier{Name: "b"},
+								},
+								Index: &ast.Identifier{Name: "i"},
+							},
+							Property: &ast.StringLiteral{Value: "c"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "index call result",
+			raw:  `f()[0]`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.IndexExpression{
+							Array: &ast.CallExpression{
+								Callee: &ast.Identifier{Name: "f"},
+							},
+							Index: &ast.IntegerLiteral{Value: 0},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "string interpolation",
+			raw:  `"${r.host}-${r[\"region\"]}"`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.StringExpression{
+							Parts: []ast.StringExpressionPart{
+								&ast.InterpolatedPart{
+									Expression: &ast.MemberExpression{
+										Object:   &ast.Identifier{Name: "r"},
+										Property: &ast.Identifier{Name: "host"},
+									},
+								},
+								&ast.TextPart{Value: "-"},
+								&ast.InterpolatedPart{
+									Expression: &ast.MemberExpression{
+										Object:   &ast.Identifier{Name: "r"},
+										Property: &ast.StringLiteral{Value: "region"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "escaped string interpolation",
+			raw:  `"costs \${price}"`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.StringLiteral{Value: "costs ${price}"},
+					},
+				},
+			},
+		},
+		{
+			name:    "unterminated string interpolation",
+			raw:     `"${a"`,
+			wantErr: true,
+		},
 		{
 			name: "unary expressions with too many comments",
 			raw: `// define a
@@ -621,6 +958,11 @@ a = 5.0
 						},
 					},
 				},
+				Comments: []*ast.Comment{
+					{Text: "// define a"},
+					{Text: "// eval this"},
+					{Text: "// or this"},
+				},
 			},
 		},
 		{
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,13 +18,42 @@ func toIfaceSlice(v interface{}) []interface{} {
 	return v.([]interface{})
 }
 
-func program(body interface{}, text []byte, pos position) (*ast.Program, error) {
-	return &ast.Program{
+func program(pkg, imports, body interface{}, text []byte, pos position) (*ast.Program, error) {
+	p := &ast.Program{
 		Body:     body.([]ast.Statement),
 		BaseNode: base(text, pos),
+	}
+	if pkg != nil {
+		p.Package = pkg.(*ast.PackageClause)
+	}
+	for _, imp := range toIfaceSlice(imports) {
+		p.Imports = append(p.Imports, toIfaceSlice(imp)[0].(*ast.ImportDeclaration))
+	}
+	return p, nil
+}
+
+func packageClause(name interface{}, text []byte, pos position) (*ast.PackageClause, error) {
+	return &ast.PackageClause{
+		Name:     name.(*ast.Identifier),
+		BaseNode: base(text, pos),
 	}, nil
 }
 
+func importDeclaration(alias, path interface{}, text []byte, pos position) (*ast.ImportDeclaration, error) {
+	lit, ok := path.(*ast.StringLiteral)
+	if !ok {
+		return nil, errors.New("import path must be a string literal")
+	}
+	imp := &ast.ImportDeclaration{
+		Path:     lit,
+		BaseNode: base(text, pos),
+	}
+	if alias != nil {
+		imp.As = toIfaceSlice(alias)[0].(*ast.Identifier)
+	}
+	return imp, nil
+}
+
 func srcElems(head, tails interface{}) ([]ast.Statement, error) {
 	elems := []ast.Statement{head.(ast.Statement)}
 	for _, tail := range toIfaceSlice(tails) {
@@ -52,6 +83,13 @@ func varstmt(declaration interface{}, text []byte, pos position) (*ast.VariableD
 	}, nil
 }
 
+func optionstmt(declaration interface{}, text []byte, pos position) (*ast.OptionStatement, error) {
+	return &ast.OptionStatement{
+		Declaration: declaration.(*ast.VariableDeclarator),
+		BaseNode:    base(text, pos),
+	}, nil
+}
+
 func vardecl(id, initializer interface{}, text []byte, pos position) (*ast.VariableDeclarator, error) {
 	return &ast.VariableDeclarator{
 		ID:   id.(*ast.Identifier),
@@ -96,6 +134,14 @@ func incompletePipeExpr(call interface{}, text []byte, pos position) (*ast.PipeE
 func memberexprs(head, tail interface{}, text []byte, pos position) (ast.Expression, error) {
 	res := head.(ast.Expression)
 	for _, prop := range toIfaceSlice(tail) {
+		if idx, ok := prop.(index); ok {
+			res = &ast.IndexExpression{
+				Array:    res,
+				Index:    idx.expr,
+				BaseNode: base(text, pos),
+			}
+			continue
+		}
 		res = &ast.MemberExpression{
 			Object:   res,
 			Property: prop.(ast.Expression),
@@ -105,7 +151,18 @@ func memberexprs(head, tail interface{}, text []byte, pos position) (ast.Express
 	return res, nil
 }
 
-func memberexpr(object, property interface{}, text []byte, pos position) (*ast.MemberExpression, error) {
+func memberexpr(object, property interface{}, text []byte, pos position) (ast.Expression, error) {
+	if idx, ok := property.(index); ok {
+		e := &ast.IndexExpression{
+			Index:    idx.expr,
+			BaseNode: base(text, pos),
+		}
+		if object != nil {
+			e.Array = object.(ast.Expression)
+		}
+		return e, nil
+	}
+
 	m := &ast.MemberExpression{
 		BaseNode: base(text, pos),
 	}
@@ -115,12 +172,26 @@ func memberexpr(object, property interface{}, text []byte, pos position) (*ast.M
 	}
 
 	if property != nil {
-		m.Property = property.(*ast.Identifier)
+		m.Property = property.(ast.Expression)
 	}
 
 	return m, nil
 }
 
+// index is a bracketed member expression property that indexes into an array.
+type index struct {
+	expr ast.Expression
+}
+
+// bracketProperty returns the property of a bracketed member expression.
+// String literals access a property of an object, any other expression indexes into an array.
+func bracketProperty(property interface{}) (interface{}, error) {
+	if s, ok := property.(*ast.StringLiteral); ok {
+		return s, nil
+	}
+	return index{expr: property.(ast.Expression)}, nil
+}
+
 func callexpr(callee, args interface{}, text []byte, pos position) (*ast.CallExpression, error) {
 	c := &ast.CallExpression{
 		BaseNode: base(text, pos),
@@ -146,6 +217,9 @@ func callexprs(head, tail interface{}, text []byte, pos position) (ast.Expressio
 		case *ast.MemberExpression:
 			elem.Object = expr
 			expr = elem
+		case *ast.IndexExpression:
+			elem.Array = expr
+			expr = elem
 		}
 	}
 	return expr, nil
@@ -227,6 +301,15 @@ func logicalExpression(head, tails interface{}, text []byte, pos position) (ast.
 	return res, nil
 }
 
+func conditionalExpression(test, consequent, alternate interface{}, text []byte, pos position) (*ast.ConditionalExpression, error) {
+	return &ast.ConditionalExpression{
+		Test:       test.(ast.Expression),
+		Consequent: consequent.(ast.Expression),
+		Alternate:  alternate.(ast.Expression),
+		BaseNode:   base(text, pos),
+	}, nil
+}
+
 func logicalOp(text []byte) (ast.LogicalOperatorKind, error) {
 	return ast.LogicalOperatorLookup(strings.ToLower(string(text))), nil
 }
@@ -257,17 +340,135 @@ func operator(text []byte) (ast.OperatorKind, error) {
 	return ast.OperatorLookup(strings.ToLower(string(text))), nil
 }
 
-func stringLiteral(text []byte, pos position) (*ast.StringLiteral, error) {
-	s, err := strconv.Unquote(string(text))
+// stringLiteral returns a string literal, or a string expression if the string contains interpolated expressions.
+func stringLiteral(text []byte, pos position) (ast.Expression, error) {
+	parts, err := stringParts(text[1:len(text)-1], pos)
 	if err != nil {
 		return nil, err
 	}
-	return &ast.StringLiteral{
+	switch len(parts) {
+	case 0:
+		return &ast.StringLiteral{
+			BaseNode: base(text, pos),
+		}, nil
+	case 1:
+		if t, ok := parts[0].(*ast.TextPart); ok {
+			return &ast.StringLiteral{
+				BaseNode: base(text, pos),
+				Value:    t.Value,
+			}, nil
+		}
+	}
+	return &ast.StringExpression{
 		BaseNode: base(text, pos),
-		Value:    s,
+		Parts:    parts,
 	}, nil
 }
 
+// stringParts splits the body of a string literal into text and ${} interpolated expressions.
+// A dollar sign can be escaped as \$ to prevent interpolation.
+func stringParts(body []byte, pos position) ([]ast.StringExpressionPart, error) {
+	var parts []ast.StringExpressionPart
+	var text []byte
+	addText := func() error {
+		if len(text) == 0 {
+			return nil
+		}
+		v, err := strconv.Unquote(`"` + string(text) + `"`)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, &ast.TextPart{
+			BaseNode: base(text, pos),
+			Value:    v,
+		})
+		text = nil
+		return nil
+	}
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\' && i+1 < len(body):
+			if body[i+1] == '$' {
+				text = append(text, '$')
+			} else {
+				text = append(text, body[i], body[i+1])
+			}
+			i++
+		case body[i] == '$' && i+1 < len(body) && body[i+1] == '{':
+			end := interpolationEnd(body, i+2)
+			if end < 0 {
+				return nil, errors.New("interpolated expression not terminated")
+			}
+			if err := addText(); err != nil {
+				return nil, err
+			}
+			expr, err := interpolatedExpression(body[i+2 : end])
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, &ast.InterpolatedPart{
+				BaseNode:   base(body[i:end+1], pos),
+				Expression: expr,
+			})
+			i = end
+		default:
+			text = append(text, body[i])
+		}
+	}
+	if err := addText(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// interpolationEnd returns the index of the brace closing an interpolated expression starting at start,
+// or -1 if the expression is not terminated.
+func interpolationEnd(body []byte, start int) int {
+	depth := 1
+	for i := start; i < len(body); i++ {
+		switch body[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parse is Parse, it is assigned in init to avoid an initialization cycle
+// between the grammar and the parsing of interpolated expressions.
+var parse func(filename string, b []byte, opts ...Option) (interface{}, error)
+
+func init() {
+	parse = Parse
+}
+
+// interpolatedExpression parses the source of an interpolated expression.
+// Double quotes within the expression are escaped in the enclosing string literal.
+func interpolatedExpression(src []byte) (ast.Expression, error) {
+	unquoted, err := strconv.Unquote(`"` + string(src) + `"`)
+	if err != nil {
+		return nil, err
+	}
+	v, err := parse("", []byte(unquoted))
+	if err != nil {
+		return nil, fmt.Errorf("invalid interpolated expression %q: %v", unquoted, err)
+	}
+	body := v.(*ast.Program).Body
+	if len(body) != 1 {
+		return nil, fmt.Errorf("interpolated expression %q must be a single expression", unquoted)
+	}
+	stmt, ok := body[0].(*ast.ExpressionStatement)
+	if !ok {
+		return nil, fmt.Errorf("interpolated expression %q must be an expression", unquoted)
+	}
+	return stmt.Expression, nil
+}
+
 func pipeLiteral(text []byte, pos position) *ast.PipeLiteral {
 	return &ast.PipeLiteral{
 		BaseNode: base(text, pos),
@@ -2,8 +2,10 @@ package plan
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/influxdata/platform/query"
+	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -14,6 +16,10 @@ type LogicalPlanSpec struct {
 	Procedures map[ProcedureID]*Procedure
 	Order      []ProcedureID
 	Resources  query.ResourceManagement
+	// Now is the time set by the query, it is zero if the query did not set it.
+	Now time.Time
+	// Location is the time zone of the query, UTC is used if it is nil.
+	Location *time.Location
 }
 
 func (lp *LogicalPlanSpec) Do(f func(pr *Procedure)) {
@@ -44,6 +50,14 @@ func (p *logicalPlanner) Plan(q *query.Spec) (*LogicalPlanSpec, error) {
 	p.plan = &LogicalPlanSpec{
 		Procedures: make(map[ProcedureID]*Procedure),
 		Resources:  q.Resources,
+		Now:        q.Now,
+	}
+	if q.Location != "" {
+		loc, err := time.LoadLocation(q.Location)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid location")
+		}
+		p.plan.Location = loc
 	}
 	err := q.Walk(p.walkQuery)
 	if err != nil {
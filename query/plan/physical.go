@@ -15,8 +15,10 @@ const DefaultYieldName = "_result"
 
 type PlanSpec struct {
 	// Now represents the relative currentl time of the plan.
-	Now    time.Time
-	Bounds BoundsSpec
+	Now time.Time
+	// Location is the time zone of the plan, UTC is used if it is nil.
+	Location *time.Location
+	Bounds   BoundsSpec
 	// Procedures is a set of all operations
 	Procedures map[ProcedureID]*Procedure
 	Order      []ProcedureID
@@ -42,6 +44,7 @@ func (p *PlanSpec) lookup(id ProcedureID) *Procedure {
 
 type Planner interface {
 	// Plan create a plan from the logical plan and available storage.
+	// Relative times are resolved against now, unless the logical plan sets its own time.
 	Plan(p *LogicalPlanSpec, s Storage, now time.Time) (*PlanSpec, error)
 }
 
@@ -62,8 +65,13 @@ func NewPlanner() Planner {
 }
 
 func (p *planner) Plan(lp *LogicalPlanSpec, s Storage, now time.Time) (*PlanSpec, error) {
+	// The time set by the query takes precedence.
+	if !lp.Now.IsZero() {
+		now = lp.Now
+	}
 	p.plan = &PlanSpec{
 		Now:        now,
+		Location:   lp.Location,
 		Procedures: make(map[ProcedureID]*Procedure, len(lp.Procedures)),
 		Order:      make([]ProcedureID, 0, len(lp.Order)),
 		Resources:  lp.Resources,
@@ -170,9 +178,15 @@ func (p *planner) Plan(lp *LogicalPlanSpec, s Storage, now time.Time) (*PlanSpec
 		return nil, errors.New("unbounded queries are not supported. Add a 'range' call to bound the query.")
 	}
 
+	// Restrict storage reads to the shards that hold the data.
+	concurrency := len(p.plan.Procedures)
+	if s != nil {
+		concurrency += p.assignShards(s.ShardMapping(), now)
+	}
+
 	// Update concurrency quota
 	if p.plan.Resources.ConcurrencyQuota == 0 {
-		p.plan.Resources.ConcurrencyQuota = len(p.plan.Procedures)
+		p.plan.Resources.ConcurrencyQuota = concurrency
 	}
 	// Update memory quota
 	if p.plan.Resources.MemoryBytesQuota == 0 {
@@ -182,6 +196,36 @@ func (p *planner) Plan(lp *LogicalPlanSpec, s Storage, now time.Time) (*PlanSpec
 	return p.plan, nil
 }
 
+// assignShards informs each shard aware procedure of the shards it needs to read.
+// The return value is the additional concurrency gained by reading from multiple nodes in parallel.
+func (p *planner) assignShards(shardMap ShardMap, now time.Time) int {
+	extra := 0
+	for _, id := range p.plan.Order {
+		pr := p.plan.Procedures[id]
+		sa, ok := pr.Spec.(ShardAwareProcedureSpec)
+		if !ok {
+			continue
+		}
+		bounds := sa.TimeBounds()
+		r := TimeRange{
+			Start: bounds.Start.Time(now),
+			Stop:  bounds.Stop.Time(now),
+		}
+		if bounds.Stop.IsZero() {
+			r.Stop = now
+		}
+		shards := shardMap.Lookup(sa.ShardKey(), r)
+		if len(shards) == 0 {
+			// Nothing is known about where the data lives, read from all nodes.
+			continue
+		}
+		if n := len(sa.AssignShards(shards)); n > 1 {
+			extra += n - 1
+		}
+	}
+	return extra
+}
+
 func hasKind(kind ProcedureKind, kinds []ProcedureKind) bool {
 	for _, k := range kinds {
 		if k == kind {
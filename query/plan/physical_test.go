@@ -103,6 +103,77 @@ func TestPhysicalPlanner_Plan(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "now set by query",
+			lp: &plan.LogicalPlanSpec{
+				Now: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					plan.ProcedureIDFromOperationID("from"): {
+						ID: plan.ProcedureIDFromOperationID("from"),
+						Spec: &functions.FromProcedureSpec{
+							Database: "mydb",
+						},
+						Parents:  nil,
+						Children: []plan.ProcedureID{plan.ProcedureIDFromOperationID("range")},
+					},
+					plan.ProcedureIDFromOperationID("range"): {
+						ID: plan.ProcedureIDFromOperationID("range"),
+						Spec: &functions.RangeProcedureSpec{
+							Bounds: plan.BoundsSpec{
+								Start: query.Time{
+									IsRelative: true,
+									Relative:   -1 * time.Hour,
+								},
+							},
+						},
+						Parents: []plan.ProcedureID{
+							plan.ProcedureIDFromOperationID("from"),
+						},
+						Children: nil,
+					},
+				},
+				Order: []plan.ProcedureID{
+					plan.ProcedureIDFromOperationID("from"),
+					plan.ProcedureIDFromOperationID("range"),
+				},
+			},
+			pp: &plan.PlanSpec{
+				Now: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+				Resources: query.ResourceManagement{
+					ConcurrencyQuota: 1,
+					MemoryBytesQuota: math.MaxInt64,
+				},
+				Bounds: plan.BoundsSpec{
+					Start: query.Time{
+						IsRelative: true,
+						Relative:   -1 * time.Hour,
+					},
+				},
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					plan.ProcedureIDFromOperationID("from"): {
+						ID: plan.ProcedureIDFromOperationID("from"),
+						Spec: &functions.FromProcedureSpec{
+							Database:  "mydb",
+							BoundsSet: true,
+							Bounds: plan.BoundsSpec{
+								Start: query.Time{
+									IsRelative: true,
+									Relative:   -1 * time.Hour,
+								},
+							},
+						},
+						Parents:  nil,
+						Children: []plan.ProcedureID{},
+					},
+				},
+				Results: map[string]plan.YieldSpec{
+					plan.DefaultYieldName: {ID: plan.ProcedureIDFromOperationID("from")},
+				},
+				Order: []plan.ProcedureID{
+					plan.ProcedureIDFromOperationID("from"),
+				},
+			},
+		},
 		{
 			name: "single push down with match",
 			lp: &plan.LogicalPlanSpec{
@@ -1048,11 +1119,124 @@ func TestPhysicalPlanner_Plan_PushDown_Mixed(t *testing.T) {
 	PhysicalPlanTestHelper(t, lp, want)
 }
 
+type staticStorage plan.ShardMap
+
+func (s staticStorage) ShardMapping() plan.ShardMap {
+	return plan.ShardMap(s)
+}
+
+func TestPhysicalPlanner_Plan_Shards(t *testing.T) {
+	now := time.Date(2017, 8, 8, 0, 0, 0, 0, time.UTC)
+	storage := staticStorage{
+		"mydb": {
+			{
+				Node:  "hostA",
+				Range: plan.TimeRange{Start: now.Add(-3 * time.Hour), Stop: now.Add(-2 * time.Hour)},
+			},
+			{
+				Node:  "hostB",
+				Range: plan.TimeRange{Start: now.Add(-2 * time.Hour), Stop: now.Add(-1 * time.Hour)},
+			},
+			{
+				Node:  "hostC",
+				Range: plan.TimeRange{Start: now.Add(-1 * time.Hour), Stop: now},
+			},
+		},
+	}
+	testCases := []struct {
+		name        string
+		start       time.Duration
+		stop        time.Duration
+		hosts       []string
+		wantHosts   []string
+		concurrency int
+	}{
+		{
+			name:        "single shard",
+			start:       -1 * time.Hour,
+			wantHosts:   []string{"hostC"},
+			concurrency: 1,
+		},
+		{
+			name:        "multiple shards",
+			start:       -90 * time.Minute,
+			wantHosts:   []string{"hostB", "hostC"},
+			concurrency: 2,
+		},
+		{
+			name:        "explicit hosts",
+			start:       -3 * time.Hour,
+			hosts:       []string{"hostA", "hostD"},
+			wantHosts:   []string{"hostA"},
+			concurrency: 1,
+		},
+		{
+			name:        "explicit hosts on multiple shards",
+			start:       -3 * time.Hour,
+			hosts:       []string{"hostA", "hostC"},
+			wantHosts:   []string{"hostA", "hostC"},
+			concurrency: 2,
+		},
+		{
+			name:        "no shards",
+			start:       -5 * time.Hour,
+			stop:        -4 * time.Hour,
+			hosts:       []string{"hostD"},
+			wantHosts:   []string{"hostD"},
+			concurrency: 1,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fromID := plan.ProcedureIDFromOperationID("from")
+			bounds := plan.BoundsSpec{
+				Start: query.Time{
+					IsRelative: true,
+					Relative:   tc.start,
+				},
+				Stop: query.Time{
+					IsRelative: true,
+					Relative:   tc.stop,
+				},
+			}
+			lp := &plan.LogicalPlanSpec{
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					fromID: {
+						ID: fromID,
+						Spec: &functions.FromProcedureSpec{
+							Database:  "mydb",
+							Hosts:     tc.hosts,
+							BoundsSet: true,
+							Bounds:    bounds,
+						},
+					},
+				},
+				Order: []plan.ProcedureID{fromID},
+			}
+
+			got, err := plan.NewPlanner().Plan(lp, storage, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec := got.Procedures[fromID].Spec.(*functions.FromProcedureSpec)
+			if !cmp.Equal(tc.wantHosts, spec.Hosts) {
+				t.Errorf("unexpected hosts -want/+got:\n%s", cmp.Diff(tc.wantHosts, spec.Hosts))
+			}
+			if got, want := got.Resources.ConcurrencyQuota, tc.concurrency; got != want {
+				t.Errorf("unexpected concurrency quota: got %d want %d", got, want)
+			}
+		})
+	}
+}
+
 func PhysicalPlanTestHelper(t *testing.T, lp *plan.LogicalPlanSpec, want *plan.PlanSpec) {
 	t.Helper()
-	// Setup expected now time
+	// Setup expected now time, unless the logical plan sets its own
 	now := time.Now()
-	want.Now = now
+	if lp.Now.IsZero() {
+		want.Now = now
+	}
 
 	planner := plan.NewPlanner()
 	got, err := planner.Plan(lp, nil, now)
@@ -77,6 +77,24 @@ type BoundedProcedureSpec interface {
 	TimeBounds() BoundsSpec
 }
 
+// ShardAwareProcedureSpec is implemented by procedures that read directly from storage
+// and can be restricted to the shards holding the data they read.
+type ShardAwareProcedureSpec interface {
+	BoundedProcedureSpec
+	// ShardKey reports the name of the database, used to find its shards in a ShardMap.
+	ShardKey() string
+	// AssignShards restricts the procedure to read only from the given shards.
+	// It returns the nodes the procedure reads from.
+	AssignShards(shards []Shard) []string
+}
+
+// PartitionIndependentProcedureSpec is implemented by procedures that process each table independently of all other tables.
+// Tables whose partition keys differ in columns other than the time bounds never produce tables with the same partition key,
+// so such tables may be processed concurrently.
+type PartitionIndependentProcedureSpec interface {
+	PartitionIndependent()
+}
+
 type YieldProcedureSpec interface {
 	YieldName() string
 }
@@ -1,6 +1,9 @@
 package plan
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 type Storage interface {
 	ShardMapping() ShardMap
@@ -9,12 +12,48 @@ type Storage interface {
 // ShardMap is a mapping of database names to list of shards for that database.
 type ShardMap map[string][]Shard
 
+// Lookup returns the shards of the database that overlap the time range.
+// A nil slice is returned if the database is not present in the map.
+func (m ShardMap) Lookup(db string, r TimeRange) []Shard {
+	shards, ok := m[db]
+	if !ok {
+		return nil
+	}
+	overlapping := make([]Shard, 0, len(shards))
+	for _, s := range shards {
+		if s.Range.Overlaps(r) {
+			overlapping = append(overlapping, s)
+		}
+	}
+	return overlapping
+}
+
 type Shard struct {
 	Node  string
 	Range TimeRange
 }
 
+// ShardNodes returns the sorted list of distinct nodes that hold the shards.
+func ShardNodes(shards []Shard) []string {
+	var nodes []string
+	seen := make(map[string]bool, len(shards))
+	for _, s := range shards {
+		if !seen[s.Node] {
+			seen[s.Node] = true
+			nodes = append(nodes, s.Node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
 type TimeRange struct {
 	Start time.Time
 	Stop  time.Time
 }
+
+// Overlaps reports whether the two time ranges share any time.
+// Both ranges are treated as half open, the start is inclusive and the stop is exclusive.
+func (r TimeRange) Overlaps(o TimeRange) bool {
+	return r.Start.Before(o.Stop) && o.Start.Before(r.Stop)
+}
@@ -104,25 +104,53 @@ func newResultIterator(q Query) *resultIterator {
 }
 
 func (r *resultIterator) More() bool {
+	if r.wait() && r.results.More() {
+		return true
+	}
+	r.query.Done()
+	return false
+}
+
+// wait waits for the query results to be ready.
+// It reports false if the query finished or was canceled before producing any results.
+func (r *resultIterator) wait() bool {
 	if !r.ready {
 		select {
 		case <-r.cancel:
-			goto DONE
+			return false
 		case results, ok := <-r.query.Ready():
 			if !ok {
-				goto DONE
+				return false
 			}
 			r.ready = true
 			r.results = NewMapResultIterator(results)
 		}
 	}
-	if r.results.More() {
-		return true
-	}
+	return true
+}
 
-DONE:
-	r.query.Done()
-	return false
+// Stream streams the blocks of all remaining results as they are produced by the query.
+func (r *resultIterator) Stream(f func(name string, b Block) error) error {
+	defer r.query.Done()
+	if !r.wait() {
+		return r.query.Err()
+	}
+	var results []Result
+	for r.results.More() {
+		results = append(results, r.results.Next())
+	}
+	err := StreamResults(results, func(name string, b Block) error {
+		if err := f(name, b); err != nil {
+			// Stop the query so that the remaining results stop producing blocks.
+			r.query.Cancel()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return r.query.Err()
 }
 
 func (r *resultIterator) Next() Result {
@@ -15,11 +15,10 @@ import (
 	"path/filepath"
 
 	prompt "github.com/c-bata/go-prompt"
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/query"
-	"github.com/influxdata/platform/query/control"
 	"github.com/influxdata/platform/query/execute"
 	"github.com/influxdata/platform/query/functions"
-	"github.com/influxdata/platform/query/id"
 	"github.com/influxdata/platform/query/interpreter"
 	"github.com/influxdata/platform/query/parser"
 	"github.com/influxdata/platform/query/semantic"
@@ -28,11 +27,12 @@ import (
 )
 
 type REPL struct {
-	orgID id.ID
+	orgID platform.ID
 
 	scope        *interpreter.Scope
 	declarations semantic.DeclarationScope
-	c            *control.Controller
+	importer     *query.PackageImporter
+	qs           query.QueryService
 
 	cancelMu   sync.Mutex
 	cancelFunc context.CancelFunc
@@ -54,15 +54,18 @@ func addBuiltIn(script string, scope *interpreter.Scope, declarations semantic.D
 	return nil
 }
 
-func New(c *control.Controller, orgID id.ID) *REPL {
-	scope, declarations := query.BuiltIns()
+// New creates a REPL that executes queries for the organization using qs.
+// The query service may execute queries locally or on a remote server.
+func New(qs query.QueryService, orgID platform.ID) *REPL {
+	scope, declarations, importer := query.BuiltInsWithImporter()
 	interpScope := interpreter.NewScopeWithValues(scope)
 	addBuiltIn("run = () => yield(table:_)", interpScope, declarations)
 	return &REPL{
 		orgID:        orgID,
 		scope:        interpScope,
 		declarations: declarations,
-		c:            c,
+		importer:     importer,
+		qs:           qs,
 	}
 }
 
@@ -165,10 +168,18 @@ func (r *REPL) executeLine(t string, expectYield bool) (values.Value, error) {
 		return nil, err
 	}
 
+	if err := r.importer.ResolveImports(astProg, r.scope, r.declarations); err != nil {
+		return nil, err
+	}
+
+	decls := r.declarations.Copy()
 	semProg, err := semantic.New(astProg, r.declarations)
 	if err != nil {
 		return nil, err
 	}
+	if err := semantic.Infer(semProg, decls); err != nil {
+		return nil, err
+	}
 
 	if err := interpreter.Eval(semProg, r.scope); err != nil {
 		return nil, err
@@ -187,6 +198,7 @@ func (r *REPL) executeLine(t string, expectYield bool) (values.Value, error) {
 	}
 
 	r.scope.Set("_", v)
+	r.declarations["_"] = semantic.NewExternalVariableDeclaration("_", v.Type())
 
 	// Print value
 	if v.Type() != semantic.Invalid {
@@ -202,29 +214,23 @@ func (r *REPL) doQuery(spec *query.Spec) error {
 	defer cancelFunc()
 	defer r.clearCancel()
 
-	q, err := r.c.Query(ctx, r.orgID, spec)
+	results, err := r.qs.Query(ctx, r.orgID, spec)
 	if err != nil {
 		return err
 	}
-	defer q.Done()
-
-	results, ok := <-q.Ready()
-	if !ok {
-		err := q.Err()
-		return err
-	}
-
-	names := make([]string, 0, len(results))
-	for name := range results {
-		names = append(names, name)
-	}
-	sort.Strings(names)
-
-	for _, name := range names {
-		r := results[name]
-		blocks := r.Blocks()
-		fmt.Println("Result:", name)
-		err := blocks.Do(func(b query.Block) error {
+	defer results.Cancel()
+
+	// Cancelling the context only stops queries that have not started returning results,
+	// so the result iterator is cancelled as well.
+	r.setCancel(func() {
+		cancelFunc()
+		results.Cancel()
+	})
+
+	for results.More() {
+		res := results.Next()
+		fmt.Println("Result:", res.Name())
+		err := res.Blocks().Do(func(b query.Block) error {
 			_, err := execute.NewFormatter(b, nil).WriteTo(os.Stdout)
 			return err
 		})
@@ -232,7 +238,7 @@ func (r *REPL) doQuery(spec *query.Spec) error {
 			return err
 		}
 	}
-	return nil
+	return results.Err()
 }
 
 func getFluxFiles(path string) ([]string, error) {
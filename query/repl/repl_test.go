@@ -0,0 +1,171 @@
+package repl
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/execute"
+	"github.com/influxdata/platform/query/execute/executetest"
+)
+
+type queryService struct {
+	specs chan *query.Spec
+}
+
+func (s queryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.ResultIterator, error) {
+	s.specs <- spec
+	return query.NewSliceResultIterator([]query.Result{&executetest.Result{
+		Nm: "_result",
+		Blks: []*executetest.Block{{
+			KeyCols: []string{"_measurement"},
+			ColMeta: []query.ColMeta{
+				{Label: "_time", Type: query.TTime},
+				{Label: "_measurement", Type: query.TString},
+				{Label: "_value", Type: query.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), "cpu", 1.0},
+			},
+		}},
+	}}), nil
+}
+
+func (s queryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.ResultIterator, error) {
+	return nil, fmt.Errorf("unexpected query %q", q)
+}
+
+// requireToken wraps h to reject requests without the token.
+func requireToken(token string, h nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Header.Get("Authorization") != token {
+			w.WriteHeader(nethttp.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func TestREPL_RemoteQuery(t *testing.T) {
+	qs := queryService{specs: make(chan *query.Spec, 1)}
+	h := http.NewQueryHandler()
+	h.QueryService = qs
+	server := httptest.NewServer(requireToken("Token secret", h))
+	defer server.Close()
+
+	r := New(&http.QueryService{Addr: server.URL, Token: "Token secret"}, platform.ID("org1"))
+	if err := r.Input(`from(bucket:"telegraf") |> range(start:-1h)`); err != nil {
+		t.Fatal(err)
+	}
+	spec := <-qs.specs
+	if len(spec.Operations) != 2 || spec.Operations[1].Spec.Kind() != "range" {
+		t.Errorf("unexpected query spec: %+v", spec)
+	}
+
+	r = New(&http.QueryService{Addr: server.URL, Token: "Token nope"}, platform.ID("org1"))
+	if err := r.Input(`from(bucket:"telegraf") |> range(start:-1h)`); err == nil {
+		t.Error("expected an error querying with an unknown token")
+	}
+}
+
+func TestREPL_Cancel(t *testing.T) {
+	started := make(chan struct{})
+	done := make(chan struct{})
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		// Start a response that never completes, until the client goes away.
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.WriteHeader(nethttp.StatusOK)
+		fmt.Fprint(w, "#datatype,string,long,dateTime:RFC3339,double\r\n")
+		w.(nethttp.Flusher).Flush()
+		close(started)
+		<-r.Context().Done()
+		close(done)
+	}))
+	defer server.Close()
+
+	r := New(&http.QueryService{Addr: server.URL}, platform.ID("org1"))
+	errC := make(chan error, 1)
+	go func() {
+		errC <- r.Input(`from(bucket:"telegraf") |> range(start:-1h)`)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the query")
+	}
+	r.cancel()
+
+	select {
+	case <-errC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the canceled query to return")
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the request to be canceled")
+	}
+}
+
+// blockingResults is a result iterator that blocks until it is canceled, ignoring its context.
+type blockingResults struct {
+	started  chan struct{}
+	canceled chan struct{}
+}
+
+func (r *blockingResults) More() bool {
+	close(r.started)
+	<-r.canceled
+	return false
+}
+func (r *blockingResults) Next() query.Result { return nil }
+func (r *blockingResults) Cancel() {
+	select {
+	case <-r.canceled:
+	default:
+		close(r.canceled)
+	}
+}
+func (r *blockingResults) Err() error { return nil }
+
+type blockingQueryService struct {
+	results *blockingResults
+}
+
+func (s blockingQueryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.ResultIterator, error) {
+	return s.results, nil
+}
+
+func (s blockingQueryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.ResultIterator, error) {
+	return s.results, nil
+}
+
+func TestREPL_CancelResults(t *testing.T) {
+	results := &blockingResults{started: make(chan struct{}), canceled: make(chan struct{})}
+	r := New(blockingQueryService{results: results}, platform.ID("org1"))
+	errC := make(chan error, 1)
+	go func() {
+		errC <- r.Input(`from(bucket:"telegraf") |> range(start:-1h)`)
+	}()
+
+	select {
+	case <-results.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the query")
+	}
+	r.cancel()
+
+	select {
+	case <-errC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected canceling the REPL to cancel the results")
+	}
+}
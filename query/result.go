@@ -48,6 +48,8 @@ const (
 	TFloat
 	TString
 	TTime
+	TDuration
+	TBytes
 )
 
 func (t DataType) String() string {
@@ -66,6 +68,10 @@ func (t DataType) String() string {
 		return "string"
 	case TTime:
 		return "time"
+	case TDuration:
+		return "duration"
+	case TBytes:
+		return "bytes"
 	default:
 		return "unknown"
 	}
@@ -87,6 +93,12 @@ type ColReader interface {
 	Floats(j int) []float64
 	Strings(j int) []string
 	Times(j int) []values.Time
+	Durations(j int) []values.Duration
+	Bytes(j int) [][]byte
+	// Nulls reports for each value of the column whether the value is missing.
+	// Nulls returns nil if no value of the column is missing.
+	// The slices returned by the other methods hold the zero value of their type for missing values.
+	Nulls(j int) []bool
 }
 
 type PartitionKey interface {
@@ -100,6 +112,7 @@ type PartitionKey interface {
 	ValueFloat(j int) float64
 	ValueString(j int) string
 	ValueDuration(j int) values.Duration
+	ValueBytes(j int) []byte
 	ValueTime(j int) values.Time
 	Value(j int) values.Value
 
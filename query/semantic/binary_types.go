@@ -7,6 +7,16 @@ type binarySignature struct {
 	left, right Kind
 }
 
+// BinaryType returns the type of the result of a binary expression with operands of the given kinds.
+// It returns Invalid if the operator does not support operands of these kinds.
+func BinaryType(operator ast.OperatorKind, left, right Kind) Type {
+	return binaryTypesLookup[binarySignature{
+		operator: operator,
+		left:     left,
+		right:    right,
+	}]
+}
+
 var binaryTypesLookup = map[binarySignature]Kind{
 	//---------------
 	// Math Operators
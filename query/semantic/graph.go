@@ -17,13 +17,29 @@ type Node interface {
 	NodeType() string
 	Copy() Node
 
+	// Location is the location in the source of the AST node the node was created from.
+	// It is nil if the node was not created from the AST, or the AST has no locations.
+	Location() *ast.SourceLocation
+
 	json.Marshaler
 }
 
+// loc records the source location of a node, it is not part of the JSON encoding of the node.
+type loc struct {
+	l *ast.SourceLocation
+}
+
+func locOf(n ast.Node) loc {
+	return loc{l: n.Location()}
+}
+
+func (l loc) Location() *ast.SourceLocation { return l.l }
+
 func (*Program) node() {}
 
 func (*BlockStatement) node()              {}
 func (*ExpressionStatement) node()         {}
+func (*OptionStatement) node()             {}
 func (*ReturnStatement) node()             {}
 func (*NativeVariableDeclaration) node()   {}
 func (*ExternalVariableDeclaration) node() {}
@@ -34,6 +50,7 @@ func (*BinaryExpression) node()      {}
 func (*CallExpression) node()        {}
 func (*ConditionalExpression) node() {}
 func (*IdentifierExpression) node()  {}
+func (*IndexExpression) node()       {}
 func (*LogicalExpression) node()     {}
 func (*MemberExpression) node()      {}
 func (*ObjectExpression) node()      {}
@@ -59,6 +76,7 @@ type Statement interface {
 
 func (*BlockStatement) stmt()              {}
 func (*ExpressionStatement) stmt()         {}
+func (*OptionStatement) stmt()             {}
 func (*ReturnStatement) stmt()             {}
 func (*NativeVariableDeclaration) stmt()   {}
 func (*ExternalVariableDeclaration) stmt() {}
@@ -79,6 +97,7 @@ func (*DurationLiteral) expression()        {}
 func (*FloatLiteral) expression()           {}
 func (*FunctionExpression) expression()     {}
 func (*IdentifierExpression) expression()   {}
+func (*IndexExpression) expression()        {}
 func (*IntegerLiteral) expression()         {}
 func (*LogicalExpression) expression()      {}
 func (*MemberExpression) expression()       {}
@@ -103,6 +122,8 @@ func (*StringLiteral) literal()          {}
 func (*UnsignedIntegerLiteral) literal() {}
 
 type Program struct {
+	loc
+
 	Body []Statement `json:"body"`
 }
 
@@ -126,6 +147,8 @@ func (p *Program) Copy() Node {
 }
 
 type BlockStatement struct {
+	loc
+
 	Body []Statement `json:"body"`
 }
 
@@ -153,6 +176,8 @@ func (s *BlockStatement) Copy() Node {
 }
 
 type ExpressionStatement struct {
+	loc
+
 	Expression Expression `json:"expression"`
 }
 
@@ -170,7 +195,29 @@ func (s *ExpressionStatement) Copy() Node {
 	return ns
 }
 
+type OptionStatement struct {
+	loc
+
+	Declaration *NativeVariableDeclaration `json:"declaration"`
+}
+
+func (*OptionStatement) NodeType() string { return "OptionStatement" }
+
+func (s *OptionStatement) Copy() Node {
+	if s == nil {
+		return s
+	}
+	ns := new(OptionStatement)
+	*ns = *s
+
+	ns.Declaration = s.Declaration.Copy().(*NativeVariableDeclaration)
+
+	return ns
+}
+
 type ReturnStatement struct {
+	loc
+
 	Argument Expression `json:"argument"`
 }
 
@@ -195,6 +242,8 @@ type VariableDeclaration interface {
 }
 
 type NativeVariableDeclaration struct {
+	loc
+
 	Identifier *Identifier `json:"identifier"`
 	Init       Expression  `json:"init"`
 }
@@ -225,6 +274,8 @@ func (s *NativeVariableDeclaration) Copy() Node {
 }
 
 type ExternalVariableDeclaration struct {
+	loc
+
 	Identifier *Identifier `json:"identifier"`
 	Type       Type        `json:"type"`
 }
@@ -258,6 +309,8 @@ func (s *ExternalVariableDeclaration) Copy() Node {
 }
 
 type ArrayExpression struct {
+	loc
+
 	Elements []Expression `json:"elements"`
 	typ      atomic.Value //    Type
 }
@@ -291,6 +344,8 @@ func (e *ArrayExpression) Copy() Node {
 }
 
 type FunctionExpression struct {
+	loc
+
 	Params []*FunctionParam `json:"params"`
 	Body   Node             `json:"body"`
 	typ    atomic.Value     //Type
@@ -326,6 +381,8 @@ func (e *FunctionExpression) Copy() Node {
 }
 
 type FunctionParam struct {
+	loc
+
 	Key         *Identifier `json:"key"`
 	Default     Expression  `json:"default"`
 	Piped       bool        `json:"piped,omitempty"`
@@ -364,6 +421,8 @@ func (p *FunctionParam) Copy() Node {
 }
 
 type BinaryExpression struct {
+	loc
+
 	Operator ast.OperatorKind `json:"operator"`
 	Left     Expression       `json:"left"`
 	Right    Expression       `json:"right"`
@@ -392,6 +451,8 @@ func (e *BinaryExpression) Copy() Node {
 }
 
 type CallExpression struct {
+	loc
+
 	Callee    Expression        `json:"callee"`
 	Arguments *ObjectExpression `json:"arguments"`
 }
@@ -415,6 +476,8 @@ func (e *CallExpression) Copy() Node {
 }
 
 type ConditionalExpression struct {
+	loc
+
 	Test       Expression `json:"test"`
 	Alternate  Expression `json:"alternate"`
 	Consequent Expression `json:"consequent"`
@@ -422,6 +485,15 @@ type ConditionalExpression struct {
 
 func (*ConditionalExpression) NodeType() string { return "ConditionalExpression" }
 
+// Type reports the type of the expression, both branches must have the same type.
+func (e *ConditionalExpression) Type() Type {
+	ct := e.Consequent.Type()
+	if at := e.Alternate.Type(); ct != at {
+		return Invalid
+	}
+	return ct
+}
+
 func (e *ConditionalExpression) Copy() Node {
 	if e == nil {
 		return e
@@ -437,6 +509,8 @@ func (e *ConditionalExpression) Copy() Node {
 }
 
 type LogicalExpression struct {
+	loc
+
 	Operator ast.LogicalOperatorKind `json:"operator"`
 	Left     Expression              `json:"left"`
 	Right    Expression              `json:"right"`
@@ -459,6 +533,8 @@ func (e *LogicalExpression) Copy() Node {
 }
 
 type MemberExpression struct {
+	loc
+
 	Object   Expression `json:"object"`
 	Property string     `json:"property"`
 }
@@ -485,7 +561,39 @@ func (e *MemberExpression) Copy() Node {
 	return ne
 }
 
+type IndexExpression struct {
+	loc
+
+	Array Expression `json:"array"`
+	Index Expression `json:"index"`
+}
+
+func (*IndexExpression) NodeType() string { return "IndexExpression" }
+
+func (e *IndexExpression) Type() Type {
+	t := e.Array.Type()
+	if t.Kind() != Array {
+		return Invalid
+	}
+	return t.ElementType()
+}
+
+func (e *IndexExpression) Copy() Node {
+	if e == nil {
+		return e
+	}
+	ne := new(IndexExpression)
+	*ne = *e
+
+	ne.Array = e.Array.Copy().(Expression)
+	ne.Index = e.Index.Copy().(Expression)
+
+	return ne
+}
+
 type ObjectExpression struct {
+	loc
+
 	Properties []*Property  `json:"properties"`
 	typ        atomic.Value //Type
 }
@@ -519,6 +627,8 @@ func (e *ObjectExpression) Copy() Node {
 }
 
 type UnaryExpression struct {
+	loc
+
 	Operator ast.OperatorKind `json:"operator"`
 	Argument Expression       `json:"argument"`
 }
@@ -541,6 +651,8 @@ func (e *UnaryExpression) Copy() Node {
 }
 
 type Property struct {
+	loc
+
 	Key   *Identifier `json:"key"`
 	Value Expression  `json:"value"`
 }
@@ -560,6 +672,8 @@ func (p *Property) Copy() Node {
 }
 
 type IdentifierExpression struct {
+	loc
+
 	Name string `json:"name"`
 	// declaration is the node that declares this identifier
 	declaration VariableDeclaration
@@ -589,6 +703,8 @@ func (e *IdentifierExpression) Copy() Node {
 }
 
 type Identifier struct {
+	loc
+
 	Name string `json:"name"`
 }
 
@@ -605,6 +721,8 @@ func (i *Identifier) Copy() Node {
 }
 
 type BooleanLiteral struct {
+	loc
+
 	Value bool `json:"value"`
 }
 
@@ -622,6 +740,8 @@ func (l *BooleanLiteral) Copy() Node {
 }
 
 type DateTimeLiteral struct {
+	loc
+
 	Value time.Time `json:"value"`
 }
 
@@ -639,6 +759,8 @@ func (l *DateTimeLiteral) Copy() Node {
 }
 
 type DurationLiteral struct {
+	loc
+
 	Value time.Duration `json:"value"`
 }
 
@@ -656,6 +778,8 @@ func (l *DurationLiteral) Copy() Node {
 }
 
 type IntegerLiteral struct {
+	loc
+
 	Value int64 `json:"value"`
 }
 
@@ -673,6 +797,8 @@ func (l *IntegerLiteral) Copy() Node {
 }
 
 type FloatLiteral struct {
+	loc
+
 	Value float64 `json:"value"`
 }
 
@@ -690,6 +816,8 @@ func (l *FloatLiteral) Copy() Node {
 }
 
 type RegexpLiteral struct {
+	loc
+
 	Value *regexp.Regexp `json:"value"`
 }
 
@@ -709,6 +837,8 @@ func (l *RegexpLiteral) Copy() Node {
 }
 
 type StringLiteral struct {
+	loc
+
 	Value string `json:"value"`
 }
 
@@ -726,6 +856,8 @@ func (l *StringLiteral) Copy() Node {
 }
 
 type UnsignedIntegerLiteral struct {
+	loc
+
 	Value uint64 `json:"value"`
 }
 
@@ -808,6 +940,7 @@ func (s DeclarationScope) Copy() DeclarationScope {
 
 func analyzeProgram(prog *ast.Program, declarations DeclarationScope) (*Program, error) {
 	p := &Program{
+		loc:  locOf(prog),
 		Body: make([]Statement, len(prog.Body)),
 	}
 	for i, s := range prog.Body {
@@ -837,6 +970,8 @@ func analyzeStatment(s ast.Statement, declarations DeclarationScope) (Statement,
 		return analyzeBlockStatement(s, declarations)
 	case *ast.ExpressionStatement:
 		return analyzeExpressionStatement(s, declarations)
+	case *ast.OptionStatement:
+		return analyzeOptionStatement(s, declarations)
 	case *ast.ReturnStatement:
 		return analyzeReturnStatement(s, declarations)
 	case *ast.VariableDeclaration:
@@ -853,6 +988,7 @@ func analyzeStatment(s ast.Statement, declarations DeclarationScope) (Statement,
 func analyzeBlockStatement(block *ast.BlockStatement, declarations DeclarationScope) (*BlockStatement, error) {
 	declarations = declarations.Copy()
 	b := &BlockStatement{
+		loc:  locOf(block),
 		Body: make([]Statement, len(block.Body)),
 	}
 	for i, s := range block.Body {
@@ -875,16 +1011,29 @@ func analyzeExpressionStatement(expr *ast.ExpressionStatement, declarations Decl
 		return nil, err
 	}
 	return &ExpressionStatement{
+		loc:        locOf(expr),
 		Expression: e,
 	}, nil
 }
 
+func analyzeOptionStatement(option *ast.OptionStatement, declarations DeclarationScope) (*OptionStatement, error) {
+	declaration, err := analyzeVariableDeclaration(option.Declaration, declarations)
+	if err != nil {
+		return nil, err
+	}
+	return &OptionStatement{
+		loc:         locOf(option),
+		Declaration: declaration,
+	}, nil
+}
+
 func analyzeReturnStatement(ret *ast.ReturnStatement, declarations DeclarationScope) (*ReturnStatement, error) {
 	arg, err := analyzeExpression(ret.Argument, declarations)
 	if err != nil {
 		return nil, err
 	}
 	return &ReturnStatement{
+		loc:      locOf(ret),
 		Argument: arg,
 	}, nil
 }
@@ -899,6 +1048,7 @@ func analyzeVariableDeclaration(decl *ast.VariableDeclarator, declarations Decla
 		return nil, err
 	}
 	vd := &NativeVariableDeclaration{
+		loc:        locOf(decl),
 		Identifier: id,
 		Init:       init,
 	}
@@ -914,6 +1064,10 @@ func analyzeExpression(expr ast.Expression, declarations DeclarationScope) (Expr
 		return analyzeCallExpression(expr, declarations)
 	case *ast.MemberExpression:
 		return analyzeMemberExpression(expr, declarations)
+	case *ast.IndexExpression:
+		return analyzeIndexExpression(expr, declarations)
+	case *ast.StringExpression:
+		return analyzeStringExpression(expr, declarations)
 	case *ast.PipeExpression:
 		return analyzePipeExpression(expr, declarations)
 	case *ast.BinaryExpression:
@@ -922,6 +1076,8 @@ func analyzeExpression(expr ast.Expression, declarations DeclarationScope) (Expr
 		return analyzeUnaryExpression(expr, declarations)
 	case *ast.LogicalExpression:
 		return analyzeLogicalExpression(expr, declarations)
+	case *ast.ConditionalExpression:
+		return analyzeConditionalExpression(expr, declarations)
 	case *ast.ObjectExpression:
 		return analyzeObjectExpression(expr, declarations)
 	case *ast.ArrayExpression:
@@ -963,6 +1119,7 @@ func analyzeLiteral(lit ast.Literal, declarations DeclarationScope) (Literal, er
 func analyzeArrowFunctionExpression(arrow *ast.ArrowFunctionExpression, declarations DeclarationScope) (*FunctionExpression, error) {
 	declarations = declarations.Copy()
 	f := &FunctionExpression{
+		loc:    locOf(arrow),
 		Params: make([]*FunctionParam, len(arrow.Params)),
 	}
 	pipedCount := 0
@@ -1000,6 +1157,7 @@ func analyzeArrowFunctionExpression(arrow *ast.ArrowFunctionExpression, declarat
 		}
 
 		f.Params[i] = &FunctionParam{
+			loc:         locOf(p),
 			Key:         key,
 			Default:     def,
 			Piped:       piped,
@@ -1040,6 +1198,7 @@ func analyzeCallExpression(call *ast.CallExpression, declarations DeclarationSco
 	}
 
 	expr := &CallExpression{
+		loc:       locOf(call),
 		Callee:    callee,
 		Arguments: args,
 	}
@@ -1109,28 +1268,92 @@ func analyzeMemberExpression(member *ast.MemberExpression, declarations Declarat
 	}
 
 	return &MemberExpression{
+		loc:      locOf(member),
 		Object:   obj,
 		Property: propertyName,
 	}, nil
 }
 
+func analyzeIndexExpression(index *ast.IndexExpression, declarations DeclarationScope) (*IndexExpression, error) {
+	array, err := analyzeExpression(index.Array, declarations)
+	if err != nil {
+		return nil, err
+	}
+	if k := array.Type().Kind(); k != Invalid && k != Array {
+		return nil, fmt.Errorf("cannot index into a value of kind %v", k)
+	}
+	idx, err := analyzeExpression(index.Index, declarations)
+	if err != nil {
+		return nil, err
+	}
+	if k := idx.Type().Kind(); k != Invalid && k != Int {
+		return nil, fmt.Errorf("array index must be an integer, got kind %v", k)
+	}
+	return &IndexExpression{
+		loc:   locOf(index),
+		Array: array,
+		Index: idx,
+	}, nil
+}
+
+// analyzeStringExpression converts an interpolated string into the concatenation of its parts.
+func analyzeStringExpression(str *ast.StringExpression, declarations DeclarationScope) (Expression, error) {
+	var expr Expression
+	for _, p := range str.Parts {
+		var part Expression
+		switch p := p.(type) {
+		case *ast.TextPart:
+			part = &StringLiteral{loc: locOf(p), Value: p.Value}
+		case *ast.InterpolatedPart:
+			e, err := analyzeExpression(p.Expression, declarations)
+			if err != nil {
+				return nil, err
+			}
+			if k := e.Type().Kind(); k != Invalid && k != String {
+				return nil, fmt.Errorf("interpolated expression must be a string, got kind %v", k)
+			}
+			part = e
+		default:
+			return nil, fmt.Errorf("unsupported string expression part %T", p)
+		}
+		if expr == nil {
+			if _, ok := part.(*StringLiteral); ok {
+				expr = part
+				continue
+			}
+			// Concatenate a leading interpolated expression with an empty string,
+			// so that the result is a string even if the string has no other parts.
+			expr = &StringLiteral{loc: locOf(str)}
+		}
+		expr = &BinaryExpression{
+			loc:      locOf(str),
+			Operator: ast.AdditionOperator,
+			Left:     expr,
+			Right:    part,
+		}
+	}
+	if expr == nil {
+		return &StringLiteral{loc: locOf(str)}, nil
+	}
+	return expr, nil
+}
+
 func analyzePipeExpression(pipe *ast.PipeExpression, declarations DeclarationScope) (*CallExpression, error) {
 	call, err := analyzeCallExpression(pipe.Call, declarations)
 	if err != nil {
 		return nil, err
 	}
 
-	decl, err := resolveDeclaration(call.Callee)
+	name, fnTyp, err := resolveCallee(call.Callee)
 	if err != nil {
 		return nil, err
 	}
-	fnTyp := decl.InitType()
 	if fnTyp.Kind() != Function {
 		return nil, fmt.Errorf("cannot pipe into non function %q", fnTyp.Kind())
 	}
 	key := fnTyp.PipeArgument()
 	if key == "" {
-		return nil, fmt.Errorf("function %q does not have a pipe argument", decl.ID().Name)
+		return nil, fmt.Errorf("function %q does not have a pipe argument", name)
 	}
 
 	value, err := analyzeExpression(pipe.Argument, declarations)
@@ -1138,7 +1361,8 @@ func analyzePipeExpression(pipe *ast.PipeExpression, declarations DeclarationSco
 		return nil, err
 	}
 	property := &Property{
-		Key:   &Identifier{Name: key},
+		loc:   locOf(pipe.Argument),
+		Key:   &Identifier{loc: locOf(pipe.Argument), Name: key},
 		Value: value,
 	}
 
@@ -1156,6 +1380,30 @@ func analyzePipeExpression(pipe *ast.PipeExpression, declarations DeclarationSco
 	return call, nil
 }
 
+// resolveCallee determines the name and type of the callee of a call expression.
+// The callee is either declared directly or is a member of a declared object, such as an imported package.
+func resolveCallee(callee Expression) (string, Type, error) {
+	if m, ok := callee.(*MemberExpression); ok {
+		name, t, err := resolveCallee(m.Object)
+		if err != nil {
+			return "", nil, err
+		}
+		if t.Kind() != Object {
+			return "", nil, fmt.Errorf("cannot access property %q of non object %q", m.Property, t.Kind())
+		}
+		pt := t.PropertyType(m.Property)
+		if pt == nil {
+			return "", nil, fmt.Errorf("object %q has no property %q", name, m.Property)
+		}
+		return name + "." + m.Property, pt, nil
+	}
+	decl, err := resolveDeclaration(callee)
+	if err != nil {
+		return "", nil, err
+	}
+	return decl.ID().Name, decl.InitType(), nil
+}
+
 // resolveDeclaration traverse the expression until a variable declaration is found for the expression.
 func resolveDeclaration(n Node) (VariableDeclaration, error) {
 	switch n := n.(type) {
@@ -1188,6 +1436,7 @@ func analyzeBinaryExpression(binary *ast.BinaryExpression, declarations Declarat
 		return nil, err
 	}
 	return &BinaryExpression{
+		loc:      locOf(binary),
 		Operator: binary.Operator,
 		Left:     left,
 		Right:    right,
@@ -1205,6 +1454,7 @@ func analyzeUnaryExpression(unary *ast.UnaryExpression, declarations Declaration
 	//	return nil, fmt.Errorf("invalid unary operator %v on type %v", unary.Operator, k)
 	//}
 	return &UnaryExpression{
+		loc:      locOf(unary),
 		Operator: unary.Operator,
 		Argument: arg,
 	}, nil
@@ -1226,13 +1476,44 @@ func analyzeLogicalExpression(logical *ast.LogicalExpression, declarations Decla
 	//	return nil, fmt.Errorf("right operand to logical expression is not a boolean, got kind %v", k)
 	//}
 	return &LogicalExpression{
+		loc:      locOf(logical),
 		Operator: logical.Operator,
 		Left:     left,
 		Right:    right,
 	}, nil
 }
+func analyzeConditionalExpression(cond *ast.ConditionalExpression, declarations DeclarationScope) (*ConditionalExpression, error) {
+	test, err := analyzeExpression(cond.Test, declarations)
+	if err != nil {
+		return nil, err
+	}
+	if k := test.Type().Kind(); k != Invalid && k != Bool {
+		return nil, fmt.Errorf("test of conditional expression is not a boolean, got kind %v", k)
+	}
+	consequent, err := analyzeExpression(cond.Consequent, declarations)
+	if err != nil {
+		return nil, err
+	}
+	alternate, err := analyzeExpression(cond.Alternate, declarations)
+	if err != nil {
+		return nil, err
+	}
+	// Types that are not yet known are checked when the expression is evaluated.
+	ct, at := consequent.Type(), alternate.Type()
+	if ct.Kind() != Invalid && at.Kind() != Invalid && ct != at {
+		return nil, fmt.Errorf("branches of conditional expression have different types, %v and %v", ct, at)
+	}
+	return &ConditionalExpression{
+		loc:        locOf(cond),
+		Test:       test,
+		Consequent: consequent,
+		Alternate:  alternate,
+	}, nil
+}
+
 func analyzeObjectExpression(obj *ast.ObjectExpression, declarations DeclarationScope) (*ObjectExpression, error) {
 	o := &ObjectExpression{
+		loc:        locOf(obj),
 		Properties: make([]*Property, len(obj.Properties)),
 	}
 	for i, p := range obj.Properties {
@@ -1246,6 +1527,7 @@ func analyzeObjectExpression(obj *ast.ObjectExpression, declarations Declaration
 }
 func analyzeArrayExpression(array *ast.ArrayExpression, declarations DeclarationScope) (*ArrayExpression, error) {
 	a := &ArrayExpression{
+		loc:      locOf(array),
 		Elements: make([]Expression, len(array.Elements)),
 	}
 	for i, e := range array.Elements {
@@ -1260,12 +1542,14 @@ func analyzeArrayExpression(array *ast.ArrayExpression, declarations Declaration
 
 func analyzeIdentifier(ident *ast.Identifier, declarations DeclarationScope) (*Identifier, error) {
 	return &Identifier{
+		loc:  locOf(ident),
 		Name: ident.Name,
 	}, nil
 }
 
 func analyzeIdentifierExpression(ident *ast.Identifier, declarations DeclarationScope) (*IdentifierExpression, error) {
 	return &IdentifierExpression{
+		loc:         locOf(ident),
 		Name:        ident.Name,
 		declaration: declarations[ident.Name],
 	}, nil
@@ -1281,6 +1565,7 @@ func analyzeProperty(property *ast.Property, declarations DeclarationScope) (*Pr
 		return nil, err
 	}
 	return &Property{
+		loc:   locOf(property),
 		Key:   key,
 		Value: value,
 	}, nil
@@ -1288,41 +1573,49 @@ func analyzeProperty(property *ast.Property, declarations DeclarationScope) (*Pr
 
 func analyzeDateTimeLiteral(lit *ast.DateTimeLiteral, declarations DeclarationScope) (*DateTimeLiteral, error) {
 	return &DateTimeLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
 func analyzeDurationLiteral(lit *ast.DurationLiteral, declarations DeclarationScope) (*DurationLiteral, error) {
 	return &DurationLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
 func analyzeFloatLiteral(lit *ast.FloatLiteral, declarations DeclarationScope) (*FloatLiteral, error) {
 	return &FloatLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
 func analyzeIntegerLiteral(lit *ast.IntegerLiteral, declarations DeclarationScope) (*IntegerLiteral, error) {
 	return &IntegerLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
 func analyzeUnsignedIntegerLiteral(lit *ast.UnsignedIntegerLiteral, declarations DeclarationScope) (*UnsignedIntegerLiteral, error) {
 	return &UnsignedIntegerLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
 func analyzeStringLiteral(lit *ast.StringLiteral, declarations DeclarationScope) (*StringLiteral, error) {
 	return &StringLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
 func analyzeBooleanLiteral(lit *ast.BooleanLiteral, declarations DeclarationScope) (*BooleanLiteral, error) {
 	return &BooleanLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
 func analyzeRegexpLiteral(lit *ast.RegexpLiteral, declarations DeclarationScope) (*RegexpLiteral, error) {
 	return &RegexpLiteral{
+		loc:   locOf(lit),
 		Value: lit.Value,
 	}, nil
 }
@@ -50,6 +50,35 @@ func TestNew(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "option statement",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.OptionStatement{
+						Declaration: &ast.VariableDeclarator{
+							ID:   &ast.Identifier{Name: "location"},
+							Init: &ast.StringLiteral{Value: "UTC"},
+						},
+					},
+					&ast.ExpressionStatement{
+						Expression: &ast.Identifier{Name: "location"},
+					},
+				},
+			},
+			want: &semantic.Program{
+				Body: []semantic.Statement{
+					&semantic.OptionStatement{
+						Declaration: &semantic.NativeVariableDeclaration{
+							Identifier: &semantic.Identifier{Name: "location"},
+							Init:       &semantic.StringLiteral{Value: "UTC"},
+						},
+					},
+					&semantic.ExpressionStatement{
+						Expression: &semantic.IdentifierExpression{Name: "location"},
+					},
+				},
+			},
+		},
 		{
 			name: "function",
 			program: &ast.Program{
@@ -119,6 +148,139 @@ func TestNew(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "index expression",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.IndexExpression{
+							Array: &ast.ArrayExpression{
+								Elements: []ast.Expression{&ast.IntegerLiteral{Value: 1}},
+							},
+							Index: &ast.IntegerLiteral{Value: 0},
+						},
+					},
+				},
+			},
+			want: &semantic.Program{
+				Body: []semantic.Statement{
+					&semantic.ExpressionStatement{
+						Expression: &semantic.IndexExpression{
+							Array: &semantic.ArrayExpression{
+								Elements: []semantic.Expression{&semantic.IntegerLiteral{Value: 1}},
+							},
+							Index: &semantic.IntegerLiteral{Value: 0},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "index expression with non integer index",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.IndexExpression{
+							Array: &ast.ArrayExpression{
+								Elements: []ast.Expression{&ast.IntegerLiteral{Value: 1}},
+							},
+							Index: &ast.StringLiteral{Value: "0"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "string interpolation",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.StringExpression{
+							Parts: []ast.StringExpressionPart{
+								&ast.InterpolatedPart{Expression: &ast.Identifier{Name: "host"}},
+								&ast.TextPart{Value: "-"},
+								&ast.InterpolatedPart{Expression: &ast.Identifier{Name: "region"}},
+							},
+						},
+					},
+				},
+			},
+			want: &semantic.Program{
+				Body: []semantic.Statement{
+					&semantic.ExpressionStatement{
+						Expression: &semantic.BinaryExpression{
+							Operator: ast.AdditionOperator,
+							Left: &semantic.BinaryExpression{
+								Operator: ast.AdditionOperator,
+								Left: &semantic.BinaryExpression{
+									Operator: ast.AdditionOperator,
+									Left:     &semantic.StringLiteral{},
+									Right:    &semantic.IdentifierExpression{Name: "host"},
+								},
+								Right: &semantic.StringLiteral{Value: "-"},
+							},
+							Right: &semantic.IdentifierExpression{Name: "region"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "conditional",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.ConditionalExpression{
+							Test:       &ast.BooleanLiteral{Value: true},
+							Consequent: &ast.IntegerLiteral{Value: 1},
+							Alternate:  &ast.IntegerLiteral{Value: 2},
+						},
+					},
+				},
+			},
+			want: &semantic.Program{
+				Body: []semantic.Statement{
+					&semantic.ExpressionStatement{
+						Expression: &semantic.ConditionalExpression{
+							Test:       &semantic.BooleanLiteral{Value: true},
+							Consequent: &semantic.IntegerLiteral{Value: 1},
+							Alternate:  &semantic.IntegerLiteral{Value: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "conditional with non boolean test",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.ConditionalExpression{
+							Test:       &ast.StringLiteral{Value: "true"},
+							Consequent: &ast.IntegerLiteral{Value: 1},
+							Alternate:  &ast.IntegerLiteral{Value: 2},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conditional with differing branch types",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.ConditionalExpression{
+							Test:       &ast.BooleanLiteral{Value: true},
+							Consequent: &ast.IntegerLiteral{Value: 1},
+							Alternate:  &ast.StringLiteral{Value: "2"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -178,6 +340,24 @@ func TestExpression_Kind(t *testing.T) {
 			expr: &semantic.DurationLiteral{},
 			want: semantic.Duration,
 		},
+		{
+			name: "conditional",
+			expr: &semantic.ConditionalExpression{
+				Test:       &semantic.BooleanLiteral{},
+				Consequent: &semantic.FloatLiteral{},
+				Alternate:  &semantic.FloatLiteral{},
+			},
+			want: semantic.Float,
+		},
+		{
+			name: "conditional differing branches",
+			expr: &semantic.ConditionalExpression{
+				Test:       &semantic.BooleanLiteral{},
+				Consequent: &semantic.FloatLiteral{},
+				Alternate:  &semantic.IntegerLiteral{},
+			},
+			want: semantic.Invalid,
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -0,0 +1,912 @@
+package semantic
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/platform/query/ast"
+)
+
+// TypeError is a type error found in a program.
+type TypeError struct {
+	// Loc is the location in the source of the expression with the error, it may be nil.
+	Loc *ast.SourceLocation
+	Msg string
+}
+
+func (e *TypeError) Error() string {
+	if e.Loc == nil {
+		return e.Msg
+	}
+	return fmt.Sprintf("%d:%d: %s", e.Loc.Start.Line, e.Loc.Start.Column, e.Msg)
+}
+
+// TypeErrors is a list of type errors sorted by their position in the source.
+type TypeErrors []*TypeError
+
+func (e TypeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e TypeErrors) sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		li, lj := e[i].Loc, e[j].Loc
+		switch {
+		case li == nil || lj == nil:
+			return li != nil
+		case li.Start.Line != lj.Start.Line:
+			return li.Start.Line < lj.Start.Line
+		default:
+			return li.Start.Column < lj.Start.Column
+		}
+	})
+}
+
+// Infer checks that the types of all expressions in the program are consistent.
+// The declarations are the declarations visible to the program before its first statement,
+// usually the builtin declarations. Their types are used where they are known.
+//
+// The types of expressions are inferred with Hindley-Milner style unification.
+// Variables declared with a function are generalized, so that a function may be called with arguments of different types.
+// Objects whose properties are accessed without their type being known are treated as records
+// that have at least the accessed properties, such as the rows passed to the function of a filter.
+//
+// All errors found are returned as TypeErrors.
+func Infer(prog *Program, declarations DeclarationScope) error {
+	i := &inferrer{
+		declarations: declarations,
+		builtins:     make(map[string]*scheme),
+	}
+	env := newTypeEnv(nil)
+	for _, s := range prog.Body {
+		i.statement(s, env)
+	}
+	if len(i.errs) == 0 {
+		return nil
+	}
+	i.errs.sort()
+	return i.errs
+}
+
+// monoType is a type that may contain type variables.
+type monoType interface {
+	fmt.Stringer
+	monoType()
+}
+
+// typeVar is a type that is not yet known.
+type typeVar struct {
+	id    int
+	level int
+	bound monoType
+	// properties is non-nil if the type must be a record with at least these properties.
+	properties map[string]monoType
+}
+
+// basicType is a type without any type parameters.
+type basicType Kind
+
+type arrayMonoType struct {
+	element monoType
+}
+
+// recordType is the type of an object with exactly the given properties.
+type recordType struct {
+	properties map[string]monoType
+}
+
+type functionMonoType struct {
+	// params is nil if the parameters of the function are not known.
+	params map[string]*paramType
+	pipe   string
+	ret    monoType
+}
+
+type paramType struct {
+	typ      monoType
+	optional bool
+}
+
+func (*typeVar) monoType()          {}
+func (basicType) monoType()         {}
+func (*arrayMonoType) monoType()    {}
+func (*recordType) monoType()       {}
+func (*functionMonoType) monoType() {}
+
+func (v *typeVar) String() string {
+	if v.bound != nil {
+		return v.bound.String()
+	}
+	if v.properties != nil {
+		return propertiesString(v.properties, true)
+	}
+	return fmt.Sprintf("t%d", v.id)
+}
+func (t basicType) String() string {
+	return Kind(t).String()
+}
+func (t *arrayMonoType) String() string {
+	return "[" + t.element.String() + "]"
+}
+func (t *recordType) String() string {
+	return propertiesString(t.properties, false)
+}
+func (t *functionMonoType) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("(")
+	if t.params == nil {
+		buf.WriteString("...")
+	}
+	for i, k := range sortedKeys(t.params) {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		p := t.params[k]
+		if k == t.pipe {
+			buf.WriteString("<-")
+		}
+		if p.optional {
+			buf.WriteString("?")
+		}
+		fmt.Fprintf(&buf, "%s: %v", k, p.typ)
+	}
+	fmt.Fprintf(&buf, ") -> %v", t.ret)
+	return buf.String()
+}
+
+func propertiesString(properties map[string]monoType, open bool) string {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%s: %v", k, properties[k])
+	}
+	if open {
+		if len(keys) > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("...")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+func sortedKeys(params map[string]*paramType) []string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// prune returns the type a type variable is bound to, or the variable itself if it is not bound.
+func prune(t monoType) monoType {
+	if v, ok := t.(*typeVar); ok && v.bound != nil {
+		v.bound = prune(v.bound)
+		return v.bound
+	}
+	return t
+}
+
+// scheme is a type that is polymorphic in its type variables.
+type scheme struct {
+	vars []*typeVar
+	typ  monoType
+}
+
+// typeEnv maps names to their types.
+type typeEnv struct {
+	parent *typeEnv
+	names  map[string]*scheme
+}
+
+func newTypeEnv(parent *typeEnv) *typeEnv {
+	return &typeEnv{
+		parent: parent,
+		names:  make(map[string]*scheme),
+	}
+}
+
+func (e *typeEnv) lookup(name string) (*scheme, bool) {
+	for ; e != nil; e = e.parent {
+		if s, ok := e.names[name]; ok {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+type inferrer struct {
+	declarations DeclarationScope
+	// builtins caches the types of the declarations.
+	builtins map[string]*scheme
+
+	level  int
+	nextID int
+	errs   TypeErrors
+}
+
+func (i *inferrer) errorf(n Node, format string, a ...interface{}) {
+	i.errs = append(i.errs, &TypeError{
+		Loc: n.Location(),
+		Msg: fmt.Sprintf(format, a...),
+	})
+}
+
+func (i *inferrer) fresh() *typeVar {
+	i.nextID++
+	return &typeVar{
+		id:    i.nextID,
+		level: i.level,
+	}
+}
+
+// freshRecord returns a type variable for an object whose properties are not yet known.
+func (i *inferrer) freshRecord() *typeVar {
+	v := i.fresh()
+	v.properties = make(map[string]monoType)
+	return v
+}
+
+func (i *inferrer) lookup(name string, env *typeEnv) (*scheme, bool) {
+	if s, ok := env.lookup(name); ok {
+		return s, true
+	}
+	if s, ok := i.builtins[name]; ok {
+		return s, true
+	}
+	d, ok := i.declarations[name]
+	if !ok {
+		return nil, false
+	}
+	// Types of declarations are generic in their unknown types.
+	i.level++
+	t := i.declarationType(d)
+	i.level--
+	s := i.generalize(t)
+	i.builtins[name] = s
+	return s, true
+}
+
+// declarationType returns the type of a declaration made outside of the program.
+// Only the parameters of functions declared in Flux are known,
+// and only the types of other native declarations that are literals.
+func (i *inferrer) declarationType(d VariableDeclaration) monoType {
+	switch d := d.(type) {
+	case *ExternalVariableDeclaration:
+		return i.fromType(d.Type)
+	case *NativeVariableDeclaration:
+		switch init := d.Init.(type) {
+		case *FunctionExpression:
+			f := &functionMonoType{
+				params: make(map[string]*paramType, len(init.Params)),
+				ret:    i.fresh(),
+			}
+			for _, p := range init.Params {
+				f.params[p.Key.Name] = &paramType{
+					typ:      i.fresh(),
+					optional: p.Default != nil,
+				}
+				if p.Piped {
+					f.pipe = p.Key.Name
+				}
+			}
+			return f
+		case Literal:
+			return i.fromType(init.Type())
+		}
+	}
+	return i.fresh()
+}
+
+// fromType converts a type of the semantic graph, types that are not fully known become type variables.
+func (i *inferrer) fromType(t Type) monoType {
+	switch t := t.(type) {
+	case *arrayType:
+		return &arrayMonoType{element: i.fromType(t.elementType)}
+	case *objectType:
+		r := &recordType{properties: make(map[string]monoType, len(t.properties))}
+		for k, p := range t.properties {
+			r.properties[k] = i.fromType(p)
+		}
+		return r
+	case *functionType:
+		// The signatures of functions implemented in Go do not list all of their parameters,
+		// nor their exact types.
+		return &functionMonoType{
+			pipe: t.pipeArgument,
+			ret:  i.fromType(t.returnType),
+		}
+	}
+	switch k := t.Kind(); k {
+	case Invalid:
+		return i.fresh()
+	case Array:
+		return &arrayMonoType{element: i.fresh()}
+	case Object:
+		return i.freshRecord()
+	case Function:
+		return &functionMonoType{ret: i.fresh()}
+	default:
+		return basicType(k)
+	}
+}
+
+// generalize returns a scheme of the type that is polymorphic in the type variables created at a deeper level.
+func (i *inferrer) generalize(t monoType) *scheme {
+	s := &scheme{typ: t}
+	seen := make(map[*typeVar]bool)
+	var collect func(t monoType)
+	collect = func(t monoType) {
+		switch t := prune(t).(type) {
+		case *typeVar:
+			if seen[t] {
+				return
+			}
+			seen[t] = true
+			if t.level > i.level {
+				s.vars = append(s.vars, t)
+			}
+			for _, p := range t.properties {
+				collect(p)
+			}
+		case *arrayMonoType:
+			collect(t.element)
+		case *recordType:
+			for _, p := range t.properties {
+				collect(p)
+			}
+		case *functionMonoType:
+			for _, p := range t.params {
+				collect(p.typ)
+			}
+			collect(t.ret)
+		}
+	}
+	collect(t)
+	return s
+}
+
+// instantiate returns the type of the scheme with fresh type variables.
+func (i *inferrer) instantiate(s *scheme) monoType {
+	if len(s.vars) == 0 {
+		return s.typ
+	}
+	subst := make(map[*typeVar]*typeVar, len(s.vars))
+	for _, v := range s.vars {
+		subst[v] = i.fresh()
+	}
+	var cpy func(t monoType) monoType
+	cpy = func(t monoType) monoType {
+		switch t := prune(t).(type) {
+		case *typeVar:
+			if v, ok := subst[t]; ok {
+				return v
+			}
+			return t
+		case *arrayMonoType:
+			return &arrayMonoType{element: cpy(t.element)}
+		case *recordType:
+			r := &recordType{properties: make(map[string]monoType, len(t.properties))}
+			for k, p := range t.properties {
+				r.properties[k] = cpy(p)
+			}
+			return r
+		case *functionMonoType:
+			f := &functionMonoType{
+				pipe: t.pipe,
+				ret:  cpy(t.ret),
+			}
+			if t.params != nil {
+				f.params = make(map[string]*paramType, len(t.params))
+				for k, p := range t.params {
+					f.params[k] = &paramType{typ: cpy(p.typ), optional: p.optional}
+				}
+			}
+			return f
+		default:
+			return t
+		}
+	}
+	for old, v := range subst {
+		if old.properties != nil {
+			v.properties = make(map[string]monoType, len(old.properties))
+			for k, p := range old.properties {
+				v.properties[k] = cpy(p)
+			}
+		}
+	}
+	return cpy(s.typ)
+}
+
+// unifyAt unifies the expected type with the actual type of the node and records any error.
+func (i *inferrer) unifyAt(n Node, expected, actual monoType) {
+	if err := i.unify(expected, actual); err != nil {
+		i.errorf(n, "%v", err)
+	}
+}
+
+func (i *inferrer) unify(expected, actual monoType) error {
+	expected, actual = prune(expected), prune(actual)
+	if expected == actual {
+		return nil
+	}
+	if v, ok := expected.(*typeVar); ok {
+		return i.bind(v, actual, false)
+	}
+	if v, ok := actual.(*typeVar); ok {
+		return i.bind(v, expected, true)
+	}
+	switch e := expected.(type) {
+	case *arrayMonoType:
+		if a, ok := actual.(*arrayMonoType); ok {
+			return i.unify(e.element, a.element)
+		}
+	case *recordType:
+		if a, ok := actual.(*recordType); ok {
+			for k, p := range e.properties {
+				ap, ok := a.properties[k]
+				if !ok {
+					return fmt.Errorf("missing property %q in %v", k, a)
+				}
+				if err := i.unify(p, ap); err != nil {
+					return err
+				}
+			}
+			for k := range a.properties {
+				if _, ok := e.properties[k]; !ok {
+					return fmt.Errorf("unexpected property %q in %v", k, a)
+				}
+			}
+			return nil
+		}
+	case *functionMonoType:
+		if a, ok := actual.(*functionMonoType); ok {
+			return i.unifyFunctions(e, a)
+		}
+	}
+	return fmt.Errorf("expected %v but found %v", expected, actual)
+}
+
+func (i *inferrer) unifyFunctions(expected, actual *functionMonoType) error {
+	// Nothing can be said about functions whose parameters are not known.
+	if expected.params == nil || actual.params == nil {
+		return nil
+	}
+	if expected.pipe != actual.pipe {
+		return fmt.Errorf("expected %v but found %v", expected, actual)
+	}
+	for k, p := range expected.params {
+		ap, ok := actual.params[k]
+		if !ok {
+			return fmt.Errorf("missing parameter %q in %v", k, actual)
+		}
+		if err := i.unify(p.typ, ap.typ); err != nil {
+			return err
+		}
+	}
+	for k := range actual.params {
+		if _, ok := expected.params[k]; !ok {
+			return fmt.Errorf("unexpected parameter %q in %v", k, actual)
+		}
+	}
+	return i.unify(expected.ret, actual.ret)
+}
+
+// bind binds the type variable to the type, actual reports whether the variable is the actual type being unified.
+func (i *inferrer) bind(v *typeVar, t monoType, actual bool) error {
+	if i.occurs(v, t) {
+		return fmt.Errorf("type %v refers to itself", t)
+	}
+	i.adjustLevels(t, v.level)
+	v.bound = t
+	if v.properties == nil {
+		return nil
+	}
+	switch t := t.(type) {
+	case *typeVar:
+		if t.properties == nil {
+			t.properties = make(map[string]monoType, len(v.properties))
+		}
+		for k, p := range v.properties {
+			if tp, ok := t.properties[k]; ok {
+				if err := i.unifyOrdered(p, tp, actual); err != nil {
+					return err
+				}
+			} else {
+				t.properties[k] = p
+			}
+		}
+	case *recordType:
+		for k, p := range v.properties {
+			tp, ok := t.properties[k]
+			if !ok {
+				return fmt.Errorf("missing property %q in %v", k, t)
+			}
+			if err := i.unifyOrdered(p, tp, actual); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("expected an object but found %v", t)
+	}
+	return nil
+}
+
+// unifyOrdered unifies the types where v belongs to the variable being bound.
+func (i *inferrer) unifyOrdered(v, t monoType, actual bool) error {
+	if actual {
+		return i.unify(t, v)
+	}
+	return i.unify(v, t)
+}
+
+func (i *inferrer) occurs(v *typeVar, t monoType) bool {
+	switch t := prune(t).(type) {
+	case *typeVar:
+		if t == v {
+			return true
+		}
+		for _, p := range t.properties {
+			if i.occurs(v, p) {
+				return true
+			}
+		}
+	case *arrayMonoType:
+		return i.occurs(v, t.element)
+	case *recordType:
+		for _, p := range t.properties {
+			if i.occurs(v, p) {
+				return true
+			}
+		}
+	case *functionMonoType:
+		for _, p := range t.params {
+			if i.occurs(v, p.typ) {
+				return true
+			}
+		}
+		return i.occurs(v, t.ret)
+	}
+	return false
+}
+
+// adjustLevels ensures that no type variable in the type is generalized at a deeper level than level.
+func (i *inferrer) adjustLevels(t monoType, level int) {
+	switch t := prune(t).(type) {
+	case *typeVar:
+		if t.level > level {
+			t.level = level
+			for _, p := range t.properties {
+				i.adjustLevels(p, level)
+			}
+		}
+	case *arrayMonoType:
+		i.adjustLevels(t.element, level)
+	case *recordType:
+		for _, p := range t.properties {
+			i.adjustLevels(p, level)
+		}
+	case *functionMonoType:
+		for _, p := range t.params {
+			i.adjustLevels(p.typ, level)
+		}
+		i.adjustLevels(t.ret, level)
+	}
+}
+
+// statement infers the types of the statement, it returns the type of the argument of a return statement.
+func (i *inferrer) statement(s Statement, env *typeEnv) monoType {
+	switch s := s.(type) {
+	case *NativeVariableDeclaration:
+		i.declaration(s, env)
+	case *OptionStatement:
+		i.declaration(s.Declaration, env)
+	case *ExpressionStatement:
+		i.expression(s.Expression, env)
+	case *ReturnStatement:
+		return i.expression(s.Argument, env)
+	case *BlockStatement:
+		return i.block(s, env)
+	}
+	return nil
+}
+
+func (i *inferrer) block(b *BlockStatement, env *typeEnv) monoType {
+	env = newTypeEnv(env)
+	var ret monoType
+	for _, s := range b.Body {
+		ret = i.statement(s, env)
+	}
+	if ret == nil {
+		return i.fresh()
+	}
+	return ret
+}
+
+func (i *inferrer) declaration(d *NativeVariableDeclaration, env *typeEnv) {
+	name := d.Identifier.Name
+	i.level++
+	var t monoType
+	if _, ok := d.Init.(*FunctionExpression); ok {
+		// The function may refer to itself, but is not polymorphic within its own body.
+		v := i.fresh()
+		env.names[name] = &scheme{typ: v}
+		t = i.expression(d.Init, env)
+		i.unifyAt(d, v, t)
+	} else {
+		t = i.expression(d.Init, env)
+	}
+	i.level--
+	env.names[name] = i.generalize(t)
+}
+
+func (i *inferrer) expression(e Expression, env *typeEnv) monoType {
+	switch e := e.(type) {
+	case *IdentifierExpression:
+		s, ok := i.lookup(e.Name, env)
+		if !ok {
+			i.errorf(e, "undefined identifier %q", e.Name)
+			return i.fresh()
+		}
+		return i.instantiate(s)
+	case *ArrayExpression:
+		elem := monoType(i.fresh())
+		for _, el := range e.Elements {
+			t := i.expression(el, env)
+			if err := i.unify(elem, t); err != nil {
+				i.errorf(el, "array elements must have the same type: %v", err)
+			}
+		}
+		return &arrayMonoType{element: elem}
+	case *ObjectExpression:
+		return i.object(e, env)
+	case *FunctionExpression:
+		return i.function(e, env)
+	case *CallExpression:
+		return i.call(e, env)
+	case *MemberExpression:
+		return i.member(e, env)
+	case *IndexExpression:
+		elem := i.fresh()
+		i.unifyAt(e.Array, &arrayMonoType{element: elem}, i.expression(e.Array, env))
+		i.unifyAt(e.Index, basicType(Int), i.expression(e.Index, env))
+		return elem
+	case *BinaryExpression:
+		return i.binary(e, env)
+	case *UnaryExpression:
+		t := i.expression(e.Argument, env)
+		switch e.Operator {
+		case ast.NotOperator:
+			i.unifyAt(e.Argument, basicType(Bool), t)
+			return basicType(Bool)
+		case ast.SubtractionOperator:
+			switch pt := prune(t).(type) {
+			case *typeVar:
+				if pt.properties == nil {
+					return t
+				}
+			case basicType:
+				if k := Kind(pt); k == Int || k == Float || k == Duration {
+					return t
+				}
+			}
+			i.errorf(e, "operand of unary operator %v must be a number, found %v", e.Operator, t)
+			return t
+		}
+		i.errorf(e, "unsupported unary operator %v", e.Operator)
+		return i.fresh()
+	case *LogicalExpression:
+		i.unifyAt(e.Left, basicType(Bool), i.expression(e.Left, env))
+		i.unifyAt(e.Right, basicType(Bool), i.expression(e.Right, env))
+		return basicType(Bool)
+	case *ConditionalExpression:
+		i.unifyAt(e.Test, basicType(Bool), i.expression(e.Test, env))
+		c := i.expression(e.Consequent, env)
+		a := i.expression(e.Alternate, env)
+		if err := i.unify(c, a); err != nil {
+			i.errorf(e.Alternate, "branches of conditional expression have different types: %v", err)
+		}
+		return c
+	case Literal:
+		return basicType(e.Type().Kind())
+	}
+	i.errorf(e, "unsupported expression %T", e)
+	return i.fresh()
+}
+
+func (i *inferrer) object(o *ObjectExpression, env *typeEnv) monoType {
+	r := &recordType{properties: make(map[string]monoType, len(o.Properties))}
+	for _, p := range o.Properties {
+		if _, ok := r.properties[p.Key.Name]; ok {
+			i.errorf(p, "duplicate property %q", p.Key.Name)
+			continue
+		}
+		r.properties[p.Key.Name] = i.expression(p.Value, env)
+	}
+	return r
+}
+
+func (i *inferrer) function(f *FunctionExpression, env *typeEnv) monoType {
+	env = newTypeEnv(env)
+	ft := &functionMonoType{
+		params: make(map[string]*paramType, len(f.Params)),
+	}
+	for _, p := range f.Params {
+		var t monoType
+		if p.Default != nil {
+			t = i.expression(p.Default, env)
+		} else {
+			t = i.fresh()
+		}
+		if p.Piped {
+			ft.pipe = p.Key.Name
+		}
+		ft.params[p.Key.Name] = &paramType{
+			typ:      t,
+			optional: p.Default != nil,
+		}
+		env.names[p.Key.Name] = &scheme{typ: t}
+	}
+	switch b := f.Body.(type) {
+	case Expression:
+		ft.ret = i.expression(b, env)
+	case *BlockStatement:
+		ft.ret = i.block(b, env)
+	default:
+		ft.ret = i.fresh()
+	}
+	return ft
+}
+
+func (i *inferrer) call(c *CallExpression, env *typeEnv) monoType {
+	callee := i.expression(c.Callee, env)
+	args := make(map[string]monoType, len(c.Arguments.Properties))
+	for _, p := range c.Arguments.Properties {
+		args[p.Key.Name] = i.expression(p.Value, env)
+	}
+	switch f := prune(callee).(type) {
+	case *functionMonoType:
+		if f.params == nil {
+			return f.ret
+		}
+		for _, p := range c.Arguments.Properties {
+			param, ok := f.params[p.Key.Name]
+			if !ok {
+				i.errorf(p, "function %s does not have a parameter %q", calleeName(c), p.Key.Name)
+				continue
+			}
+			if err := i.unify(param.typ, args[p.Key.Name]); err != nil {
+				i.errorf(p.Value, "invalid argument %q: %v", p.Key.Name, err)
+			}
+		}
+		for _, k := range sortedKeys(f.params) {
+			if _, ok := args[k]; !ok && !f.params[k].optional {
+				i.errorf(c, "missing required argument %q in call to %s", k, calleeName(c))
+			}
+		}
+		return f.ret
+	case *typeVar:
+		if f.properties != nil {
+			i.errorf(c.Callee, "cannot call %s, it is an object", calleeName(c))
+		}
+		// The function is not known yet, such as a function passed as an argument.
+		return i.fresh()
+	default:
+		i.errorf(c.Callee, "cannot call %s of type %v", calleeName(c), f)
+		return i.fresh()
+	}
+}
+
+func calleeName(c *CallExpression) string {
+	switch callee := c.Callee.(type) {
+	case *IdentifierExpression:
+		return callee.Name
+	case *MemberExpression:
+		return callee.Property
+	default:
+		return "function"
+	}
+}
+
+func (i *inferrer) member(m *MemberExpression, env *typeEnv) monoType {
+	obj := i.expression(m.Object, env)
+	switch o := prune(obj).(type) {
+	case *recordType:
+		if t, ok := o.properties[m.Property]; ok {
+			return t
+		}
+		i.errorf(m, "object %v has no property %q", o, m.Property)
+		return i.fresh()
+	case *typeVar:
+		if o.properties == nil {
+			o.properties = make(map[string]monoType)
+		}
+		if t, ok := o.properties[m.Property]; ok {
+			return t
+		}
+		t := i.fresh()
+		t.level = o.level
+		o.properties[m.Property] = t
+		return t
+	default:
+		i.errorf(m, "cannot access property %q of %v", m.Property, o)
+		return i.fresh()
+	}
+}
+
+func (i *inferrer) binary(b *BinaryExpression, env *typeEnv) monoType {
+	l := prune(i.expression(b.Left, env))
+	r := prune(i.expression(b.Right, env))
+	lk, lok := l.(basicType)
+	rk, rok := r.(basicType)
+	if lok && rok {
+		k, ok := binaryTypesLookup[binarySignature{operator: b.Operator, left: Kind(lk), right: Kind(rk)}]
+		if !ok {
+			i.errorf(b, "invalid binary operator %v for types %v and %v", b.Operator, l, r)
+			return i.fresh()
+		}
+		return basicType(k)
+	}
+	if !lok && !isPlainVar(l) || !rok && !isPlainVar(r) {
+		i.errorf(b, "invalid binary operator %v for types %v and %v", b.Operator, l, r)
+		return i.fresh()
+	}
+	if !lok && !rok {
+		return i.fresh()
+	}
+
+	// Only one side is known, the other side is constrained to the types that the operator supports.
+	others := make(map[Kind]bool)
+	results := make(map[Kind]bool)
+	for sig, k := range binaryTypesLookup {
+		if sig.operator != b.Operator {
+			continue
+		}
+		switch {
+		case lok && sig.left == Kind(lk):
+			others[sig.right] = true
+			results[k] = true
+		case rok && sig.right == Kind(rk):
+			others[sig.left] = true
+			results[k] = true
+		}
+	}
+	// Operators the lookup does not know of for the known type are not reported,
+	// since predicates such as a comparison of a column with a regular expression may be pushed down into storage.
+	if len(others) == 1 {
+		for k := range others {
+			if lok {
+				i.unifyAt(b.Right, basicType(k), r)
+			} else {
+				i.unifyAt(b.Left, basicType(k), l)
+			}
+		}
+	}
+	if len(results) == 1 {
+		for k := range results {
+			return basicType(k)
+		}
+	}
+	return i.fresh()
+}
+
+// isPlainVar reports whether the type is a type variable that is not known to be an object.
+func isPlainVar(t monoType) bool {
+	v, ok := t.(*typeVar)
+	return ok && v.properties == nil
+}
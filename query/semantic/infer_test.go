@@ -0,0 +1,149 @@
+package semantic_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/query/parser"
+	"github.com/influxdata/platform/query/semantic"
+)
+
+func TestInfer(t *testing.T) {
+	testCases := []struct {
+		name    string
+		program string
+		want    []string
+	}{
+		{
+			name: "literals and operators",
+			program: `a = 1 + 2
+b = "a" + "b"
+c = a > 1 and b == "ab"
+d = -1.5
+e = [1, 2, 3][a]`,
+		},
+		{
+			name: "polymorphic function",
+			program: `identity = (x) => x
+a = identity(x: 1) + 1
+b = identity(x: "a") + "b"`,
+		},
+		{
+			name: "default and pipe arguments",
+			program: `f = (table=<-, n=1) => table + n
+a = 1 |> f()
+b = f(table: 2, n: 3)`,
+		},
+		{
+			name: "open records",
+			program: `f = (r) => r._value > 0 and r.host == "a"
+f(r: {_value: 1, host: "a", region: "west"})`,
+		},
+		{
+			name: "builtins",
+			program: `from(db: "telegraf") |> filter(fn: (r) => r._measurement == "cpu")
+x + 1`,
+		},
+		{
+			name:    "invalid binary operator",
+			program: `a = 1 + "b"`,
+			want:    []string{`1:5: invalid binary operator + for types int and string`},
+		},
+		{
+			name: "argument type",
+			program: `f = (x) => x + 1
+f(x: "a")`,
+			want: []string{`2:6: invalid argument "x": expected int but found string`},
+		},
+		{
+			name: "missing and unexpected arguments",
+			program: `f = (a, b=1) => a + b
+f(c: 1)`,
+			want: []string{
+				`2:1: missing required argument "a" in call to f`,
+				`2:3: function f does not have a parameter "c"`,
+			},
+		},
+		{
+			name: "record property type",
+			program: `f = (r) => r.value + 1
+f(r: {value: "a"})`,
+			want: []string{`2:7: invalid argument "r": expected int but found string`},
+		},
+		{
+			name: "missing record property",
+			program: `o = {a: 1}
+o.b`,
+			want: []string{`2:1: object {a: int} has no property "b"`},
+		},
+		{
+			name: "interpolated non string",
+			program: `f = (v) => "value: ${v}"
+f(v: 1)`,
+			want: []string{`2:6: invalid argument "v": expected string but found int`},
+		},
+		{
+			name:    "conditional test",
+			program: `f = (v) => if v + 1 then "a" else "b"`,
+			want:    []string{`1:15: expected bool but found int`},
+		},
+		{
+			name:    "array elements",
+			program: `a = [1, "a"]`,
+			want:    []string{`1:9: array elements must have the same type: expected int but found string`},
+		},
+		{
+			name: "all errors are reported",
+			program: `a = undefined
+b = 1 and true
+c = 1.0 - 1`,
+			want: []string{
+				`1:5: undefined identifier "undefined"`,
+				`2:5: expected bool but found int`,
+				`3:5: invalid binary operator - for types float and int`,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewAST(tc.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			declarations := semantic.DeclarationScope{
+				"x": semantic.NewExternalVariableDeclaration("x", semantic.Int),
+				"from": semantic.NewExternalVariableDeclaration("from", semantic.NewFunctionType(semantic.FunctionSignature{
+					Params:     map[string]semantic.Type{"db": semantic.String},
+					ReturnType: semantic.EmptyObject,
+				})),
+				"filter": semantic.NewExternalVariableDeclaration("filter", semantic.NewFunctionType(semantic.FunctionSignature{
+					Params: map[string]semantic.Type{
+						"table": semantic.EmptyObject,
+						"fn":    semantic.Function,
+					},
+					ReturnType:   semantic.EmptyObject,
+					PipeArgument: "table",
+				})),
+			}
+			semProg, err := semantic.New(program, declarations.Copy())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if err := semantic.Infer(semProg, declarations); err != nil {
+				typeErrs, ok := err.(semantic.TypeErrors)
+				if !ok {
+					t.Fatalf("unexpected error type %T", err)
+				}
+				for _, e := range typeErrs {
+					got = append(got, e.Error())
+				}
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected type errors -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
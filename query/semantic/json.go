@@ -108,6 +108,17 @@ func (s *ExpressionStatement) UnmarshalJSON(data []byte) error {
 	s.Expression = e
 	return nil
 }
+func (s *OptionStatement) MarshalJSON() ([]byte, error) {
+	type Alias OptionStatement
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  s.NodeType(),
+		Alias: (*Alias)(s),
+	}
+	return json.Marshal(raw)
+}
 func (s *ReturnStatement) MarshalJSON() ([]byte, error) {
 	type Alias ReturnStatement
 	raw := struct {
@@ -205,6 +216,45 @@ func (e *CallExpression) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+func (e *IndexExpression) MarshalJSON() ([]byte, error) {
+	type Alias IndexExpression
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  e.NodeType(),
+		Alias: (*Alias)(e),
+	}
+	return json.Marshal(raw)
+}
+func (e *IndexExpression) UnmarshalJSON(data []byte) error {
+	type Alias IndexExpression
+	raw := struct {
+		*Alias
+		Array json.RawMessage `json:"array"`
+		Index json.RawMessage `json:"index"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Alias != nil {
+		*e = *(*IndexExpression)(raw.Alias)
+	}
+
+	array, err := unmarshalExpression(raw.Array)
+	if err != nil {
+		return err
+	}
+	e.Array = array
+
+	index, err := unmarshalExpression(raw.Index)
+	if err != nil {
+		return err
+	}
+	e.Index = index
+
+	return nil
+}
 func (e *MemberExpression) MarshalJSON() ([]byte, error) {
 	type Alias MemberExpression
 	raw := struct {
@@ -808,6 +858,8 @@ func unmarshalNode(msg json.RawMessage) (Node, error) {
 		node = new(BlockStatement)
 	case "ExpressionStatement":
 		node = new(ExpressionStatement)
+	case "OptionStatement":
+		node = new(OptionStatement)
 	case "ReturnStatement":
 		node = new(ReturnStatement)
 	case "NativeVariableDeclaration":
@@ -816,6 +868,8 @@ func unmarshalNode(msg json.RawMessage) (Node, error) {
 		node = new(CallExpression)
 	case "MemberExpression":
 		node = new(MemberExpression)
+	case "IndexExpression":
+		node = new(IndexExpression)
 	case "BinaryExpression":
 		node = new(BinaryExpression)
 	case "UnaryExpression":
@@ -48,6 +48,16 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"ExpressionStatement","expression":{"type":"StringLiteral","value":"hello"}}`,
 		},
+		{
+			name: "option statement",
+			node: &semantic.OptionStatement{
+				Declaration: &semantic.NativeVariableDeclaration{
+					Identifier: &semantic.Identifier{Name: "location"},
+					Init:       &semantic.StringLiteral{Value: "UTC"},
+				},
+			},
+			want: `{"type":"OptionStatement","declaration":{"type":"NativeVariableDeclaration","identifier":{"type":"Identifier","name":"location"},"init":{"type":"StringLiteral","value":"UTC"}}}`,
+		},
 		{
 			name: "return statement",
 			node: &semantic.ReturnStatement{
@@ -130,6 +140,14 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"ObjectExpression","properties":[{"type":"Property","key":{"type":"Identifier","name":"a"},"value":{"type":"StringLiteral","value":"hello"}}]}`,
 		},
+		{
+			name: "index expression",
+			node: &semantic.IndexExpression{
+				Array: &semantic.IdentifierExpression{Name: "a"},
+				Index: &semantic.IntegerLiteral{Value: 3},
+			},
+			want: `{"type":"IndexExpression","array":{"type":"IdentifierExpression","name":"a"},"index":{"type":"IntegerLiteral","value":"3"}}`,
+		},
 		{
 			name: "conditional expression",
 			node: &semantic.ConditionalExpression{
@@ -9,10 +9,34 @@ import (
 )
 
 var CmpOptions = []cmp.Option{
+	cmpopts.IgnoreUnexported(semantic.Program{}),
+	cmpopts.IgnoreUnexported(semantic.BlockStatement{}),
+	cmpopts.IgnoreUnexported(semantic.ExpressionStatement{}),
+	cmpopts.IgnoreUnexported(semantic.OptionStatement{}),
+	cmpopts.IgnoreUnexported(semantic.ReturnStatement{}),
+	cmpopts.IgnoreUnexported(semantic.NativeVariableDeclaration{}),
+	cmpopts.IgnoreUnexported(semantic.ExternalVariableDeclaration{}),
 	cmpopts.IgnoreUnexported(semantic.ArrayExpression{}),
-	cmpopts.IgnoreUnexported(semantic.ObjectExpression{}),
 	cmpopts.IgnoreUnexported(semantic.FunctionExpression{}),
+	cmpopts.IgnoreUnexported(semantic.BinaryExpression{}),
+	cmpopts.IgnoreUnexported(semantic.CallExpression{}),
+	cmpopts.IgnoreUnexported(semantic.ConditionalExpression{}),
 	cmpopts.IgnoreUnexported(semantic.IdentifierExpression{}),
+	cmpopts.IgnoreUnexported(semantic.IndexExpression{}),
+	cmpopts.IgnoreUnexported(semantic.LogicalExpression{}),
+	cmpopts.IgnoreUnexported(semantic.MemberExpression{}),
+	cmpopts.IgnoreUnexported(semantic.ObjectExpression{}),
+	cmpopts.IgnoreUnexported(semantic.UnaryExpression{}),
+	cmpopts.IgnoreUnexported(semantic.Identifier{}),
+	cmpopts.IgnoreUnexported(semantic.Property{}),
 	cmpopts.IgnoreUnexported(semantic.FunctionParam{}),
+	cmpopts.IgnoreUnexported(semantic.BooleanLiteral{}),
+	cmpopts.IgnoreUnexported(semantic.DateTimeLiteral{}),
+	cmpopts.IgnoreUnexported(semantic.DurationLiteral{}),
+	cmpopts.IgnoreUnexported(semantic.FloatLiteral{}),
+	cmpopts.IgnoreUnexported(semantic.IntegerLiteral{}),
+	cmpopts.IgnoreUnexported(semantic.StringLiteral{}),
+	cmpopts.IgnoreUnexported(semantic.RegexpLiteral{}),
+	cmpopts.IgnoreUnexported(semantic.UnsignedIntegerLiteral{}),
 	cmp.Comparer(func(x, y *regexp.Regexp) bool { return x.String() == y.String() }),
 }
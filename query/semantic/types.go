@@ -57,6 +57,7 @@ const (
 	Bool
 	Time
 	Duration
+	Bytes
 	Regexp
 	Array
 	Object
@@ -73,6 +74,7 @@ var kindNames = []string{
 	Bool:     "bool",
 	Time:     "time",
 	Duration: "duration",
+	Bytes:    "bytes",
 	Regexp:   "regexp",
 	Array:    "array",
 	Object:   "object",
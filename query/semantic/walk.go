@@ -31,6 +31,11 @@ func walk(v Visitor, n Node) {
 		if w != nil {
 			walk(w, n.Expression)
 		}
+	case *OptionStatement:
+		w := v.Visit(n)
+		if w != nil {
+			walk(w, n.Declaration)
+		}
 	case *ReturnStatement:
 		w := v.Visit(n)
 		if w != nil {
@@ -90,6 +95,12 @@ func walk(v Visitor, n Node) {
 		if w != nil {
 			walk(w, n.declaration)
 		}
+	case *IndexExpression:
+		w := v.Visit(n)
+		if w != nil {
+			walk(w, n.Array)
+			walk(w, n.Index)
+		}
 	case *LogicalExpression:
 		w := v.Visit(n)
 		if w != nil {
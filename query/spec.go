@@ -2,6 +2,7 @@ package query
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -12,6 +13,15 @@ type Spec struct {
 	Edges      []Edge             `json:"edges"`
 	Resources  ResourceManagement `json:"resources"`
 
+	// Now is the time relative times of the query are resolved against.
+	// If it is zero the time the query is executed is used.
+	Now time.Time `json:"now"`
+	// Location is the name of the time zone used to align times of the query, such as windows of days.
+	// If it is empty UTC is used.
+	Location string `json:"location,omitempty"`
+	// Task holds the options of the task, if the query is run as a task.
+	Task *TaskOptions `json:"task,omitempty"`
+
 	sorted   []*Operation
 	children map[OperationID][]*Operation
 	parents  map[OperationID][]*Operation
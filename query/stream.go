@@ -0,0 +1,80 @@
+package query
+
+import (
+	"errors"
+	"sync"
+)
+
+// StreamingResultIterator is a ResultIterator whose results are produced concurrently.
+// Instead of reading one result after another, the blocks of all results can be streamed as they are produced.
+type StreamingResultIterator interface {
+	ResultIterator
+
+	// Stream calls f for every block of the remaining results in the order the blocks are produced.
+	// Blocks of different results may be interleaved, f is never called concurrently.
+	// A block is only valid until f returns.
+	// Once Stream returns the iterator has no more results.
+	Stream(f func(name string, b Block) error) error
+}
+
+var errStreamAborted = errors.New("stream aborted")
+
+type streamedBlock struct {
+	name  string
+	block Block
+	done  chan error
+}
+
+// StreamResults reads all results concurrently and calls f for each block as it is produced.
+// The results are not read faster than f consumes the blocks,
+// so a slow consumer applies backpressure to the producers of the results.
+// If f returns an error the remaining blocks are discarded,
+// the producers of the results must stop producing blocks in order for StreamResults to return.
+func StreamResults(results []Result, f func(name string, b Block) error) error {
+	blocks := make(chan streamedBlock)
+	abort := make(chan struct{})
+	errs := make(chan error, len(results))
+
+	var wg sync.WaitGroup
+	wg.Add(len(results))
+	for _, r := range results {
+		go func(r Result) {
+			defer wg.Done()
+			name := r.Name()
+			done := make(chan error, 1)
+			errs <- r.Blocks().Do(func(b Block) error {
+				select {
+				case blocks <- streamedBlock{name: name, block: b, done: done}:
+				case <-abort:
+					return errStreamAborted
+				}
+				// Wait for the block to be consumed before it is released.
+				return <-done
+			})
+		}(r)
+	}
+	go func() {
+		wg.Wait()
+		close(blocks)
+	}()
+
+	var err error
+	for sb := range blocks {
+		if err == nil {
+			if err = f(sb.name, sb.block); err != nil {
+				close(abort)
+			}
+		}
+		sb.done <- err
+	}
+	if err != nil {
+		return err
+	}
+	close(errs)
+	for e := range errs {
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/execute/executetest"
+)
+
+// chanResult is a result whose blocks are produced on a channel.
+type chanResult struct {
+	name   string
+	blocks chan query.Block
+}
+
+func newChanResult(name string) *chanResult {
+	return &chanResult{
+		name:   name,
+		blocks: make(chan query.Block),
+	}
+}
+
+func (r *chanResult) Name() string {
+	return r.name
+}
+
+func (r *chanResult) Blocks() query.BlockIterator {
+	return r
+}
+
+func (r *chanResult) Do(f func(query.Block) error) error {
+	for b := range r.blocks {
+		if err := f(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamResults(t *testing.T) {
+	a, b := newChanResult("a"), newChanResult("b")
+	blocks := make([]*executetest.Block, 4)
+	for i := range blocks {
+		blocks[i] = &executetest.Block{KeyCols: []string{}}
+	}
+
+	// Produce the blocks in an interleaved order, waiting for each block to be consumed.
+	consumed := make(chan struct{}, 1)
+	go func() {
+		a.blocks <- blocks[0]
+		<-consumed
+		b.blocks <- blocks[1]
+		<-consumed
+		a.blocks <- blocks[2]
+		<-consumed
+		close(a.blocks)
+		b.blocks <- blocks[3]
+		close(b.blocks)
+	}()
+
+	var got []string
+	err := query.StreamResults([]query.Result{a, b}, func(name string, blk query.Block) error {
+		got = append(got, name)
+		consumed <- struct{}{}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "a", "b"}; !cmp.Equal(want, got) {
+		t.Errorf("unexpected order -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestStreamResults_Error(t *testing.T) {
+	a, b := newChanResult("a"), newChanResult("b")
+	go func() {
+		a.blocks <- &executetest.Block{}
+		close(a.blocks)
+		close(b.blocks)
+	}()
+
+	want := errors.New("expected error")
+	got := query.StreamResults([]query.Result{a, b}, func(name string, blk query.Block) error {
+		return want
+	})
+	if got != want {
+		t.Errorf("unexpected error: want %v got %v", want, got)
+	}
+}
@@ -96,6 +96,9 @@ func (a *array) Time() Time {
 func (a *array) Duration() Duration {
 	panic(UnexpectedKind(semantic.Object, semantic.Duration))
 }
+func (a *array) Bytes() []byte {
+	panic(UnexpectedKind(semantic.Object, semantic.Bytes))
+}
 func (a *array) Regexp() *regexp.Regexp {
 	panic(UnexpectedKind(semantic.Object, semantic.Regexp))
 }
@@ -0,0 +1,72 @@
+package values
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/influxdata/platform/query/semantic"
+)
+
+// ErrNull is the panic value raised when the value of a null is accessed.
+// Evaluators recover it to propagate nulls through expressions.
+var ErrNull = errors.New("value is null")
+
+// null is a missing value of a type.
+type null struct {
+	t semantic.Type
+}
+
+// NewNull returns a missing value of the given type.
+func NewNull(t semantic.Type) Value {
+	return null{t: t}
+}
+
+// IsNull reports whether the value is missing.
+func IsNull(v Value) bool {
+	_, ok := v.(null)
+	return ok
+}
+
+func (n null) Type() semantic.Type {
+	return n.t
+}
+func (n null) Str() string {
+	panic(ErrNull)
+}
+func (n null) Int() int64 {
+	panic(ErrNull)
+}
+func (n null) UInt() uint64 {
+	panic(ErrNull)
+}
+func (n null) Float() float64 {
+	panic(ErrNull)
+}
+func (n null) Bool() bool {
+	panic(ErrNull)
+}
+func (n null) Time() Time {
+	panic(ErrNull)
+}
+func (n null) Duration() Duration {
+	panic(ErrNull)
+}
+func (n null) Bytes() []byte {
+	panic(ErrNull)
+}
+func (n null) Regexp() *regexp.Regexp {
+	panic(ErrNull)
+}
+func (n null) Array() Array {
+	panic(ErrNull)
+}
+func (n null) Object() Object {
+	panic(ErrNull)
+}
+func (n null) Function() Function {
+	panic(ErrNull)
+}
+
+func (n null) String() string {
+	return "null"
+}
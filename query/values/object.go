@@ -80,6 +80,9 @@ func (o *object) Time() Time {
 func (o *object) Duration() Duration {
 	panic(UnexpectedKind(semantic.Object, semantic.Duration))
 }
+func (o *object) Bytes() []byte {
+	panic(UnexpectedKind(semantic.Object, semantic.Bytes))
+}
 func (o *object) Regexp() *regexp.Regexp {
 	panic(UnexpectedKind(semantic.Object, semantic.Regexp))
 }
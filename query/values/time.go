@@ -34,6 +34,17 @@ func (t Time) Truncate(d Duration) Time {
 	return t - Time(r)
 }
 
+// TruncateIn is like Truncate but truncates relative to the local time in the location.
+// For example truncating to a day results in midnight in the location.
+func (t Time) TruncateIn(d Duration, loc *time.Location) Time {
+	if d <= 0 || loc == nil {
+		return t.Truncate(d)
+	}
+	_, offset := t.Time().In(loc).Zone()
+	o := Time(time.Duration(offset) * time.Second)
+	return (t + o).Truncate(d) - o
+}
+
 func (t Time) Add(d Duration) Time {
 	return t + Time(d)
 }
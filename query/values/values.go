@@ -20,6 +20,7 @@ type Value interface {
 	Bool() bool
 	Time() Time
 	Duration() Duration
+	Bytes() []byte
 	Regexp() *regexp.Regexp
 	Array() Array
 	Object() Object
@@ -68,6 +69,10 @@ func (v value) Duration() Duration {
 	CheckKind(v.t.Kind(), semantic.Duration)
 	return v.v.(Duration)
 }
+func (v value) Bytes() []byte {
+	CheckKind(v.t.Kind(), semantic.Bytes)
+	return v.v.([]byte)
+}
 func (v value) Regexp() *regexp.Regexp {
 	CheckKind(v.t.Kind(), semantic.Regexp)
 	return v.v.(*regexp.Regexp)
@@ -129,6 +134,11 @@ func NewValue(v interface{}, k semantic.Kind) (Value, error) {
 		if !ok {
 			return nil, fmt.Errorf("duration value must have type Duration, got %T", v)
 		}
+	case semantic.Bytes:
+		_, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("bytes value must have type []byte, got %T", v)
+		}
 	case semantic.Regexp:
 		_, ok := v.(*regexp.Regexp)
 		if !ok {
@@ -185,6 +195,12 @@ func NewDurationValue(v Duration) Value {
 		v: v,
 	}
 }
+func NewBytesValue(v []byte) Value {
+	return value{
+		t: semantic.Bytes,
+		v: v,
+	}
+}
 func NewRegexpValue(v *regexp.Regexp) Value {
 	return value{
 		t: semantic.Regexp,
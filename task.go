@@ -0,0 +1,160 @@
+package platform
+
+import (
+	"context"
+	"time"
+)
+
+// Task statuses.
+const (
+	TaskEnabled  = "enabled"
+	TaskDisabled = "disabled"
+)
+
+// Run statuses.
+const (
+	RunQueued    = "queued"
+	RunExecuting = "executing"
+	RunFailed    = "failed"
+	RunSuccess   = "success"
+)
+
+// Run error codes.
+const (
+	// RunErrorExecution indicates the Flux script of the task failed.
+	RunErrorExecution = 1
+	// RunErrorCanceled indicates the run was canceled before it was executed.
+	RunErrorCanceled = 2
+)
+
+// Task is a Flux script that is run on a schedule.
+// The schedule is defined by the task option of the script.
+type Task struct {
+	ID             ID     `json:"id,omitempty"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	Owner          User   `json:"owner"`
+	OrganizationID ID     `json:"organizationID"`
+	Flux           string `json:"flux"`
+	// Every and Cron are parsed from the Flux script and are read-only.
+	Every string `json:"every,omitempty"`
+	Cron  string `json:"cron,omitempty"`
+	// Last is the most recent run of the task.
+	Last *Run `json:"last,omitempty"`
+}
+
+// Run is a single execution of a task.
+type Run struct {
+	ID        ID         `json:"id,omitempty"`
+	TaskID    ID         `json:"taskID"`
+	Status    string     `json:"status"`
+	QueuedAt  time.Time  `json:"queuedAt"`
+	StartTime *time.Time `json:"startTime,omitempty"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+	Error     *RunError  `json:"error,omitempty"`
+	// Log is a URL to the logs of the run.
+	Log string `json:"log,omitempty"`
+}
+
+// RunError describes why a run failed.
+type RunError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Log is a single message logged during a run.
+type Log struct {
+	RunID   ID        `json:"runID"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// TaskService represents a service for managing tasks and their runs.
+type TaskService interface {
+	// FindTaskByID returns a single task by ID.
+	FindTaskByID(ctx context.Context, id ID) (*Task, error)
+
+	// FindTasks returns a list of tasks that match filter and the total count of matching tasks.
+	// Additional options provide pagination & sorting.
+	FindTasks(ctx context.Context, filter TaskFilter, opt ...FindOptions) ([]*Task, int, error)
+
+	// CreateTask creates a new task and sets t.ID with the new identifier.
+	// The schedule of the task is parsed from its Flux script.
+	CreateTask(ctx context.Context, t *Task) error
+
+	// UpdateTask updates a single task with changeset and cancels all of its queued runs.
+	// Returns the new task state after update.
+	UpdateTask(ctx context.Context, id ID, upd TaskUpdate) (*Task, error)
+
+	// DeleteTask removes a task by ID together with its runs and logs.
+	DeleteTask(ctx context.Context, id ID) error
+
+	// FindRuns returns a list of runs that match filter and the total count of matching runs.
+	FindRuns(ctx context.Context, filter RunFilter) ([]*Run, int, error)
+
+	// FindRunByID returns a single run of a task.
+	FindRunByID(ctx context.Context, taskID, runID ID) (*Run, error)
+
+	// RetryRun queues a new run of the task which ran as runID.
+	// Returns the newly queued run.
+	RetryRun(ctx context.Context, taskID, runID ID) (*Run, error)
+
+	// FindLogs returns a list of logs that match filter and the total count of matching logs.
+	FindLogs(ctx context.Context, filter LogFilter) ([]*Log, int, error)
+}
+
+// TaskRunService represents a service used by a scheduler to record the execution of runs.
+type TaskRunService interface {
+	// CreateRun queues a new run and sets r.ID with the new identifier.
+	CreateRun(ctx context.Context, r *Run) error
+
+	// UpdateRun updates a single run with changeset.
+	// Returns the new run state after update.
+	UpdateRun(ctx context.Context, taskID, runID ID, upd RunUpdate) (*Run, error)
+
+	// AddRunLog appends a log to a run of a task.
+	AddRunLog(ctx context.Context, taskID ID, l *Log) error
+}
+
+// TaskFilter represents a set of filter that restrict the returned results.
+type TaskFilter struct {
+	ID             *ID
+	User           *ID
+	OrganizationID *ID
+}
+
+// TaskUpdate represents updates to a task.
+// Only fields which are set are updated.
+type TaskUpdate struct {
+	Name   *string `json:"name,omitempty"`
+	Status *string `json:"status,omitempty"`
+	Flux   *string `json:"flux,omitempty"`
+}
+
+// RunFilter represents a set of filter that restrict the returned runs of a task.
+type RunFilter struct {
+	Task   ID
+	Status *string
+	// After restricts the results to runs with an ID after the given ID.
+	After *ID
+	// AfterTime and BeforeTime restrict the results to runs queued within the time range.
+	AfterTime  *time.Time
+	BeforeTime *time.Time
+	// Limit is the maximum number of runs returned, zero means no limit.
+	Limit int
+}
+
+// RunUpdate represents updates to a run.
+// Only fields which are set are updated.
+type RunUpdate struct {
+	Status    *string
+	StartTime *time.Time
+	EndTime   *time.Time
+	Error     *RunError
+}
+
+// LogFilter represents a set of filter that restrict the returned logs of a task.
+type LogFilter struct {
+	Task ID
+	Run  *ID
+}
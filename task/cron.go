@@ -0,0 +1,168 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a schedule described by a cron expression.
+// An expression has five fields (minute, hour, day of month, month and day of week)
+// or six fields where the additional leading field is the second.
+// Each field is a "*", a value, a range "a-b" or a comma separated list of them,
+// and may be followed by a step "/n".
+// Times are evaluated in UTC.
+type Cron struct {
+	second, minute, hour, dom, month, dow uint64
+	// domStar and dowStar record whether the day fields are unrestricted,
+	// if both are restricted a day matches when either matches.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	secondField = cronField{name: "second", min: 0, max: 59}
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12}
+	dowField    = cronField{name: "day of week", min: 0, max: 6}
+)
+
+// ParseCron parses a cron expression.
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression %q must have 5 or 6 fields, got %d", expr, len(fields))
+	}
+	c := new(Cron)
+	var err error
+	if c.second, err = secondField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if c.minute, err = minuteField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if c.hour, err = hourField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if c.dom, err = domField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.month, err = monthField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if c.dow, err = dowField.parse(fields[5]); err != nil {
+		return nil, err
+	}
+	c.domStar = fields[3] == "*"
+	c.dowStar = fields[5] == "*"
+	return c, nil
+}
+
+// parse returns the set of values matched by the field as a bit set.
+func (f cronField) parse(s string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", part[i+1:], f.name)
+			}
+			rng, step = part[:i], n
+		}
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			var err error
+			bounds := strings.SplitN(rng, "-", 2)
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// A value with a step is the start of a range ending at the maximum.
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q in %s field", rng, f.name)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d,%d] in %s field", v, f.min, f.max, f.name)
+	}
+	return v, nil
+}
+
+// maxCronYears bounds the search for the next scheduled time,
+// an expression like "0 0 30 2 *" never matches.
+const maxCronYears = 5
+
+// Next returns the first time strictly after t that matches the expression.
+// The zero time is returned if no time within the next few years matches.
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(maxCronYears, 0, 0)
+	for t.Before(limit) {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if !has(c.second, t.Second()) {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) matchDay(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
@@ -0,0 +1,108 @@
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/task"
+)
+
+func TestCron_Next(t *testing.T) {
+	from := time.Date(2018, 7, 1, 10, 30, 15, 0, time.UTC) // a Sunday
+	testCases := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			from: from,
+			want: time.Date(2018, 7, 1, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name: "every second",
+			expr: "* * * * * *",
+			from: from,
+			want: time.Date(2018, 7, 1, 10, 30, 16, 0, time.UTC),
+		},
+		{
+			name: "hourly",
+			expr: "0 * * * *",
+			from: from,
+			want: time.Date(2018, 7, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "step",
+			expr: "*/20 * * * *",
+			from: from,
+			want: time.Date(2018, 7, 1, 10, 40, 0, 0, time.UTC),
+		},
+		{
+			name: "nightly wraps day",
+			expr: "0 2 * * *",
+			from: from,
+			want: time.Date(2018, 7, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "list and range",
+			expr: "15 9-17 * * 1,3",
+			from: from,
+			want: time.Date(2018, 7, 2, 9, 15, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 15 * 3",
+			from: from,
+			want: time.Date(2018, 7, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly wraps year",
+			expr: "0 0 1 1 *",
+			from: from,
+			want: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "exact match is skipped",
+			expr: "30 10 * * *",
+			from: time.Date(2018, 7, 1, 10, 30, 0, 0, time.UTC),
+			want: time.Date(2018, 7, 2, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			expr: "0 0 30 2 *",
+			from: from,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := task.ParseCron(tc.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Next(tc.from); !got.Equal(tc.want) {
+				t.Errorf("unexpected next time: got %v want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCron_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		if _, err := task.ParseCron(expr); err == nil {
+			t.Errorf("expected error parsing %q", expr)
+		}
+	}
+}
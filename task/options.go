@@ -0,0 +1,50 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/influxdata/platform/query"
+)
+
+// Options compiles a Flux script and returns its task options.
+// The built-in functions used by the script must have been registered.
+func Options(ctx context.Context, flux string) (*query.TaskOptions, error) {
+	spec, err := query.Compile(ctx, flux)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Task == nil {
+		return nil, errors.New("missing task option")
+	}
+	if _, err := NewSchedule(spec.Task); err != nil {
+		return nil, err
+	}
+	return spec.Task, nil
+}
+
+// Schedule determines when a task is run.
+type Schedule interface {
+	// Next returns the first scheduled time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+// NewSchedule returns the schedule described by the task options.
+func NewSchedule(o *query.TaskOptions) (Schedule, error) {
+	if o.Cron != "" {
+		return ParseCron(o.Cron)
+	}
+	if o.Every <= 0 {
+		return nil, errors.New("task must be scheduled with a positive every or a cron expression")
+	}
+	return every(o.Every), nil
+}
+
+// every schedules a task at multiples of a duration since the zero time.
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	d := time.Duration(e)
+	return t.Truncate(d).Add(d)
+}
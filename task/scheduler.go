@@ -0,0 +1,258 @@
+// Package task schedules and executes the runs of platform tasks.
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"go.uber.org/zap"
+)
+
+// DefaultInterval is how often a scheduler checks for due tasks by default.
+const DefaultInterval = time.Second
+
+// Scheduler queues runs of enabled tasks according to their schedule,
+// and executes the queued runs, including retries, through a query service.
+type Scheduler struct {
+	TaskService  platform.TaskService
+	RunService   platform.TaskRunService
+	QueryService query.QueryService
+	Logger       *zap.Logger
+
+	// Interval is how often the scheduler checks for due tasks.
+	Interval time.Duration
+	// Now returns the current time.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	schedules map[string]*scheduled
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// scheduled is the schedule state of a single task.
+type scheduled struct {
+	flux     string
+	schedule Schedule
+	delay    time.Duration
+	// next is the next time the task is due, before the delay is applied.
+	next time.Time
+	// err is the error encountered parsing the options of the task.
+	err error
+}
+
+// NewScheduler returns a new instance of Scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		Logger:    zap.NewNop(),
+		Interval:  DefaultInterval,
+		Now:       time.Now,
+		schedules: make(map[string]*scheduled),
+	}
+}
+
+// Open starts checking for due tasks every interval.
+func (s *Scheduler) Open() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx)
+	return nil
+}
+
+// Close stops the scheduler and waits for the executing runs to finish.
+func (s *Scheduler) Close() error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	<-s.done
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Tick(ctx, s.Now()); err != nil {
+				s.Logger.Info("failed to schedule tasks", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Tick queues a run of every enabled task that is due at now,
+// and starts executing all queued runs of enabled tasks.
+// The first time a task is seen it is scheduled after now, missed runs are never queued.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks, _, err := s.TaskService.FindTasks(ctx, platform.TaskFilter{})
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		if t.Status != platform.TaskEnabled {
+			continue
+		}
+		key := t.ID.String()
+		seen[key] = true
+
+		if err := s.queueDue(ctx, key, t, now); err != nil {
+			s.Logger.Info("failed to queue run", zap.String("task", key), zap.Error(err))
+		}
+		if err := s.startQueued(ctx, t); err != nil {
+			s.Logger.Info("failed to start runs", zap.String("task", key), zap.Error(err))
+		}
+	}
+
+	// Forget deleted and disabled tasks so they are rescheduled when they reappear.
+	for key := range s.schedules {
+		if !seen[key] {
+			delete(s.schedules, key)
+		}
+	}
+	return nil
+}
+
+// Wait waits for all executing runs to finish.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) queueDue(ctx context.Context, key string, t *platform.Task, now time.Time) error {
+	sc, ok := s.schedules[key]
+	if !ok || sc.flux != t.Flux {
+		sc = &scheduled{flux: t.Flux}
+		s.schedules[key] = sc
+
+		opts, err := Options(ctx, t.Flux)
+		if err != nil {
+			sc.err = err
+			return err
+		}
+		sc.schedule, _ = NewSchedule(opts)
+		sc.delay = time.Duration(opts.Delay)
+		sc.next = sc.schedule.Next(now)
+	}
+	if sc.err != nil || sc.next.IsZero() || now.Before(sc.next.Add(sc.delay)) {
+		return nil
+	}
+	for !sc.next.IsZero() && !now.Before(sc.next.Add(sc.delay)) {
+		sc.next = sc.schedule.Next(sc.next)
+	}
+
+	r := &platform.Run{
+		TaskID:   t.ID,
+		Status:   platform.RunQueued,
+		QueuedAt: now,
+	}
+	return s.RunService.CreateRun(ctx, r)
+}
+
+func (s *Scheduler) startQueued(ctx context.Context, t *platform.Task) error {
+	queued := platform.RunQueued
+	runs, _, err := s.TaskService.FindRuns(ctx, platform.RunFilter{Task: t.ID, Status: &queued})
+	if err != nil {
+		return err
+	}
+
+	executing := platform.RunExecuting
+	for _, r := range runs {
+		start := s.Now()
+		r, err := s.RunService.UpdateRun(ctx, t.ID, r.ID, platform.RunUpdate{
+			Status:    &executing,
+			StartTime: &start,
+		})
+		if err != nil {
+			return err
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.execute(ctx, t, r)
+		}()
+	}
+	return nil
+}
+
+// execute runs the Flux script of the task and records the outcome on the run.
+func (s *Scheduler) execute(ctx context.Context, t *platform.Task, r *platform.Run) {
+	status := platform.RunSuccess
+	upd := platform.RunUpdate{Status: &status}
+	if err := s.query(ctx, t, r); err != nil {
+		status = platform.RunFailed
+		upd.Error = &platform.RunError{
+			Code:    platform.RunErrorExecution,
+			Message: err.Error(),
+		}
+		s.log(ctx, r, "run failed: %v", err)
+	} else {
+		s.log(ctx, r, "run succeeded")
+	}
+
+	end := s.Now()
+	upd.EndTime = &end
+	if _, err := s.RunService.UpdateRun(ctx, t.ID, r.ID, upd); err != nil {
+		s.Logger.Info("failed to update run", zap.String("task", t.ID.String()), zap.String("run", r.ID.String()), zap.Error(err))
+	}
+}
+
+func (s *Scheduler) query(ctx context.Context, t *platform.Task, r *platform.Run) error {
+	s.log(ctx, r, "executing task %q", t.Name)
+	results, err := s.QueryService.QueryWithCompile(ctx, t.OrganizationID, t.Flux)
+	if err != nil {
+		return err
+	}
+
+	for results.More() {
+		res := results.Next()
+		var blocks, rows int
+		err := res.Blocks().Do(func(b query.Block) error {
+			blocks++
+			return b.Do(func(cr query.ColReader) error {
+				rows += cr.Len()
+				return nil
+			})
+		})
+		if err != nil {
+			results.Cancel()
+			// Drain the remaining results so that the query is freed.
+			for results.More() {
+				results.Next()
+			}
+			return err
+		}
+		s.log(ctx, r, "result %q: %d tables, %d rows", res.Name(), blocks, rows)
+	}
+	return results.Err()
+}
+
+// log appends a message to the logs of the run.
+func (s *Scheduler) log(ctx context.Context, r *platform.Run, format string, args ...interface{}) {
+	l := &platform.Log{
+		RunID:   r.ID,
+		Time:    s.Now(),
+		Message: fmt.Sprintf(format, args...),
+	}
+	if err := s.RunService.AddRunLog(ctx, r.TaskID, l); err != nil {
+		s.Logger.Info("failed to add run log", zap.String("run", r.ID.String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,184 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/query/execute/executetest"
+	"github.com/influxdata/platform/task"
+)
+
+const hourlyFlux = `option task = {name: "hourly", every: 1h, delay: 5m}
+from(db: "telegraf") |> range(start: -1h)`
+
+// queryService returns a single result with one block of two rows, or err.
+type queryService struct {
+	err     error
+	queries int
+}
+
+func (s *queryService) Query(ctx context.Context, orgID platform.ID, spec *query.Spec) (query.ResultIterator, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *queryService) QueryWithCompile(ctx context.Context, orgID platform.ID, q string) (query.ResultIterator, error) {
+	s.queries++
+	if s.err != nil {
+		return nil, s.err
+	}
+	result := &executetest.Result{
+		Nm: "_result",
+		Blks: []*executetest.Block{{
+			ColMeta: []query.ColMeta{
+				{Label: "_value", Type: query.TFloat},
+			},
+			Data: [][]interface{}{
+				{1.0},
+				{2.0},
+			},
+		}},
+	}
+	result.Normalize()
+	return query.NewMapResultIterator(map[string]query.Result{"_result": result}), nil
+}
+
+func newTestClient(t *testing.T) (*bolt.Client, func()) {
+	f, err := ioutil.TempFile("", "influxdata-platform-task-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return c, func() {
+		c.Close()
+		os.Remove(c.Path)
+	}
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	ctx := context.Background()
+	c, done := newTestClient(t)
+	defer done()
+
+	tk := &platform.Task{Flux: hourlyFlux}
+	if err := c.CreateTask(ctx, tk); err != nil {
+		t.Fatal(err)
+	}
+
+	qs := &queryService{}
+	now := time.Date(2018, 7, 1, 10, 30, 0, 0, time.UTC)
+	s := task.NewScheduler()
+	s.TaskService = c
+	s.RunService = c
+	s.QueryService = qs
+	s.Now = func() time.Time { return now }
+
+	tick := func(at time.Time) []*platform.Run {
+		t.Helper()
+		now = at
+		if err := s.Tick(ctx, at); err != nil {
+			t.Fatal(err)
+		}
+		s.Wait()
+		runs, _, err := c.FindRuns(ctx, platform.RunFilter{Task: tk.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return runs
+	}
+
+	// The first run is due at 11:00 and is delayed by 5m.
+	if runs := tick(now); len(runs) != 0 {
+		t.Fatalf("expected no runs before the task is due, got %d", len(runs))
+	}
+	if runs := tick(time.Date(2018, 7, 1, 11, 0, 0, 0, time.UTC)); len(runs) != 0 {
+		t.Fatalf("expected no runs before the delay has passed, got %d", len(runs))
+	}
+
+	queuedAt := time.Date(2018, 7, 1, 11, 5, 0, 0, time.UTC)
+	runs := tick(queuedAt)
+	if len(runs) != 1 {
+		t.Fatalf("expected one run, got %d", len(runs))
+	}
+	want := &platform.Run{
+		ID:        runs[0].ID,
+		TaskID:    tk.ID,
+		Status:    platform.RunSuccess,
+		QueuedAt:  queuedAt,
+		StartTime: &queuedAt,
+		EndTime:   &queuedAt,
+	}
+	if !cmp.Equal(runs[0], want) {
+		t.Errorf("unexpected run -want/+got\n%s", cmp.Diff(want, runs[0]))
+	}
+
+	logs, _, err := c.FindLogs(ctx, platform.LogFilter{Task: tk.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []string
+	for _, l := range logs {
+		msgs = append(msgs, l.Message)
+	}
+	wantMsgs := []string{
+		`executing task "hourly"`,
+		`result "_result": 1 tables, 2 rows`,
+		`run succeeded`,
+	}
+	if !cmp.Equal(msgs, wantMsgs) {
+		t.Errorf("unexpected logs -want/+got\n%s", cmp.Diff(wantMsgs, msgs))
+	}
+
+	// The task is not due again until 12:05, and failed queries fail the run.
+	qs.err = errors.New("storage unavailable")
+	if runs := tick(time.Date(2018, 7, 1, 12, 4, 0, 0, time.UTC)); len(runs) != 1 {
+		t.Fatalf("expected one run, got %d", len(runs))
+	}
+	runs = tick(time.Date(2018, 7, 1, 12, 5, 0, 0, time.UTC))
+	if len(runs) != 2 {
+		t.Fatalf("expected two runs, got %d", len(runs))
+	}
+	failed := runs[1]
+	if failed.Status != platform.RunFailed || failed.Error == nil || failed.Error.Message != "storage unavailable" {
+		t.Errorf("expected failed run, got %+v", failed)
+	}
+
+	// A retried run is executed on the next tick.
+	qs.err = nil
+	if _, err := c.RetryRun(ctx, tk.ID, failed.ID); err != nil {
+		t.Fatal(err)
+	}
+	runs = tick(time.Date(2018, 7, 1, 12, 6, 0, 0, time.UTC))
+	if len(runs) != 3 {
+		t.Fatalf("expected three runs, got %d", len(runs))
+	}
+	if runs[2].Status != platform.RunSuccess {
+		t.Errorf("expected retried run to succeed, got %q", runs[2].Status)
+	}
+
+	// Disabled tasks are not run.
+	disabled := platform.TaskDisabled
+	if _, err := c.UpdateTask(ctx, tk.ID, platform.TaskUpdate{Status: &disabled}); err != nil {
+		t.Fatal(err)
+	}
+	if runs := tick(time.Date(2018, 7, 1, 13, 5, 0, 0, time.UTC)); len(runs) != 3 {
+		t.Fatalf("expected no new runs of a disabled task, got %d runs", len(runs))
+	}
+	if qs.queries != 3 {
+		t.Errorf("expected 3 queries, got %d", qs.queries)
+	}
+}
@@ -3,16 +3,18 @@ package testing
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/mock"
 )
 
 var authorizationCmpOptions = cmp.Options{
+	cmpopts.IgnoreFields(platform.Authorization{}, "Meta"),
 	cmp.Comparer(func(x, y []byte) bool {
 		return bytes.Equal(x, y)
 	}),
@@ -174,8 +176,8 @@ func CreateAuthorization(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 			defer s.DeleteAuthorization(ctx, tt.args.authorization.ID)
@@ -272,8 +274,8 @@ func FindAuthorizationByID(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -384,8 +386,8 @@ func FindAuthorizationByToken(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -636,8 +638,8 @@ func FindAuthorizations(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -754,7 +756,7 @@ func DeleteAuthorization(
 				ID: "123",
 			},
 			wants: wants{
-				err: fmt.Errorf("authorization not found"),
+				err: kerrors.NotFoundf("authorization not found"),
 				authorizations: []*platform.Authorization{
 					{
 						ID:     platform.ID("1"),
@@ -791,8 +793,8 @@ func DeleteAuthorization(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
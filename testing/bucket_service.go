@@ -3,17 +3,19 @@ package testing
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/mock"
 )
 
 var bucketCmpOptions = cmp.Options{
+	cmpopts.IgnoreFields(platform.Bucket{}, "Meta"),
 	cmp.Comparer(func(x, y []byte) bool {
 		return bytes.Equal(x, y)
 	}),
@@ -220,7 +222,7 @@ func CreateBucket(
 						OrganizationID: platform.ID("org1"),
 					},
 				},
-				err: fmt.Errorf("bucket with name bucket1 already exists"),
+				err: kerrors.Conflictf("bucket with name bucket1 already exists"),
 			},
 		},
 		{
@@ -285,8 +287,8 @@ func CreateBucket(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 			defer s.DeleteBucket(ctx, tt.args.bucket.ID)
@@ -369,8 +371,8 @@ func FindBucketByID(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -391,12 +393,39 @@ func FindBuckets(
 		name           string
 		organization   string
 		organizationID string
+		opts           []platform.FindOptions
 	}
 
 	type wants struct {
 		buckets []*platform.Bucket
 		err     error
 	}
+
+	pagingFields := BucketFields{
+		Organizations: []*platform.Organization{
+			{
+				Name: "theorg",
+				ID:   platform.ID("org1"),
+			},
+		},
+		Buckets: []*platform.Bucket{
+			{
+				ID:             platform.ID("test1"),
+				OrganizationID: platform.ID("org1"),
+				Name:           "abc",
+			},
+			{
+				ID:             platform.ID("test2"),
+				OrganizationID: platform.ID("org1"),
+				Name:           "xyz",
+			},
+			{
+				ID:             platform.ID("test3"),
+				OrganizationID: platform.ID("org1"),
+				Name:           "123",
+			},
+		},
+	}
 	tests := []struct {
 		name   string
 		fields BucketFields
@@ -621,6 +650,50 @@ func FindBuckets(
 				},
 			},
 		},
+		{
+			name:   "find buckets with limit and offset",
+			fields: pagingFields,
+			args: args{
+				opts: []platform.FindOptions{{Limit: 1, Offset: 1}},
+			},
+			wants: wants{
+				buckets: []*platform.Bucket{
+					{
+						ID:             platform.ID("test2"),
+						OrganizationID: platform.ID("org1"),
+						Organization:   "theorg",
+						Name:           "xyz",
+					},
+				},
+			},
+		},
+		{
+			name:   "find buckets sorted by name descending after cursor",
+			fields: pagingFields,
+			args: args{
+				opts: []platform.FindOptions{{Limit: 1, SortBy: "name", Descending: true, After: platform.ID("test2")}},
+			},
+			wants: wants{
+				buckets: []*platform.Bucket{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Organization:   "theorg",
+						Name:           "abc",
+					},
+				},
+			},
+		},
+		{
+			name:   "find buckets sorted by unknown field",
+			fields: pagingFields,
+			args: args{
+				opts: []platform.FindOptions{{SortBy: "retentionPeriod"}},
+			},
+			wants: wants{
+				err: kerrors.InvalidDataf(`cannot sort by "retentionPeriod"`),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -645,14 +718,14 @@ func FindBuckets(
 				filter.Name = &tt.args.name
 			}
 
-			buckets, _, err := s.FindBuckets(ctx, filter)
+			buckets, _, err := s.FindBuckets(ctx, filter, tt.args.opts...)
 			if (err != nil) != (tt.wants.err != nil) {
 				t.Fatalf("expected errors to be equal '%v' got '%v'", tt.wants.err, err)
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -744,7 +817,7 @@ func DeleteBucket(
 				ID: "123",
 			},
 			wants: wants{
-				err: fmt.Errorf("bucket not found"),
+				err: kerrors.NotFoundf("bucket not found"),
 				buckets: []*platform.Bucket{
 					{
 						Name:           "A",
@@ -774,8 +847,8 @@ func DeleteBucket(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -869,8 +942,8 @@ func FindBucket(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -1033,8 +1106,8 @@ func UpdateBucket(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -3,16 +3,18 @@ package testing
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/mock"
 )
 
 var dashboardCmpOptions = cmp.Options{
+	cmpopts.IgnoreFields(platform.Dashboard{}, "Meta"),
 	cmp.Comparer(func(x, y []byte) bool {
 		return bytes.Equal(x, y)
 	}),
@@ -220,8 +222,8 @@ func CreateDashboard(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 			defer s.DeleteDashboard(ctx, tt.args.dashboard.ID)
@@ -304,8 +306,8 @@ func FindDashboardByID(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -399,8 +401,8 @@ func FindDashboardsByOrganizationName(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -496,8 +498,8 @@ func FindDashboardsByOrganizationID(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -739,8 +741,8 @@ func FindDashboards(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -832,7 +834,7 @@ func DeleteDashboard(
 				ID: "123",
 			},
 			wants: wants{
-				err: fmt.Errorf("dashboard not found"),
+				err: kerrors.NotFoundf("dashboard not found"),
 				dashboards: []*platform.Dashboard{
 					{
 						Name:           "A",
@@ -862,8 +864,8 @@ func DeleteDashboard(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -888,6 +890,7 @@ func UpdateDashboard(
 		name      string
 		id        platform.ID
 		retention int
+		variables []platform.DashboardVariable
 	}
 	type wants struct {
 		err       error
@@ -935,6 +938,73 @@ func UpdateDashboard(
 				},
 			},
 		},
+		{
+			name: "update variables",
+			fields: DashboardFields{
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   platform.ID("org1"),
+					},
+				},
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("1"),
+						OrganizationID: platform.ID("org1"),
+						Name:           "dashboard1",
+					},
+				},
+			},
+			args: args{
+				id: platform.ID("1"),
+				variables: []platform.DashboardVariable{
+					{Name: "bucket", Type: platform.ConstantVariableType, Values: []string{"telegraf", "metrics"}},
+					{Name: "host", Type: platform.QueryVariableType, Query: `from(db:"telegraf") |> range(start:-1h) |> keys()`},
+					{Name: "timeRange", Type: platform.TimeRangeVariableType, TimeRange: &platform.TimeRange{Duration: "1h"}},
+				},
+			},
+			wants: wants{
+				dashboard: &platform.Dashboard{
+					ID:             platform.ID("1"),
+					OrganizationID: platform.ID("org1"),
+					Organization:   "theorg",
+					Name:           "dashboard1",
+					Variables: []platform.DashboardVariable{
+						{Name: "bucket", Type: platform.ConstantVariableType, Values: []string{"telegraf", "metrics"}},
+						{Name: "host", Type: platform.QueryVariableType, Query: `from(db:"telegraf") |> range(start:-1h) |> keys()`},
+						{Name: "timeRange", Type: platform.TimeRangeVariableType, TimeRange: &platform.TimeRange{Duration: "1h"}},
+					},
+				},
+			},
+		},
+		{
+			name: "update with duplicate variables",
+			fields: DashboardFields{
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   platform.ID("org1"),
+					},
+				},
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("1"),
+						OrganizationID: platform.ID("org1"),
+						Name:           "dashboard1",
+					},
+				},
+			},
+			args: args{
+				id: platform.ID("1"),
+				variables: []platform.DashboardVariable{
+					{Name: "host", Type: platform.ConstantVariableType, Values: []string{"server01"}},
+					{Name: "host", Type: platform.QueryVariableType, Query: `from(db:"telegraf") |> range(start:-1h) |> keys()`},
+				},
+			},
+			wants: wants{
+				err: kerrors.InvalidDataf("invalid variables: duplicate variable \"host\""),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -947,6 +1017,9 @@ func UpdateDashboard(
 			if tt.args.name != "" {
 				upd.Name = &tt.args.name
 			}
+			if tt.args.variables != nil {
+				upd.Variables = &tt.args.variables
+			}
 
 			dashboard, err := s.UpdateDashboard(ctx, tt.args.id, upd)
 			if (err != nil) != (tt.wants.err != nil) {
@@ -954,8 +1027,8 @@ func UpdateDashboard(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -1045,6 +1118,122 @@ func AddDashboardCell(
 				},
 			},
 		},
+		{
+			name: "add dashboard cells with rich visualizations",
+			fields: DashboardFields{
+				IDGenerator: mock.NewIDGenerator("id1"),
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   platform.ID("org1"),
+					},
+				},
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Name:           "abc",
+					},
+				},
+			},
+			args: args{
+				dashboardID: platform.ID("test1"),
+				cell: &platform.DashboardCell{
+					DashboardCellContents: platform.DashboardCellContents{
+						Name: "cpu",
+						W:    6,
+						H:    4,
+					},
+					Visualization: platform.LineGraphVisualization{
+						Queries: []platform.DashboardQuery{
+							{
+								Query:     "SELECT usage FROM cpu",
+								TimeRange: &platform.TimeRange{Duration: "24h"},
+							},
+						},
+						YAxis:  platform.Axis{Label: "usage", Suffix: "%"},
+						Legend: platform.Legend{Orientation: "bottom"},
+						Colors: []string{"#7A65F2"},
+					},
+				},
+			},
+			wants: wants{
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Organization:   "theorg",
+						Name:           "abc",
+						Cells: []platform.DashboardCell{
+							{
+								DashboardCellContents: platform.DashboardCellContents{
+									ID:   platform.ID("id1"),
+									Name: "cpu",
+									W:    6,
+									H:    4,
+								},
+								Visualization: platform.LineGraphVisualization{
+									Queries: []platform.DashboardQuery{
+										{
+											Query:     "SELECT usage FROM cpu",
+											TimeRange: &platform.TimeRange{Duration: "24h"},
+										},
+									},
+									YAxis:  platform.Axis{Label: "usage", Suffix: "%"},
+									Legend: platform.Legend{Orientation: "bottom"},
+									Colors: []string{"#7A65F2"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "add invalid dashboard cell",
+			fields: DashboardFields{
+				IDGenerator: mock.NewIDGenerator("id1"),
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   platform.ID("org1"),
+					},
+				},
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Name:           "abc",
+					},
+				},
+			},
+			args: args{
+				dashboardID: platform.ID("test1"),
+				cell: &platform.DashboardCell{
+					DashboardCellContents: platform.DashboardCellContents{
+						Name: "cpu",
+						W:    6,
+						H:    4,
+					},
+					Visualization: platform.GaugeVisualization{
+						Queries: []platform.DashboardQuery{{Query: "SELECT last(usage) FROM cpu"}},
+						Min:     100,
+						Max:     0,
+					},
+				},
+			},
+			wants: wants{
+				err: kerrors.InvalidDataf("invalid cell: gauge min must be less than its max"),
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Organization:   "theorg",
+						Name:           "abc",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1058,8 +1247,8 @@ func AddDashboardCell(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -1209,8 +1398,8 @@ func RemoveDashboardCell(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -1319,6 +1508,157 @@ func ReplaceDashboardCell(
 				},
 			},
 		},
+		{
+			name: "replace dashboard cell with a table",
+			fields: DashboardFields{
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   platform.ID("org1"),
+					},
+				},
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Name:           "abc",
+						Cells: []platform.DashboardCell{
+							{
+								DashboardCellContents: platform.DashboardCellContents{
+									ID:   platform.ID("id1"),
+									Name: "hello",
+									X:    10,
+									Y:    10,
+									W:    100,
+									H:    12,
+								},
+								Visualization: platform.CommonVisualization{
+									Query: "SELECT * FROM foo",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				dashboardID: platform.ID("test1"),
+				cell: &platform.DashboardCell{
+					DashboardCellContents: platform.DashboardCellContents{
+						ID:   platform.ID("id1"),
+						Name: "hosts",
+						W:    100,
+						H:    12,
+					},
+					Visualization: platform.TableVisualization{
+						Queries: []platform.DashboardQuery{{Query: "SELECT * FROM cpu"}},
+						Columns: []platform.TableColumn{
+							{Name: "host", DisplayName: "Host"},
+							{Name: "usage", DecimalPlaces: intPtr(1)},
+						},
+						SortBy: "host",
+					},
+				},
+			},
+			wants: wants{
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Organization:   "theorg",
+						Name:           "abc",
+						Cells: []platform.DashboardCell{
+							{
+								DashboardCellContents: platform.DashboardCellContents{
+									ID:   platform.ID("id1"),
+									Name: "hosts",
+									W:    100,
+									H:    12,
+								},
+								Visualization: platform.TableVisualization{
+									Queries: []platform.DashboardQuery{{Query: "SELECT * FROM cpu"}},
+									Columns: []platform.TableColumn{
+										{Name: "host", DisplayName: "Host"},
+										{Name: "usage", DecimalPlaces: intPtr(1)},
+									},
+									SortBy: "host",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "replace dashboard cell with an invalid cell",
+			fields: DashboardFields{
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   platform.ID("org1"),
+					},
+				},
+				Dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Name:           "abc",
+						Cells: []platform.DashboardCell{
+							{
+								DashboardCellContents: platform.DashboardCellContents{
+									ID:   platform.ID("id1"),
+									Name: "hello",
+									X:    10,
+									Y:    10,
+									W:    100,
+									H:    12,
+								},
+								Visualization: platform.CommonVisualization{
+									Query: "SELECT * FROM foo",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				dashboardID: platform.ID("test1"),
+				cell: &platform.DashboardCell{
+					DashboardCellContents: platform.DashboardCellContents{
+						ID:   platform.ID("id1"),
+						Name: "note",
+						W:    100,
+						H:    12,
+					},
+					Visualization: platform.MarkdownVisualization{},
+				},
+			},
+			wants: wants{
+				err: kerrors.InvalidDataf("invalid cell: markdown note is empty"),
+				dashboards: []*platform.Dashboard{
+					{
+						ID:             platform.ID("test1"),
+						OrganizationID: platform.ID("org1"),
+						Organization:   "theorg",
+						Name:           "abc",
+						Cells: []platform.DashboardCell{
+							{
+								DashboardCellContents: platform.DashboardCellContents{
+									ID:   platform.ID("id1"),
+									Name: "hello",
+									X:    10,
+									Y:    10,
+									W:    100,
+									H:    12,
+								},
+								Visualization: platform.CommonVisualization{
+									Query: "SELECT * FROM foo",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1332,8 +1672,8 @@ func ReplaceDashboardCell(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -1347,3 +1687,7 @@ func ReplaceDashboardCell(
 		})
 	}
 }
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -3,16 +3,18 @@ package testing
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/mock"
 )
 
 var organizationCmpOptions = cmp.Options{
+	cmpopts.IgnoreFields(platform.Organization{}, "Meta"),
 	cmp.Comparer(func(x, y []byte) bool {
 		return bytes.Equal(x, y)
 	}),
@@ -130,7 +132,7 @@ func CreateOrganization(
 						Name: "organization1",
 					},
 				},
-				err: fmt.Errorf("organization with name organization1 already exists"),
+				err: kerrors.Conflictf("organization with name organization1 already exists"),
 			},
 		},
 	}
@@ -146,8 +148,8 @@ func CreateOrganization(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 			defer s.DeleteOrganization(ctx, tt.args.organization.ID)
@@ -220,8 +222,8 @@ func FindOrganizationByID(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -355,8 +357,8 @@ func FindOrganizations(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -430,7 +432,7 @@ func DeleteOrganization(
 				ID: "123",
 			},
 			wants: wants{
-				err: fmt.Errorf("organization not found"),
+				err: kerrors.NotFoundf("organization not found"),
 				organizations: []*platform.Organization{
 					{
 						Name: "orgA",
@@ -456,8 +458,8 @@ func DeleteOrganization(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -535,8 +537,8 @@ func FindOrganization(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
@@ -611,8 +613,8 @@ func UpdateOrganization(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
 				}
 			}
 
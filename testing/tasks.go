@@ -0,0 +1,1121 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/mock"
+)
+
+var taskCmpOptions = cmp.Options{
+	cmp.Comparer(func(x, y []byte) bool {
+		return bytes.Equal(x, y)
+	}),
+	cmp.Transformer("Sort", func(in []*platform.Task) []*platform.Task {
+		out := append([]*platform.Task(nil), in...) // Copy input to avoid mutating it
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID.String() > out[j].ID.String()
+		})
+		return out
+	}),
+}
+
+// runTimeCmpOptions ignores the times a service sets from its own clock.
+var runTimeCmpOptions = cmp.Options{
+	cmpopts.IgnoreFields(platform.Run{}, "QueuedAt", "EndTime"),
+}
+
+const (
+	hourlyFlux = `option task = {name: "hourly", every: 1h}
+from(db: "telegraf") |> range(start: -1h)`
+	nightlyFlux = `option task = {name: "nightly", cron: "0 2 * * *"}
+from(db: "telegraf") |> range(start: -24h)`
+)
+
+var (
+	runQueuedAt = time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)
+	runStart    = time.Date(2018, 7, 1, 10, 0, 1, 0, time.UTC)
+	runEnd      = time.Date(2018, 7, 1, 10, 0, 2, 0, time.UTC)
+)
+
+// TaskFields will include the IDGenerator, and tasks with their runs and logs.
+type TaskFields struct {
+	IDGenerator   platform.IDGenerator
+	Tasks         []*platform.Task
+	Runs          []*platform.Run
+	Logs          []*platform.Log
+	Users         []*platform.User
+	Organizations []*platform.Organization
+}
+
+// CreateTask testing
+func CreateTask(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		task *platform.Task
+	}
+	type wants struct {
+		err   error
+		tasks []*platform.Task
+	}
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "create task parses schedule from flux",
+			fields: TaskFields{
+				IDGenerator: mock.NewIDGenerator("id1"),
+				Users: []*platform.User{
+					{
+						Name: "user1",
+						ID:   platform.ID("user1"),
+					},
+				},
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   platform.ID("org1"),
+					},
+				},
+			},
+			args: args{
+				task: &platform.Task{
+					Owner:          platform.User{ID: platform.ID("user1")},
+					OrganizationID: platform.ID("org1"),
+					Flux:           hourlyFlux,
+				},
+			},
+			wants: wants{
+				tasks: []*platform.Task{
+					{
+						ID:             platform.ID("id1"),
+						Name:           "hourly",
+						Status:         platform.TaskEnabled,
+						Owner:          platform.User{ID: platform.ID("user1"), Name: "user1"},
+						OrganizationID: platform.ID("org1"),
+						Flux:           hourlyFlux,
+						Every:          "1h0m0s",
+					},
+				},
+			},
+		},
+		{
+			name: "create disabled task with cron and name",
+			fields: TaskFields{
+				IDGenerator: mock.NewIDGenerator("id2"),
+				Tasks: []*platform.Task{
+					{
+						ID:     platform.ID("id1"),
+						Name:   "hourly",
+						Status: platform.TaskEnabled,
+						Flux:   hourlyFlux,
+						Every:  "1h0m0s",
+					},
+				},
+			},
+			args: args{
+				task: &platform.Task{
+					Name:   "backup",
+					Status: platform.TaskDisabled,
+					Flux:   nightlyFlux,
+				},
+			},
+			wants: wants{
+				tasks: []*platform.Task{
+					{
+						ID:     platform.ID("id1"),
+						Name:   "hourly",
+						Status: platform.TaskEnabled,
+						Flux:   hourlyFlux,
+						Every:  "1h0m0s",
+					},
+					{
+						ID:     platform.ID("id2"),
+						Name:   "backup",
+						Status: platform.TaskDisabled,
+						Flux:   nightlyFlux,
+						Cron:   "0 2 * * *",
+					},
+				},
+			},
+		},
+		{
+			name: "create task without task option",
+			fields: TaskFields{
+				IDGenerator: mock.NewIDGenerator("id1"),
+			},
+			args: args{
+				task: &platform.Task{
+					Flux: `from(db: "telegraf") |> range(start: -1h)`,
+				},
+			},
+			wants: wants{
+				err:   kerrors.InvalidDataf("invalid task flux: missing task option"),
+				tasks: []*platform.Task{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+			err := s.CreateTask(ctx, tt.args.task)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
+				}
+			}
+			defer s.DeleteTask(ctx, tt.args.task.ID)
+
+			tasks, _, err := s.FindTasks(ctx, platform.TaskFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve tasks: %v", err)
+			}
+			if diff := cmp.Diff(tasks, tt.wants.tasks, taskCmpOptions...); diff != "" {
+				t.Errorf("tasks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindTaskByID testing
+func FindTaskByID(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id platform.ID
+	}
+	type wants struct {
+		err  error
+		task *platform.Task
+	}
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "find task by id with last run",
+			fields: TaskFields{
+				Tasks: []*platform.Task{
+					{
+						ID:     platform.ID("task1"),
+						Name:   "hourly",
+						Status: platform.TaskEnabled,
+						Flux:   hourlyFlux,
+						Every:  "1h0m0s",
+					},
+					{
+						ID:     platform.ID("task2"),
+						Name:   "nightly",
+						Status: platform.TaskEnabled,
+						Flux:   nightlyFlux,
+						Cron:   "0 2 * * *",
+					},
+				},
+				Runs: []*platform.Run{
+					{
+						ID:       platform.ID("run1"),
+						TaskID:   platform.ID("task1"),
+						Status:   platform.RunFailed,
+						QueuedAt: runQueuedAt,
+					},
+					{
+						ID:       platform.ID("run2"),
+						TaskID:   platform.ID("task1"),
+						Status:   platform.RunQueued,
+						QueuedAt: runQueuedAt.Add(time.Hour),
+					},
+				},
+			},
+			args: args{
+				id: platform.ID("task1"),
+			},
+			wants: wants{
+				task: &platform.Task{
+					ID:     platform.ID("task1"),
+					Name:   "hourly",
+					Status: platform.TaskEnabled,
+					Flux:   hourlyFlux,
+					Every:  "1h0m0s",
+					Last: &platform.Run{
+						ID:       platform.ID("run2"),
+						TaskID:   platform.ID("task1"),
+						Status:   platform.RunQueued,
+						QueuedAt: runQueuedAt.Add(time.Hour),
+					},
+				},
+			},
+		},
+		{
+			name: "find missing task",
+			fields: TaskFields{
+				Tasks: []*platform.Task{},
+			},
+			args: args{
+				id: platform.ID("task1"),
+			},
+			wants: wants{
+				err: kerrors.NotFoundf("task not found"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			task, err := s.FindTaskByID(ctx, tt.args.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected errors to be equal '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
+				}
+			}
+
+			if diff := cmp.Diff(task, tt.wants.task, taskCmpOptions...); diff != "" {
+				t.Errorf("task is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindTasks testing
+func FindTasks(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		user           platform.ID
+		organizationID platform.ID
+		opts           platform.FindOptions
+	}
+	type wants struct {
+		tasks []*platform.Task
+		count int
+	}
+
+	fields := TaskFields{
+		Users: []*platform.User{
+			{
+				Name: "user1",
+				ID:   platform.ID("user1"),
+			},
+		},
+		Organizations: []*platform.Organization{
+			{
+				Name: "theorg",
+				ID:   platform.ID("org1"),
+			},
+		},
+		Tasks: []*platform.Task{
+			{
+				ID:             platform.ID("task1"),
+				Name:           "hourly",
+				Status:         platform.TaskEnabled,
+				Owner:          platform.User{ID: platform.ID("user1")},
+				OrganizationID: platform.ID("org1"),
+				Flux:           hourlyFlux,
+				Every:          "1h0m0s",
+			},
+			{
+				ID:     platform.ID("task2"),
+				Name:   "nightly",
+				Status: platform.TaskEnabled,
+				Flux:   nightlyFlux,
+				Cron:   "0 2 * * *",
+			},
+		},
+	}
+	task1 := &platform.Task{
+		ID:             platform.ID("task1"),
+		Name:           "hourly",
+		Status:         platform.TaskEnabled,
+		Owner:          platform.User{ID: platform.ID("user1"), Name: "user1"},
+		OrganizationID: platform.ID("org1"),
+		Flux:           hourlyFlux,
+		Every:          "1h0m0s",
+	}
+	task2 := &platform.Task{
+		ID:     platform.ID("task2"),
+		Name:   "nightly",
+		Status: platform.TaskEnabled,
+		Flux:   nightlyFlux,
+		Cron:   "0 2 * * *",
+	}
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "find all tasks",
+			fields: fields,
+			wants: wants{
+				tasks: []*platform.Task{task1, task2},
+				count: 2,
+			},
+		},
+		{
+			name:   "find tasks by user",
+			fields: fields,
+			args: args{
+				user: platform.ID("user1"),
+			},
+			wants: wants{
+				tasks: []*platform.Task{task1},
+				count: 1,
+			},
+		},
+		{
+			name:   "find tasks by organization",
+			fields: fields,
+			args: args{
+				organizationID: platform.ID("org1"),
+			},
+			wants: wants{
+				tasks: []*platform.Task{task1},
+				count: 1,
+			},
+		},
+		{
+			name:   "find tasks after id",
+			fields: fields,
+			args: args{
+				opts: platform.FindOptions{After: platform.ID("task1")},
+			},
+			wants: wants{
+				tasks: []*platform.Task{task2},
+				count: 2,
+			},
+		},
+		{
+			name:   "find tasks with limit",
+			fields: fields,
+			args: args{
+				opts: platform.FindOptions{Limit: 1},
+			},
+			wants: wants{
+				tasks: []*platform.Task{task1},
+				count: 2,
+			},
+		},
+		{
+			name:   "find tasks sorted by name",
+			fields: fields,
+			args: args{
+				opts: platform.FindOptions{SortBy: "name"},
+			},
+			wants: wants{
+				tasks: []*platform.Task{task1, task2},
+				count: 2,
+			},
+		},
+		{
+			name:   "find tasks sorted by name descending with offset",
+			fields: fields,
+			args: args{
+				opts: platform.FindOptions{SortBy: "name", Descending: true, Offset: 1},
+			},
+			wants: wants{
+				tasks: []*platform.Task{task1},
+				count: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			filter := platform.TaskFilter{}
+			if tt.args.user != nil {
+				filter.User = &tt.args.user
+			}
+			if tt.args.organizationID != nil {
+				filter.OrganizationID = &tt.args.organizationID
+			}
+
+			tasks, n, err := s.FindTasks(ctx, filter, tt.args.opts)
+			if err != nil {
+				t.Fatalf("failed to retrieve tasks: %v", err)
+			}
+			if n != tt.wants.count {
+				t.Errorf("unexpected count of tasks: got %d want %d", n, tt.wants.count)
+			}
+
+			if diff := cmp.Diff(tasks, tt.wants.tasks, taskCmpOptions...); diff != "" {
+				t.Errorf("tasks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateTask testing
+func UpdateTask(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id  platform.ID
+		upd platform.TaskUpdate
+	}
+	type wants struct {
+		err  error
+		task *platform.Task
+		runs []*platform.Run
+	}
+
+	disabled := platform.TaskDisabled
+	newName := "renamed"
+	newFlux := nightlyFlux
+	badStatus := "paused"
+
+	fields := TaskFields{
+		Tasks: []*platform.Task{
+			{
+				ID:     platform.ID("task1"),
+				Name:   "hourly",
+				Status: platform.TaskEnabled,
+				Flux:   hourlyFlux,
+				Every:  "1h0m0s",
+			},
+		},
+		Runs: []*platform.Run{
+			{
+				ID:        platform.ID("run1"),
+				TaskID:    platform.ID("task1"),
+				Status:    platform.RunSuccess,
+				QueuedAt:  runQueuedAt,
+				StartTime: &runStart,
+				EndTime:   &runEnd,
+			},
+			{
+				ID:       platform.ID("run2"),
+				TaskID:   platform.ID("task1"),
+				Status:   platform.RunQueued,
+				QueuedAt: runQueuedAt.Add(time.Hour),
+			},
+		},
+	}
+	runs := []*platform.Run{
+		{
+			ID:        platform.ID("run1"),
+			TaskID:    platform.ID("task1"),
+			Status:    platform.RunSuccess,
+			QueuedAt:  runQueuedAt,
+			StartTime: &runStart,
+			EndTime:   &runEnd,
+		},
+		{
+			ID:       platform.ID("run2"),
+			TaskID:   platform.ID("task1"),
+			Status:   platform.RunFailed,
+			QueuedAt: runQueuedAt.Add(time.Hour),
+			Error: &platform.RunError{
+				Code:    platform.RunErrorCanceled,
+				Message: "run canceled by task update",
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "disable task cancels queued runs",
+			fields: fields,
+			args: args{
+				id:  platform.ID("task1"),
+				upd: platform.TaskUpdate{Status: &disabled},
+			},
+			wants: wants{
+				task: &platform.Task{
+					ID:     platform.ID("task1"),
+					Name:   "hourly",
+					Status: platform.TaskDisabled,
+					Flux:   hourlyFlux,
+					Every:  "1h0m0s",
+					Last:   runs[1],
+				},
+				runs: runs,
+			},
+		},
+		{
+			name:   "update name and flux",
+			fields: fields,
+			args: args{
+				id:  platform.ID("task1"),
+				upd: platform.TaskUpdate{Name: &newName, Flux: &newFlux},
+			},
+			wants: wants{
+				task: &platform.Task{
+					ID:     platform.ID("task1"),
+					Name:   "renamed",
+					Status: platform.TaskEnabled,
+					Flux:   nightlyFlux,
+					Cron:   "0 2 * * *",
+					Last:   runs[1],
+				},
+				runs: runs,
+			},
+		},
+		{
+			name:   "update with invalid status",
+			fields: fields,
+			args: args{
+				id:  platform.ID("task1"),
+				upd: platform.TaskUpdate{Status: &badStatus},
+			},
+			wants: wants{
+				err:  kerrors.InvalidDataf(`invalid task status "paused"`),
+				runs: fields.Runs,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			task, err := s.UpdateTask(ctx, tt.args.id, tt.args.upd)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
+				}
+			}
+
+			if diff := cmp.Diff(task, tt.wants.task, taskCmpOptions, runTimeCmpOptions); diff != "" {
+				t.Errorf("task is different -got/+want\ndiff %s", diff)
+			}
+
+			runs, _, err := s.FindRuns(ctx, platform.RunFilter{Task: tt.args.id})
+			if err != nil {
+				t.Fatalf("failed to retrieve runs: %v", err)
+			}
+			if diff := cmp.Diff(runs, tt.wants.runs, taskCmpOptions, runTimeCmpOptions); diff != "" {
+				t.Errorf("runs are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteTask testing
+func DeleteTask(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id platform.ID
+	}
+	type wants struct {
+		err   error
+		tasks []*platform.Task
+	}
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "delete task with runs and logs",
+			fields: TaskFields{
+				Tasks: []*platform.Task{
+					{
+						ID:     platform.ID("task1"),
+						Name:   "hourly",
+						Status: platform.TaskEnabled,
+						Flux:   hourlyFlux,
+						Every:  "1h0m0s",
+					},
+					{
+						ID:     platform.ID("task2"),
+						Name:   "nightly",
+						Status: platform.TaskEnabled,
+						Flux:   nightlyFlux,
+						Cron:   "0 2 * * *",
+					},
+				},
+				Runs: []*platform.Run{
+					{
+						ID:       platform.ID("run1"),
+						TaskID:   platform.ID("task1"),
+						Status:   platform.RunFailed,
+						QueuedAt: runQueuedAt,
+					},
+				},
+				Logs: []*platform.Log{
+					{
+						RunID:   platform.ID("run1"),
+						Time:    runStart,
+						Message: "run failed",
+					},
+				},
+			},
+			args: args{
+				id: platform.ID("task1"),
+			},
+			wants: wants{
+				tasks: []*platform.Task{
+					{
+						ID:     platform.ID("task2"),
+						Name:   "nightly",
+						Status: platform.TaskEnabled,
+						Flux:   nightlyFlux,
+						Cron:   "0 2 * * *",
+					},
+				},
+			},
+		},
+		{
+			name: "delete missing task",
+			fields: TaskFields{
+				Tasks: []*platform.Task{},
+			},
+			args: args{
+				id: platform.ID("task1"),
+			},
+			wants: wants{
+				err:   kerrors.NotFoundf("task not found"),
+				tasks: []*platform.Task{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+			err := s.DeleteTask(ctx, tt.args.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
+				}
+			}
+
+			tasks, _, err := s.FindTasks(ctx, platform.TaskFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve tasks: %v", err)
+			}
+			if diff := cmp.Diff(tasks, tt.wants.tasks, taskCmpOptions...); diff != "" {
+				t.Errorf("tasks are different -got/+want\ndiff %s", diff)
+			}
+
+			if _, err := s.FindRunByID(ctx, tt.args.id, platform.ID("run1")); err == nil {
+				t.Errorf("expected runs of deleted task to be removed")
+			}
+		})
+	}
+}
+
+// FindRuns testing
+func FindRuns(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err  error
+		runs []*platform.Run
+	}
+
+	run1 := &platform.Run{
+		ID:        platform.ID("run1"),
+		TaskID:    platform.ID("task1"),
+		Status:    platform.RunFailed,
+		QueuedAt:  runQueuedAt,
+		StartTime: &runStart,
+		EndTime:   &runEnd,
+		Error:     &platform.RunError{Code: platform.RunErrorExecution, Message: "boom"},
+	}
+	run2 := &platform.Run{
+		ID:       platform.ID("run2"),
+		TaskID:   platform.ID("task1"),
+		Status:   platform.RunSuccess,
+		QueuedAt: runQueuedAt.Add(time.Hour),
+	}
+	run3 := &platform.Run{
+		ID:       platform.ID("run3"),
+		TaskID:   platform.ID("task1"),
+		Status:   platform.RunQueued,
+		QueuedAt: runQueuedAt.Add(2 * time.Hour),
+	}
+	fields := TaskFields{
+		Tasks: []*platform.Task{
+			{
+				ID:     platform.ID("task1"),
+				Name:   "hourly",
+				Status: platform.TaskEnabled,
+				Flux:   hourlyFlux,
+				Every:  "1h0m0s",
+			},
+			{
+				ID:     platform.ID("task2"),
+				Name:   "nightly",
+				Status: platform.TaskEnabled,
+				Flux:   nightlyFlux,
+				Cron:   "0 2 * * *",
+			},
+		},
+		Runs: []*platform.Run{
+			run1,
+			run2,
+			run3,
+			{
+				ID:       platform.ID("run4"),
+				TaskID:   platform.ID("task2"),
+				Status:   platform.RunQueued,
+				QueuedAt: runQueuedAt,
+			},
+		},
+	}
+
+	after := platform.ID("run1")
+	afterTime := runQueuedAt
+	beforeTime := runQueuedAt.Add(2 * time.Hour)
+	queued := platform.RunQueued
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		filter platform.RunFilter
+		wants  wants
+	}{
+		{
+			name:   "find all runs of a task",
+			fields: fields,
+			filter: platform.RunFilter{Task: platform.ID("task1")},
+			wants: wants{
+				runs: []*platform.Run{run1, run2, run3},
+			},
+		},
+		{
+			name:   "find runs after id with limit",
+			fields: fields,
+			filter: platform.RunFilter{Task: platform.ID("task1"), After: &after, Limit: 1},
+			wants: wants{
+				runs: []*platform.Run{run2},
+			},
+		},
+		{
+			name:   "find runs within time range",
+			fields: fields,
+			filter: platform.RunFilter{Task: platform.ID("task1"), AfterTime: &afterTime, BeforeTime: &beforeTime},
+			wants: wants{
+				runs: []*platform.Run{run2},
+			},
+		},
+		{
+			name:   "find runs by status",
+			fields: fields,
+			filter: platform.RunFilter{Task: platform.ID("task1"), Status: &queued},
+			wants: wants{
+				runs: []*platform.Run{run3},
+			},
+		},
+		{
+			name:   "find runs of missing task",
+			fields: fields,
+			filter: platform.RunFilter{Task: platform.ID("task3")},
+			wants: wants{
+				err: kerrors.NotFoundf("task not found"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			runs, _, err := s.FindRuns(ctx, tt.filter)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(runs, tt.wants.runs, taskCmpOptions...); diff != "" {
+				t.Errorf("runs are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// RetryRun testing
+func RetryRun(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		taskID platform.ID
+		runID  platform.ID
+	}
+	type wants struct {
+		err error
+		run *platform.Run
+	}
+
+	fields := TaskFields{
+		IDGenerator: mock.NewIDGenerator("run3"),
+		Tasks: []*platform.Task{
+			{
+				ID:     platform.ID("task1"),
+				Name:   "hourly",
+				Status: platform.TaskEnabled,
+				Flux:   hourlyFlux,
+				Every:  "1h0m0s",
+			},
+		},
+		Runs: []*platform.Run{
+			{
+				ID:        platform.ID("run1"),
+				TaskID:    platform.ID("task1"),
+				Status:    platform.RunFailed,
+				QueuedAt:  runQueuedAt,
+				StartTime: &runStart,
+				EndTime:   &runEnd,
+				Error:     &platform.RunError{Code: platform.RunErrorExecution, Message: "boom"},
+			},
+			{
+				ID:        platform.ID("run2"),
+				TaskID:    platform.ID("task1"),
+				Status:    platform.RunExecuting,
+				QueuedAt:  runQueuedAt.Add(time.Hour),
+				StartTime: &runStart,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "retry failed run",
+			fields: fields,
+			args: args{
+				taskID: platform.ID("task1"),
+				runID:  platform.ID("run1"),
+			},
+			wants: wants{
+				run: &platform.Run{
+					ID:     platform.ID("run3"),
+					TaskID: platform.ID("task1"),
+					Status: platform.RunQueued,
+				},
+			},
+		},
+		{
+			name:   "retry executing run",
+			fields: fields,
+			args: args{
+				taskID: platform.ID("task1"),
+				runID:  platform.ID("run2"),
+			},
+			wants: wants{
+				err: kerrors.Conflictf("run is executing and cannot be retried"),
+			},
+		},
+		{
+			name:   "retry missing run",
+			fields: fields,
+			args: args{
+				taskID: platform.ID("task1"),
+				runID:  platform.ID("run9"),
+			},
+			wants: wants{
+				err: kerrors.NotFoundf("run not found"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			run, err := s.RetryRun(ctx, tt.args.taskID, tt.args.runID)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
+				}
+				return
+			}
+
+			if run.QueuedAt.IsZero() {
+				t.Errorf("expected retried run to have a queued time")
+			}
+			if diff := cmp.Diff(run, tt.wants.run, taskCmpOptions, runTimeCmpOptions); diff != "" {
+				t.Errorf("run is different -got/+want\ndiff %s", diff)
+			}
+
+			found, err := s.FindRunByID(ctx, tt.args.taskID, run.ID)
+			if err != nil {
+				t.Fatalf("failed to retrieve retried run: %v", err)
+			}
+			if diff := cmp.Diff(found, run, taskCmpOptions...); diff != "" {
+				t.Errorf("stored run is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindLogs testing
+func FindLogs(
+	init func(TaskFields, *testing.T) (platform.TaskService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err  error
+		logs []*platform.Log
+	}
+
+	log1 := &platform.Log{RunID: platform.ID("run1"), Time: runStart, Message: "executing task \"hourly\""}
+	log2 := &platform.Log{RunID: platform.ID("run1"), Time: runEnd, Message: "run failed: boom"}
+	log3 := &platform.Log{RunID: platform.ID("run2"), Time: runEnd, Message: "run succeeded"}
+	fields := TaskFields{
+		Tasks: []*platform.Task{
+			{
+				ID:     platform.ID("task1"),
+				Name:   "hourly",
+				Status: platform.TaskEnabled,
+				Flux:   hourlyFlux,
+				Every:  "1h0m0s",
+			},
+		},
+		Runs: []*platform.Run{
+			{
+				ID:       platform.ID("run1"),
+				TaskID:   platform.ID("task1"),
+				Status:   platform.RunFailed,
+				QueuedAt: runQueuedAt,
+			},
+			{
+				ID:       platform.ID("run2"),
+				TaskID:   platform.ID("task1"),
+				Status:   platform.RunSuccess,
+				QueuedAt: runQueuedAt.Add(time.Hour),
+			},
+		},
+		Logs: []*platform.Log{log1, log2, log3},
+	}
+
+	run1 := platform.ID("run1")
+	run9 := platform.ID("run9")
+
+	tests := []struct {
+		name   string
+		fields TaskFields
+		filter platform.LogFilter
+		wants  wants
+	}{
+		{
+			name:   "find all logs of a task",
+			fields: fields,
+			filter: platform.LogFilter{Task: platform.ID("task1")},
+			wants: wants{
+				logs: []*platform.Log{log1, log2, log3},
+			},
+		},
+		{
+			name:   "find logs of a run",
+			fields: fields,
+			filter: platform.LogFilter{Task: platform.ID("task1"), Run: &run1},
+			wants: wants{
+				logs: []*platform.Log{log1, log2},
+			},
+		},
+		{
+			name:   "find logs of missing run",
+			fields: fields,
+			filter: platform.LogFilter{Task: platform.ID("task1"), Run: &run9},
+			wants: wants{
+				err: kerrors.NotFoundf("run not found"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			logs, _, err := s.FindLogs(ctx, tt.filter)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if code := kerrors.Reference(err); code != kerrors.Reference(tt.wants.err) {
+					t.Fatalf("expected error code %d got %d: %v", kerrors.Reference(tt.wants.err), code, err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(logs, tt.wants.logs, taskCmpOptions...); diff != "" {
+				t.Errorf("logs are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
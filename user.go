@@ -6,6 +6,7 @@ import "context"
 type User struct {
 	ID   ID     `json:"id,omitempty"`
 	Name string `json:"name"`
+	Meta
 }
 
 // UserService represents a service for managing user data.
@@ -0,0 +1,438 @@
+package platform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Visualization is the visual representation of the data queried by a dashboard cell.
+type Visualization interface {
+	Visualization()
+	// Validate returns an error if the visualization is invalid.
+	Validate() error
+}
+
+// maxDecimalPlaces is the greatest number of decimal places a value may be displayed with.
+const maxDecimalPlaces = 10
+
+// colorPattern matches hexadecimal RGB colors such as "#7A65F2".
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// TimeRange is the range of time queried, either relative to now or absolute.
+type TimeRange struct {
+	// Duration is a range ending now, such as "1h".
+	Duration string `json:"duration,omitempty"`
+	// Start and Stop are an absolute range. A range without Stop ends now.
+	Start *time.Time `json:"start,omitempty"`
+	Stop  *time.Time `json:"stop,omitempty"`
+}
+
+// Validate returns an error if the time range is neither relative nor absolute, or is empty.
+func (r TimeRange) Validate() error {
+	switch {
+	case r.Duration != "" && r.Start != nil:
+		return fmt.Errorf("time range must not have both a duration and a start")
+	case r.Duration != "":
+		d, err := time.ParseDuration(r.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid time range duration: %v", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("time range duration must be positive")
+		}
+		if r.Stop != nil {
+			return fmt.Errorf("time range with a duration must not have a stop")
+		}
+	case r.Start != nil:
+		if r.Stop != nil && !r.Stop.After(*r.Start) {
+			return fmt.Errorf("time range stop must be after its start")
+		}
+	default:
+		return fmt.Errorf("time range must have a duration or a start")
+	}
+	return nil
+}
+
+// DashboardQuery is a query of a visualization.
+type DashboardQuery struct {
+	Query string `json:"query"`
+	// TimeRange overrides the time range of the dashboard for the query.
+	TimeRange *TimeRange `json:"timeRange,omitempty"`
+}
+
+func validateQueries(qs []DashboardQuery) error {
+	if len(qs) == 0 {
+		return fmt.Errorf("visualization must have at least one query")
+	}
+	for i, q := range qs {
+		if q.Query == "" {
+			return fmt.Errorf("query %d is empty", i)
+		}
+		if q.TimeRange != nil {
+			if err := q.TimeRange.Validate(); err != nil {
+				return fmt.Errorf("query %d: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateColor(c string) error {
+	if !colorPattern.MatchString(c) {
+		return fmt.Errorf("invalid color %q", c)
+	}
+	return nil
+}
+
+func validateDecimalPlaces(n *int) error {
+	if n != nil && (*n < 0 || *n > maxDecimalPlaces) {
+		return fmt.Errorf("decimal places must be between 0 and %d", maxDecimalPlaces)
+	}
+	return nil
+}
+
+// Threshold colors values greater than or equal to Value.
+type Threshold struct {
+	Value float64 `json:"value"`
+	Color string  `json:"color"`
+}
+
+// validateThresholds requires thresholds to have valid colors and strictly increasing values.
+func validateThresholds(ts []Threshold) error {
+	for i, t := range ts {
+		if err := validateColor(t.Color); err != nil {
+			return fmt.Errorf("threshold %d: %v", i, err)
+		}
+		if i > 0 && t.Value <= ts[i-1].Value {
+			return fmt.Errorf("threshold values must be increasing")
+		}
+	}
+	return nil
+}
+
+// Axis describes an axis of a graph.
+type Axis struct {
+	Label string `json:"label,omitempty"`
+	// Min and Max bound the axis. Unset bounds fit the data.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// Scale is either "linear", the default, or "log".
+	Scale  string `json:"scale,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// Validate returns an error if the axis bounds or scale are invalid.
+func (a Axis) Validate() error {
+	if a.Min != nil && a.Max != nil && *a.Min >= *a.Max {
+		return fmt.Errorf("axis min must be less than its max")
+	}
+	switch a.Scale {
+	case "", "linear", "log":
+	default:
+		return fmt.Errorf("unknown axis scale %q", a.Scale)
+	}
+	return nil
+}
+
+// Legend describes the legend of a graph.
+type Legend struct {
+	Hidden bool `json:"hidden,omitempty"`
+	// Orientation is the side of the graph of the legend: "top", "bottom", the default, "left" or "right".
+	Orientation string `json:"orientation,omitempty"`
+}
+
+// Validate returns an error if the legend orientation is unknown.
+func (l Legend) Validate() error {
+	switch l.Orientation {
+	case "", "top", "bottom", "left", "right":
+		return nil
+	default:
+		return fmt.Errorf("unknown legend orientation %q", l.Orientation)
+	}
+}
+
+// CommonVisualization is a visualization of a single query without any formatting.
+type CommonVisualization struct {
+	Query string `json:"query"`
+}
+
+func (CommonVisualization) Visualization() {}
+
+// Validate returns nil, any query is valid.
+func (CommonVisualization) Validate() error { return nil }
+
+// LineGraphVisualization is a line graph of the series of its queries.
+type LineGraphVisualization struct {
+	Queries []DashboardQuery `json:"queries"`
+	XAxis   Axis             `json:"xAxis"`
+	YAxis   Axis             `json:"yAxis"`
+	Legend  Legend           `json:"legend"`
+	// Colors are the colors of the series, in order.
+	Colors []string `json:"colors,omitempty"`
+}
+
+func (LineGraphVisualization) Visualization() {}
+
+// Validate returns an error if the queries, axes, legend or colors are invalid.
+func (v LineGraphVisualization) Validate() error {
+	if err := validateQueries(v.Queries); err != nil {
+		return err
+	}
+	if err := v.XAxis.Validate(); err != nil {
+		return fmt.Errorf("x axis: %v", err)
+	}
+	if err := v.YAxis.Validate(); err != nil {
+		return fmt.Errorf("y axis: %v", err)
+	}
+	if err := v.Legend.Validate(); err != nil {
+		return err
+	}
+	for _, c := range v.Colors {
+		if err := validateColor(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SingleStatVisualization displays the last value of its query, colored by thresholds.
+type SingleStatVisualization struct {
+	Queries []DashboardQuery `json:"queries"`
+	Prefix  string           `json:"prefix,omitempty"`
+	Suffix  string           `json:"suffix,omitempty"`
+	// DecimalPlaces rounds the value. Unset displays the value as is.
+	DecimalPlaces *int        `json:"decimalPlaces,omitempty"`
+	Thresholds    []Threshold `json:"thresholds,omitempty"`
+}
+
+func (SingleStatVisualization) Visualization() {}
+
+// Validate returns an error if the queries, decimal places or thresholds are invalid.
+func (v SingleStatVisualization) Validate() error {
+	if err := validateQueries(v.Queries); err != nil {
+		return err
+	}
+	if err := validateDecimalPlaces(v.DecimalPlaces); err != nil {
+		return err
+	}
+	return validateThresholds(v.Thresholds)
+}
+
+// GaugeVisualization displays the last value of its query on a gauge from Min to Max.
+type GaugeVisualization struct {
+	Queries       []DashboardQuery `json:"queries"`
+	Min           float64          `json:"min"`
+	Max           float64          `json:"max"`
+	Prefix        string           `json:"prefix,omitempty"`
+	Suffix        string           `json:"suffix,omitempty"`
+	DecimalPlaces *int             `json:"decimalPlaces,omitempty"`
+	Thresholds    []Threshold      `json:"thresholds,omitempty"`
+}
+
+func (GaugeVisualization) Visualization() {}
+
+// Validate returns an error if the queries, range, decimal places or thresholds are invalid.
+// Thresholds must be within the range of the gauge.
+func (v GaugeVisualization) Validate() error {
+	if err := validateQueries(v.Queries); err != nil {
+		return err
+	}
+	if v.Min >= v.Max {
+		return fmt.Errorf("gauge min must be less than its max")
+	}
+	if err := validateDecimalPlaces(v.DecimalPlaces); err != nil {
+		return err
+	}
+	if err := validateThresholds(v.Thresholds); err != nil {
+		return err
+	}
+	for _, t := range v.Thresholds {
+		if t.Value < v.Min || t.Value > v.Max {
+			return fmt.Errorf("threshold %v is outside of the gauge range", t.Value)
+		}
+	}
+	return nil
+}
+
+// TableColumn formats a column of a table.
+type TableColumn struct {
+	// Name is the name of the column in the query results.
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+	// DecimalPlaces rounds the values of the column. Unset displays values as is.
+	DecimalPlaces *int `json:"decimalPlaces,omitempty"`
+}
+
+// TableVisualization displays the results of its queries as a table.
+// Columns without formatting are displayed as is, after the formatted columns.
+type TableVisualization struct {
+	Queries []DashboardQuery `json:"queries"`
+	Columns []TableColumn    `json:"columns,omitempty"`
+	// SortBy is the name of the column the table is sorted by.
+	SortBy string `json:"sortBy,omitempty"`
+	// TimeFormat is the layout of times, as in the time package.
+	TimeFormat     string `json:"timeFormat,omitempty"`
+	FixFirstColumn bool   `json:"fixFirstColumn,omitempty"`
+}
+
+func (TableVisualization) Visualization() {}
+
+// Validate returns an error if the queries or columns are invalid.
+// Columns must be uniquely named, and a table with formatted columns can only be sorted by one of them.
+func (v TableVisualization) Validate() error {
+	if err := validateQueries(v.Queries); err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(v.Columns))
+	for i, c := range v.Columns {
+		if c.Name == "" {
+			return fmt.Errorf("column %d has no name", i)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("duplicate column %q", c.Name)
+		}
+		names[c.Name] = true
+		if err := validateDecimalPlaces(c.DecimalPlaces); err != nil {
+			return fmt.Errorf("column %q: %v", c.Name, err)
+		}
+	}
+	if v.SortBy != "" && len(v.Columns) > 0 && !names[v.SortBy] {
+		return fmt.Errorf("cannot sort by unknown column %q", v.SortBy)
+	}
+	return nil
+}
+
+// MarkdownVisualization displays a markdown note.
+type MarkdownVisualization struct {
+	Note string `json:"note"`
+}
+
+func (MarkdownVisualization) Visualization() {}
+
+// Validate returns an error if the note is empty.
+func (v MarkdownVisualization) Validate() error {
+	if v.Note == "" {
+		return fmt.Errorf("markdown note is empty")
+	}
+	return nil
+}
+
+// UnmarshalVisualizationJSON decodes the visualization of a cell.
+// Fields that are not fields of the visualization type are an error.
+func UnmarshalVisualizationJSON(b []byte) (Visualization, error) {
+	var v struct {
+		B json.RawMessage `json:"visualization"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	var t struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(v.B, &t); err != nil {
+		return nil, err
+	}
+
+	switch t.Type {
+	case "common":
+		var vis CommonVisualization
+		if err := unmarshalVisualization(v.B, &vis); err != nil {
+			return nil, err
+		}
+		return vis, nil
+	case "line":
+		var vis LineGraphVisualization
+		if err := unmarshalVisualization(v.B, &vis); err != nil {
+			return nil, err
+		}
+		return vis, nil
+	case "single-stat":
+		var vis SingleStatVisualization
+		if err := unmarshalVisualization(v.B, &vis); err != nil {
+			return nil, err
+		}
+		return vis, nil
+	case "gauge":
+		var vis GaugeVisualization
+		if err := unmarshalVisualization(v.B, &vis); err != nil {
+			return nil, err
+		}
+		return vis, nil
+	case "table":
+		var vis TableVisualization
+		if err := unmarshalVisualization(v.B, &vis); err != nil {
+			return nil, err
+		}
+		return vis, nil
+	case "markdown":
+		var vis MarkdownVisualization
+		if err := unmarshalVisualization(v.B, &vis); err != nil {
+			return nil, err
+		}
+		return vis, nil
+	default:
+		return nil, fmt.Errorf("unknown type %v", t.Type)
+	}
+}
+
+// unmarshalVisualization decodes the fields of a visualization, other than its type, into vis.
+func unmarshalVisualization(b []byte, vis interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	delete(fields, "type")
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	return dec.Decode(vis)
+}
+
+// MarshalVisualizationJSON encodes v with its type.
+func MarshalVisualizationJSON(v Visualization) ([]byte, error) {
+	var typ string
+	switch v.(type) {
+	case CommonVisualization:
+		typ = "common"
+	case LineGraphVisualization:
+		typ = "line"
+	case SingleStatVisualization:
+		typ = "single-stat"
+	case GaugeVisualization:
+		typ = "gauge"
+	case TableVisualization:
+		typ = "table"
+	case MarkdownVisualization:
+		typ = "markdown"
+	default:
+		return nil, fmt.Errorf("unsupported type")
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	fields["type"], err = json.Marshal(typ)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}